@@ -31,6 +31,7 @@ import (
 	"runtime"
 
 	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/metrics/tracing"
 	"github.com/mattn/go-colorable"
 	"github.com/mattn/go-isatty"
 	"github.com/urfave/cli/v2"
@@ -349,6 +350,7 @@ func Exit() {
 	Handler.StopCPUProfile()
 	Handler.StopGoTrace()
 	Handler.StopPProf()
+	tracing.Stop()
 }
 
 func validateLogLocation(path string) error {
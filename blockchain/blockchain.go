@@ -23,6 +23,7 @@
 package blockchain
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -52,12 +53,14 @@ import (
 	"github.com/kaiachain/kaia/kaiax"
 	"github.com/kaiachain/kaia/log"
 	kaiametrics "github.com/kaiachain/kaia/metrics"
+	"github.com/kaiachain/kaia/metrics/tracing"
 	"github.com/kaiachain/kaia/params"
 	"github.com/kaiachain/kaia/rlp"
 	"github.com/kaiachain/kaia/snapshot"
 	"github.com/kaiachain/kaia/storage/database"
 	"github.com/kaiachain/kaia/storage/statedb"
 	"github.com/rcrowley/go-metrics"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // If total insertion time of a block exceeds insertTimeLimit,
@@ -1367,7 +1370,10 @@ func (bc *BlockChain) writeReceipts(hash common.Hash, number uint64, receipts ty
 // writeStateTrie writes state trie to database if possible.
 // If an archiving node is running, it always flushes state trie to DB.
 // If not, it flushes state trie to DB periodically. (period = bc.cacheConfig.BlockInterval)
-func (bc *BlockChain) writeStateTrie(block *types.Block, state *state.StateDB) error {
+func (bc *BlockChain) writeStateTrie(ctx context.Context, block *types.Block, state *state.StateDB) (err error) {
+	_, span := tracing.StartSpan(ctx, "state.commit")
+	defer func() { tracing.EndSpan(span, err) }()
+
 	state.LockGCCachedNode()
 	defer state.UnlockGCCachedNode()
 
@@ -1561,20 +1567,22 @@ func (bc *BlockChain) WriteBlockWithState(block *types.Block, receipts []*types.
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 
-	return bc.writeBlockWithState(block, receipts, stateDB)
+	return bc.writeBlockWithState(context.Background(), block, receipts, stateDB)
 }
 
 // writeBlockWithState writes the block and all associated state to the database.
 // If BlockChain.parallelDBWrite is true, it calls writeBlockWithStateParallel.
 // If not, it calls writeBlockWithStateSerial.
-func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.Receipt, stateDB *state.StateDB) (WriteResult, error) {
+func (bc *BlockChain) writeBlockWithState(ctx context.Context, block *types.Block, receipts []*types.Receipt, stateDB *state.StateDB) (WriteResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "blockchain.writeBlockWithState", attribute.Bool("parallel", bc.parallelDBWrite))
 	var status WriteResult
 	var err error
 	if bc.parallelDBWrite {
-		status, err = bc.writeBlockWithStateParallel(block, receipts, stateDB)
+		status, err = bc.writeBlockWithStateParallel(ctx, block, receipts, stateDB)
 	} else {
-		status, err = bc.writeBlockWithStateSerial(block, receipts, stateDB)
+		status, err = bc.writeBlockWithStateSerial(ctx, block, receipts, stateDB)
 	}
+	tracing.EndSpan(span, err)
 
 	if err != nil {
 		return status, err
@@ -1631,7 +1639,7 @@ func (bc *BlockChain) writeBlockLogsToRemoteCache(blockLogsKey []byte, receipts
 }
 
 // writeBlockWithStateSerial writes the block and all associated state to the database in serial manner.
-func (bc *BlockChain) writeBlockWithStateSerial(block *types.Block, receipts []*types.Receipt, state *state.StateDB) (WriteResult, error) {
+func (bc *BlockChain) writeBlockWithStateSerial(ctx context.Context, block *types.Block, receipts []*types.Receipt, state *state.StateDB) (WriteResult, error) {
 	start := time.Now()
 	bc.wg.Add(1)
 	defer bc.wg.Done()
@@ -1657,15 +1665,19 @@ func (bc *BlockChain) writeBlockWithStateSerial(block *types.Block, receipts []*
 	bc.hc.WriteTd(block.Hash(), block.NumberU64(), externTd)
 
 	// Write other block data.
+	_, span := tracing.StartSpan(ctx, "db.writeBlock")
 	bc.writeBlock(block)
+	span.End()
 
 	trieWriteStart := time.Now()
-	if err := bc.writeStateTrie(block, state); err != nil {
+	if err := bc.writeStateTrie(ctx, block, state); err != nil {
 		return WriteResult{Status: NonStatTy}, err
 	}
 	trieWriteTime := time.Since(trieWriteStart)
 
+	_, span = tracing.StartSpan(ctx, "db.writeReceipts")
 	bc.writeReceipts(block.Hash(), block.NumberU64(), receipts)
+	span.End()
 
 	// TODO-Klaytn-Issue264 If we are using istanbul BFT, then we always have a canonical chain.
 	//         Later we may be able to refine below code.
@@ -1696,7 +1708,7 @@ func (bc *BlockChain) writeBlockWithStateSerial(block *types.Block, receipts []*
 }
 
 // writeBlockWithStateParallel writes the block and all associated state to the database using goroutines.
-func (bc *BlockChain) writeBlockWithStateParallel(block *types.Block, receipts []*types.Receipt, state *state.StateDB) (WriteResult, error) {
+func (bc *BlockChain) writeBlockWithStateParallel(ctx context.Context, block *types.Block, receipts []*types.Receipt, state *state.StateDB) (WriteResult, error) {
 	start := time.Now()
 	bc.wg.Add(1)
 	defer bc.wg.Done()
@@ -1731,14 +1743,16 @@ func (bc *BlockChain) writeBlockWithStateParallel(block *types.Block, receipts [
 	// Write other block data.
 	go func() {
 		defer parallelDBWriteWG.Done()
+		_, span := tracing.StartSpan(ctx, "db.writeBlock")
 		bc.writeBlock(block)
+		span.End()
 	}()
 
 	var trieWriteTime time.Duration
 	trieWriteStart := time.Now()
 	go func() {
 		defer parallelDBWriteWG.Done()
-		if err := bc.writeStateTrie(block, state); err != nil {
+		if err := bc.writeStateTrie(ctx, block, state); err != nil {
 			parallelDBWriteErrCh <- err
 		}
 		trieWriteTime = time.Since(trieWriteStart)
@@ -1746,7 +1760,9 @@ func (bc *BlockChain) writeBlockWithStateParallel(block *types.Block, receipts [
 
 	go func() {
 		defer parallelDBWriteWG.Done()
+		_, span := tracing.StartSpan(ctx, "db.writeReceipts")
 		bc.writeReceipts(block.Hash(), block.NumberU64(), receipts)
+		span.End()
 	}()
 
 	// Wait until all writing goroutines are terminated.
@@ -1873,6 +1889,10 @@ func (bc *BlockChain) insertChain(chain types.Blocks) (int, []interface{}, []*ty
 	bc.wg.Add(1)
 	defer bc.wg.Done()
 
+	ctx, span := tracing.StartSpan(context.Background(), "blockchain.insertChain",
+		attribute.Int("blocks", len(chain)), attribute.Int64("block.first", int64(chain[0].NumberU64())))
+	defer span.End()
+
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 
@@ -2054,17 +2074,28 @@ func (bc *BlockChain) insertChain(chain types.Blocks) (int, []interface{}, []*ty
 			return i, events, coalescedLogs, err
 		}
 
+		blockCtx, blockSpan := tracing.StartSpanFunc(ctx, func() (string, []attribute.KeyValue) {
+			return "blockchain.insertBlock", []attribute.KeyValue{
+				attribute.Int64("block.number", int64(block.NumberU64())), attribute.String("block.hash", block.Hash().Hex()),
+				attribute.Int("block.txs", len(block.Transactions())),
+			}
+		})
+
 		// Process block using the parent state as reference point.
-		receipts, logs, usedGas, internalTxTraces, procStats, err := bc.processor.Process(block, stateDB, bc.vmConfig)
+		receipts, logs, usedGas, internalTxTraces, procStats, err := bc.processor.Process(blockCtx, block, stateDB, bc.vmConfig)
 		if err != nil {
+			tracing.EndSpan(blockSpan, err)
 			bc.reportBlock(block, receipts, err)
 			atomic.StoreUint32(&followupInterrupt, 1)
 			return i, events, coalescedLogs, err
 		}
 
 		// Validate the state using the default validator
+		_, validateSpan := tracing.StartSpan(blockCtx, "blockchain.validateState")
 		err = bc.validator.ValidateState(block, parent, stateDB, receipts, usedGas)
+		tracing.EndSpan(validateSpan, err)
 		if err != nil {
+			tracing.EndSpan(blockSpan, err)
 			bc.reportBlock(block, receipts, err)
 			atomic.StoreUint32(&followupInterrupt, 1)
 			return i, events, coalescedLogs, err
@@ -2072,7 +2103,8 @@ func (bc *BlockChain) insertChain(chain types.Blocks) (int, []interface{}, []*ty
 		afterValidate := time.Now()
 
 		// Write the block to the chain and get the writeResult.
-		writeResult, err := bc.writeBlockWithState(block, receipts, stateDB)
+		writeResult, err := bc.writeBlockWithState(blockCtx, block, receipts, stateDB)
+		tracing.EndSpan(blockSpan, err)
 		if err != nil {
 			atomic.StoreUint32(&followupInterrupt, 1)
 			if err == ErrKnownBlock {
@@ -20,6 +20,7 @@ package blockchain
 
 import (
 	"errors"
+	"maps"
 	"sync"
 	"time"
 
@@ -39,6 +40,7 @@ var (
 	thresholdGauge           = metrics.NewRegisteredGauge("txpool/throttler/threshold", nil)
 	candidateSizeGauge       = metrics.NewRegisteredGauge("txpool/throttler/candidate/size", nil)
 	throttledSizeGauge       = metrics.NewRegisteredGauge("txpool/throttler/throttled/size", nil)
+	throttledPeersSizeGauge  = metrics.NewRegisteredGauge("txpool/throttler/throttled/peers/size", nil)
 	allowedSizeGauge         = metrics.NewRegisteredGauge("txpool/throttler/allowed/size", nil)
 	throttlerUpdateTimeGauge = metrics.NewRegisteredGauge("txpool/throttler/update/time", nil)
 	throttlerDropCount       = metrics.NewRegisteredCounter("txpool/throttler/dropped/count", nil)
@@ -46,11 +48,19 @@ var (
 
 type throttler struct {
 	config *ThrottlerConfig
+	rules  []SpamScoringRule
+	signer types.Signer // to recover the senders if a rule requires them. Can be nil
 
-	candidates map[common.Address]int  // throttle candidates with spam weight. Not for concurrent use
-	throttled  map[common.Address]int  // throttled addresses with throttle time. Requires mu.lock for concurrent use
-	allowed    map[common.Address]bool // white listed addresses. Requires mu.lock for concurrent use
-	mu         *sync.RWMutex           // mutex for throttled and allowed
+	candidates     map[common.Address]int            // throttle candidates with spam weight
+	contributions  map[common.Address]map[string]int // spam weight given to the candidates by each rule
+	peerCandidates map[string]int                    // throttle candidate peers and remote IPs with spam weight
+	throttled      map[common.Address]int            // throttled addresses with throttle time
+	throttledPeers map[string]int                    // throttled peers and remote IPs with throttle time
+	allowed        map[common.Address]int64          // white listed addresses with expiry unix time. Zero means no expiry
+	mu             *sync.RWMutex                     // mutex for all of the above maps
+
+	journalPath string // file to persist the throttled and allowed lists. Empty means no persistence
+	journalMu   sync.Mutex
 
 	threshold  int
 	throttleCh chan *types.Transaction
@@ -69,6 +79,16 @@ type ThrottlerConfig struct {
 	MinimumThreshold    int `json:"minimum_threshold"`
 	ThresholdAdjustment int `json:"threshold_adjustment"`
 	ThrottleSeconds     int `json:"throttle_seconds"`
+
+	// Optional scoring rules. Each rule is disabled if its first field is zero.
+	RevertRatio         uint   `json:"revert_ratio"`            // percentage of failed txs to a to-address in a block
+	RevertRatioMinTxs   uint   `json:"revert_ratio_min_txs"`    // minimum number of txs to a to-address to apply RevertRatio
+	RevertRatioWeight   int    `json:"revert_ratio_weight"`     // weight given once per block
+	GasWastedUnit       uint64 `json:"gas_wasted_unit"`         // weight 1 is given per GasWastedUnit used by failed txs
+	MaxNonceGap         uint64 `json:"max_nonce_gap"`           // maximum gap between the nonce of a tx and the pending nonce of the sender
+	NonceGapWeight      int    `json:"nonce_gap_weight"`        // weight given to the sender per tx exceeding MaxNonceGap
+	MaxPeerTxsPerSecond uint   `json:"max_peer_txs_per_second"` // maximum number of txs from a peer or a remote IP per second
+	PeerBurstWeight     int    `json:"peer_burst_weight"`       // weight given to the peer or the remote IP per tx exceeding MaxPeerTxsPerSecond
 }
 
 var DefaultSpamThrottlerConfig = &ThrottlerConfig{
@@ -83,6 +103,11 @@ var DefaultSpamThrottlerConfig = &ThrottlerConfig{
 	MinimumThreshold:    100,
 	ThresholdAdjustment: 5,
 	ThrottleSeconds:     300,
+
+	RevertRatioMinTxs: 10,
+	RevertRatioWeight: 50,
+	NonceGapWeight:    5,
+	PeerBurstWeight:   1,
 }
 
 func GetSpamThrottler() *throttler {
@@ -102,6 +127,9 @@ func validateConfig(conf *ThrottlerConfig) error {
 	if conf.InitialThreshold < conf.MinimumThreshold {
 		return errors.New("invalid ThrottlerConfig. MinimumThreshold <= InitialThreshold")
 	}
+	if conf.RevertRatio > 100 {
+		return errors.New("invalid ThrottlerConfig. 0 <= RevertRatio <= 100")
+	}
 
 	return nil
 }
@@ -133,71 +161,102 @@ func (t *throttler) newAllowed(allowed []common.Address) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	a := make(map[common.Address]bool, len(allowed))
+	a := make(map[common.Address]int64, len(allowed))
 	for _, addr := range allowed {
-		a[addr] = true
+		a[addr] = 0
 	}
 	t.allowed = a
 }
 
-// updateThrottled removes outdated addresses from the throttle list and adds new addresses to the list.
-func (t *throttler) updateThrottled(newThrottled []common.Address) {
-	var removeThrottled []common.Address
+// updateThrottled removes outdated addresses and peers from the throttle lists and adds new ones to the lists.
+// It also removes the expired addresses from the allowed list.
+func (t *throttler) updateThrottled(newThrottled []common.Address, newThrottledPeers []string) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	// Decrease throttling remained time for all throttled addresses.
+	// Decrease throttling remained time for all throttled addresses and peers.
 	for addr, remained := range t.throttled {
-		t.throttled[addr] = remained - 1
-		if t.throttled[addr] < 0 {
-			removeThrottled = append(removeThrottled, addr)
+		if remained <= 0 {
+			delete(t.throttled, addr)
+		} else {
+			t.throttled[addr] = remained - 1
 		}
 	}
-
-	// Remove throttled addresses from throttled map.
-	for _, addr := range removeThrottled {
-		delete(t.throttled, addr)
+	for peer, remained := range t.throttledPeers {
+		if remained <= 0 {
+			delete(t.throttledPeers, peer)
+		} else {
+			t.throttledPeers[peer] = remained - 1
+		}
 	}
 
 	for _, addr := range newThrottled {
 		t.throttled[addr] = t.config.ThrottleSeconds
 	}
+	for _, peer := range newThrottledPeers {
+		t.throttledPeers[peer] = t.config.ThrottleSeconds
+	}
+
+	now := time.Now().Unix()
+	for addr, expiry := range t.allowed {
+		if expiry != 0 && expiry <= now {
+			delete(t.allowed, addr)
+		}
+	}
 
 	// Update metrics
 	throttledSizeGauge.Update(int64(len(t.throttled)))
+	throttledPeersSizeGauge.Update(int64(len(t.throttledPeers)))
 	allowedSizeGauge.Update(int64(len(t.allowed)))
 }
 
-// updateThrottlerState updates the throttle list by calculating spam weight of candidates.
+// addWeights adds the spam weights given by a rule to the candidates.
+// New candidates are not added if the number of candidates reaches MaxCandidates.
+func (t *throttler) addWeights(rule string, addrs map[common.Address]int, peers map[string]int) {
+	for addr, weight := range addrs {
+		if weight <= 0 {
+			continue
+		}
+		if _, ok := t.candidates[addr]; !ok {
+			if uint(len(t.candidates)) >= t.config.MaxCandidates {
+				continue
+			}
+			t.contributions[addr] = make(map[string]int)
+		}
+		t.candidates[addr] += weight
+		t.contributions[addr][rule] += weight
+	}
+	for peer, weight := range peers {
+		if weight <= 0 {
+			continue
+		}
+		if _, ok := t.peerCandidates[peer]; !ok && uint(len(t.peerCandidates)) >= t.config.MaxCandidates {
+			continue
+		}
+		t.peerCandidates[peer] += weight
+	}
+}
+
+// updateThrottlerState updates the throttle lists by calculating spam weight of candidates.
 func (t *throttler) updateThrottlerState(txs types.Transactions, receipts types.Receipts) {
-	var removeCandidate []common.Address
 	var newThrottled []common.Address
+	var newThrottledPeers []string
 
 	startTime := time.Now()
 	numFailed := 0
 	failRatio := uint(0)
-	mapSize := uint(len(t.candidates))
 
-	// Increase spam weight of throttle candidates who generate failed txs.
-	for i, receipt := range receipts {
+	for _, receipt := range receipts {
 		if receipt.Status != types.ReceiptStatusSuccessful {
 			numFailed++
+		}
+	}
 
-			toAddr := txs[i].To()
-			if toAddr == nil {
-				continue
-			}
-
-			weight := t.candidates[*toAddr]
-			if weight == 0 {
-				if mapSize >= t.config.MaxCandidates {
-					continue
-				}
-				mapSize++
-			}
+	t.mu.Lock()
 
-			t.candidates[*toAddr] = weight + t.config.IncreaseWeight
-		}
+	// Increase spam weight of throttle candidates by the scoring rules.
+	for _, rule := range t.rules {
+		t.addWeights(rule.Name(), rule.ScoreBlock(txs, receipts), nil)
 	}
 
 	// Decrease spam weight for all candidates and update throttle lists in throttled.
@@ -206,44 +265,102 @@ func (t *throttler) updateThrottlerState(txs types.Transactions, receipts types.
 
 		switch {
 		case newWeight <= 0:
-			removeCandidate = append(removeCandidate, addr)
+			delete(t.candidates, addr)
+			delete(t.contributions, addr)
 
 		case newWeight > t.threshold:
-			removeCandidate = append(removeCandidate, addr)
+			delete(t.candidates, addr)
+			delete(t.contributions, addr)
 			newThrottled = append(newThrottled, addr)
 
 		default:
 			t.candidates[addr] = newWeight
 		}
 	}
+	for peer, weight := range t.peerCandidates {
+		newWeight := weight - t.config.DecreaseWeight
 
-	// Remove throttle candidates from candidates map.
-	for _, addr := range removeCandidate {
-		delete(t.candidates, addr)
+		switch {
+		case newWeight <= 0:
+			delete(t.peerCandidates, peer)
+
+		case newWeight > t.threshold:
+			delete(t.peerCandidates, peer)
+			newThrottledPeers = append(newThrottledPeers, peer)
+
+		default:
+			t.peerCandidates[peer] = newWeight
+		}
 	}
+	numCandidates := len(t.candidates)
+
+	t.mu.Unlock()
 
 	if len(receipts) != 0 {
 		failRatio = uint(100 * numFailed / len(receipts))
 	}
 
 	// Update throttled and threshold
-	t.updateThrottled(newThrottled)
+	t.updateThrottled(newThrottled, newThrottledPeers)
 	t.adjustThreshold(failRatio)
 
 	// Update metrics
-	candidateSizeGauge.Update(int64(len(t.candidates)))
+	candidateSizeGauge.Update(int64(numCandidates))
 	throttlerUpdateTimeGauge.Update(int64(time.Since(startTime)))
 }
 
-// classifyTxs classifies given txs into allowTxs and throttleTxs.
-// If to-address of tx is listed in the throttle list, it is classified as throttleTx.
-func (t *throttler) classifyTxs(txs types.Transactions) (types.Transactions, types.Transactions) {
-	allowTxs := txs[:0]
-	throttleTxs := txs[:0]
+// updateTxMsgState increases spam weight of throttle candidates by a tx message from a peer.
+func (t *throttler) updateTxMsgState(msg *SpamTxMsg) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, rule := range t.rules {
+		addrs, peers := rule.ScoreTxMsg(msg)
+		t.addWeights(rule.Name(), addrs, peers)
+	}
+}
+
+// needSenders returns true if a scoring rule requires the senders of the txs in a tx message.
+func (t *throttler) needSenders() bool {
+	return t.signer != nil && t.config.MaxNonceGap > 0
+}
+
+// classifyTxs classifies given txs from a peer into allowTxs and throttleTxs.
+// If the peer or its remote IP is listed in the throttle list, all txs are classified as throttleTxs,
+// since the allowed list only exempts addresses. Otherwise, if the to-address or sender of tx is listed
+// in the throttle list, it is classified as throttleTx unless the to-address or sender is listed in
+// the allowed list. The peerIP can be empty and the senders can be nil if unknown.
+func (t *throttler) classifyTxs(peerID, peerIP string, txs types.Transactions, senders []common.Address) (types.Transactions, types.Transactions) {
+	// Do not reuse the underlying array of txs, since throttleTxs would overwrite allowTxs.
+	allowTxs := make(types.Transactions, 0, len(txs))
+	throttleTxs := make(types.Transactions, 0)
 
 	t.mu.RLock()
-	for _, tx := range txs {
-		if tx.To() != nil && t.throttled[*tx.To()] > 0 && t.allowed[*tx.To()] == false {
+	now := time.Now().Unix()
+	peerThrottled := t.throttledPeers[peerID] > 0 || (peerIP != "" && t.throttledPeers[peerIP] > 0)
+	for i, tx := range txs {
+		if peerThrottled {
+			throttleTxs = append(throttleTxs, tx)
+			continue
+		}
+
+		var sender common.Address
+		if i < len(senders) {
+			sender = senders[i]
+		}
+
+		throttled := false
+		allowed := false
+		if to := tx.To(); to != nil {
+			throttled = t.throttled[*to] > 0
+			allowed = t.isAllowed(*to, now)
+		}
+		if sender != (common.Address{}) {
+			throttled = throttled || t.throttled[sender] > 0
+			allowed = allowed || t.isAllowed(sender, now)
+		}
+
+		if throttled && !allowed {
 			throttleTxs = append(throttleTxs, tx)
 		} else {
 			allowTxs = append(allowTxs, tx)
@@ -254,14 +371,28 @@ func (t *throttler) classifyTxs(txs types.Transactions) (types.Transactions, typ
 	return allowTxs, throttleTxs
 }
 
+// isAllowed returns true if the address is in the allowed list and not expired. Requires mu.RLock.
+func (t *throttler) isAllowed(addr common.Address, now int64) bool {
+	expiry, ok := t.allowed[addr]
+	return ok && (expiry == 0 || expiry > now)
+}
+
 // SetAllowed resets the allowed list of throttler. The previous list will be abandoned.
 func (t *throttler) SetAllowed(list []common.Address) {
+	t.newAllowed(list)
+}
+
+// AddAllowed adds the addresses to the allowed list for the given duration. Zero duration means no expiry.
+func (t *throttler) AddAllowed(list []common.Address, duration time.Duration) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	t.allowed = make(map[common.Address]bool)
+	expiry := int64(0)
+	if duration > 0 {
+		expiry = time.Now().Add(duration).Unix()
+	}
 	for _, addr := range list {
-		t.allowed[addr] = true
+		t.allowed[addr] = expiry
 	}
 }
 
@@ -269,9 +400,12 @@ func (t *throttler) GetAllowed() []common.Address {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
+	now := time.Now().Unix()
 	allowList := make([]common.Address, 0)
 	for addr := range t.allowed {
-		allowList = append(allowList, addr)
+		if t.isAllowed(addr, now) {
+			allowList = append(allowList, addr)
+		}
 	}
 	return allowList
 }
@@ -291,7 +425,63 @@ func (t *throttler) GetCandidates() map[common.Address]int {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	return t.candidates
+	return maps.Clone(t.candidates)
+}
+
+// SpamScore is the spam throttler state of an address.
+type SpamScore struct {
+	Address      common.Address `json:"address"`
+	Score        int            `json:"score"`
+	Threshold    int            `json:"threshold"`
+	Rules        map[string]int `json:"rules"` // spam weight given by each rule since the address became a candidate
+	ThrottledFor int            `json:"throttledFor"`
+	Allowed      bool           `json:"allowed"`
+	AllowedUntil int64          `json:"allowedUntil,omitempty"`
+}
+
+// SpamPeerScore is the spam throttler state of a peer or a remote IP.
+type SpamPeerScore struct {
+	Score        int `json:"score"`
+	ThrottledFor int `json:"throttledFor"`
+}
+
+func (t *throttler) GetScore(addr common.Address) *SpamScore {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	score := &SpamScore{
+		Address:      addr,
+		Score:        t.candidates[addr],
+		Threshold:    t.threshold,
+		Rules:        maps.Clone(t.contributions[addr]),
+		ThrottledFor: t.throttled[addr],
+		Allowed:      t.isAllowed(addr, time.Now().Unix()),
+	}
+	if score.Rules == nil {
+		score.Rules = make(map[string]int)
+	}
+	if score.Allowed {
+		score.AllowedUntil = t.allowed[addr]
+	}
+	return score
+}
+
+// GetPeerScores returns the states of the candidate and throttled peers, keyed by the peer ID or the remote IP.
+func (t *throttler) GetPeerScores() map[string]*SpamPeerScore {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	scores := make(map[string]*SpamPeerScore)
+	for peer, weight := range t.peerCandidates {
+		scores[peer] = &SpamPeerScore{Score: weight}
+	}
+	for peer, remained := range t.throttledPeers {
+		if scores[peer] == nil {
+			scores[peer] = &SpamPeerScore{}
+		}
+		scores[peer].ThrottledFor = remained
+	}
+	return scores
 }
 
 func (t *throttler) GetConfig() *ThrottlerConfig {
@@ -0,0 +1,122 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/kaiachain/kaia/common"
+)
+
+// spamThrottlerJournalInterval is the time interval to persist the throttled and allowed lists.
+const spamThrottlerJournalInterval = time.Minute
+
+// throttlerJournal is the disk format of the throttled and allowed lists.
+// The throttle times are stored as unix times so that they keep expiring while the node is down.
+type throttlerJournal struct {
+	Throttled      map[common.Address]int64 `json:"throttled"`
+	ThrottledPeers map[string]int64         `json:"throttledPeers"`
+	Allowed        map[common.Address]int64 `json:"allowed"`
+}
+
+// loadJournal restores the throttled and allowed lists from the journal, dropping the expired entries.
+func (t *throttler) loadJournal() error {
+	if t.journalPath == "" {
+		return nil
+	}
+
+	t.journalMu.Lock()
+	defer t.journalMu.Unlock()
+
+	b, err := os.ReadFile(t.journalPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var journal throttlerJournal
+	if err := json.Unmarshal(b, &journal); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now().Unix()
+	for addr, expiry := range journal.Throttled {
+		if expiry > now {
+			t.throttled[addr] = int(expiry - now)
+		}
+	}
+	for peer, expiry := range journal.ThrottledPeers {
+		if expiry > now {
+			t.throttledPeers[peer] = int(expiry - now)
+		}
+	}
+	for addr, expiry := range journal.Allowed {
+		if expiry == 0 || expiry > now {
+			t.allowed[addr] = expiry
+		}
+	}
+	logger.Info("Loaded spam throttler journal", "throttled", len(t.throttled), "throttledPeers", len(t.throttledPeers), "allowed", len(t.allowed))
+	return nil
+}
+
+// saveJournal persists the throttled and allowed lists to the journal.
+func (t *throttler) saveJournal() error {
+	if t.journalPath == "" {
+		return nil
+	}
+
+	t.journalMu.Lock()
+	defer t.journalMu.Unlock()
+
+	// The throttle time is decreased every block, which is roughly a second.
+	t.mu.RLock()
+	now := time.Now().Unix()
+	journal := throttlerJournal{
+		Throttled:      make(map[common.Address]int64, len(t.throttled)),
+		ThrottledPeers: make(map[string]int64, len(t.throttledPeers)),
+		Allowed:        make(map[common.Address]int64, len(t.allowed)),
+	}
+	for addr, remained := range t.throttled {
+		journal.Throttled[addr] = now + int64(remained)
+	}
+	for peer, remained := range t.throttledPeers {
+		journal.ThrottledPeers[peer] = now + int64(remained)
+	}
+	for addr, expiry := range t.allowed {
+		journal.Allowed[addr] = expiry
+	}
+	t.mu.RUnlock()
+
+	b, err := json.Marshal(journal)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first not to corrupt the journal on failure.
+	tmp := t.journalPath + ".new"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, t.journalPath)
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+)
+
+const (
+	SpamRuleFailedTx    = "failedTx"
+	SpamRuleRevertRatio = "revertRatio"
+	SpamRuleGasWasted   = "gasWasted"
+	SpamRuleNonceGap    = "nonceGap"
+	SpamRulePeerBurst   = "peerBurst"
+)
+
+// SpamTxMsg is a tx message received from a peer, to be scored by the spam throttler.
+type SpamTxMsg struct {
+	PeerID string
+	PeerIP string // empty if unknown
+	Txs    types.Transactions
+
+	// Senders are the senders of Txs. They are only recovered if a rule requires them,
+	// and a zero address means that the sender is unknown.
+	Senders []common.Address
+	// PendingNonces are the pending nonces of the senders in the txpool.
+	PendingNonces map[common.Address]uint64
+}
+
+// SpamScoringRule gives spam weights to the addresses and the peers. The peers are keyed by
+// the peer ID or the remote IP, so that a spammer cannot evade by reconnecting. The weights given by
+// all rules are summed up, and the address or the peer is throttled if the sum exceeds the
+// threshold of the throttler. A rule returns nil for the events it does not score.
+type SpamScoringRule interface {
+	Name() string
+
+	// ScoreBlock scores the addresses by the txs executed in a block.
+	ScoreBlock(txs types.Transactions, receipts types.Receipts) map[common.Address]int
+
+	// ScoreTxMsg scores the addresses and the peers by a tx message from a peer.
+	ScoreTxMsg(msg *SpamTxMsg) (map[common.Address]int, map[string]int)
+}
+
+// newSpamScoringRules returns the rules enabled by the config.
+func newSpamScoringRules(conf *ThrottlerConfig) []SpamScoringRule {
+	rules := []SpamScoringRule{&failedTxRule{weight: conf.IncreaseWeight}}
+	if conf.RevertRatio > 0 {
+		rules = append(rules, &revertRatioRule{ratio: conf.RevertRatio, minTxs: conf.RevertRatioMinTxs, weight: conf.RevertRatioWeight})
+	}
+	if conf.GasWastedUnit > 0 {
+		rules = append(rules, &gasWastedRule{unit: conf.GasWastedUnit})
+	}
+	if conf.MaxNonceGap > 0 {
+		rules = append(rules, &nonceGapRule{maxGap: conf.MaxNonceGap, weight: conf.NonceGapWeight})
+	}
+	if conf.MaxPeerTxsPerSecond > 0 {
+		rules = append(rules, &peerBurstRule{maxTxs: conf.MaxPeerTxsPerSecond, weight: conf.PeerBurstWeight, counts: make(map[string]uint)})
+	}
+	return rules
+}
+
+// blockRule is embedded by the rules that only score the executed blocks.
+type blockRule struct{}
+
+func (blockRule) ScoreTxMsg(*SpamTxMsg) (map[common.Address]int, map[string]int) { return nil, nil }
+
+// txMsgRule is embedded by the rules that only score the tx messages.
+type txMsgRule struct{}
+
+func (txMsgRule) ScoreBlock(types.Transactions, types.Receipts) map[common.Address]int { return nil }
+
+// failedTxRule gives weight to the to-address of each failed tx.
+type failedTxRule struct {
+	blockRule
+	weight int
+}
+
+func (r *failedTxRule) Name() string { return SpamRuleFailedTx }
+
+func (r *failedTxRule) ScoreBlock(txs types.Transactions, receipts types.Receipts) map[common.Address]int {
+	scores := make(map[common.Address]int)
+	for i, receipt := range receipts {
+		if receipt.Status != types.ReceiptStatusSuccessful && txs[i].To() != nil {
+			scores[*txs[i].To()] += r.weight
+		}
+	}
+	return scores
+}
+
+// revertRatioRule gives weight to the to-address whose txs in a block fail at least at the given ratio.
+type revertRatioRule struct {
+	blockRule
+	ratio  uint // percentage
+	minTxs uint
+	weight int
+}
+
+func (r *revertRatioRule) Name() string { return SpamRuleRevertRatio }
+
+func (r *revertRatioRule) ScoreBlock(txs types.Transactions, receipts types.Receipts) map[common.Address]int {
+	var (
+		total  = make(map[common.Address]uint)
+		failed = make(map[common.Address]uint)
+	)
+	for i, receipt := range receipts {
+		if txs[i].To() == nil {
+			continue
+		}
+		to := *txs[i].To()
+		total[to]++
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			failed[to]++
+		}
+	}
+
+	scores := make(map[common.Address]int)
+	for addr, n := range failed {
+		if total[addr] >= r.minTxs && 100*n >= r.ratio*total[addr] {
+			scores[addr] = r.weight
+		}
+	}
+	return scores
+}
+
+// gasWastedRule gives weight to the to-address of the failed txs by every unit of gas they used.
+type gasWastedRule struct {
+	blockRule
+	unit uint64
+}
+
+func (r *gasWastedRule) Name() string { return SpamRuleGasWasted }
+
+func (r *gasWastedRule) ScoreBlock(txs types.Transactions, receipts types.Receipts) map[common.Address]int {
+	wasted := make(map[common.Address]uint64)
+	for i, receipt := range receipts {
+		if receipt.Status != types.ReceiptStatusSuccessful && txs[i].To() != nil {
+			wasted[*txs[i].To()] += receipt.GasUsed
+		}
+	}
+
+	scores := make(map[common.Address]int)
+	for addr, gas := range wasted {
+		if w := int(gas / r.unit); w > 0 {
+			scores[addr] = w
+		}
+	}
+	return scores
+}
+
+// nonceGapRule gives weight to the sender of each tx whose nonce is too far ahead of the pending nonce.
+// Such txs can only wait in the queue, occupying the txpool.
+type nonceGapRule struct {
+	txMsgRule
+	maxGap uint64
+	weight int
+}
+
+func (r *nonceGapRule) Name() string { return SpamRuleNonceGap }
+
+func (r *nonceGapRule) ScoreTxMsg(msg *SpamTxMsg) (map[common.Address]int, map[string]int) {
+	scores := make(map[common.Address]int)
+	for i, tx := range msg.Txs {
+		if i >= len(msg.Senders) || msg.Senders[i] == (common.Address{}) {
+			continue
+		}
+		sender := msg.Senders[i]
+		if nonce, ok := msg.PendingNonces[sender]; ok && tx.Nonce() > nonce+r.maxGap {
+			scores[sender] += r.weight
+		}
+	}
+	return scores, nil
+}
+
+// peerBurstRule gives weight to the peer and its remote IP for each tx exceeding the per-second limit.
+// Counting by remote IP catches a spammer spreading the txs over many peer IDs.
+type peerBurstRule struct {
+	txMsgRule
+	maxTxs uint
+	weight int
+
+	mu          sync.Mutex
+	windowStart time.Time
+	counts      map[string]uint
+}
+
+func (r *peerBurstRule) Name() string { return SpamRulePeerBurst }
+
+func (r *peerBurstRule) ScoreTxMsg(msg *SpamTxMsg) (map[common.Address]int, map[string]int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now := time.Now(); now.Sub(r.windowStart) >= time.Second {
+		r.windowStart = now
+		r.counts = make(map[string]uint)
+	}
+
+	scores := make(map[string]int)
+	for _, peer := range []string{msg.PeerID, msg.PeerIP} {
+		if peer == "" {
+			continue
+		}
+		prev := r.counts[peer]
+		r.counts[peer] = prev + uint(len(msg.Txs))
+
+		if excess := int(r.counts[peer]) - int(max(prev, r.maxTxs)); excess > 0 {
+			scores[peer] = excess * r.weight
+		}
+	}
+	if len(scores) == 0 {
+		return nil, nil
+	}
+	return nil, scores
+}
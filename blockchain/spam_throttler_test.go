@@ -17,25 +17,32 @@ package blockchain
 
 import (
 	"math/big"
+	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/params"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func newTestThrottler(config *ThrottlerConfig) *throttler {
 	return &throttler{
-		config:     config,
-		candidates: make(map[common.Address]int),
-		throttled:  make(map[common.Address]int),
-		allowed:    make(map[common.Address]bool),
-		mu:         new(sync.RWMutex),
-		threshold:  config.InitialThreshold,
-		throttleCh: make(chan *types.Transaction, config.ThrottleTPS*5),
-		quitCh:     make(chan struct{}),
+		config:         config,
+		rules:          newSpamScoringRules(config),
+		candidates:     make(map[common.Address]int),
+		contributions:  make(map[common.Address]map[string]int),
+		peerCandidates: make(map[string]int),
+		throttled:      make(map[common.Address]int),
+		throttledPeers: make(map[string]int),
+		allowed:        make(map[common.Address]int64),
+		mu:             new(sync.RWMutex),
+		threshold:      config.InitialThreshold,
+		throttleCh:     make(chan *types.Transaction, config.ThrottleTPS*5),
+		quitCh:         make(chan struct{}),
 	}
 }
 
@@ -110,3 +117,139 @@ func TestThrottler_updateThrottlerState(t *testing.T) {
 		assert.Equal(t, tc.throttledWeight, th.throttled[toFail])
 	}
 }
+
+func TestThrottler_ScoringRules(t *testing.T) {
+	var (
+		to       = common.BytesToAddress(common.MakeRandomBytes(20))
+		other    = common.BytesToAddress(common.MakeRandomBytes(20))
+		gasPrice = big.NewInt(25 * params.Gkei)
+	)
+
+	config := *DefaultSpamThrottlerConfig
+	config.RevertRatio = 50
+	config.GasWastedUnit = 100000
+	th := newTestThrottler(&config)
+
+	// 10 txs to `to` among which 6 fail using 50000 gas each, and 10 successful txs to `other`.
+	var txs types.Transactions
+	var receipts types.Receipts
+	for i := 0; i < 10; i++ {
+		txs = append(txs, types.NewTransaction(0, to, common.Big0, 100000, gasPrice, nil))
+		status := types.ReceiptStatusSuccessful
+		if i < 6 {
+			status = types.ReceiptStatusFailed
+		}
+		receipts = append(receipts, &types.Receipt{Status: status, GasUsed: 50000})
+	}
+	for i := 0; i < 10; i++ {
+		txs = append(txs, types.NewTransaction(0, other, common.Big0, 100000, gasPrice, nil))
+		receipts = append(receipts, &types.Receipt{Status: types.ReceiptStatusSuccessful, GasUsed: 50000})
+	}
+
+	th.updateThrottlerState(txs, receipts)
+
+	score := th.GetScore(to)
+	assert.Equal(t, map[string]int{
+		SpamRuleFailedTx:    6 * config.IncreaseWeight,
+		SpamRuleRevertRatio: config.RevertRatioWeight,
+		SpamRuleGasWasted:   3,
+	}, score.Rules)
+	assert.Equal(t, 6*config.IncreaseWeight+config.RevertRatioWeight+3-config.DecreaseWeight, score.Score)
+	assert.Equal(t, 0, th.GetScore(other).Score)
+}
+
+func TestThrottler_TxMsgRules(t *testing.T) {
+	var (
+		to       = common.BytesToAddress(common.MakeRandomBytes(20))
+		sender   = common.BytesToAddress(common.MakeRandomBytes(20))
+		gasPrice = big.NewInt(25 * params.Gkei)
+	)
+
+	config := *DefaultSpamThrottlerConfig
+	config.MaxNonceGap = 10
+	config.MaxPeerTxsPerSecond = 5
+	th := newTestThrottler(&config)
+
+	// 3 txs with nonce gaps bigger than MaxNonceGap and 5 txs without.
+	var txs types.Transactions
+	var senders []common.Address
+	for i := 0; i < 8; i++ {
+		nonce := uint64(i)
+		if i < 3 {
+			nonce = 100
+		}
+		txs = append(txs, types.NewTransaction(nonce, to, common.Big0, 21000, gasPrice, nil))
+		senders = append(senders, sender)
+	}
+	th.updateTxMsgState(&SpamTxMsg{
+		PeerID:        "peer",
+		PeerIP:        "10.0.0.1",
+		Txs:           txs,
+		Senders:       senders,
+		PendingNonces: map[common.Address]uint64{sender: 0},
+	})
+	assert.Equal(t, 3*config.NonceGapWeight, th.GetScore(sender).Rules[SpamRuleNonceGap])
+	assert.Equal(t, 3*config.PeerBurstWeight, th.GetPeerScores()["peer"].Score)
+	assert.Equal(t, 3*config.PeerBurstWeight, th.GetPeerScores()["10.0.0.1"].Score)
+
+	// Another peer ID from the same IP adds to the weight of the IP.
+	th.updateTxMsgState(&SpamTxMsg{PeerID: "peer2", PeerIP: "10.0.0.1", Txs: txs[:2]})
+	assert.Nil(t, th.GetPeerScores()["peer2"])
+	assert.Equal(t, 5*config.PeerBurstWeight, th.GetPeerScores()["10.0.0.1"].Score)
+
+	// The peer exceeding the threshold is throttled, and all of its txs are throttled
+	// even if they are to the allowed addresses.
+	th.mu.Lock()
+	th.peerCandidates["peer"] = th.threshold + config.DecreaseWeight + 1
+	th.mu.Unlock()
+	th.updateThrottlerState(nil, nil)
+	assert.Equal(t, config.ThrottleSeconds, th.GetPeerScores()["peer"].ThrottledFor)
+
+	allowTxs, throttleTxs := th.classifyTxs("peer", "", txs, nil)
+	assert.Len(t, allowTxs, 0)
+	assert.Len(t, throttleTxs, len(txs))
+
+	th.AddAllowed([]common.Address{to}, time.Hour)
+	allowTxs, throttleTxs = th.classifyTxs("peer", "", txs, nil)
+	assert.Len(t, allowTxs, 0)
+	assert.Len(t, throttleTxs, len(txs))
+
+	allowTxs, throttleTxs = th.classifyTxs("other", "", txs, nil)
+	assert.Len(t, allowTxs, len(txs))
+	assert.Len(t, throttleTxs, 0)
+
+	// A throttled remote IP throttles all of the peers from it.
+	th.updateThrottled(nil, []string{"10.0.0.1"})
+	allowTxs, throttleTxs = th.classifyTxs("other", "10.0.0.1", txs, nil)
+	assert.Len(t, allowTxs, 0)
+	assert.Len(t, throttleTxs, len(txs))
+}
+
+func TestThrottler_Journal(t *testing.T) {
+	var (
+		throttledAddr = common.BytesToAddress(common.MakeRandomBytes(20))
+		allowedAddr   = common.BytesToAddress(common.MakeRandomBytes(20))
+		expiredAddr   = common.BytesToAddress(common.MakeRandomBytes(20))
+	)
+
+	th := newTestThrottler(DefaultSpamThrottlerConfig)
+	th.journalPath = filepath.Join(t.TempDir(), "spam_throttler.json")
+	th.updateThrottled([]common.Address{throttledAddr}, []string{"peer"})
+	th.SetAllowed([]common.Address{allowedAddr})
+	th.allowed[expiredAddr] = time.Now().Unix() - 1
+	require.NoError(t, th.saveJournal())
+
+	// A restarted throttler restores the lists, except for the expired entries.
+	restored := newTestThrottler(DefaultSpamThrottlerConfig)
+	restored.journalPath = th.journalPath
+	require.NoError(t, restored.loadJournal())
+
+	assert.Equal(t, []common.Address{throttledAddr}, restored.GetThrottled())
+	assert.InDelta(t, DefaultSpamThrottlerConfig.ThrottleSeconds, restored.throttled[throttledAddr], 1)
+	assert.InDelta(t, DefaultSpamThrottlerConfig.ThrottleSeconds, restored.GetPeerScores()["peer"].ThrottledFor, 1)
+	assert.Equal(t, []common.Address{allowedAddr}, restored.GetAllowed())
+
+	// A missing journal is not an error.
+	restored.journalPath = filepath.Join(t.TempDir(), "missing.json")
+	assert.NoError(t, restored.loadJournal())
+}
@@ -23,13 +23,16 @@
 package blockchain
 
 import (
+	"context"
 	"time"
 
 	"github.com/kaiachain/kaia/blockchain/state"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/blockchain/vm"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/metrics/tracing"
 	"github.com/kaiachain/kaia/params"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // StateProcessor is a basic Processor, which takes care of transitioning
@@ -62,7 +65,7 @@ func NewStateProcessor(config *params.ChainConfig, bc *BlockChain) *StateProcess
 // Process returns the receipts and logs accumulated during the process and
 // returns the amount of gas that was used in the process. If any of the
 // transactions failed to execute due to insufficient gas it will return an error.
-func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg vm.Config) (types.Receipts, []*types.Log, uint64, []*vm.InternalTxTrace, ProcessStats, error) {
+func (p *StateProcessor) Process(ctx context.Context, block *types.Block, statedb *state.StateDB, cfg vm.Config) (types.Receipts, []*types.Log, uint64, []*vm.InternalTxTrace, ProcessStats, error) {
 	var (
 		receipts         types.Receipts
 		usedGas          = new(uint64)
@@ -81,7 +84,14 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions() {
 		statedb.SetTxContext(tx.Hash(), block.Hash(), i)
+		_, span := tracing.StartSpanFunc(ctx, func() (string, []attribute.KeyValue) {
+			return "blockchain.applyTransaction", []attribute.KeyValue{attribute.Int("tx.index", i), attribute.String("tx.hash", tx.Hash().Hex())}
+		})
 		receipt, internalTxTrace, err := p.bc.ApplyTransaction(p.config, &author, statedb, header, tx, usedGas, &cfg)
+		if err == nil {
+			span.SetAttributes(attribute.Int64("tx.gasUsed", int64(receipt.GasUsed)))
+		}
+		tracing.EndSpan(span, err)
 		if err != nil {
 			return nil, nil, 0, nil, processStats, err
 		}
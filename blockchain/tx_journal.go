@@ -23,18 +23,210 @@
 package blockchain
 
 import (
+	"bufio"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/rlp"
 )
 
-// errNoActiveJournal is returned if a transaction is attempted to be inserted
-// into the journal, but no such file is currently open.
-var errNoActiveJournal = errors.New("no active journal")
+const (
+	// txJournalMagic is the first RLP item of a versioned journal. Unversioned (v0)
+	// journals are a bare stream of transactions, which never start with a string
+	// longer than a byte, so the magic tells the two formats apart.
+	txJournalMagic = "kaia-txpool-journal"
+
+	// TxJournalVersion is the version of the journal format written by this node.
+	TxJournalVersion = 1
+)
+
+var (
+	// errNoActiveJournal is returned if a transaction is attempted to be inserted
+	// into the journal, but no such file is currently open.
+	errNoActiveJournal = errors.New("no active journal")
+
+	errUnknownTxJournalVersion = errors.New("unknown tx journal version")
+)
+
+// TxOrigin tells where a journaled transaction came from.
+type TxOrigin uint8
+
+const (
+	TxOriginUnknown TxOrigin = iota // Loaded from an unversioned journal
+	TxOriginLocal                   // Submitted through the local APIs
+	TxOriginRemote                  // Received from a peer, but sent from a local account
+	TxOriginBridge                  // Sent by the service chain bridge
+	TxOriginGasless                 // Gasless approve or swap transaction
+)
+
+var txOriginNames = []string{"unknown", "local", "remote", "bridge", "gasless"}
+
+func (o TxOrigin) String() string {
+	if int(o) < len(txOriginNames) {
+		return txOriginNames[o]
+	}
+	return fmt.Sprintf("origin(%d)", uint8(o))
+}
+
+// defaultTxOrigin returns the origin of transactions added without one.
+func defaultTxOrigin(local bool) TxOrigin {
+	if local {
+		return TxOriginLocal
+	}
+	return TxOriginRemote
+}
+
+// txJournalModule is implemented by txpool modules whose transactions are kept
+// in the local journal. Transactions of other modules are never journaled.
+type txJournalModule interface {
+	TxJournalOrigin() TxOrigin
+}
+
+// ParseTxOrigin parses the name of a TxOrigin.
+func ParseTxOrigin(name string) (TxOrigin, error) {
+	for i, n := range txOriginNames {
+		if strings.EqualFold(n, name) {
+			return TxOrigin(i), nil
+		}
+	}
+	return TxOriginUnknown, fmt.Errorf("unknown tx origin: %s", name)
+}
+
+// TxJournalEntry is a transaction recorded in the local transaction journal.
+type TxJournalEntry struct {
+	Time    uint64 // Unix time the transaction was first added to the pool, zero if unknown
+	Origin  TxOrigin
+	Tx      *types.Transaction
+	Sidecar *types.BlobTxSidecar `rlp:"nil"` // Sidecar is not part of the transaction encoding
+}
+
+// newTxJournalEntry creates a journal entry for the given transaction.
+func newTxJournalEntry(tx *types.Transaction, insertedAt time.Time, origin TxOrigin) *TxJournalEntry {
+	entry := &TxJournalEntry{
+		Origin:  origin,
+		Tx:      tx.WithoutBlobTxSidecar(),
+		Sidecar: tx.BlobTxSidecar(),
+	}
+	if !insertedAt.IsZero() {
+		entry.Time = uint64(insertedAt.Unix())
+	}
+	return entry
+}
+
+// Transaction returns the journaled transaction with its blob sidecar attached.
+func (e *TxJournalEntry) Transaction() *types.Transaction {
+	if e.Sidecar != nil {
+		return e.Tx.WithBlobTxSidecar(e.Sidecar)
+	}
+	return e.Tx
+}
+
+// InsertedAt returns the time the transaction was first added to the pool.
+// The zero time is returned for entries of unversioned journals.
+func (e *TxJournalEntry) InsertedAt() time.Time {
+	if e.Time == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(e.Time), 0)
+}
+
+// ReadTxJournal iterates all entries of the journal at path, stopping early if
+// fn returns false. It returns the version of the journal.
+func ReadTxJournal(path string, fn func(*TxJournalEntry) bool) (uint64, error) {
+	input, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer input.Close()
+
+	return readTxJournal(input, fn)
+}
+
+func readTxJournal(r io.Reader, fn func(*TxJournalEntry) bool) (uint64, error) {
+	stream := rlp.NewStream(bufio.NewReader(r), 0)
+
+	kind, size, err := stream.Kind()
+	if err == io.EOF {
+		return TxJournalVersion, nil
+	} else if err != nil {
+		return 0, err
+	}
+	// Unversioned journals are a bare stream of transactions
+	if kind != rlp.String || size <= 1 {
+		for {
+			tx := new(types.Transaction)
+			if err := stream.Decode(tx); err == io.EOF {
+				return 0, nil
+			} else if err != nil {
+				return 0, err
+			}
+			if !fn(&TxJournalEntry{Origin: TxOriginUnknown, Tx: tx}) {
+				return 0, nil
+			}
+		}
+	}
+	magic, err := stream.Bytes()
+	if err != nil {
+		return 0, err
+	}
+	if string(magic) != txJournalMagic {
+		return 0, fmt.Errorf("invalid tx journal magic: %q", magic)
+	}
+	version, err := stream.Uint64()
+	if err != nil {
+		return 0, err
+	}
+	if version != TxJournalVersion {
+		return version, fmt.Errorf("%w: %d", errUnknownTxJournalVersion, version)
+	}
+	for {
+		entry := new(TxJournalEntry)
+		if err := stream.Decode(entry); err == io.EOF {
+			return version, nil
+		} else if err != nil {
+			return version, err
+		}
+		if !fn(entry) {
+			return version, nil
+		}
+	}
+}
+
+// WriteTxJournal writes the given entries as a journal of the current version,
+// replacing any existing file at path. Without entries, the file is left empty,
+// which is read as a journal of the current version.
+func WriteTxJournal(path string, entries []*TxJournalEntry) error {
+	output, err := os.OpenFile(path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		err = writeTxJournalHeader(output)
+	}
+	for i := 0; err == nil && i < len(entries); i++ {
+		err = rlp.Encode(output, entries[i])
+	}
+	if closeErr := output.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(path+".new", path)
+}
+
+func writeTxJournalHeader(w io.Writer) error {
+	if err := rlp.Encode(w, txJournalMagic); err != nil {
+		return err
+	}
+	return rlp.Encode(w, uint64(TxJournalVersion))
+}
 
 // devNull is a WriteCloser that just discards anything written into it. Its
 // goal is to allow the transaction journal to write into a fake journal when
@@ -45,41 +237,42 @@ type devNull struct{}
 func (*devNull) Write(p []byte) (n int, err error) { return len(p), nil }
 func (*devNull) Close() error                      { return nil }
 
+// txJournalMeta is the part of a journal entry which is not kept in the pool.
+type txJournalMeta struct {
+	insertedAt time.Time
+	origin     TxOrigin
+}
+
 // txJournal is a rotating log of transactions with the aim of storing locally
 // created transactions to allow non-executed ones to survive node restarts.
 type txJournal struct {
-	path   string         // Filesystem path to store the transactions at
-	writer io.WriteCloser // Output stream to write new transactions into
+	path       string                        // Filesystem path to store the transactions at
+	writer     io.WriteCloser                // Output stream to write new transactions into
+	needHeader bool                          // Whether the file is empty and the header must precede the next entry
+	meta       map[common.Hash]txJournalMeta // Insertion time and origin of the journaled transactions
 }
 
 // newTxJournal creates a new transaction journal to
 func newTxJournal(path string) *txJournal {
 	return &txJournal{
 		path: path,
+		meta: make(map[common.Hash]txJournalMeta),
 	}
 }
 
 // load parses a transaction journal dump from disk, loading its contents into
-// the specified pool.
-func (journal *txJournal) load(add func([]*types.Transaction) []error) error {
+// the specified pool. Entries for which skip returns true are not loaded.
+func (journal *txJournal) load(add func([]*types.Transaction) []error, skip func(*TxJournalEntry) bool) error {
 	// Skip the parsing if the journal file doesn't exist at all
 	if _, err := os.Stat(journal.path); os.IsNotExist(err) {
 		return nil
 	}
-	// Open the journal for loading any past transactions
-	input, err := os.Open(journal.path)
-	if err != nil {
-		return err
-	}
-	defer input.Close()
-
 	// Temporarily discard any journal additions (don't double add on load)
 	journal.writer = new(devNull)
 	defer func() { journal.writer = nil }()
 
 	// Inject all transactions from the journal into the pool
-	stream := rlp.NewStream(input, 0)
-	total, dropped := 0, 0
+	total, skipped, dropped := 0, 0, 0
 
 	// Create a method to load a limited batch of transactions and bump the
 	// appropriate progress counters. Then use this method to load all the
@@ -92,41 +285,50 @@ func (journal *txJournal) load(add func([]*types.Transaction) []error) error {
 			}
 		}
 	}
-	var (
-		failure error
-		batch   types.Transactions
-	)
-	for {
-		// Parse the next transaction and terminate on error
-		tx := new(types.Transaction)
-		if err = stream.Decode(tx); err != nil {
-			if err != io.EOF {
-				failure = err
-			}
-			if batch.Len() > 0 {
-				loadBatch(batch)
-			}
-			break
-		}
+	var batch types.Transactions
+
+	version, failure := ReadTxJournal(journal.path, func(entry *TxJournalEntry) bool {
 		// New transaction parsed, queue up for later, import if threnshold is reached
 		total++
+		if skip != nil && skip(entry) {
+			skipped++
+			return true
+		}
+		tx := entry.Transaction()
+		journal.meta[tx.Hash()] = txJournalMeta{insertedAt: entry.InsertedAt(), origin: entry.Origin}
 
 		if batch = append(batch, tx); batch.Len() > 1024 {
 			loadBatch(batch)
 			batch = batch[:0]
 		}
+		return true
+	})
+	if batch.Len() > 0 {
+		loadBatch(batch)
 	}
-	logger.Info("Loaded local transaction journal", "transactions", total, "dropped", dropped)
+	logger.Info("Loaded local transaction journal", "version", version, "transactions", total, "skipped", skipped, "dropped", dropped)
 
 	return failure
 }
 
 // insert adds the specified transaction to the local disk journal.
-func (journal *txJournal) insert(tx *types.Transaction) error {
+func (journal *txJournal) insert(tx *types.Transaction, origin TxOrigin) error {
 	if journal.writer == nil {
 		return errNoActiveJournal
 	}
-	if err := rlp.Encode(journal.writer, tx); err != nil {
+	meta, ok := journal.meta[tx.Hash()]
+	if !ok {
+		// Keep the metadata of transactions reinjected from the journal
+		meta = txJournalMeta{insertedAt: time.Now(), origin: origin}
+		journal.meta[tx.Hash()] = meta
+	}
+	if journal.needHeader {
+		if err := writeTxJournalHeader(journal.writer); err != nil {
+			return err
+		}
+		journal.needHeader = false
+	}
+	if err := rlp.Encode(journal.writer, newTxJournalEntry(tx, meta.insertedAt, meta.origin)); err != nil {
 		return err
 	}
 	return nil
@@ -143,34 +345,32 @@ func (journal *txJournal) rotate(all map[common.Address]types.Transactions, sign
 		journal.writer = nil
 	}
 	// Generate a new journal with the contents of the current pool
-	replacement, err := os.OpenFile(journal.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o755)
-	if err != nil {
-		return err
-	}
-	journaled := 0
-
+	var (
+		entries []*TxJournalEntry
+		meta    = make(map[common.Hash]txJournalMeta)
+	)
 	txSet := types.NewTransactionsByPriceAndNonce(signer, all, nil)
 	for tx := txSet.Peek(); tx != nil; tx = txSet.Peek() {
-		if err = rlp.Encode(replacement, tx); err != nil {
-			replacement.Close()
-			return err
+		m, ok := journal.meta[tx.Hash()]
+		if !ok {
+			m = txJournalMeta{insertedAt: tx.Time(), origin: TxOriginLocal}
 		}
-		journaled++
+		meta[tx.Hash()] = m
+		entries = append(entries, newTxJournalEntry(tx, m.insertedAt, m.origin))
 		txSet.Shift()
 	}
-
-	replacement.Close()
-
-	// Replace the live journal with the newly generated one
-	if err = os.Rename(journal.path+".new", journal.path); err != nil {
+	if err := WriteTxJournal(journal.path, entries); err != nil {
 		return err
 	}
+	journal.meta = meta
+
 	sink, err := os.OpenFile(journal.path, os.O_WRONLY|os.O_APPEND, 0o755)
 	if err != nil {
 		return err
 	}
 	journal.writer = sink
-	logger.Info("Regenerated local transaction journal", "transactions", journaled, "accounts", len(all))
+	journal.needHeader = len(entries) == 0
+	logger.Info("Regenerated local transaction journal", "transactions", len(entries), "accounts", len(all))
 
 	return nil
 }
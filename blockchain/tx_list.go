@@ -31,6 +31,7 @@ import (
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/kaiax"
+	"github.com/kaiachain/kaia/params"
 )
 
 // nonceHeap is a heap.Interface implementation over 64bit unsigned integers for
@@ -332,14 +333,25 @@ func (l *txList) Contains(nonce uint64) bool {
 //
 // If the new transaction is accepted into the list, the lists' cost and gas
 // thresholds are also potentially updated.
-func (l *txList) Add(tx *types.Transaction, priceBump uint64, magmaHardforked bool) (bool, *types.Transaction) {
+//
+// After the Magma hardfork a transaction can be replaced by one with a higher gas
+// price. After the Kaia hardfork the replacement must not lower either the gas fee
+// cap or the gas tip cap and must raise at least one of them, so that dynamic-fee
+// transactions cannot be replaced by ones paying a lower effective tip.
+func (l *txList) Add(tx *types.Transaction, priceBump uint64, rules params.Rules) (bool, *types.Transaction) {
 	// If there's an older better transaction, abort
 	old := l.txs.Get(tx.Nonce())
 	if old != nil {
 		// If tx is CancelTransaction, replace it even thought tx has lower gasPrice than previous tx.
 		if tx.Type().IsCancelTransaction() {
 			logger.Trace("New tx is a cancel transaction. replace it!", "old", old.String(), "new", tx.String())
-		} else if magmaHardforked {
+		} else if rules.IsKaia {
+			if !isReplaceable(old, tx) {
+				logger.Trace("already nonce exist and the fee caps are not higher than older", "nonce", tx.Nonce(), "old gasFeeCap", old.GasFeeCap(), "old gasTipCap", old.GasTipCap(), "new gasFeeCap", tx.GasFeeCap(), "new gasTipCap", tx.GasTipCap())
+				return false, nil
+			}
+			logger.Trace("The transaction was substituted by competitive fee caps", "old", old.String(), "new", tx.String())
+		} else if rules.IsMagma {
 			if old.GasPrice().Cmp(tx.GasPrice()) >= 0 {
 				// If gas price of older is bigger than newer, abort.
 				logger.Trace("already nonce exist and the gasprice is lower then older", "nonce", tx.Nonce(), "with gasprice", old.GasPrice(), "priceBump", priceBump, "new tx.gasprice", tx.GasPrice())
@@ -357,6 +369,15 @@ func (l *txList) Add(tx *types.Transaction, priceBump uint64, magmaHardforked bo
 	return true, old
 }
 
+// isReplaceable reports whether tx pays at least as much as old at any baseFee
+// and strictly more at some. For non dynamic-fee types both caps are the gas
+// price, so this is the same as requiring a higher gas price.
+func isReplaceable(old, tx *types.Transaction) bool {
+	feeCmp := tx.GasFeeCap().Cmp(old.GasFeeCap())
+	tipCmp := tx.GasTipCap().Cmp(old.GasTipCap())
+	return feeCmp >= 0 && tipCmp >= 0 && (feeCmp > 0 || tipCmp > 0)
+}
+
 // Forward removes all transactions from the list with a nonce lower than the
 // provided threshold. Every removed transaction is returned for any post-removal
 // maintenance.
@@ -529,32 +550,57 @@ func (l *txList) CachedTxsFlattenByCount(count int) types.Transactions {
 
 // priceHeap is a heap.Interface implementation over transactions for retrieving
 // price-sorted transactions to discard when the pool fills up.
-type priceHeap []*types.Transaction
-
-func (h priceHeap) Len() int      { return len(h) }
-func (h priceHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
-
-func (h priceHeap) Less(i, j int) bool {
+//
+// Once the Kaia hardfork is active the proposer is paid baseFee plus the effective
+// tip, so transactions are sorted by min(gasTipCap, gasFeeCap-baseFee) against the
+// baseFee of the pending block. Fee-delegated transactions (including the partial
+// fee ratio types) are ranked the same way, since the split between sender and fee
+// payer does not change what the block proposer earns. Before Kaia, or if baseFee
+// is unset, the ordering falls back to gasFeeCap which equals gasPrice for all
+// non dynamic-fee types.
+type priceHeap struct {
+	baseFee *big.Int // nil before the Kaia hardfork
+	list    []*types.Transaction
+}
+
+func (h *priceHeap) Len() int      { return len(h.list) }
+func (h *priceHeap) Swap(i, j int) { h.list[i], h.list[j] = h.list[j], h.list[i] }
+
+func (h *priceHeap) Less(i, j int) bool {
 	// Sort primarily by price, returning the cheaper one
-	switch h[i].GasPrice().Cmp(h[j].GasPrice()) {
+	switch h.cmp(h.list[i], h.list[j]) {
 	case -1:
 		return true
 	case 1:
 		return false
 	}
 	// If the prices match, stabilize via nonces (high nonce is worse)
-	return h[i].Nonce() > h[j].Nonce()
+	return h.list[i].Nonce() > h.list[j].Nonce()
+}
+
+// cmp compares the price of two transactions, returning -1 if a pays less than b.
+func (h *priceHeap) cmp(a, b *types.Transaction) int {
+	if h.baseFee != nil {
+		if c := a.EffectiveGasTip(h.baseFee).Cmp(b.EffectiveGasTip(h.baseFee)); c != 0 {
+			return c
+		}
+	}
+	if c := a.GasFeeCap().Cmp(b.GasFeeCap()); c != 0 {
+		return c
+	}
+	return a.GasTipCap().Cmp(b.GasTipCap())
 }
 
 func (h *priceHeap) Push(x interface{}) {
-	*h = append(*h, x.(*types.Transaction))
+	h.list = append(h.list, x.(*types.Transaction))
 }
 
 func (h *priceHeap) Pop() interface{} {
-	old := *h
+	old := h.list
 	n := len(old)
 	x := old[n-1]
-	*h = old[0 : n-1]
+	old[n-1] = nil
+	h.list = old[0 : n-1]
 	return x
 }
 
@@ -585,15 +631,37 @@ func (l *txPricedList) Put(tx *types.Transaction) {
 func (l *txPricedList) Removed() {
 	// Bump the stale counter, but exit if still too low (< 25%)
 	l.stales++
-	if l.stales <= len(*l.items)/4 {
+	if l.stales <= l.items.Len()/4 {
 		return
 	}
 	// Seems we've reached a critical number of stale transactions, reheap
-	reheap := make(priceHeap, 0, l.all.Count())
+	l.Reheap()
+}
+
+// SetBaseFee updates the baseFee used to compute effective tips and reorders
+// the heap if it has changed. A nil baseFee sorts transactions by fee cap only.
+func (l *txPricedList) SetBaseFee(baseFee *big.Int) {
+	old := l.items.baseFee
+	if old == baseFee || (old != nil && baseFee != nil && old.Cmp(baseFee) == 0) {
+		return
+	}
+	if baseFee != nil {
+		baseFee = new(big.Int).Set(baseFee)
+	}
+	l.items.baseFee = baseFee
+	l.Reheap()
+}
 
-	l.stales, l.items = 0, &reheap
+// Reheap forcibly rebuilds the heap from the transactions currently in the pool,
+// dropping all stale price points.
+func (l *txPricedList) Reheap() {
+	reheap := &priceHeap{
+		baseFee: l.items.baseFee,
+		list:    make([]*types.Transaction, 0, l.all.Count()),
+	}
+	l.stales, l.items = 0, reheap
 	l.all.Range(func(hash common.Hash, tx *types.Transaction) bool {
-		*l.items = append(*l.items, tx)
+		l.items.list = append(l.items.list, tx)
 		return true
 	})
 	heap.Init(l.items)
@@ -605,7 +673,7 @@ func (l *txPricedList) Cap(threshold *big.Int, local *accountSet) types.Transact
 	drop := make(types.Transactions, 0, 128) // Remote underpriced transactions to drop
 	save := make(types.Transactions, 0, 64)  // Local underpriced transactions to keep
 
-	for len(*l.items) > 0 {
+	for l.items.Len() > 0 {
 		// Discard stale transactions if found during cleanup
 		tx := heap.Pop(l.items).(*types.Transaction)
 		if l.all.Get(tx.Hash()) == nil {
@@ -638,8 +706,8 @@ func (l *txPricedList) Underpriced(tx *types.Transaction, local *accountSet) boo
 		return false
 	}
 	// Discard stale price points if found at the heap start
-	for len(*l.items) > 0 {
-		head := []*types.Transaction(*l.items)[0]
+	for l.items.Len() > 0 {
+		head := l.items.list[0]
 		if l.all.Get(head.Hash()) == nil {
 			l.stales--
 			heap.Pop(l.items)
@@ -648,21 +716,33 @@ func (l *txPricedList) Underpriced(tx *types.Transaction, local *accountSet) boo
 		break
 	}
 	// Check if the transaction is underpriced or not
-	if len(*l.items) == 0 {
+	if l.items.Len() == 0 {
 		logger.Error("Pricing query for empty pool") // This cannot happen, print to catch programming errors
 		return false
 	}
-	cheapest := []*types.Transaction(*l.items)[0]
-	return cheapest.GasPrice().Cmp(tx.GasPrice()) >= 0
+	cheapest := l.items.list[0]
+	return l.items.cmp(cheapest, tx) >= 0
 }
 
 // Discard finds a number of most underpriced transactions, removes them from the
 // priced list and returns them for further removal from the entire pool.
 func (l *txPricedList) Discard(slots int, local *accountSet) types.Transactions {
+	drop, _ := l.discard(slots, local, nil)
+	return drop
+}
+
+// DiscardCheaper is like Discard, but only succeeds if every transaction it would
+// drop pays strictly less than tx. Otherwise nothing is removed from the priced
+// list and false is returned.
+func (l *txPricedList) DiscardCheaper(slots int, local *accountSet, tx *types.Transaction) (types.Transactions, bool) {
+	return l.discard(slots, local, tx)
+}
+
+func (l *txPricedList) discard(slots int, local *accountSet, than *types.Transaction) (types.Transactions, bool) {
 	drop := make(types.Transactions, 0, slots) // Remote underpriced transactions to drop
 	save := make(types.Transactions, 0, 64)    // Local underpriced transactions to keep
 
-	for len(*l.items) > 0 && slots > 0 {
+	for l.items.Len() > 0 && slots > 0 {
 		// Discard stale transactions if found during cleanup
 		tx := heap.Pop(l.items).(*types.Transaction)
 		if l.all.Get(tx.Hash()) == nil {
@@ -672,13 +752,27 @@ func (l *txPricedList) Discard(slots int, local *accountSet) types.Transactions
 		// Non stale transaction found, discard unless local
 		if local.containsTx(tx) {
 			save = append(save, tx)
-		} else {
-			drop = append(drop, tx)
-			slots -= numSlots(tx)
+			continue
 		}
+		if than != nil && l.items.cmp(tx, than) >= 0 {
+			// Not cheaper than the incoming transaction, restore everything
+			save = append(save, tx)
+			save = append(save, drop...)
+			drop = nil
+			break
+		}
+		drop = append(drop, tx)
+		slots -= numSlots(tx)
 	}
 	for _, tx := range save {
 		heap.Push(l.items, tx)
 	}
-	return drop
+	if slots > 0 && than != nil {
+		// Not enough remote transactions to make room, restore everything
+		for _, tx := range drop {
+			heap.Push(l.items, tx)
+		}
+		return nil, false
+	}
+	return drop, true
 }
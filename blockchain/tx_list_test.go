@@ -30,6 +30,7 @@ import (
 
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/params"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -46,7 +47,7 @@ func TestStrictTxListAdd(t *testing.T) {
 	// Insert the transactions in a random order
 	list := newTxList(true)
 	for _, v := range rand.Perm(len(txs)) {
-		list.Add(txs[v], DefaultTxPoolConfig.PriceBump, false)
+		list.Add(txs[v], DefaultTxPoolConfig.PriceBump, params.Rules{})
 	}
 	// Verify internal state
 	if len(list.txs.items) != len(txs) {
@@ -82,7 +83,7 @@ func TestTxListReadyWithGasPriceBasic(t *testing.T) {
 	// Insert the transactions in a random order
 	list := newTxList(true)
 	for _, v := range rand.Perm(len(txs)) {
-		list.Add(txs[v], DefaultTxPoolConfig.PriceBump, true)
+		list.Add(txs[v], DefaultTxPoolConfig.PriceBump, params.Rules{IsMagma: true})
 	}
 
 	ready := list.ReadyWithGasPrice(uint64(startNonce), expectedBaseFee, nil)
@@ -141,7 +142,7 @@ func TestTxListReadyWithGasPricePartialFilter(t *testing.T) {
 	// Insert the transactions in a random order
 	list := newTxList(true)
 	for _, v := range rand.Perm(len(txs)) {
-		list.Add(txs[v], DefaultTxPoolConfig.PriceBump, true)
+		list.Add(txs[v], DefaultTxPoolConfig.PriceBump, params.Rules{IsMagma: true})
 	}
 
 	ready := list.ReadyWithGasPrice(uint64(startNonce), expectedBaseFee, nil)
@@ -173,11 +174,11 @@ func TestSubstituteTxByGasPrice(t *testing.T) {
 	oldTx := pricedTransaction(0, 21000, big.NewInt(50), key)
 	newTx := pricedTransaction(0, 21000, big.NewInt(60), key)
 
-	if result, _ := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, true); !result {
+	if result, _ := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, params.Rules{IsMagma: true}); !result {
 		t.Error("it cannot add tx in tx list.")
 	}
 
-	result, replaced := txList.Add(newTx, DefaultTxPoolConfig.PriceBump, true)
+	result, replaced := txList.Add(newTx, DefaultTxPoolConfig.PriceBump, params.Rules{IsMagma: true})
 	if !result {
 		t.Error("it cannot replace tx in tx list.")
 	}
@@ -195,11 +196,80 @@ func TestSubstituteTransactionAbort(t *testing.T) {
 	oldTx := pricedTransaction(0, 21000, big.NewInt(50), key)
 	newTx := pricedTransaction(0, 21000, big.NewInt(40), key)
 
-	if result, _ := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, true); !result {
+	if result, _ := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, params.Rules{IsMagma: true}); !result {
 		t.Error("it cannot add tx in tx list.")
 	}
 
-	if result, replaced := txList.Add(newTx, DefaultTxPoolConfig.PriceBump, true); result || replaced != nil {
+	if result, replaced := txList.Add(newTx, DefaultTxPoolConfig.PriceBump, params.Rules{IsMagma: true}); result || replaced != nil {
 		t.Error("Expected to not substitute by a tx with lower gas price")
 	}
 }
+
+// TestSubstituteDynamicFeeTxKaia checks that after the Kaia hardfork a replacement
+// must not lower either fee cap and must raise at least one of them.
+func TestSubstituteDynamicFeeTxKaia(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	rules := params.Rules{IsMagma: true, IsKaia: true}
+
+	testcases := []struct {
+		name     string
+		newTx    *types.Transaction
+		replaced bool
+	}{
+		{"same caps", dynamicFeeTx(0, 21000, big.NewInt(50), big.NewInt(10), key), false},
+		{"higher fee cap, lower tip cap", dynamicFeeTx(0, 21000, big.NewInt(60), big.NewInt(5), key), false},
+		{"lower fee cap, higher tip cap", dynamicFeeTx(0, 21000, big.NewInt(40), big.NewInt(20), key), false},
+		{"higher fee cap", dynamicFeeTx(0, 21000, big.NewInt(60), big.NewInt(10), key), true},
+		{"higher tip cap", dynamicFeeTx(0, 21000, big.NewInt(50), big.NewInt(11), key), true},
+		{"legacy with higher price", pricedTransaction(0, 21000, big.NewInt(51), key), true},
+		{"legacy with price between caps", pricedTransaction(0, 21000, big.NewInt(30), key), false},
+	}
+	for _, tc := range testcases {
+		txList := newTxList(false)
+		oldTx := dynamicFeeTx(0, 21000, big.NewInt(50), big.NewInt(10), key)
+		if result, _ := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, rules); !result {
+			t.Fatalf("%s: it cannot add tx in tx list", tc.name)
+		}
+		result, replaced := txList.Add(tc.newTx, DefaultTxPoolConfig.PriceBump, rules)
+		assert.Equal(t, tc.replaced, result, tc.name)
+		if tc.replaced {
+			assert.Equal(t, oldTx, replaced, tc.name)
+		}
+	}
+}
+
+// TestPricedListEffectiveTip checks that the priced list sorts transactions by
+// their effective tip once a baseFee is set, and by fee cap otherwise.
+func TestPricedListEffectiveTip(t *testing.T) {
+	var (
+		key, _      = crypto.GenerateKey()
+		feePayer, _ = crypto.GenerateKey()
+
+		dynLowCap  = dynamicFeeTx(0, 21000, big.NewInt(27), big.NewInt(10), key)                                         // tip 2
+		dynHighCap = dynamicFeeTx(1, 21000, big.NewInt(100), big.NewInt(2), key)                                         // tip 2
+		legacy     = pricedTransaction(2, 21000, big.NewInt(30), key)                                                    // tip 5
+		ratio      = feeDelegatedWithRatioTx(3, 21000, big.NewInt(40), big.NewInt(1), key, feePayer, types.FeeRatio(30)) // tip 15
+	)
+	all := newTxLookup()
+	priced := newTxPricedList(all)
+	for _, tx := range []*types.Transaction{ratio, legacy, dynHighCap, dynLowCap} {
+		all.Add(tx)
+		priced.Put(tx)
+	}
+	local := newAccountSet(types.LatestSignerForChainID(params.TestChainConfig.ChainID))
+
+	// Without a baseFee, transactions are sorted by fee cap
+	assert.Equal(t, types.Transactions{dynLowCap, legacy, ratio, dynHighCap}, priced.Discard(4, local))
+	for _, tx := range []*types.Transaction{ratio, legacy, dynHighCap, dynLowCap} {
+		priced.Put(tx)
+	}
+
+	// With a baseFee, transactions are sorted by effective tip, then by fee cap
+	priced.SetBaseFee(big.NewInt(25))
+	assert.False(t, priced.Underpriced(pricedTransaction(4, 21000, big.NewInt(28), key), local))
+	assert.True(t, priced.Underpriced(pricedTransaction(4, 21000, big.NewInt(26), key), local))
+
+	_, ok := priced.DiscardCheaper(2, local, pricedTransaction(4, 21000, big.NewInt(26), key))
+	assert.False(t, ok)
+	assert.Equal(t, types.Transactions{dynLowCap, dynHighCap, legacy, ratio}, priced.Discard(4, local))
+}
@@ -164,8 +164,9 @@ type TxPoolConfig struct {
 	KeepLocals bool          // Disables removing timed-out local transactions
 	Lifetime   time.Duration // Maximum amount of time non-executable transaction are queued
 
-	NoAccountCreation            bool // Whether account creation transactions should be disabled
-	EnableSpamThrottlerAtRuntime bool // Enable txpool spam throttler at runtime
+	NoAccountCreation            bool   // Whether account creation transactions should be disabled
+	EnableSpamThrottlerAtRuntime bool   // Enable txpool spam throttler at runtime
+	SpamThrottlerJournal         string // Journal of the spam throttler lists to survive node restarts
 
 	BlobStorageConfig *BlobStorageConfig // Blob storage configuration
 }
@@ -176,6 +177,8 @@ var DefaultTxPoolConfig = TxPoolConfig{
 	Journal:         "transactions.rlp",
 	JournalInterval: time.Hour,
 
+	SpamThrottlerJournal: "spam_throttler.json",
+
 	PriceLimit: 1,
 	PriceBump:  10,
 
@@ -255,7 +258,7 @@ type TxPool struct {
 
 	wg sync.WaitGroup // for shutdown sync
 
-	txMsgCh  chan types.Transactions // A buffer for async tx intake via AddRemotes
+	txMsgCh  chan *txMsg             // A buffer for async tx intake via AddRemotes
 	txFeedCh chan types.Transactions // A buffer for async tx event emission via txFeed
 
 	missingBlobSidecarsCh chan *MissingBlobSidecar // A buffer for async missing blob sidecars event emission
@@ -291,7 +294,7 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 		chainHeadCh:           make(chan ChainHeadEvent, chainHeadChanSize),
 		gasPrice:              new(big.Int).SetUint64(pset.UnitPrice),
 		blobBaseFee:           new(big.Int).SetUint64(params.ZeroBaseFee),
-		txMsgCh:               make(chan types.Transactions, txMsgChSize),
+		txMsgCh:               make(chan *txMsg, txMsgChSize),
 		txFeedCh:              make(chan types.Transactions, txFeedChSize),
 		missingBlobSidecarsCh: make(chan *MissingBlobSidecar, missingBlobSidecarsChSize),
 		govModule:             govModule,
@@ -304,7 +307,7 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 	if !config.NoLocals && config.Journal != "" {
 		pool.journal = newTxJournal(config.Journal)
 
-		if err := pool.journal.load(pool.AddLocals); err != nil {
+		if err := pool.journal.load(pool.AddLocals, pool.skipJournalEntry); err != nil {
 			logger.Error("Failed to load transaction journal", "err", err)
 		}
 		if err := pool.journal.rotate(pool.local(), pool.signer); err != nil {
@@ -570,6 +573,7 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 			pool.blobBaseFee = eip4844.CalcBlobFee(pool.gasPrice)
 		}
 	}
+	pool.updatePricedBaseFee()
 
 	func() {
 		pool.txMu.Lock()
@@ -597,6 +601,16 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	}()
 }
 
+// updatePricedBaseFee lets the priced list sort transactions by their effective
+// tip once the Kaia hardfork is active, as the proposer is paid baseFee plus tip.
+func (pool *TxPool) updatePricedBaseFee() {
+	if pool.rules.IsKaia {
+		pool.priced.SetBaseFee(pool.gasPrice)
+	} else {
+		pool.priced.SetBaseFee(nil)
+	}
+}
+
 // Stop terminates the transaction pool.
 func (pool *TxPool) Stop() {
 	// Unsubscribe all subscriptions registered from txpool
@@ -1127,6 +1141,11 @@ func (pool *TxPool) getMaxTxFromQueueWhenNonceIsMissing(tx *types.Transaction, f
 // whitelisted, preventing any associated transaction from being dropped out of
 // the pool due to pricing constraints.
 func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
+	return pool.addWithOrigin(tx, local, defaultTxOrigin(local))
+}
+
+// addWithOrigin is add, recording the given origin if the transaction is journaled.
+func (pool *TxPool) addWithOrigin(tx *types.Transaction, local bool, origin TxOrigin) (bool, error) {
 	for _, module := range pool.modules {
 		if module.IsModuleTx(tx) {
 			err := module.PreAddTx(tx, local)
@@ -1151,51 +1170,46 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 	}
 
 	// If the transaction pool is full and new Tx is valid,
-	// (1) discard a new Tx if there is no room for the account of the Tx
-	// (2) remove an old Tx with the largest nonce from queue to make a room for a new Tx with missing nonce
-	// (3) discard a new Tx if the new Tx does not have a missing nonce
-	// (4) discard underpriced transactions
+	// (1) discard remote Txs paying less than the new Tx, cheapest (by effective tip) first
+	// (2) discard a new Tx if there is no room for the account of the Tx
+	// (3) remove an old Tx with the largest nonce from queue to make a room for a new Tx with missing nonce
+	// (4) discard a new Tx if the new Tx does not have a missing nonce
 	if uint64(pool.all.Slots()+numSlots(tx)) > pool.config.ExecSlotsAll+pool.config.NonExecSlotsAll {
-		// (1) discard a new Tx if there is no room for the account of the Tx
-		from, _ := types.Sender(pool.signer, tx)
-		if pool.queue[from] == nil {
-			logger.Trace("Rejecting a new Tx, because TxPool is full and there is no room for the account", "hash", tx.Hash(), "account", from)
-			refusedTxCounter.Inc(1)
-			return false, fmt.Errorf("txpool is full: %d", uint64(pool.all.Count()))
-		}
-
-		maxTx := pool.getMaxTxFromQueueWhenNonceIsMissing(tx, &from)
-		if maxTx != tx {
-			// (2) remove an old Tx with the largest nonce from queue to make a room for a new Tx with missing nonce
-			pool.removeTx(maxTx.Hash(), true)
-			logger.Trace("Removing an old Tx with the max nonce to insert a new Tx with missing nonce, because TxPool is full", "account", from, "new nonce(previously missing)", tx.Nonce(), "removed max nonce", maxTx.Nonce())
+		// (1) discard remote Txs paying less than the new Tx, cheapest (by effective tip) first
+		slots := pool.all.Slots() - int(pool.config.ExecSlotsAll+pool.config.NonExecSlotsAll) + numSlots(tx)
+		if drop, ok := pool.priced.DiscardCheaper(slots, pool.locals, tx); ok {
+			for _, tx := range drop {
+				logger.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "price", tx.GasPrice())
+				underpricedTxCounter.Inc(1)
+				pool.removeTx(tx.Hash(), false)
+			}
 		} else {
-			// (3) discard a new Tx if the new Tx does not have a missing nonce
-			logger.Trace("Rejecting a new Tx, because TxPool is full and a new TX does not have missing nonce", "hash", tx.Hash())
-			refusedTxCounter.Inc(1)
-			return false, fmt.Errorf("txpool is full and the new tx does not have missing nonce: %d", uint64(pool.all.Count()))
-		}
+			// (2) discard a new Tx if there is no room for the account of the Tx
+			from, _ := types.Sender(pool.signer, tx)
+			if pool.queue[from] == nil {
+				logger.Trace("Rejecting a new Tx, because TxPool is full and there is no room for the account", "hash", tx.Hash(), "account", from)
+				refusedTxCounter.Inc(1)
+				return false, fmt.Errorf("txpool is full: %d", uint64(pool.all.Count()))
+			}
 
-		// (4) discard underpriced transactions
-		// If the new transaction is underpriced, don't accept it
-		if !local && pool.priced.Underpriced(tx, pool.locals) {
-			logger.Trace("Discarding underpriced transaction", "hash", hash, "price", tx.GasPrice())
-			underpricedTxCounter.Inc(1)
-			return false, ErrUnderpriced
-		}
-		// New transaction is better than our worse ones, make room for it
-		drop := pool.priced.Discard(pool.all.Slots()-int(pool.config.ExecSlotsAll+pool.config.NonExecSlotsAll)+numSlots(tx), pool.locals)
-		for _, tx := range drop {
-			logger.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "price", tx.GasPrice())
-			underpricedTxCounter.Inc(1)
-			pool.removeTx(tx.Hash(), false)
+			maxTx := pool.getMaxTxFromQueueWhenNonceIsMissing(tx, &from)
+			if maxTx != tx {
+				// (3) remove an old Tx with the largest nonce from queue to make a room for a new Tx with missing nonce
+				pool.removeTx(maxTx.Hash(), true)
+				logger.Trace("Removing an old Tx with the max nonce to insert a new Tx with missing nonce, because TxPool is full", "account", from, "new nonce(previously missing)", tx.Nonce(), "removed max nonce", maxTx.Nonce())
+			} else {
+				// (4) discard a new Tx if the new Tx does not have a missing nonce
+				logger.Trace("Rejecting a new Tx, because TxPool is full and a new TX does not have missing nonce", "hash", tx.Hash())
+				refusedTxCounter.Inc(1)
+				return false, fmt.Errorf("txpool is full and the new tx does not have missing nonce: %d", uint64(pool.all.Count()))
+			}
 		}
 	}
 	// If the transaction is replacing an already pending one, do directly
 	from, _ := types.Sender(pool.signer, tx) // already validated
 	if list := pool.pending[from]; list != nil && list.Overlaps(tx) {
 		// Nonce already pending, check if required price bump is met
-		inserted, old := list.Add(tx, pool.config.PriceBump, pool.rules.IsMagma)
+		inserted, old := list.Add(tx, pool.config.PriceBump, pool.rules)
 		if !inserted {
 			pendingDiscardCounter.Inc(1)
 			return false, ErrAlreadyNonceExistInPool
@@ -1208,7 +1222,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		}
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
-		pool.journalTx(from, tx)
+		pool.journalTx(from, tx, origin)
 
 		logger.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
@@ -1226,7 +1240,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 	if local {
 		pool.locals.add(from)
 	}
-	pool.journalTx(from, tx)
+	pool.journalTx(from, tx, origin)
 
 	logger.Trace("Pooled new future transaction", "hash", hash, "from", from, "to", tx.To())
 	return replace, nil
@@ -1241,7 +1255,7 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction) (bool, er
 	if pool.queue[from] == nil {
 		pool.queue[from] = newTxList(false)
 	}
-	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBump, pool.rules.IsMagma)
+	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBump, pool.rules)
 	if !inserted {
 		// An older transaction was better, discard this
 		queuedDiscardCounter.Inc(1)
@@ -1263,22 +1277,40 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction) (bool, er
 }
 
 // journalTx adds the specified transaction to the local disk journal if it is
-// deemed to have been sent from a local account.
-func (pool *TxPool) journalTx(from common.Address, tx *types.Transaction) {
+// deemed to have been sent from a local account. Module transactions are only
+// journaled if the module tells the origin to record them with.
+func (pool *TxPool) journalTx(from common.Address, tx *types.Transaction, origin TxOrigin) {
 	// Only journal if it's enabled and the transaction is local
 	if pool.journal == nil || !pool.locals.contains(from) {
 		return
 	}
 	for _, module := range pool.modules {
 		if module.IsModuleTx(tx) {
-			return
+			journaled, ok := module.(txJournalModule)
+			if !ok {
+				return
+			}
+			origin = journaled.TxJournalOrigin()
+			break
 		}
 	}
-	if err := pool.journal.insert(tx); err != nil {
+	if err := pool.journal.insert(tx, origin); err != nil {
 		logger.Error("Failed to journal local transaction", "err", err)
 	}
 }
 
+// skipJournalEntry reports whether a journaled transaction should not be
+// reinjected, because it is already mined or has outlived txpool.lifetime.
+func (pool *TxPool) skipJournalEntry(entry *TxJournalEntry) bool {
+	if insertedAt := entry.InsertedAt(); !insertedAt.IsZero() && time.Since(insertedAt) > pool.config.Lifetime {
+		return true
+	}
+	if tx, _, _, _ := pool.chain.GetTxAndLookupInfo(entry.Tx.Hash()); tx != nil {
+		return true
+	}
+	return false
+}
+
 // promoteTx adds a transaction to the pending (processable) list of transactions
 // and returns whether it was inserted or an older was better.
 //
@@ -1290,7 +1322,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 	}
 	list := pool.pending[addr]
 
-	inserted, old := list.Add(tx, pool.config.PriceBump, pool.rules.IsMagma)
+	inserted, old := list.Add(tx, pool.config.PriceBump, pool.rules)
 	if !inserted {
 		// An older transaction was better, discard this
 		pool.all.Remove(hash)
@@ -1318,14 +1350,31 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 	return true
 }
 
+// txMsg is a tx message from a peer waiting in txMsgCh.
+type txMsg struct {
+	peerID string
+	peerIP string
+	txs    types.Transactions
+}
+
 // HandleTxMsg transfers transactions to a channel where handleTxMsg calls AddRemotes
 // to handle them. This is made not to wait from the results from TxPool.AddRemotes.
-func (pool *TxPool) HandleTxMsg(txs types.Transactions) {
+func (pool *TxPool) HandleTxMsg(peerID, peerIP string, txs types.Transactions) {
 	if pool.config.DenyRemoteTx {
 		return
 	}
 
-	// Filter spam txs based on to-address of failed txs
+	// Recover the senders in background, so that handleTxMsg finds them cached.
+	// TODO-Kaia: Consider moving throttleTxMsg to `addTx` or `AddRemotes`
+	senderCacher.recover(pool.signer, txs)
+	pool.txMsgCh <- &txMsg{peerID: peerID, peerIP: peerIP, txs: txs}
+}
+
+// throttleTxMsg filters spam txs based on the spam weights of the peer and the addresses.
+// It runs in handleTxMsg rather than the p2p handler, as the spam scoring may need the senders
+// and the pending nonces of the txs.
+func (pool *TxPool) throttleTxMsg(msg *txMsg) types.Transactions {
+	txs := msg.txs
 	spamThrottler := GetSpamThrottler()
 	if spamThrottler != nil {
 		pool.mu.RLock()
@@ -1334,7 +1383,13 @@ func (pool *TxPool) HandleTxMsg(txs types.Transactions) {
 
 		// Activate spam throttler when pool has enough txs
 		if poolSize > uint64(spamThrottler.config.ActivateTxPoolSize) {
-			allowTxs, throttleTxs := spamThrottler.classifyTxs(txs)
+			spamMsg := &SpamTxMsg{PeerID: msg.peerID, PeerIP: msg.peerIP, Txs: txs}
+			if spamThrottler.needSenders() {
+				pool.fillSpamTxMsgSenders(spamThrottler.signer, spamMsg)
+			}
+			spamThrottler.updateTxMsgState(spamMsg)
+
+			allowTxs, throttleTxs := spamThrottler.classifyTxs(msg.peerID, msg.peerIP, txs, spamMsg.Senders)
 
 			for _, tx := range throttleTxs {
 				select {
@@ -1348,14 +1403,33 @@ func (pool *TxPool) HandleTxMsg(txs types.Transactions) {
 			txs = allowTxs
 		}
 	}
+	return txs
+}
+
+// fillSpamTxMsgSenders reads the senders of the txs, mostly cached by senderCacher already,
+// and their pending nonces.
+func (pool *TxPool) fillSpamTxMsgSenders(signer types.Signer, msg *SpamTxMsg) {
+	msg.Senders = make([]common.Address, len(msg.Txs))
+	for i, tx := range msg.Txs {
+		if from, err := types.Sender(signer, tx); err == nil {
+			msg.Senders[i] = from
+		}
+	}
 
-	// TODO-Kaia: Consider removing the next line and move the above logic to `addTx` or `AddRemotes`
-	senderCacher.recover(pool.signer, txs)
-	pool.txMsgCh <- txs
+	msg.PendingNonces = make(map[common.Address]uint64)
+	pool.mu.Lock()
+	for _, from := range msg.Senders {
+		if _, ok := msg.PendingNonces[from]; !ok && from != (common.Address{}) {
+			msg.PendingNonces[from] = pool.getPendingNonce(from)
+		}
+	}
+	pool.mu.Unlock()
 }
 
 func (pool *TxPool) throttleLoop(spamThrottler *throttler) {
 	ticker := time.Tick(time.Second)
+	journal := time.NewTicker(spamThrottlerJournalInterval)
+	defer journal.Stop()
 	throttleNum := int(spamThrottler.config.ThrottleTPS)
 
 	for {
@@ -1364,6 +1438,11 @@ func (pool *TxPool) throttleLoop(spamThrottler *throttler) {
 			logger.Info("Stop spam throttler loop")
 			return
 
+		case <-journal.C:
+			if err := spamThrottler.saveJournal(); err != nil {
+				logger.Warn("Failed to save spam throttler journal", "err", err)
+			}
+
 		case <-ticker:
 			txs := types.Transactions{}
 
@@ -1398,14 +1477,23 @@ func (pool *TxPool) StartSpamThrottler(conf *ThrottlerConfig) error {
 	}
 
 	t := &throttler{
-		config:     conf,
-		candidates: make(map[common.Address]int),
-		throttled:  make(map[common.Address]int),
-		allowed:    make(map[common.Address]bool),
-		mu:         new(sync.RWMutex),
-		threshold:  conf.InitialThreshold,
-		throttleCh: make(chan *types.Transaction, conf.ThrottleTPS*5),
-		quitCh:     make(chan struct{}),
+		config:         conf,
+		rules:          newSpamScoringRules(conf),
+		signer:         pool.signer,
+		candidates:     make(map[common.Address]int),
+		contributions:  make(map[common.Address]map[string]int),
+		peerCandidates: make(map[string]int),
+		throttled:      make(map[common.Address]int),
+		throttledPeers: make(map[string]int),
+		allowed:        make(map[common.Address]int64),
+		mu:             new(sync.RWMutex),
+		journalPath:    pool.config.SpamThrottlerJournal,
+		threshold:      conf.InitialThreshold,
+		throttleCh:     make(chan *types.Transaction, conf.ThrottleTPS*5),
+		quitCh:         make(chan struct{}),
+	}
+	if err := t.loadJournal(); err != nil {
+		logger.Warn("Failed to load spam throttler journal", "err", err)
 	}
 
 	go pool.throttleLoop(t)
@@ -1420,12 +1508,16 @@ func (pool *TxPool) StopSpamThrottler() {
 	defer spamThrottlerMu.Unlock()
 
 	if spamThrottler != nil {
+		if err := spamThrottler.saveJournal(); err != nil {
+			logger.Warn("Failed to save spam throttler journal", "err", err)
+		}
 		close(spamThrottler.quitCh)
 	}
 
 	spamThrottler = nil
 	candidateSizeGauge.Update(0)
 	throttledSizeGauge.Update(0)
+	throttledPeersSizeGauge.Update(0)
 	allowedSizeGauge.Update(0)
 	throttlerUpdateTimeGauge.Update(0)
 	throttlerDropCount.Clear()
@@ -1437,8 +1529,8 @@ func (pool *TxPool) handleTxMsg() {
 
 	for {
 		select {
-		case txs := <-pool.txMsgCh:
-			pool.AddRemotes(txs)
+		case msg := <-pool.txMsgCh:
+			pool.AddRemotes(pool.throttleTxMsg(msg))
 		case <-pool.chainHeadSub.Err():
 			return
 		}
@@ -1462,6 +1554,12 @@ func (pool *TxPool) handleTxFeed() {
 // the sender as a local one in the mean time, ensuring it goes around the local
 // pricing constraints.
 func (pool *TxPool) AddLocal(tx *types.Transaction) error {
+	return pool.AddLocalWithOrigin(tx, TxOriginLocal)
+}
+
+// AddLocalWithOrigin is AddLocal, recording the given origin in the local
+// transaction journal.
+func (pool *TxPool) AddLocalWithOrigin(tx *types.Transaction, origin TxOrigin) error {
 	if tx.Type().IsChainDataAnchoring() && !pool.config.AllowLocalAnchorTx {
 		return errNotAllowedAnchoringTx
 	}
@@ -1472,7 +1570,7 @@ func (pool *TxPool) AddLocal(tx *types.Transaction) error {
 	if poolSize >= pool.config.ExecSlotsAll+pool.config.NonExecSlotsAll {
 		return fmt.Errorf("txpool is full: %d", poolSize)
 	}
-	return pool.addTx(tx, !pool.config.NoLocals)
+	return pool.addTxWithOrigin(tx, !pool.config.NoLocals, origin)
 }
 
 // AddRemote enqueues a single transaction into the pool if it is valid. If the
@@ -1523,13 +1621,18 @@ func (pool *TxPool) checkAndAddTxs(txs []*types.Transaction, local bool) []error
 
 // addTx enqueues a single transaction into the pool if it is valid.
 func (pool *TxPool) addTx(tx *types.Transaction, local bool) error {
+	return pool.addTxWithOrigin(tx, local, defaultTxOrigin(local))
+}
+
+// addTxWithOrigin is addTx, recording the given origin if the transaction is journaled.
+func (pool *TxPool) addTxWithOrigin(tx *types.Transaction, local bool, origin TxOrigin) error {
 	senderCacher.recover(pool.signer, []*types.Transaction{tx})
 
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
 	// Try to inject the transaction and update any state
-	replace, err := pool.add(tx, local)
+	replace, err := pool.addWithOrigin(tx, local, origin)
 	if err != nil {
 		return err
 	}
@@ -2283,6 +2386,7 @@ func (pool *TxPool) Clear() {
 
 	pool.all = newTxLookup()
 	pool.priced = newTxPricedList(pool.all)
+	pool.updatePricedBaseFee()
 	pool.pending = make(map[common.Address]*txList)
 	pool.queue = make(map[common.Address]*txList)
 	pool.pendingNonce = make(map[common.Address]uint64)
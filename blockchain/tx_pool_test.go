@@ -28,7 +28,6 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
-	"io"
 	"math/big"
 	"math/rand"
 	"os"
@@ -91,6 +90,7 @@ type testBlockChain struct {
 
 func (pool *TxPool) SetBaseFee(baseFee *big.Int) {
 	pool.gasPrice = baseFee
+	pool.updatePricedBaseFee()
 }
 
 func (bc *testBlockChain) CurrentBlock() *types.Block {
@@ -3309,25 +3309,15 @@ func TestTransactionJournalingSortedByTime(t *testing.T) {
 	pool.mu.Unlock()
 
 	// Read a journal and load it.
-	input, err := os.Open(pool.journal.path)
-	if err != nil {
-		t.Error(err)
-	}
-	defer input.Close()
-
-	txsFromFile := make(types.Transactions, 4)
-	stream := rlp.NewStream(input, 0)
-	for i := 0; i < 4; i++ {
-		tx := new(types.Transaction)
-		if err = stream.Decode(tx); err != nil {
-			if err != io.EOF {
-				t.Error(err)
-			}
-
-			break
-		}
-		txsFromFile[i] = tx
-	}
+	txsFromFile := make(types.Transactions, 0, 4)
+	version, err := ReadTxJournal(pool.journal.path, func(entry *TxJournalEntry) bool {
+		assert.Equal(t, TxOriginLocal, entry.Origin)
+		txsFromFile = append(txsFromFile, entry.Transaction())
+		return true
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(TxJournalVersion), version)
+	assert.Len(t, txsFromFile, 4)
 
 	// Check whether transactions loaded from journal file is sorted by time
 	for i, tx := range txsFromFile {
@@ -3336,6 +3326,120 @@ func TestTransactionJournalingSortedByTime(t *testing.T) {
 	}
 }
 
+// TestTransactionJournalReplay tests that the journal keeps the insertion time and
+// origin of the transactions, reads unversioned journals, and skips the entries
+// which are already mined or have outlived the lifetime on replay.
+func TestTransactionJournalReplay(t *testing.T) {
+	t.Parallel()
+
+	journal := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	blockchain := &testBlockChain{statedb: statedb, gasLimit: 1000000, chainHeadFeed: new(event.Feed), txMap: make(map[common.Hash]*types.Transaction)}
+
+	keys := make([]*ecdsa.PrivateKey, 4)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		statedb.AddBalance(crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(1000000000))
+	}
+	var (
+		now     = time.Now()
+		fresh   = pricedTransaction(0, 100000, big.NewInt(1), keys[0])
+		bridge  = pricedTransaction(0, 100000, big.NewInt(1), keys[1])
+		expired = pricedTransaction(0, 100000, big.NewInt(1), keys[2])
+		mined   = pricedTransaction(0, 100000, big.NewInt(1), keys[3])
+	)
+	blockchain.txMap[mined.Hash()] = mined
+
+	// An unversioned journal is a bare stream of transactions
+	legacy, err := os.Create(journal)
+	require.NoError(t, err)
+	for _, tx := range []*types.Transaction{fresh, mined} {
+		require.NoError(t, rlp.Encode(legacy, tx))
+	}
+	require.NoError(t, legacy.Close())
+
+	var entries []*TxJournalEntry
+	version, err := ReadTxJournal(journal, func(entry *TxJournalEntry) bool {
+		entries = append(entries, entry)
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), version)
+	require.Len(t, entries, 2)
+	assert.Equal(t, TxOriginUnknown, entries[0].Origin)
+	assert.True(t, entries[0].InsertedAt().IsZero())
+
+	// Replace it with a versioned journal
+	require.NoError(t, WriteTxJournal(journal, []*TxJournalEntry{
+		newTxJournalEntry(fresh, now.Add(-time.Minute), TxOriginLocal),
+		newTxJournalEntry(bridge, now.Add(-2*time.Minute), TxOriginBridge),
+		newTxJournalEntry(expired, now.Add(-time.Hour), TxOriginLocal),
+		newTxJournalEntry(mined, now.Add(-time.Minute), TxOriginGasless),
+	}))
+
+	config := testTxPoolConfig
+	config.Journal = journal
+	config.Lifetime = 10 * time.Minute
+
+	pool := NewTxPool(config, params.TestChainConfig, blockchain, &dummyGovModule{chainConfig: params.TestChainConfig})
+	pending, queued := pool.Stats()
+	assert.Equal(t, 2, pending)
+	assert.Equal(t, 0, queued)
+	assert.NotNil(t, pool.Get(fresh.Hash()))
+	assert.NotNil(t, pool.Get(bridge.Hash()))
+
+	// The insertion time and origin survive the rotation on startup
+	added := pricedTransaction(1, 100000, big.NewInt(1), keys[0])
+	require.NoError(t, pool.AddLocal(added))
+	pool.Stop()
+
+	got := make(map[common.Hash]*TxJournalEntry)
+	_, err = ReadTxJournal(journal, func(entry *TxJournalEntry) bool {
+		got[entry.Tx.Hash()] = entry
+		return true
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, TxOriginLocal, got[fresh.Hash()].Origin)
+	assert.Equal(t, now.Add(-time.Minute).Unix(), got[fresh.Hash()].InsertedAt().Unix())
+	assert.Equal(t, TxOriginBridge, got[bridge.Hash()].Origin)
+	assert.Equal(t, now.Add(-2*time.Minute).Unix(), got[bridge.Hash()].InsertedAt().Unix())
+	assert.Equal(t, TxOriginLocal, got[added.Hash()].Origin)
+	assert.False(t, got[added.Hash()].InsertedAt().Before(now.Truncate(time.Second)))
+
+	// Blob sidecars are not part of the transaction encoding, but are journaled
+	blobTx := blobTransaction(0, 100000, big.NewInt(10), big.NewInt(1), big.NewInt(1), keys[0], 1, nil)
+	blobJournal := filepath.Join(t.TempDir(), "blob.rlp")
+	require.NoError(t, WriteTxJournal(blobJournal, []*TxJournalEntry{newTxJournalEntry(blobTx, now, TxOriginLocal)}))
+	_, err = ReadTxJournal(blobJournal, func(entry *TxJournalEntry) bool {
+		assert.Equal(t, blobTx.Hash(), entry.Transaction().Hash())
+		assert.Equal(t, blobTx.BlobTxSidecar(), entry.Transaction().BlobTxSidecar())
+		return true
+	})
+	require.NoError(t, err)
+
+	// An empty pool leaves the journal empty, and the header is written along with the first entry
+	config.Journal = filepath.Join(t.TempDir(), "empty.rlp")
+	pool = NewTxPool(config, params.TestChainConfig, blockchain, &dummyGovModule{chainConfig: params.TestChainConfig})
+	info, err := os.Stat(config.Journal)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+
+	require.NoError(t, pool.AddLocal(fresh))
+	pool.Stop()
+
+	entries = nil
+	version, err = ReadTxJournal(config.Journal, func(entry *TxJournalEntry) bool {
+		entries = append(entries, entry)
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(TxJournalVersion), version)
+	require.Len(t, entries, 1)
+	assert.Equal(t, fresh.Hash(), entries[0].Tx.Hash())
+}
+
 // Test the transaction slots consumption is computed correctly
 func TestTransactionSlotCount(t *testing.T) {
 	t.Parallel()
@@ -3358,6 +3462,73 @@ func TestTransactionSlotCount(t *testing.T) {
 	}
 }
 
+// TestTransactionPoolEvictByEffectiveTip checks that once the pool is full, the
+// remote transactions paying the lowest effective tip are evicted first in favor
+// of better paying ones, and local transactions are never evicted.
+func TestTransactionPoolEvictByEffectiveTip(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	blockchain := &testBlockChain{statedb: statedb, gasLimit: 1000000, chainHeadFeed: new(event.Feed), txMap: make(map[common.Hash]*types.Transaction)}
+
+	testChainConfig := params.TestKaiaConfig("kaia")
+	config := testTxPoolConfig
+	config.ExecSlotsAll = 3
+	config.NonExecSlotsAll = 1
+
+	pool := NewTxPool(config, testChainConfig, blockchain, &dummyGovModule{chainConfig: testChainConfig})
+	defer pool.Stop()
+	pool.SetBaseFee(big.NewInt(25))
+
+	keys := make([]*ecdsa.PrivateKey, 7)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(params.KAIA))
+	}
+	var (
+		local      = pricedTransaction(0, 100000, big.NewInt(25), keys[0])                                                   // tip 0
+		dynLowTip  = dynamicFeeTx(0, 100000, big.NewInt(1000), big.NewInt(1), keys[1])                                       // tip 1
+		legacy     = pricedTransaction(0, 100000, big.NewInt(28), keys[2])                                                   // tip 3
+		ratio      = feeDelegatedWithRatioTx(0, 100000, big.NewInt(35), big.NewInt(1), keys[3], keys[3], types.FeeRatio(30)) // tip 10
+		cheap      = pricedTransaction(0, 100000, big.NewInt(26), keys[4])                                                   // tip 1
+		better     = dynamicFeeTx(0, 100000, big.NewInt(30), big.NewInt(4), keys[5])                                         // tip 4
+		evenBetter = pricedTransaction(0, 100000, big.NewInt(40), keys[6])                                                   // tip 15
+	)
+	if err := pool.AddLocal(local); err != nil {
+		t.Fatalf("failed to add local transaction: %v", err)
+	}
+	for _, tx := range []*types.Transaction{dynLowTip, legacy, ratio} {
+		if err := pool.AddRemote(tx); err != nil {
+			t.Fatalf("failed to add remote transaction: %v", err)
+		}
+	}
+	pending, queued := pool.Stats()
+	assert.Equal(t, 4, pending)
+	assert.Equal(t, 0, queued)
+
+	// A transaction which does not pay more than the cheapest remote one is rejected,
+	// even though dynLowTip has a higher gas fee cap.
+	assert.Error(t, pool.AddRemote(cheap))
+	assert.NotNil(t, pool.Get(dynLowTip.Hash()))
+
+	// Better paying transactions evict the lowest effective tip first, but never the local one
+	assert.NoError(t, pool.AddRemote(better))
+	assert.Nil(t, pool.Get(dynLowTip.Hash()))
+
+	assert.NoError(t, pool.AddRemote(evenBetter))
+	assert.Nil(t, pool.Get(legacy.Hash()))
+
+	for _, tx := range []*types.Transaction{local, ratio, better, evenBetter} {
+		assert.NotNil(t, pool.Get(tx.Hash()))
+	}
+	pending, queued = pool.Stats()
+	assert.Equal(t, 4, pending)
+	assert.Equal(t, 0, queued)
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Benchmarks the speed of validating the contents of the pending queue of the
 // transaction pool.
 func BenchmarkPendingDemotion100(b *testing.B)   { benchmarkPendingDemotion(b, 100) }
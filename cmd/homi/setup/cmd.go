@@ -43,6 +43,7 @@ import (
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/kaiax/auction"
+	"github.com/kaiachain/kaia/kaiax/bundle"
 	"github.com/kaiachain/kaia/kaiax/gasless"
 	"github.com/kaiachain/kaia/log"
 	"github.com/kaiachain/kaia/networks/p2p/discover"
@@ -151,6 +152,12 @@ var HomiFlags = []cli.Flag{
 	altsrc.NewBoolFlag(auction.DisableFlag),
 	altsrc.NewInt64Flag(auction.MaxBidPoolSizeFlag),
 	altsrc.NewDurationFlag(auction.EDOffsetFlag),
+	altsrc.NewUint64Flag(auction.BidJournalRetentionFlag),
+	// kaiax/bundle
+	altsrc.NewBoolFlag(bundle.EnableFlag),
+	altsrc.NewIntFlag(bundle.MaxBundlesFlag),
+	altsrc.NewIntFlag(bundle.MaxBundleTxsFlag),
+	altsrc.NewIntFlag(bundle.MaxBundlesPerSenderFlag),
 }
 
 var SetupCommand = &cli.Command{
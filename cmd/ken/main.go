@@ -73,6 +73,12 @@ func init() {
 
 		// See utils/nodecmd/snapshot.go:
 		nodecmd.SnapshotCommand,
+
+		// See utils/nodecmd/supplycmd.go:
+		nodecmd.SupplyCommand,
+
+		// See utils/nodecmd/txpoolcmd.go:
+		nodecmd.TxPoolCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
@@ -52,7 +52,10 @@ import (
 	"github.com/kaiachain/kaia/datasync/dbsyncer"
 	"github.com/kaiachain/kaia/datasync/downloader"
 	"github.com/kaiachain/kaia/kaiax/auction"
+	"github.com/kaiachain/kaia/kaiax/bundle"
 	"github.com/kaiachain/kaia/kaiax/gasless"
+	"github.com/kaiachain/kaia/kaiax/logindex"
+	"github.com/kaiachain/kaia/kaiax/reward"
 	"github.com/kaiachain/kaia/log"
 	"github.com/kaiachain/kaia/networks/p2p"
 	"github.com/kaiachain/kaia/networks/p2p/discover"
@@ -67,6 +70,7 @@ import (
 	"github.com/kaiachain/kaia/params"
 	"github.com/kaiachain/kaia/storage/database"
 	"github.com/kaiachain/kaia/storage/statedb"
+	"github.com/kaiachain/kaia/work"
 	"github.com/naoina/toml"
 	"github.com/urfave/cli/v2"
 )
@@ -222,6 +226,14 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 	cfg.RWTimerConfig.Interval = ctx.Uint64(RWTimerIntervalFlag.Name)
 	cfg.RWTimerConfig.WaitTime = ctx.Duration(RWTimerWaitTimeFlag.Name)
 
+	cfg.Reputation = p2p.ReputationConfig{
+		Disable:        ctx.Bool(ReputationDisableFlag.Name),
+		CNBanThreshold: ctx.Int(ReputationCNBanThresholdFlag.Name),
+		PNBanThreshold: ctx.Int(ReputationPNBanThresholdFlag.Name),
+		ENBanThreshold: ctx.Int(ReputationENBanThresholdFlag.Name),
+		BanDuration:    ctx.Duration(ReputationBanDurationFlag.Name),
+	}
+
 	if netrestrict := ctx.String(NetrestrictFlag.Name); netrestrict != "" {
 		list, err := netutil.ParseNetlist(netrestrict)
 		if err != nil {
@@ -412,6 +424,7 @@ func (kCfg *KaiaConfig) SetNodeConfig(ctx *cli.Context) {
 	setHTTP(ctx, cfg)
 	setWS(ctx, cfg)
 	setgRPC(ctx, cfg)
+	setAuthRPC(ctx, cfg)
 	setAPIConfig(ctx)
 	setNodeUserIdent(ctx, cfg)
 
@@ -434,6 +447,36 @@ func (kCfg *KaiaConfig) SetNodeConfig(ctx *cli.Context) {
 	}
 }
 
+// setRPCRateLimits installs the per-client quotas and the batch and response size
+// limits shared by the HTTP and WS-RPC servers.
+func setRPCRateLimits(ctx *cli.Context) {
+	cfg := rpc.RateLimitConfig{
+		APIKeyHeader:    ctx.String(RPCRateLimitAPIKeyHeaderFlag.Name),
+		MaxBatchSize:    ctx.Int(RPCBatchLimitFlag.Name),
+		MaxResponseSize: ctx.Int(RPCResponseSizeLimitFlag.Name),
+	}
+	for _, s := range ctx.StringSlice(RPCRateLimitFlag.Name) {
+		rule, err := rpc.ParseRateLimitRule(s)
+		if err != nil {
+			log.Fatalf("Option %q: %v", RPCRateLimitFlag.Name, err)
+		}
+		cfg.Rules = append(cfg.Rules, rule)
+	}
+	for _, s := range ctx.StringSlice(RPCRateLimitAPIKeysFlag.Name) {
+		key, err := rpc.ParseAPIKeyQuota(s)
+		if err != nil {
+			log.Fatalf("Option %q: %v", RPCRateLimitAPIKeysFlag.Name, err)
+		}
+		cfg.APIKeys = append(cfg.APIKeys, key)
+	}
+	if err := rpc.SetRateLimitConfig(cfg); err != nil {
+		log.Fatalf("Invalid RPC rate limits: %v", err)
+	}
+	if len(cfg.Rules) > 0 {
+		logger.Info("Set the rate limits of RPC servers", "rules", len(cfg.Rules), "apikeys", len(cfg.APIKeys))
+	}
+}
+
 // setHTTP creates the HTTP RPC listener interface string from the set
 // command line flags, returning empty if the HTTP endpoint is disabled.
 func setHTTP(ctx *cli.Context, cfg *node.Config) {
@@ -460,6 +503,7 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 		rpc.ConcurrencyLimit = ctx.Int(RPCConcurrencyLimit.Name)
 		logger.Info("Set the concurrency limit of RPC-HTTP server", "limit", rpc.ConcurrencyLimit)
 	}
+	setRPCRateLimits(ctx)
 	if ctx.IsSet(RPCReadTimeout.Name) {
 		cfg.HTTPTimeouts.ReadTimeout = time.Duration(ctx.Int(RPCReadTimeout.Name)) * time.Second
 	}
@@ -473,8 +517,38 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 		cfg.HTTPTimeouts.ExecutionTimeout = time.Duration(ctx.Int(RPCExecutionTimeoutFlag.Name)) * time.Second
 	}
 	if ctx.IsSet(RPCUpstreamArchiveENFlag.Name) {
-		rpc.UpstreamArchiveEN = ctx.String(RPCUpstreamArchiveENFlag.Name)
-		cfg.UpstreamArchiveEN = rpc.UpstreamArchiveEN
+		cfg.UpstreamArchiveEN = ctx.String(RPCUpstreamArchiveENFlag.Name)
+	}
+	setRPCUpstreams(ctx, cfg)
+}
+
+// splitNonEmpty is SplitAndTrim without the empty elements.
+func splitNonEmpty(input string) []string {
+	var result []string
+	for _, s := range SplitAndTrim(input) {
+		if s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// setRPCUpstreams installs the upstream ENs the RPC servers forward calls to.
+func setRPCUpstreams(ctx *cli.Context, cfg *node.Config) {
+	upstreamCfg := rpc.UpstreamConfig{
+		Endpoints:           splitNonEmpty(cfg.UpstreamArchiveEN),
+		WriteEndpoints:      splitNonEmpty(ctx.String(RPCUpstreamWriteENFlag.Name)),
+		ProxyNamespaces:     splitNonEmpty(ctx.String(RPCUpstreamProxyAPIFlag.Name)),
+		HealthCheckInterval: ctx.Duration(RPCUpstreamHealthCheckIntervalFlag.Name),
+		MaxBlockLag:         ctx.Uint64(RPCUpstreamMaxBlockLagFlag.Name),
+		CacheSize:           ctx.Int(RPCUpstreamCacheSizeFlag.Name),
+	}
+	if err := rpc.SetUpstreamConfig(upstreamCfg); err != nil {
+		log.Fatalf("Invalid upstream EN configuration: %v", err)
+	}
+	if len(upstreamCfg.Endpoints) > 0 {
+		logger.Info("Set the upstream ENs of RPC servers", "reads", len(upstreamCfg.Endpoints),
+			"writes", len(upstreamCfg.WriteEndpoints), "proxied", strings.Join(upstreamCfg.ProxyNamespaces, ","))
 	}
 }
 
@@ -530,6 +604,30 @@ func setgRPC(ctx *cli.Context, cfg *node.Config) {
 	}
 }
 
+// setAuthRPC creates the authenticated RPC listener interface string from the set
+// command line flags, returning empty if the authenticated endpoint is disabled.
+func setAuthRPC(ctx *cli.Context, cfg *node.Config) {
+	if ctx.Bool(AuthRPCEnabledFlag.Name) && cfg.AuthHost == "" {
+		cfg.AuthHost = "127.0.0.1"
+		if ctx.IsSet(AuthRPCListenAddrFlag.Name) {
+			cfg.AuthHost = ctx.String(AuthRPCListenAddrFlag.Name)
+		}
+	}
+
+	if ctx.IsSet(AuthRPCPortFlag.Name) {
+		cfg.AuthPort = ctx.Int(AuthRPCPortFlag.Name)
+	}
+	if ctx.IsSet(AuthRPCVirtualHostsFlag.Name) {
+		cfg.AuthVirtualHosts = SplitAndTrim(ctx.String(AuthRPCVirtualHostsFlag.Name))
+	}
+	if ctx.IsSet(AuthRPCApiFlag.Name) {
+		cfg.AuthModules = SplitAndTrim(ctx.String(AuthRPCApiFlag.Name))
+	}
+	if ctx.IsSet(AuthRPCJWTSecretFlag.Name) {
+		cfg.JWTSecret = ctx.String(AuthRPCJWTSecretFlag.Name)
+	}
+}
+
 // setAPIConfig sets configurations for specific APIs.
 func setAPIConfig(ctx *cli.Context) {
 	filters.GetLogsDeadline = ctx.Duration(APIFilterGetLogsDeadlineFlag.Name)
@@ -737,6 +835,11 @@ func (kCfg *KaiaConfig) SetKaiaConfig(ctx *cli.Context, stack *node.Node) {
 	if ctx.IsSet(OpcodeComputationCostLimitFlag.Name) {
 		params.OpcodeComputationCostLimitOverride = ctx.Uint64(OpcodeComputationCostLimitFlag.Name)
 	}
+	cfg.BuildReportBlocks = ctx.Uint64(BlockBuildReportBlocksFlag.Name)
+	cfg.TxOrderingPolicy = ctx.String(TxOrderingPolicyFlag.Name)
+	if _, err := work.NewTxOrderingPolicy(cfg.TxOrderingPolicy); err != nil {
+		logger.Crit("Invalid tx ordering policy", "err", err)
+	}
 	cfg.UseConsoleLog = ctx.Bool(UseConsoleLogFlag.Name)
 	if cfg.UseConsoleLog && !ctx.IsSet(NetworkIdFlag.Name) {
 		logger.Crit("Use of --use-console-log is only supported for private network, however --networkid is not set.")
@@ -783,6 +886,9 @@ func (kCfg *KaiaConfig) SetKaiaConfig(ctx *cli.Context, stack *node.Node) {
 	// Set kaiax module config
 	gasless.SetGaslessConfig(ctx, cfg.Gasless)
 	auction.SetAuctionConfig(ctx, cfg.Auction, kCfg.Node.P2P.ConnectionType)
+	bundle.SetBundleConfig(ctx, cfg.Bundle, kCfg.Node.P2P.ConnectionType)
+	reward.SetLedgerConfig(ctx, cfg.RewardLedger)
+	logindex.SetLogIndexConfig(ctx, cfg.LogIndex)
 }
 
 // raiseFDLimit increases the file descriptor limit to process's maximum value
@@ -889,6 +995,9 @@ func setTxPool(ctx *cli.Context, cfg *blockchain.TxPoolConfig) {
 	// PN specific txpool setting
 	if NodeTypeFlag.Value == "pn" {
 		cfg.EnableSpamThrottlerAtRuntime = !ctx.Bool(TxPoolSpamThrottlerDisableFlag.Name)
+		if ctx.IsSet(TxPoolSpamThrottlerJournalFlag.Name) {
+			cfg.SpamThrottlerJournal = ctx.String(TxPoolSpamThrottlerJournalFlag.Name)
+		}
 	}
 }
 
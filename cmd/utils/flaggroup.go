@@ -55,6 +55,8 @@ var FlagGroups = []FlagGroup{
 			BlockGenerationIntervalFlag,
 			BlockGenerationTimeLimitFlag,
 			OpcodeComputationCostLimitFlag,
+			BlockBuildReportBlocksFlag,
+			TxOrderingPolicyFlag,
 			UseConsoleLogFlag,
 		},
 	},
@@ -245,6 +247,11 @@ var FlagGroups = []FlagGroup{
 			NoDiscoverFlag,
 			RWTimerWaitTimeFlag,
 			RWTimerIntervalFlag,
+			ReputationDisableFlag,
+			ReputationCNBanThresholdFlag,
+			ReputationPNBanThresholdFlag,
+			ReputationENBanThresholdFlag,
+			ReputationBanDurationFlag,
 			NetrestrictFlag,
 			NodeKeyFileFlag,
 			NodeKeyHexFlag,
@@ -259,6 +266,10 @@ var FlagGroups = []FlagGroup{
 			MetricsEnabledFlag,
 			PrometheusExporterFlag,
 			PrometheusExporterPortFlag,
+			TracingEnabledFlag,
+			TracingEndpointFlag,
+			TracingInsecureFlag,
+			TracingSampleRatioFlag,
 		},
 	},
 	{
@@ -285,12 +296,22 @@ var FlagGroups = []FlagGroup{
 			RPCGlobalEVMTimeoutFlag,
 			RPCGlobalEthTxFeeCapFlag,
 			RPCConcurrencyLimit,
+			RPCRateLimitFlag,
+			RPCRateLimitAPIKeyHeaderFlag,
+			RPCRateLimitAPIKeysFlag,
+			RPCBatchLimitFlag,
+			RPCResponseSizeLimitFlag,
 			RPCNonEthCompatibleFlag,
 			RPCExecutionTimeoutFlag,
 			RPCIdleTimeoutFlag,
 			RPCReadTimeout,
 			RPCWriteTimeoutFlag,
 			RPCUpstreamArchiveENFlag,
+			RPCUpstreamWriteENFlag,
+			RPCUpstreamProxyAPIFlag,
+			RPCUpstreamHealthCheckIntervalFlag,
+			RPCUpstreamMaxBlockLagFlag,
+			RPCUpstreamCacheSizeFlag,
 			UnsafeDebugDisableFlag,
 			IPCDisabledFlag,
 			IPCPathFlag,
@@ -306,6 +327,13 @@ var FlagGroups = []FlagGroup{
 			GRPCEnabledFlag,
 			GRPCListenAddrFlag,
 			GRPCPortFlag,
+			AuthRPCEnabledFlag,
+			AuthRPCListenAddrFlag,
+			AuthRPCPortFlag,
+			AuthRPCVirtualHostsFlag,
+			AuthRPCApiFlag,
+			AuthRPCJWTSecretFlag,
+			GraphQLEnabledFlag,
 			JSpathFlag,
 			ExecFlag,
 			PreloadJSFlag,
@@ -36,15 +36,20 @@ import (
 	"github.com/kaiachain/kaia/datasync/chaindatafetcher/kafka"
 	"github.com/kaiachain/kaia/datasync/dbsyncer"
 	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/metrics/tracing"
 	metricutils "github.com/kaiachain/kaia/metrics/utils"
+	"github.com/kaiachain/kaia/networks/p2p"
 	"github.com/kaiachain/kaia/networks/rpc"
 	"github.com/kaiachain/kaia/node"
 	"github.com/kaiachain/kaia/node/cn"
 	"github.com/kaiachain/kaia/node/cn/filters"
+	"github.com/kaiachain/kaia/node/cn/graphql"
+	"github.com/kaiachain/kaia/node/cn/grpcapi"
 	"github.com/kaiachain/kaia/node/sc"
 	"github.com/kaiachain/kaia/params"
 	"github.com/kaiachain/kaia/storage/database"
 	"github.com/kaiachain/kaia/storage/statedb"
+	"github.com/kaiachain/kaia/work"
 	"github.com/urfave/cli/v2"
 )
 
@@ -309,6 +314,13 @@ var (
 		Aliases: []string{},
 		EnvVars: []string{"KLAYTN_TXPOOL_SPAMTHROTTLER_DISABLE", "KAIA_TXPOOL_SPAMTHROTTLER_DISABLE"},
 	}
+	TxPoolSpamThrottlerJournalFlag = &cli.StringFlag{
+		Name:    "txpool.spamthrottler.journal",
+		Usage:   "Disk journal for the throttled and allowed lists of the spam throttler to survive node restarts",
+		Value:   blockchain.DefaultTxPoolConfig.SpamThrottlerJournal,
+		Aliases: []string{},
+		EnvVars: []string{"KAIA_TXPOOL_SPAMTHROTTLER_JOURNAL"},
+	}
 
 	// KES
 	KESNodeTypeServiceFlag = &cli.BoolFlag{
@@ -806,6 +818,34 @@ var (
 		EnvVars:  []string{"KLAYTN_METRICUTILS_PROMETHEUSEXPORTERPORTFLAG", "KAIA_METRICUTILS_PROMETHEUSEXPORTERPORTFLAG"},
 		Category: "METRIC",
 	}
+	TracingEnabledFlag = &cli.BoolFlag{
+		Name:     "tracing",
+		Usage:    "Enable OpenTelemetry tracing of RPC calls, block imports and state commits",
+		Aliases:  []string{"tracing.enable"},
+		EnvVars:  []string{"KLAYTN_TRACING", "KAIA_TRACING"},
+		Category: "METRIC",
+	}
+	TracingEndpointFlag = &cli.StringFlag{
+		Name:     "tracing.endpoint",
+		Usage:    "OTLP/HTTP collector endpoint (host:port) the spans are exported to",
+		Value:    tracing.DefaultConfig.Endpoint,
+		EnvVars:  []string{"KLAYTN_TRACING_ENDPOINT", "KAIA_TRACING_ENDPOINT"},
+		Category: "METRIC",
+	}
+	TracingInsecureFlag = &cli.BoolFlag{
+		Name:     "tracing.insecure",
+		Usage:    "Export the spans over plain HTTP instead of HTTPS",
+		Value:    tracing.DefaultConfig.Insecure,
+		EnvVars:  []string{"KLAYTN_TRACING_INSECURE", "KAIA_TRACING_INSECURE"},
+		Category: "METRIC",
+	}
+	TracingSampleRatioFlag = &cli.Float64Flag{
+		Name:     "tracing.sample-ratio",
+		Usage:    "Fraction of the traces to record, in [0, 1]",
+		Value:    tracing.DefaultConfig.SampleRatio,
+		EnvVars:  []string{"KLAYTN_TRACING_SAMPLE_RATIO", "KAIA_TRACING_SAMPLE_RATIO"},
+		Category: "METRIC",
+	}
 
 	// RPC settings
 	RPCEnabledFlag = &cli.BoolFlag{
@@ -884,6 +924,42 @@ var (
 		EnvVars:  []string{"KLAYTN_RPC_CONCURRENCYLIMIT", "KAIA_RPC_CONCURRENCYLIMIT"},
 		Category: "API AND CONSOLE",
 	}
+	RPCRateLimitFlag = &cli.StringSliceFlag{
+		Name:     "rpc.ratelimit",
+		Usage:    "Per-client token-bucket quotas of the HTTP/WS-RPC servers as <method|namespace|*>=<rate>[:<burst>] (e.g. eth_call=10:20,debug=1,graphql=5,*=100)",
+		Aliases:  []string{"http-rpc.ratelimit"},
+		EnvVars:  []string{"KLAYTN_RPC_RATELIMIT", "KAIA_RPC_RATELIMIT"},
+		Category: "API AND CONSOLE",
+	}
+	RPCRateLimitAPIKeyHeaderFlag = &cli.StringFlag{
+		Name:     "rpc.ratelimit.apikey-header",
+		Usage:    "Request header carrying the API key of a rate limited client",
+		Value:    rpc.DefaultAPIKeyHeader,
+		Aliases:  []string{"http-rpc.ratelimit.apikey-header"},
+		EnvVars:  []string{"KLAYTN_RPC_RATELIMIT_APIKEY_HEADER", "KAIA_RPC_RATELIMIT_APIKEY_HEADER"},
+		Category: "API AND CONSOLE",
+	}
+	RPCRateLimitAPIKeysFlag = &cli.StringSliceFlag{
+		Name:     "rpc.ratelimit.apikeys",
+		Usage:    "API keys accounted separately from their IP address as <label>:<key>[=<quota factor>] (factor 0 = no limit)",
+		Aliases:  []string{"http-rpc.ratelimit.apikeys"},
+		EnvVars:  []string{"KLAYTN_RPC_RATELIMIT_APIKEYS", "KAIA_RPC_RATELIMIT_APIKEYS"},
+		Category: "API AND CONSOLE",
+	}
+	RPCBatchLimitFlag = &cli.IntFlag{
+		Name:     "rpc.batch-limit",
+		Usage:    "Maximum number of requests in a batch of the HTTP/WS-RPC servers (0 = no limit)",
+		Aliases:  []string{"http-rpc.batch-limit"},
+		EnvVars:  []string{"KLAYTN_RPC_BATCH_LIMIT", "KAIA_RPC_BATCH_LIMIT"},
+		Category: "API AND CONSOLE",
+	}
+	RPCResponseSizeLimitFlag = &cli.IntFlag{
+		Name:     "rpc.response-size-limit",
+		Usage:    "Maximum size in bytes of the results of a request to the HTTP/WS-RPC servers (0 = no limit)",
+		Aliases:  []string{"http-rpc.response-size-limit"},
+		EnvVars:  []string{"KLAYTN_RPC_RESPONSE_SIZE_LIMIT", "KAIA_RPC_RESPONSE_SIZE_LIMIT"},
+		Category: "API AND CONSOLE",
+	}
 	RPCNonEthCompatibleFlag = &cli.BoolFlag{
 		Name:     "rpc.eth.noncompatible",
 		Usage:    "Disables the eth namespace API return formatting for compatibility",
@@ -925,11 +1001,49 @@ var (
 	}
 	RPCUpstreamArchiveENFlag = &cli.StringFlag{
 		Name:     "upstream-en",
-		Usage:    "upstream archive mode EN endpoint",
+		Usage:    "Comma separated upstream archive mode EN endpoints, in order of preference. Calls lacking the state are forwarded to them",
 		Aliases:  []string{"rpc.upstream-en"},
 		EnvVars:  []string{"KLAYTN_RPC_UPSTREAM_EN", "KAIA_RPC_UPSTREAM_EN"},
 		Category: "API AND CONSOLE",
 	}
+	RPCUpstreamWriteENFlag = &cli.StringFlag{
+		Name:     "upstream-en.write",
+		Usage:    "Comma separated upstream EN endpoints receiving the forwarded transactions (default: the upstream-en endpoints)",
+		Aliases:  []string{"rpc.upstream-en.write"},
+		EnvVars:  []string{"KLAYTN_RPC_UPSTREAM_EN_WRITE", "KAIA_RPC_UPSTREAM_EN_WRITE"},
+		Category: "API AND CONSOLE",
+	}
+	RPCUpstreamProxyAPIFlag = &cli.StringFlag{
+		Name:     "upstream-en.proxy-api",
+		Usage:    "Comma separated API namespaces (e.g. kaia,eth) whose calls are all forwarded to the upstream ENs instead of being served locally",
+		Aliases:  []string{"rpc.upstream-en.proxy-api"},
+		EnvVars:  []string{"KLAYTN_RPC_UPSTREAM_EN_PROXY_API", "KAIA_RPC_UPSTREAM_EN_PROXY_API"},
+		Category: "API AND CONSOLE",
+	}
+	RPCUpstreamHealthCheckIntervalFlag = &cli.DurationFlag{
+		Name:     "upstream-en.health-interval",
+		Usage:    "Interval of the health checks of the upstream ENs",
+		Value:    rpc.DefaultUpstreamHealthCheckInterval,
+		Aliases:  []string{"rpc.upstream-en.health-interval"},
+		EnvVars:  []string{"KLAYTN_RPC_UPSTREAM_EN_HEALTH_INTERVAL", "KAIA_RPC_UPSTREAM_EN_HEALTH_INTERVAL"},
+		Category: "API AND CONSOLE",
+	}
+	RPCUpstreamMaxBlockLagFlag = &cli.Uint64Flag{
+		Name:     "upstream-en.max-block-lag",
+		Usage:    "Number of blocks an upstream EN may lag behind the best one before it is considered unhealthy (0 = no limit)",
+		Value:    10,
+		Aliases:  []string{"rpc.upstream-en.max-block-lag"},
+		EnvVars:  []string{"KLAYTN_RPC_UPSTREAM_EN_MAX_BLOCK_LAG", "KAIA_RPC_UPSTREAM_EN_MAX_BLOCK_LAG"},
+		Category: "API AND CONSOLE",
+	}
+	RPCUpstreamCacheSizeFlag = &cli.IntFlag{
+		Name:     "upstream-en.cache-size",
+		Usage:    "Number of cached upstream responses to immutable queries such as blocks and receipts (0 = disabled)",
+		Value:    4096,
+		Aliases:  []string{"rpc.upstream-en.cache-size"},
+		EnvVars:  []string{"KLAYTN_RPC_UPSTREAM_EN_CACHE_SIZE", "KAIA_RPC_UPSTREAM_EN_CACHE_SIZE"},
+		Category: "API AND CONSOLE",
+	}
 
 	WSEnabledFlag = &cli.BoolFlag{
 		Name:     "ws",
@@ -1004,7 +1118,7 @@ var (
 	}
 	GRPCEnabledFlag = &cli.BoolFlag{
 		Name:     "grpc",
-		Usage:    "Enable the gRPC server",
+		Usage:    "Enable the gRPC server with the JSON-RPC tunnel and the typed KaiaChain service",
 		Aliases:  []string{"g-rpc.enable"},
 		EnvVars:  []string{"KLAYTN_GRPC", "KAIA_GRPC"},
 		Category: "API AND CONSOLE",
@@ -1025,6 +1139,59 @@ var (
 		EnvVars:  []string{"KLAYTN_GRPCPORT", "KAIA_GRPCPORT"},
 		Category: "API AND CONSOLE",
 	}
+	AuthRPCEnabledFlag = &cli.BoolFlag{
+		Name:     "authrpc",
+		Usage:    "Enable the JWT authenticated HTTP/WS-RPC server for privileged APIs",
+		Aliases:  []string{"auth-rpc.enable"},
+		EnvVars:  []string{"KLAYTN_AUTHRPC", "KAIA_AUTHRPC"},
+		Category: "API AND CONSOLE",
+	}
+	AuthRPCListenAddrFlag = &cli.StringFlag{
+		Name:     "authrpc.addr",
+		Usage:    "Authenticated RPC server listening interface",
+		Value:    node.DefaultAuthHost,
+		Aliases:  []string{"auth-rpc.addr"},
+		EnvVars:  []string{"KLAYTN_AUTHRPC_ADDR", "KAIA_AUTHRPC_ADDR"},
+		Category: "API AND CONSOLE",
+	}
+	AuthRPCPortFlag = &cli.IntFlag{
+		Name:     "authrpc.port",
+		Usage:    "Authenticated RPC server listening port",
+		Value:    node.DefaultAuthPort,
+		Aliases:  []string{"auth-rpc.port"},
+		EnvVars:  []string{"KLAYTN_AUTHRPC_PORT", "KAIA_AUTHRPC_PORT"},
+		Category: "API AND CONSOLE",
+	}
+	AuthRPCVirtualHostsFlag = &cli.StringFlag{
+		Name:     "authrpc.vhosts",
+		Usage:    "Comma separated list of virtual hostnames from which to accept requests to the authenticated RPC server (server enforced). Accepts '*' wildcard.",
+		Value:    strings.Join(node.DefaultConfig.AuthVirtualHosts, ","),
+		Aliases:  []string{"auth-rpc.vhosts"},
+		EnvVars:  []string{"KLAYTN_AUTHRPC_VHOSTS", "KAIA_AUTHRPC_VHOSTS"},
+		Category: "API AND CONSOLE",
+	}
+	AuthRPCApiFlag = &cli.StringFlag{
+		Name:     "authrpc.api",
+		Usage:    "API's offered over the authenticated RPC interface",
+		Value:    strings.Join(node.DefaultConfig.AuthModules, ","),
+		Aliases:  []string{"auth-rpc.api"},
+		EnvVars:  []string{"KLAYTN_AUTHRPC_API", "KAIA_AUTHRPC_API"},
+		Category: "API AND CONSOLE",
+	}
+	AuthRPCJWTSecretFlag = &cli.PathFlag{
+		Name:     "authrpc.jwtsecret",
+		Usage:    "Path to the hex encoded 32 bytes HS256 secret of the authenticated RPC server (generated if missing, default = <datadir>/<name>/jwtsecret)",
+		Aliases:  []string{"auth-rpc.jwtsecret"},
+		EnvVars:  []string{"KLAYTN_AUTHRPC_JWTSECRET", "KAIA_AUTHRPC_JWTSECRET"},
+		Category: "API AND CONSOLE",
+	}
+	GraphQLEnabledFlag = &cli.BoolFlag{
+		Name:     "graphql",
+		Usage:    "Enable GraphQL on the HTTP-RPC server at /graphql. Note that GraphQL can only be started if an HTTP server is started as well.",
+		Aliases:  []string{"graphql.enable"},
+		EnvVars:  []string{"KLAYTN_GRAPHQL", "KAIA_GRAPHQL"},
+		Category: "API AND CONSOLE",
+	}
 	IPCDisabledFlag = &cli.BoolFlag{
 		Name:     "ipcdisable",
 		Usage:    "Disable the IPC-RPC server",
@@ -1247,6 +1414,45 @@ var (
 		EnvVars:  []string{"KLAYTN_RWTIMERWAITTIME", "KAIA_RWTIMERWAITTIME"},
 		Category: "NETWORK",
 	}
+	ReputationDisableFlag = &cli.BoolFlag{
+		Name:     "reputation.disable",
+		Usage:    "Disable scoring misbehaving peers and banning them temporarily",
+		Aliases:  []string{"p2p.reputation.disable"},
+		EnvVars:  []string{"KLAYTN_REPUTATION_DISABLE", "KAIA_REPUTATION_DISABLE"},
+		Category: "NETWORK",
+	}
+	ReputationCNBanThresholdFlag = &cli.IntFlag{
+		Name:     "reputation.cn-ban-threshold",
+		Usage:    "Penalty a CN peer may accumulate before it is banned (0 = never ban)",
+		Value:    p2p.DefaultReputationConfig.CNBanThreshold,
+		Aliases:  []string{"p2p.reputation.cn-ban-threshold"},
+		EnvVars:  []string{"KLAYTN_REPUTATION_CN_BAN_THRESHOLD", "KAIA_REPUTATION_CN_BAN_THRESHOLD"},
+		Category: "NETWORK",
+	}
+	ReputationPNBanThresholdFlag = &cli.IntFlag{
+		Name:     "reputation.pn-ban-threshold",
+		Usage:    "Penalty a PN peer may accumulate before it is banned (0 = never ban)",
+		Value:    p2p.DefaultReputationConfig.PNBanThreshold,
+		Aliases:  []string{"p2p.reputation.pn-ban-threshold"},
+		EnvVars:  []string{"KLAYTN_REPUTATION_PN_BAN_THRESHOLD", "KAIA_REPUTATION_PN_BAN_THRESHOLD"},
+		Category: "NETWORK",
+	}
+	ReputationENBanThresholdFlag = &cli.IntFlag{
+		Name:     "reputation.en-ban-threshold",
+		Usage:    "Penalty an EN peer may accumulate before it is banned (0 = never ban)",
+		Value:    p2p.DefaultReputationConfig.ENBanThreshold,
+		Aliases:  []string{"p2p.reputation.en-ban-threshold"},
+		EnvVars:  []string{"KLAYTN_REPUTATION_EN_BAN_THRESHOLD", "KAIA_REPUTATION_EN_BAN_THRESHOLD"},
+		Category: "NETWORK",
+	}
+	ReputationBanDurationFlag = &cli.DurationFlag{
+		Name:     "reputation.ban-duration",
+		Usage:    "Duration for which a peer with low reputation is refused",
+		Value:    p2p.DefaultReputationConfig.BanDuration,
+		Aliases:  []string{"p2p.reputation.ban-duration"},
+		EnvVars:  []string{"KLAYTN_REPUTATION_BAN_DURATION", "KAIA_REPUTATION_BAN_DURATION"},
+		Category: "NETWORK",
+	}
 	MaxRequestContentLengthFlag = &cli.IntFlag{
 		Name:     "maxRequestContentLength",
 		Usage:    "Max request content length in byte for http, websocket and gRPC",
@@ -1984,6 +2190,64 @@ var (
 		Category: "DATABASE MIGRATION",
 	}
 
+	// Supply audit
+	SupplyAuditFromFlag = &cli.Uint64Flag{
+		Name:     "supply.from",
+		Usage:    "First block number of the supply audit",
+		Value:    0,
+		Category: "SUPPLY AUDIT",
+	}
+	SupplyAuditToFlag = &cli.Uint64Flag{
+		Name:     "supply.to",
+		Usage:    "Last block number of the supply audit (0 = head block)",
+		Value:    0,
+		Category: "SUPPLY AUDIT",
+	}
+	SupplyAuditIntervalFlag = &cli.Uint64Flag{
+		Name:     "supply.interval",
+		Usage:    "Block interval between the supply audit records",
+		Value:    86400,
+		Category: "SUPPLY AUDIT",
+	}
+	SupplyAuditFormatFlag = &cli.StringFlag{
+		Name:     "supply.format",
+		Usage:    `Output format of the supply audit ("csv", "json")`,
+		Value:    "csv",
+		Category: "SUPPLY AUDIT",
+	}
+	SupplyAuditOutputFlag = &cli.PathFlag{
+		Name:     "supply.output",
+		Usage:    "Output file of the supply audit (default = stdout)",
+		Category: "SUPPLY AUDIT",
+	}
+	SupplyAuditNoCheckFlag = &cli.BoolFlag{
+		Name:     "supply.no-check",
+		Usage:    "Skip cross-checking the total supply against the balance sum of the state snapshot",
+		Category: "SUPPLY AUDIT",
+	}
+
+	// Txpool journal inspection
+	TxPoolJournalOriginFlag = &cli.StringFlag{
+		Name:     "txpool.journal.origin",
+		Usage:    `Comma separated origins of the journaled transactions to keep ("unknown", "local", "remote", "bridge", "gasless")`,
+		Category: "TXPOOL JOURNAL",
+	}
+	TxPoolJournalFromFlag = &cli.StringFlag{
+		Name:     "txpool.journal.from",
+		Usage:    "Sender address of the journaled transactions to keep",
+		Category: "TXPOOL JOURNAL",
+	}
+	TxPoolJournalSkipExpiredFlag = &cli.BoolFlag{
+		Name:     "txpool.journal.skip-expired",
+		Usage:    "Skip the journaled transactions older than --txpool.lifetime",
+		Category: "TXPOOL JOURNAL",
+	}
+	TxPoolJournalOutputFlag = &cli.PathFlag{
+		Name:     "txpool.journal.output",
+		Usage:    "Write the kept transactions to a new journal file instead of printing them",
+		Category: "TXPOOL JOURNAL",
+	}
+
 	// Config
 	ConfigFileFlag = &cli.StringFlag{
 		Name:     "config",
@@ -2019,6 +2283,23 @@ var (
 		EnvVars:  []string{"KLAYTN_OPCODE_COMPUTATION_COST_LIMIT", "KAIA_OPCODE_COMPUTATION_COST_LIMIT"},
 		Category: "KAIA",
 	}
+	BlockBuildReportBlocksFlag = &cli.Uint64Flag{
+		Name: "block-build-report.blocks",
+		Usage: "Number of recent blocks to keep the block build report, which explains why each pending tx " +
+			"was included or skipped. 0 disables the report. This flag is only applicable to CN",
+		Value:    0,
+		EnvVars:  []string{"KLAYTN_BLOCK_BUILD_REPORT_BLOCKS", "KAIA_BLOCK_BUILD_REPORT_BLOCKS"},
+		Category: "KAIA",
+	}
+	TxOrderingPolicyFlag = &cli.StringFlag{
+		Name: "tx-ordering-policy",
+		Usage: "Set the execution order of the pending transactions in a block. " +
+			"\"price-time\" orders by effective tip then by arrival time, \"fifo\" strictly by arrival time, " +
+			"and \"fair-share\" takes one transaction from each sender in turn. This flag is only applicable to CN",
+		Value:    work.TxOrderingPriceAndTime,
+		EnvVars:  []string{"KLAYTN_TX_ORDERING_POLICY", "KAIA_TX_ORDERING_POLICY"},
+		Category: "KAIA",
+	}
 	UseConsoleLogFlag = &cli.BoolFlag{
 		Name:     "use-console-log",
 		Usage:    "",
@@ -2073,6 +2354,22 @@ func MakeDataDir(ctx *cli.Context) string {
 	return ""
 }
 
+// StartTracing starts exporting the OpenTelemetry spans if --tracing is set.
+// The spans are attributed to a service named after the running binary.
+func StartTracing(ctx *cli.Context) error {
+	if !ctx.Bool(TracingEnabledFlag.Name) {
+		return nil
+	}
+	cfg := tracing.DefaultConfig
+	cfg.Endpoint = ctx.String(TracingEndpointFlag.Name)
+	cfg.Insecure = ctx.Bool(TracingInsecureFlag.Name)
+	cfg.SampleRatio = ctx.Float64(TracingSampleRatioFlag.Name)
+	if ctx.App != nil && ctx.App.Name != "" {
+		cfg.ServiceName = ctx.App.Name
+	}
+	return tracing.Start(cfg)
+}
+
 // SplitAndTrim splits input separated by a comma
 // and trims excessive white space from the substrings.
 func SplitAndTrim(input string) []string {
@@ -2137,6 +2434,35 @@ func RegisterService(stack *node.Node, cfg *sc.SCConfig) {
 	}
 }
 
+// RegisterGraphQLService adds a GraphQL service to the stack if --graphql is set.
+func RegisterGraphQLService(ctx *cli.Context, stack *node.Node) {
+	if !ctx.Bool(GraphQLEnabledFlag.Name) {
+		return
+	}
+	if stack.HTTPEndpoint() == "" {
+		log.Fatalf("GraphQL requires the HTTP-RPC server, enable it with --%s", RPCEnabledFlag.Name)
+	}
+	err := stack.RegisterSubService(func(ctx *node.ServiceContext) (node.Service, error) {
+		return graphql.New(), nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to register the GraphQL service: %v", err)
+	}
+}
+
+// RegisterGRPCService adds the typed KaiaChain gRPC service to the stack if --grpc is set.
+func RegisterGRPCService(ctx *cli.Context, stack *node.Node) {
+	if !ctx.Bool(GRPCEnabledFlag.Name) {
+		return
+	}
+	err := stack.RegisterSubService(func(ctx *node.ServiceContext) (node.Service, error) {
+		return grpcapi.New(), nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to register the KaiaChain gRPC service: %v", err)
+	}
+}
+
 // RegisterChainDataFetcherService adds a ChainDataFetcher to the stack
 func RegisterChainDataFetcherService(stack *node.Node, cfg *chaindatafetcher.ChainDataFetcherConfig) {
 	if cfg.EnabledChainDataFetcher {
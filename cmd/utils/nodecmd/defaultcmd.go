@@ -73,6 +73,8 @@ func MakeFullNode(ctx *cli.Context) *node.Node {
 	utils.RegisterService(stack, &cfg.ServiceChain)
 	utils.RegisterDBSyncerService(stack, &cfg.DB)
 	utils.RegisterChainDataFetcherService(stack, &cfg.ChainDataFetcher)
+	utils.RegisterGraphQLService(ctx, stack)
+	utils.RegisterGRPCService(ctx, stack)
 	return stack
 }
 
@@ -212,6 +214,9 @@ func BeforeRunNode(ctx *cli.Context) error {
 		return err
 	}
 	metricutils.StartMetricCollectionAndExport(ctx)
+	if err := utils.StartTracing(ctx); err != nil {
+		return err
+	}
 	setupNetwork(ctx)
 	return nil
 }
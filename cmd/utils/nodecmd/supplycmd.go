@@ -0,0 +1,324 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types/account"
+	"github.com/kaiachain/kaia/blockchain/vm"
+	"github.com/kaiachain/kaia/cmd/utils"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/consensus/faker"
+	gov_impl "github.com/kaiachain/kaia/kaiax/gov/impl"
+	reward_impl "github.com/kaiachain/kaia/kaiax/reward/impl"
+	staking_impl "github.com/kaiachain/kaia/kaiax/staking/impl"
+	"github.com/kaiachain/kaia/kaiax/supply"
+	supply_impl "github.com/kaiachain/kaia/kaiax/supply/impl"
+	valset_impl "github.com/kaiachain/kaia/kaiax/valset/impl"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/snapshot"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/kaiachain/kaia/storage/statedb"
+	"github.com/urfave/cli/v2"
+)
+
+var SupplyCommand = &cli.Command{
+	Name:     "supply",
+	Usage:    "A set of commands for the native token supply",
+	Category: "MISCELLANEOUS COMMANDS",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "audit",
+			Usage:  "Export the total supply breakdown over a block range",
+			Action: utils.MigrateFlags(auditSupply),
+			Flags:  utils.SupplyAuditFlags,
+			Description: `
+Kaia supply audit --supply.from <num> --supply.to <num> --supply.interval <num>
+exports the total supply and its components (minted, burnt fee, canonical burns,
+rebalance burns) at every interval blocks as CSV or JSON.
+Then the total supply is cross-checked against the balance sum of all accounts
+in the state snapshot, for the blocks covered by the snapshot (usually the recent blocks).
+The command fails if any divergence is found.
+
+Note: Do not run this command while a node is executing.
+`,
+		},
+	},
+}
+
+// supplyAuditRecord is a TotalSupplyResponse with the cross-check result, if checked.
+type supplyAuditRecord struct {
+	*supply.TotalSupplyResponse
+	// The sum of all account balances in the state snapshot.
+	BalanceSum *hexutil.Big `json:"balanceSum,omitempty"`
+	// BalanceSum - (TotalSupply + ZeroBurn + DeadBurn). Should be zero.
+	Divergence *hexutil.Big `json:"divergence,omitempty"`
+}
+
+func auditSupply(ctx *cli.Context) error {
+	format := ctx.String(utils.SupplyAuditFormatFlag.Name)
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("unknown supply audit format: %s", format)
+	}
+
+	stack := MakeFullNode(ctx)
+	dbm := stack.OpenDatabase(getConfig(ctx))
+	defer dbm.Close()
+
+	chain, mSupply, err := newOfflineSupplyModule(dbm)
+	if err != nil {
+		return err
+	}
+	defer chain.Stop()
+
+	var (
+		from     = ctx.Uint64(utils.SupplyAuditFromFlag.Name)
+		to       = ctx.Uint64(utils.SupplyAuditToFlag.Name)
+		interval = ctx.Uint64(utils.SupplyAuditIntervalFlag.Name)
+	)
+	if to == 0 {
+		to = chain.CurrentBlock().NumberU64()
+	}
+	logger.Info("Auditing total supply", "from", from, "to", to, "interval", interval)
+
+	responses, err := mSupply.GetTotalSupplyRange(from, to, interval)
+	if err != nil {
+		return err
+	}
+	records := make([]*supplyAuditRecord, len(responses))
+	for i, response := range responses {
+		records[i] = &supplyAuditRecord{TotalSupplyResponse: response}
+	}
+
+	if !ctx.Bool(utils.SupplyAuditNoCheckFlag.Name) {
+		if err := crossCheckSupply(dbm, chain, mSupply, records); err != nil {
+			return err
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if path := ctx.String(utils.SupplyAuditOutputFlag.Name); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if format == "csv" {
+		err = writeSupplyAuditCSV(w, records)
+	} else {
+		err = writeSupplyAuditJSON(w, records)
+	}
+	if err != nil {
+		return err
+	}
+
+	diverged := 0
+	for _, record := range records {
+		if record.Divergence != nil && record.Divergence.ToInt().Sign() != 0 {
+			logger.Warn("Total supply diverged from the state balance sum", "number", record.Number.ToInt(), "divergence", record.Divergence.ToInt())
+			diverged++
+		}
+	}
+	if diverged > 0 {
+		return fmt.Errorf("total supply diverged from the state balance sum at %d blocks", diverged)
+	}
+	return nil
+}
+
+// newOfflineSupplyModule creates a supply module and its dependencies on top of the database.
+// The blocks are already verified, hence a fake consensus engine is used.
+func newOfflineSupplyModule(dbm database.DBManager) (*blockchain.BlockChain, *supply_impl.SupplyModule, error) {
+	genesisHash := dbm.ReadCanonicalHash(0)
+	if genesisHash == (common.Hash{}) {
+		return nil, nil, errors.New("empty database")
+	}
+	chainConfig, err := dbm.ReadChainConfig(genesisHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	chain, err := blockchain.NewBlockChain(dbm, nil, chainConfig, faker.NewFaker(), vm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		mStaking = staking_impl.NewStakingModule()
+		mGov     = gov_impl.NewGovModule()
+		mValset  = valset_impl.NewValsetModule()
+		mReward  = reward_impl.NewRewardModule()
+		mSupply  = supply_impl.NewSupplyModule()
+	)
+	err = errors.Join(
+		mStaking.Init(&staking_impl.InitOpts{
+			ChainKv:     dbm.GetMiscDB(),
+			ChainConfig: chainConfig,
+			Chain:       chain,
+		}),
+		mGov.Init(&gov_impl.InitOpts{
+			ChainConfig: chainConfig,
+			ChainKv:     dbm.GetMiscDB(),
+			Chain:       chain,
+			Valset:      mValset,
+		}),
+		mValset.Init(&valset_impl.InitOpts{
+			ChainKv:       dbm.GetMiscDB(),
+			Chain:         chain,
+			GovModule:     mGov,
+			StakingModule: mStaking,
+		}),
+		mReward.Init(&reward_impl.InitOpts{
+			ChainConfig:   chainConfig,
+			Chain:         chain,
+			GovModule:     mGov,
+			StakingModule: mStaking,
+		}),
+		mSupply.Init(&supply_impl.InitOpts{
+			ChainKv:      dbm.GetMiscDB(),
+			ChainConfig:  chainConfig,
+			Chain:        chain,
+			RewardModule: mReward,
+		}),
+	)
+	if err != nil {
+		chain.Stop()
+		return nil, nil, err
+	}
+	return chain, mSupply, nil
+}
+
+// crossCheckSupply fills in the balance sum and the divergence of the records whose state is in the snapshot.
+func crossCheckSupply(dbm database.DBManager, chain *blockchain.BlockChain, mSupply supply.SupplyModule, records []*supplyAuditRecord) error {
+	snaptree, err := snapshot.New(dbm, statedb.NewDatabase(dbm), 256, chain.CurrentBlock().Root(), false, false, false)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot tree (use --%s to skip the cross-check): %w", utils.SupplyAuditNoCheckFlag.Name, err)
+	}
+
+	checked := 0
+	for _, record := range records {
+		num := record.Number.ToInt().Uint64()
+		header := chain.GetHeaderByNumber(num)
+		if header == nil || snaptree.Snapshot(header.Root) == nil {
+			continue
+		}
+		ts, err := mSupply.GetTotalSupply(num)
+		if ts == nil || ts.ExpectedBalanceSum() == nil {
+			logger.Warn("Skipping cross-check of incomplete total supply", "number", num, "err", err)
+			continue
+		}
+
+		logger.Info("Summing up the balances in the snapshot", "number", num, "root", header.Root)
+		sum, err := sumSnapshotBalances(snaptree, header.Root)
+		if err != nil {
+			return err
+		}
+		record.BalanceSum = (*hexutil.Big)(sum)
+		record.Divergence = (*hexutil.Big)(new(big.Int).Sub(sum, ts.ExpectedBalanceSum()))
+		checked++
+	}
+	if checked == 0 {
+		logger.Warn("No block in the range is covered by the snapshot. Nothing cross-checked")
+	}
+	return nil
+}
+
+func sumSnapshotBalances(snaptree *snapshot.Tree, root common.Hash) (*big.Int, error) {
+	it, err := snaptree.AccountIterator(root, common.Hash{})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	var (
+		sum      = new(big.Int)
+		accounts = uint64(0)
+		logged   = time.Now()
+	)
+	for it.Next() {
+		serializer := account.NewAccountSerializer()
+		if err := rlp.DecodeBytes(it.Account(), serializer); err != nil {
+			return nil, err
+		}
+		sum.Add(sum, serializer.GetAccount().GetBalance())
+		accounts++
+
+		if time.Since(logged) > 8*time.Second {
+			logger.Info("Summing up the balances in the snapshot", "accounts", accounts, "at", it.Hash())
+			logged = time.Now()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	logger.Info("Summed up the balances in the snapshot", "root", root, "accounts", accounts, "sum", sum)
+	return sum, nil
+}
+
+var supplyAuditCSVHeader = []string{
+	"number", "totalSupply", "totalMinted", "totalBurnt", "burntFee", "zeroBurn", "deadBurn", "kip103Burn", "kip160Burn",
+	"balanceSum", "divergence", "error",
+}
+
+// writeSupplyAuditCSV writes the records in decimal numbers. Missing values are left empty.
+func writeSupplyAuditCSV(w io.Writer, records []*supplyAuditRecord) error {
+	decimal := func(b *hexutil.Big) string {
+		if b == nil {
+			return ""
+		}
+		return b.ToInt().String()
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(supplyAuditCSVHeader); err != nil {
+		return err
+	}
+	for _, r := range records {
+		errStr := ""
+		if r.Error != nil {
+			errStr = *r.Error
+		}
+		row := []string{
+			strconv.FormatUint(r.Number.ToInt().Uint64(), 10),
+			decimal(r.TotalSupply), decimal(r.TotalMinted), decimal(r.TotalBurnt), decimal(r.BurntFee),
+			decimal(r.ZeroBurn), decimal(r.DeadBurn), decimal(r.Kip103Burn), decimal(r.Kip160Burn),
+			decimal(r.BalanceSum), decimal(r.Divergence), errStr,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeSupplyAuditJSON(w io.Writer, records []*supplyAuditRecord) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/kaiax/supply"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSupplyAudit(t *testing.T) {
+	var (
+		full = &supply.TotalSupply{
+			TotalSupply: big.NewInt(70),
+			TotalMinted: big.NewInt(100),
+			TotalBurnt:  big.NewInt(30),
+			BurntFee:    big.NewInt(10),
+			ZeroBurn:    big.NewInt(5),
+			DeadBurn:    big.NewInt(5),
+			Kip103Burn:  big.NewInt(10),
+			Kip160Burn:  big.NewInt(0),
+		}
+		partial = &supply.TotalSupply{
+			TotalMinted: big.NewInt(200),
+			BurntFee:    big.NewInt(20),
+			Kip103Burn:  big.NewInt(10),
+			Kip160Burn:  big.NewInt(0),
+		}
+		records = []*supplyAuditRecord{
+			{TotalSupplyResponse: partial.ToResponse(100, supply.ErrNoCanonicalBurn(supply.ErrNoBlock))},
+			{
+				TotalSupplyResponse: full.ToResponse(200, nil),
+				BalanceSum:          (*hexutil.Big)(big.NewInt(81)),
+				Divergence:          (*hexutil.Big)(big.NewInt(1)),
+			},
+		}
+	)
+	assert.Equal(t, big.NewInt(80), full.ExpectedBalanceSum())
+	assert.Nil(t, partial.ExpectedBalanceSum())
+
+	var buf bytes.Buffer
+	require.NoError(t, writeSupplyAuditCSV(&buf, records))
+	expected := "number,totalSupply,totalMinted,totalBurnt,burntFee,zeroBurn,deadBurn,kip103Burn,kip160Burn,balanceSum,divergence,error\n" +
+		"100,,200,,20,,,10,0,,,\"cannot determine canonical (0x0, 0xdead) burn amount: block not found\"\n" +
+		"200,70,100,30,10,5,5,10,0,81,1,\n"
+	assert.Equal(t, expected, buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeSupplyAuditJSON(&buf, records))
+	var decoded []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded, 2)
+	assert.Equal(t, "0x64", decoded[0]["number"])
+	assert.Nil(t, decoded[0]["totalSupply"])
+	assert.NotContains(t, decoded[0], "balanceSum")
+	assert.Equal(t, "0x46", decoded[1]["totalSupply"])
+	assert.Equal(t, "0x51", decoded[1]["balanceSum"])
+	assert.Equal(t, "0x1", decoded[1]["divergence"])
+}
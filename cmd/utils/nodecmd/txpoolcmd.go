@@ -0,0 +1,184 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/cmd/utils"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/node"
+	"github.com/urfave/cli/v2"
+)
+
+var TxPoolCommand = &cli.Command{
+	Name:     "txpool",
+	Usage:    "A set of commands for the transaction pool",
+	Category: "MISCELLANEOUS COMMANDS",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "journal",
+			Usage:     "Inspect or filter the local transaction journal",
+			ArgsUsage: "[<journal file>]",
+			Action:    utils.MigrateFlags(inspectTxJournal),
+			Flags:     utils.TxPoolJournalFlags,
+			Description: `
+Kaia txpool journal [<journal file>]
+prints the transactions in the local transaction journal, one JSON object per line.
+If no file is given, --txpool.journal is resolved against --datadir.
+The entries can be filtered by --txpool.journal.origin, --txpool.journal.from and
+--txpool.journal.skip-expired. With --txpool.journal.output, the kept entries are
+written to a new journal file which can replace the original one.
+
+Note: Do not rewrite the journal of a running node.
+`,
+		},
+	},
+}
+
+// txJournalFilter selects the journal entries to keep.
+type txJournalFilter struct {
+	origins map[blockchain.TxOrigin]bool // nil to keep all origins
+	from    *common.Address              // nil to keep all senders
+	expiry  time.Time                    // zero to keep expired entries
+}
+
+func (f *txJournalFilter) keep(entry *blockchain.TxJournalEntry) bool {
+	if f.origins != nil && !f.origins[entry.Origin] {
+		return false
+	}
+	if f.from != nil {
+		if from, err := txJournalSender(entry.Tx); err != nil || from != *f.from {
+			return false
+		}
+	}
+	if !f.expiry.IsZero() && !entry.InsertedAt().IsZero() && entry.InsertedAt().Before(f.expiry) {
+		return false
+	}
+	return true
+}
+
+// txJournalRecord is the printed form of a journal entry.
+type txJournalRecord struct {
+	Time     *time.Time      `json:"time,omitempty"`
+	Origin   string          `json:"origin"`
+	Hash     common.Hash     `json:"hash"`
+	Type     string          `json:"type"`
+	From     *common.Address `json:"from,omitempty"`
+	To       *common.Address `json:"to,omitempty"`
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Blobs    int             `json:"blobs,omitempty"`
+}
+
+func newTxJournalRecord(entry *blockchain.TxJournalEntry) *txJournalRecord {
+	tx := entry.Tx
+	record := &txJournalRecord{
+		Origin:   entry.Origin.String(),
+		Hash:     tx.Hash(),
+		Type:     tx.Type().String(),
+		To:       tx.To(),
+		Nonce:    hexutil.Uint64(tx.Nonce()),
+		GasPrice: (*hexutil.Big)(tx.GasPrice()),
+	}
+	if insertedAt := entry.InsertedAt(); !insertedAt.IsZero() {
+		record.Time = &insertedAt
+	}
+	if from, err := txJournalSender(tx); err == nil {
+		record.From = &from
+	}
+	if entry.Sidecar != nil {
+		record.Blobs = len(entry.Sidecar.Blobs)
+	}
+	return record
+}
+
+func txJournalSender(tx *types.Transaction) (common.Address, error) {
+	return types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+}
+
+func inspectTxJournal(ctx *cli.Context) error {
+	path := ctx.Args().First()
+	if path == "" {
+		conf := &node.Config{DataDir: utils.MakeDataDir(ctx), Name: utils.ClientIdentifier}
+		path = conf.ResolvePath(ctx.String(utils.TxPoolJournalFlag.Name))
+	}
+
+	filter := &txJournalFilter{}
+	if origins := ctx.String(utils.TxPoolJournalOriginFlag.Name); origins != "" {
+		filter.origins = make(map[blockchain.TxOrigin]bool)
+		for _, name := range utils.SplitAndTrim(origins) {
+			origin, err := blockchain.ParseTxOrigin(name)
+			if err != nil {
+				return err
+			}
+			filter.origins[origin] = true
+		}
+	}
+	if from := ctx.String(utils.TxPoolJournalFromFlag.Name); from != "" {
+		if !common.IsHexAddress(from) {
+			return fmt.Errorf("invalid sender address: %s", from)
+		}
+		addr := common.HexToAddress(from)
+		filter.from = &addr
+	}
+	if ctx.Bool(utils.TxPoolJournalSkipExpiredFlag.Name) {
+		filter.expiry = time.Now().Add(-ctx.Duration(utils.TxPoolLifetimeFlag.Name))
+	}
+
+	var (
+		entries []*blockchain.TxJournalEntry
+		total   = 0
+	)
+	version, err := blockchain.ReadTxJournal(path, func(entry *blockchain.TxJournalEntry) bool {
+		total++
+		if filter.keep(entry) {
+			entries = append(entries, entry)
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read tx journal %s: %w", path, err)
+	}
+	logger.Info("Read the local transaction journal", "path", path, "version", version, "transactions", total, "kept", len(entries))
+
+	if output := ctx.Path(utils.TxPoolJournalOutputFlag.Name); output != "" {
+		if err := blockchain.WriteTxJournal(output, entries); err != nil {
+			return err
+		}
+		logger.Info("Wrote the filtered transaction journal", "path", output, "version", blockchain.TxJournalVersion, "transactions", len(entries))
+		return nil
+	}
+	return writeTxJournalRecords(os.Stdout, entries)
+}
+
+func writeTxJournalRecords(w io.Writer, entries []*blockchain.TxJournalEntry) error {
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := enc.Encode(newTxJournalRecord(entry)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxJournalFilter(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		from    = crypto.PubkeyToAddress(key.PublicKey)
+		signer  = types.LatestSignerForChainID(big.NewInt(1))
+		now     = time.Now()
+		entries = make([]*blockchain.TxJournalEntry, 3)
+	)
+	for i, origin := range []blockchain.TxOrigin{blockchain.TxOriginLocal, blockchain.TxOriginGasless, blockchain.TxOriginUnknown} {
+		tx, err := types.SignTx(types.NewTransaction(uint64(i), common.HexToAddress("0xAAAA"), big.NewInt(1), 21000, big.NewInt(25), nil), signer, key)
+		require.NoError(t, err)
+		entries[i] = &blockchain.TxJournalEntry{Origin: origin, Tx: tx}
+		if origin != blockchain.TxOriginUnknown {
+			entries[i].Time = uint64(now.Add(-time.Duration(i) * time.Hour).Unix())
+		}
+	}
+	kept := func(f *txJournalFilter) []blockchain.TxOrigin {
+		var origins []blockchain.TxOrigin
+		for _, entry := range entries {
+			if f.keep(entry) {
+				origins = append(origins, entry.Origin)
+			}
+		}
+		return origins
+	}
+	other := common.HexToAddress("0xBBBB")
+
+	assert.Len(t, kept(&txJournalFilter{}), 3)
+	assert.Equal(t, []blockchain.TxOrigin{blockchain.TxOriginGasless}, kept(&txJournalFilter{origins: map[blockchain.TxOrigin]bool{blockchain.TxOriginGasless: true}}))
+	assert.Len(t, kept(&txJournalFilter{from: &from}), 3)
+	assert.Empty(t, kept(&txJournalFilter{from: &other}))
+	// Entries without an insertion time are never expired
+	assert.Equal(t, []blockchain.TxOrigin{blockchain.TxOriginLocal, blockchain.TxOriginUnknown}, kept(&txJournalFilter{expiry: now.Add(-30 * time.Minute)}))
+
+	var buf bytes.Buffer
+	require.NoError(t, writeTxJournalRecords(&buf, entries))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, "gasless", record["origin"])
+	assert.Equal(t, entries[1].Tx.Hash().Hex(), record["hash"])
+	assert.Equal(t, strings.ToLower(from.Hex()), strings.ToLower(record["from"].(string)))
+	assert.Equal(t, "0x1", record["nonce"])
+	assert.Contains(t, record, "time")
+
+	record = nil
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &record))
+	assert.Equal(t, "unknown", record["origin"])
+	assert.NotContains(t, record, "time")
+}
@@ -25,7 +25,10 @@ package utils
 import (
 	"github.com/kaiachain/kaia/api/debug"
 	"github.com/kaiachain/kaia/kaiax/auction"
+	"github.com/kaiachain/kaia/kaiax/bundle"
 	"github.com/kaiachain/kaia/kaiax/gasless"
+	"github.com/kaiachain/kaia/kaiax/logindex"
+	"github.com/kaiachain/kaia/kaiax/reward"
 	"github.com/urfave/cli/v2"
 	"github.com/urfave/cli/v2/altsrc"
 )
@@ -250,6 +253,11 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewStringFlag(DiscoverTypesFlag),
 	altsrc.NewDurationFlag(RWTimerWaitTimeFlag),
 	altsrc.NewUint64Flag(RWTimerIntervalFlag),
+	altsrc.NewBoolFlag(ReputationDisableFlag),
+	altsrc.NewIntFlag(ReputationCNBanThresholdFlag),
+	altsrc.NewIntFlag(ReputationPNBanThresholdFlag),
+	altsrc.NewIntFlag(ReputationENBanThresholdFlag),
+	altsrc.NewDurationFlag(ReputationBanDurationFlag),
 	altsrc.NewStringFlag(NetrestrictFlag),
 	altsrc.NewStringFlag(NodeKeyFileFlag),
 	altsrc.NewStringFlag(NodeKeyHexFlag),
@@ -263,6 +271,10 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewBoolFlag(MetricsEnabledFlag),
 	altsrc.NewBoolFlag(PrometheusExporterFlag),
 	altsrc.NewIntFlag(PrometheusExporterPortFlag),
+	altsrc.NewBoolFlag(TracingEnabledFlag),
+	altsrc.NewStringFlag(TracingEndpointFlag),
+	altsrc.NewBoolFlag(TracingInsecureFlag),
+	altsrc.NewFloat64Flag(TracingSampleRatioFlag),
 	altsrc.NewStringFlag(ExtraDataFlag),
 	altsrc.NewStringFlag(SrvTypeFlag),
 	altsrc.NewBoolFlag(AutoRestartFlag),
@@ -288,6 +300,17 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewBoolFlag(auction.DisableFlag),
 	altsrc.NewInt64Flag(auction.MaxBidPoolSizeFlag),
 	altsrc.NewDurationFlag(auction.EDOffsetFlag),
+	altsrc.NewUint64Flag(auction.BidJournalRetentionFlag),
+	// kaiax/bundle
+	altsrc.NewBoolFlag(bundle.EnableFlag),
+	altsrc.NewIntFlag(bundle.MaxBundlesFlag),
+	altsrc.NewIntFlag(bundle.MaxBundleTxsFlag),
+	altsrc.NewIntFlag(bundle.MaxBundlesPerSenderFlag),
+	// kaiax/reward
+	altsrc.NewBoolFlag(reward.LedgerEnableFlag),
+	// kaiax/logindex
+	altsrc.NewBoolFlag(logindex.EnableFlag),
+	altsrc.NewUint64Flag(logindex.MinRangeFlag),
 }
 
 // Common RPC flags
@@ -308,7 +331,19 @@ var CommonRPCFlags = []cli.Flag{
 	altsrc.NewBoolFlag(GRPCEnabledFlag),
 	altsrc.NewStringFlag(GRPCListenAddrFlag),
 	altsrc.NewIntFlag(GRPCPortFlag),
+	altsrc.NewBoolFlag(AuthRPCEnabledFlag),
+	altsrc.NewStringFlag(AuthRPCListenAddrFlag),
+	altsrc.NewIntFlag(AuthRPCPortFlag),
+	altsrc.NewStringFlag(AuthRPCVirtualHostsFlag),
+	altsrc.NewStringFlag(AuthRPCApiFlag),
+	altsrc.NewPathFlag(AuthRPCJWTSecretFlag),
+	altsrc.NewBoolFlag(GraphQLEnabledFlag),
 	altsrc.NewIntFlag(RPCConcurrencyLimit),
+	altsrc.NewStringSliceFlag(RPCRateLimitFlag),
+	altsrc.NewStringFlag(RPCRateLimitAPIKeyHeaderFlag),
+	altsrc.NewStringSliceFlag(RPCRateLimitAPIKeysFlag),
+	altsrc.NewIntFlag(RPCBatchLimitFlag),
+	altsrc.NewIntFlag(RPCResponseSizeLimitFlag),
 	altsrc.NewStringFlag(WSApiFlag),
 	altsrc.NewStringFlag(WSAllowedOriginsFlag),
 	altsrc.NewIntFlag(WSMaxSubscriptionPerConn),
@@ -325,6 +360,11 @@ var CommonRPCFlags = []cli.Flag{
 	altsrc.NewIntFlag(HeavyDebugRequestLimitFlag),
 	altsrc.NewDurationFlag(StateRegenerationTimeLimitFlag),
 	altsrc.NewStringFlag(RPCUpstreamArchiveENFlag),
+	altsrc.NewStringFlag(RPCUpstreamWriteENFlag),
+	altsrc.NewStringFlag(RPCUpstreamProxyAPIFlag),
+	altsrc.NewDurationFlag(RPCUpstreamHealthCheckIntervalFlag),
+	altsrc.NewUint64Flag(RPCUpstreamMaxBlockLagFlag),
+	altsrc.NewIntFlag(RPCUpstreamCacheSizeFlag),
 }
 
 var BNFlags = []cli.Flag{
@@ -350,6 +390,8 @@ var KCNFlags = []cli.Flag{
 	altsrc.NewBoolFlag(KairosFlag),
 	altsrc.NewInt64Flag(BlockGenerationIntervalFlag),
 	altsrc.NewDurationFlag(BlockGenerationTimeLimitFlag),
+	altsrc.NewUint64Flag(BlockBuildReportBlocksFlag),
+	altsrc.NewStringFlag(TxOrderingPolicyFlag),
 	altsrc.NewBoolFlag(gasless.DisableFlag),
 	altsrc.NewUint64Flag(VRankLogFrequencyFlag),
 }
@@ -361,6 +403,7 @@ var KPNFlags = []cli.Flag{
 	altsrc.NewBoolFlag(MainnetFlag),
 	altsrc.NewBoolFlag(KairosFlag),
 	altsrc.NewBoolFlag(TxPoolSpamThrottlerDisableFlag),
+	altsrc.NewStringFlag(TxPoolSpamThrottlerJournalFlag),
 }
 
 var KENFlags = []cli.Flag{
@@ -398,6 +441,7 @@ var KSCNFlags = []cli.Flag{
 	altsrc.NewStringFlag(RewardbaseFlag),
 	altsrc.NewInt64Flag(BlockGenerationIntervalFlag),
 	altsrc.NewDurationFlag(BlockGenerationTimeLimitFlag),
+	altsrc.NewStringFlag(TxOrderingPolicyFlag),
 	altsrc.NewStringFlag(ServiceChainSignerFlag),
 	altsrc.NewUint64Flag(AnchoringPeriodFlag),
 	altsrc.NewUint64Flag(SentChainTxsLimit),
@@ -429,6 +473,7 @@ var KSPNFlags = []cli.Flag{
 	altsrc.NewIntFlag(TxResendCountFlag),
 	altsrc.NewBoolFlag(TxResendUseLegacyFlag),
 	altsrc.NewBoolFlag(TxPoolSpamThrottlerDisableFlag),
+	altsrc.NewStringFlag(TxPoolSpamThrottlerJournalFlag),
 	altsrc.NewStringFlag(ServiceChainSignerFlag),
 	altsrc.NewUint64Flag(AnchoringPeriodFlag),
 	altsrc.NewUint64Flag(SentChainTxsLimit),
@@ -526,6 +571,25 @@ var SnapshotFlags = []cli.Flag{
 	altsrc.NewBoolFlag(RocksDBCacheIndexAndFilterFlag),
 }
 
+var SupplyAuditFlags = append([]cli.Flag{
+	altsrc.NewUint64Flag(SupplyAuditFromFlag),
+	altsrc.NewUint64Flag(SupplyAuditToFlag),
+	altsrc.NewUint64Flag(SupplyAuditIntervalFlag),
+	altsrc.NewStringFlag(SupplyAuditFormatFlag),
+	altsrc.NewPathFlag(SupplyAuditOutputFlag),
+	altsrc.NewBoolFlag(SupplyAuditNoCheckFlag),
+}, SnapshotFlags...)
+
+var TxPoolJournalFlags = []cli.Flag{
+	altsrc.NewPathFlag(DataDirFlag),
+	altsrc.NewStringFlag(TxPoolJournalFlag),
+	altsrc.NewDurationFlag(TxPoolLifetimeFlag),
+	altsrc.NewStringFlag(TxPoolJournalOriginFlag),
+	altsrc.NewStringFlag(TxPoolJournalFromFlag),
+	altsrc.NewBoolFlag(TxPoolJournalSkipExpiredFlag),
+	altsrc.NewPathFlag(TxPoolJournalOutputFlag),
+}
+
 var DBMigrationSrcFlags = []cli.Flag{
 	altsrc.NewStringFlag(DbTypeFlag),
 	altsrc.NewPathFlag(DataDirFlag),
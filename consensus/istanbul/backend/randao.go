@@ -20,12 +20,10 @@ import (
 	"math/big"
 
 	"github.com/kaiachain/kaia/blockchain/types"
-	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/common/hexutil"
 	"github.com/kaiachain/kaia/consensus"
-	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/crypto/bls"
-	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/kaiax/randao"
 )
 
 // Calculate KIP-114 Randao header fields
@@ -40,13 +38,13 @@ func (sb *backend) CalcRandao(number *big.Int, prevMixHash []byte) ([]byte, []by
 	}
 
 	// block_num_to_bytes() = num.to_bytes(32, byteorder="big")
-	msg := calcRandaoMsg(number)
+	msg := randao.CalcRandaoMsg(number)
 
 	// calc_random_reveal() = sign(privateKey, headerNumber)
 	randomReveal := bls.Sign(sb.blsSecretKey, msg[:]).Marshal()
 
 	// calc_mix_hash() = xor(prevMixHash, keccak256(randomReveal))
-	mixHash := calcMixHash(randomReveal, prevMixHash)
+	mixHash := randao.CalcMixHash(randomReveal, prevMixHash)
 
 	return randomReveal, mixHash, nil
 }
@@ -70,7 +68,7 @@ func (sb *backend) VerifyRandao(chain consensus.ChainReader, header *types.Heade
 
 	// if not verify(proposerPubkey, newHeader.number, newHeader.randomReveal): return False
 	sig := header.RandomReveal
-	msg := calcRandaoMsg(header.Number)
+	msg := randao.CalcRandaoMsg(header.Number)
 	ok, err := bls.VerifySignature(sig, msg, proposerPub)
 	if err != nil {
 		return err
@@ -79,7 +77,7 @@ func (sb *backend) VerifyRandao(chain consensus.ChainReader, header *types.Heade
 	}
 
 	// if not newHeader.mixHash == calc_mix_hash(prevMixHash, newHeader.randomReveal): return False
-	mixHash := calcMixHash(header.RandomReveal, prevMixHash)
+	mixHash := randao.CalcMixHash(header.RandomReveal, prevMixHash)
 	if !bytes.Equal(header.MixHash, mixHash) {
 		return errInvalidRandaoFields
 	}
@@ -87,26 +85,7 @@ func (sb *backend) VerifyRandao(chain consensus.ChainReader, header *types.Heade
 	return nil
 }
 
-// block_num_to_bytes() = num.to_bytes(32, byteorder="big")
-func calcRandaoMsg(number *big.Int) common.Hash {
-	return common.BytesToHash(number.Bytes())
-}
-
-// calc_mix_hash() = xor(prevMixHash, keccak256(randomReveal))
-func calcMixHash(randomReveal, prevMixHash []byte) []byte {
-	mixHash := make([]byte, 32)
-	revealHash := crypto.Keccak256(randomReveal)
-	for i := 0; i < 32; i++ {
-		mixHash[i] = prevMixHash[i] ^ revealHash[i]
-	}
-	return mixHash
-}
-
 // At the fork block's parent, pretend that prevMixHash is ZeroMixHash.
 func headerMixHash(chain consensus.ChainReader, header *types.Header) []byte {
-	if chain.Config().IsRandaoForkBlockParent(header.Number) {
-		return params.ZeroMixHash
-	} else {
-		return header.MixHash
-	}
+	return randao.PrevMixHash(chain.Config(), header)
 }
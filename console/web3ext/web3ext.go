@@ -533,6 +533,20 @@ web3._extend({
 			name: 'getSpamThrottlerCandidateList',
 			call: 'admin_getSpamThrottlerCandidateList',
 		}),
+		new web3._extend.Method({
+			name: 'addSpamThrottlerWhiteList',
+			call: 'admin_addSpamThrottlerWhiteList',
+			params: 2,
+		}),
+		new web3._extend.Method({
+			name: 'getSpamThrottlerScore',
+			call: 'admin_getSpamThrottlerScore',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getSpamThrottlerPeerScores',
+			call: 'admin_getSpamThrottlerPeerScores',
+		}),
 		new web3._extend.Method({
 			name: 'syncStakingInfo',
 			call: 'admin_syncStakingInfo',
@@ -542,6 +556,16 @@ web3._extend({
 			name: 'syncStakingInfoStatus',
 			call: 'admin_syncStakingInfoStatus',
 		}),
+		new web3._extend.Method({
+			name: 'snapSyncStatus',
+			call: 'admin_snapSyncStatus',
+		}),
+		new web3._extend.Method({
+			name: 'lookupNodes',
+			call: 'admin_lookupNodes',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -552,6 +576,10 @@ web3._extend({
 			name: 'peers',
 			getter: 'admin_peers'
 		}),
+		new web3._extend.Property({
+			name: 'peerScores',
+			getter: 'admin_peerScores'
+		}),
 		new web3._extend.Property({
 			name: 'datadir',
 			getter: 'admin_datadir'
@@ -581,6 +609,17 @@ web3._extend({
 			call: 'debug_dumpBlock',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'getBlockBuildReport',
+			call: 'debug_getBlockBuildReport',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getTxBuildHistory',
+			call: 'debug_getTxBuildHistory',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'dumpStateTrie',
 			call: 'debug_dumpStateTrie',
@@ -886,6 +925,11 @@ web3._extend({
 			name: 'gaslessInfo',
 			call: 'debug_gaslessInfo',
 		}),
+		new web3._extend.Method({
+			name: 'gaslessQuote',
+			call: 'debug_gaslessQuote',
+			params: 1
+		}),
 	],
 	properties: []
 });
@@ -963,18 +1007,36 @@ var klayMethods = [
 		params: 1,
 		inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
 	}),
+	new web3._extend.Method({
+		name: 'getProposerSchedule',
+		call: 'klay_getProposerSchedule',
+		params: 3,
+		inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null, null]
+	}),
 	new web3._extend.Method({
 		name: 'getRewards',
 		call: 'klay_getRewards',
 		params: 1,
 		inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
 	}),
+	new web3._extend.Method({
+		name: 'getRewardsByAddress',
+		call: 'klay_getRewardsByAddress',
+		params: 3,
+		inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+	}),
 	new web3._extend.Method({
 		name: 'getStakingInfo',
 		call: 'klay_getStakingInfo',
 		params: 1,
 		inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
 	}),
+	new web3._extend.Method({
+		name: 'getStakingInfoTimeline',
+		call: 'klay_getStakingInfoTimeline',
+		params: 2,
+		inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+	}),
 	new web3._extend.Method({
 		name: 'getParams',
 		call: 'klay_getParams',
@@ -1040,6 +1102,12 @@ var klayMethods = [
 		params: 1,
 		inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
 	}),
+	new web3._extend.Method({
+		name: 'getRandomness',
+		call: 'klay_getRandomness',
+		params: 1,
+		inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+	}),
 	new web3._extend.Method({
 		name: 'isContractAccount',
 		call: 'klay_isContractAccount',
@@ -1184,6 +1252,12 @@ var klayMethods = [
 		params: 2,
 		inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, function (val) { return !!val; }]
 	}),
+	new web3._extend.Method({
+		name: 'getTotalSupplyRange',
+		call: 'klay_getTotalSupplyRange',
+		params: 4,
+		inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null, function (val) { return !!val; }]
+	}),
 	new web3._extend.Method({
 		name: 'getProof',
 		call: 'klay_getProof',
@@ -1196,6 +1270,22 @@ var klayMethods = [
 		params: 1,
 		inputFormatter: [null]
 	}),
+	new web3._extend.Method({
+		name: 'sendPrivateRawTransaction',
+		call: 'klay_sendPrivateRawTransaction',
+		params: 2,
+		inputFormatter: [null, web3._extend.utils.fromDecimal]
+	}),
+	new web3._extend.Method({
+		name: 'getPrivateTransactionStatus',
+		call: 'klay_getPrivateTransactionStatus',
+		params: 1
+	}),
+	new web3._extend.Method({
+		name: 'sendBundle',
+		call: 'klay_sendBundle',
+		params: 1
+	}),
 	new web3._extend.Method({
 		name: 'isConsoleLogEnabled',
 		call: 'klay_isConsoleLogEnabled',
@@ -1769,6 +1859,11 @@ web3._extend({
 			call: 'auction_submitBid',
 			params: 1,
 			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'getBidStatus',
+			call: 'auction_getBidStatus',
+			params: 1
 		})
 	]
 });
@@ -29,6 +29,7 @@ import (
 	"github.com/kaiachain/kaia"
 	"github.com/kaiachain/kaia/event"
 	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/node/cn/snap"
 )
 
 // EthDownloaderAPI wraps KaiaDownloaderAPI for eth namespace compatibility.
@@ -54,6 +55,7 @@ type downloader interface {
 	Progress() kaia.SyncProgress
 	SyncStakingInfo(id string, from, to uint64) error
 	SyncStakingInfoStatus() *SyncingStatus
+	GetSnapSyncer() *snap.Syncer
 }
 
 // NewKaiaDownloaderAPI creates a new KaiaDownloaderAPI. The API has an internal event loop that
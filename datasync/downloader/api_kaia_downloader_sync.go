@@ -22,6 +22,13 @@
 
 package downloader
 
+import (
+	"context"
+
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/node/cn/snap"
+)
+
 // KaiaDownloaderSyncAPI provides an API which gives syncing staking information.
 type KaiaDownloaderSyncAPI struct {
 	d downloader
@@ -41,3 +48,52 @@ func (api *KaiaDownloaderSyncAPI) SyncStakingInfo(id string, from, to uint64) er
 func (api *KaiaDownloaderSyncAPI) SyncStakingInfoStatus() *SyncingStatus {
 	return api.d.SyncStakingInfoStatus()
 }
+
+// SnapSyncStatus returns the detailed progress of the snap sync, including the
+// task progress, ETA and the throughput capacity of each snap peer.
+func (api *KaiaDownloaderSyncAPI) SnapSyncStatus() (*snap.SyncStatus, error) {
+	syncer := api.d.GetSnapSyncer()
+	if syncer == nil {
+		return nil, errSnapSyncUnavailable
+	}
+	return syncer.Status(), nil
+}
+
+// SnapSyncing publishes the detailed snap sync progress periodically while a
+// snap sync cycle is running.
+//
+// WebSocket API:
+// ws  KaiaDownloaderSyncAPI.SnapSyncing  admin_subscribe("snapSyncing") = SyncStatus
+func (api *KaiaDownloaderSyncAPI) SnapSyncing(ctx context.Context) (*rpc.Subscription, error) {
+	syncer := api.d.GetSnapSyncer()
+	if syncer == nil {
+		return nil, errSnapSyncUnavailable
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		statuses := make(chan *snap.SyncStatus, 16)
+		sub := syncer.SubscribeStatus(statuses)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case status := <-statuses:
+				notifier.Notify(rpcSub.ID, status)
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
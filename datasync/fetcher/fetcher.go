@@ -32,6 +32,7 @@ import (
 	"github.com/kaiachain/kaia/common/prque"
 	"github.com/kaiachain/kaia/consensus"
 	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/networks/p2p"
 )
 
 const (
@@ -80,6 +81,9 @@ type chainInsertFn func(types.Blocks) (int, error)
 // peerDropFn is a callback type for dropping a peer detected as malicious.
 type peerDropFn func(id string)
 
+// peerReportFn is a callback type for lowering the reputation of a misbehaving peer.
+type peerReportFn func(id string, ev p2p.ReputationEvent)
+
 // announce is the hash notification of the availability of a new block in the
 // network.
 type announce struct {
@@ -149,6 +153,7 @@ type Fetcher struct {
 	chainHeight        chainHeightFn          // Retrieves the current chain's height
 	insertChain        chainInsertFn          // Injects a batch of blocks into the chain
 	dropPeer           peerDropFn             // Drops a peer for misbehaving
+	reportPeer         peerReportFn           // Lowers the reputation of a misbehaving peer
 
 	// Testing hooks
 	announceChangeHook func(common.Hash, bool) // Method to call upon adding or deleting a hash from the announce list
@@ -161,7 +166,7 @@ type Fetcher struct {
 }
 
 // New creates a block fetcher to retrieve blocks based on hash announcements.
-func New(getBlock blockRetrievalFn, verifyHeader headerVerifierFn, broadcastBlock blockBroadcasterFn, broadcastBlockHash blockHashBroadcasterFn, chainHeight chainHeightFn, insertChain chainInsertFn, dropPeer peerDropFn) *Fetcher {
+func New(getBlock blockRetrievalFn, verifyHeader headerVerifierFn, broadcastBlock blockBroadcasterFn, broadcastBlockHash blockHashBroadcasterFn, chainHeight chainHeightFn, insertChain chainInsertFn, dropPeer peerDropFn, reportPeer peerReportFn) *Fetcher {
 	return &Fetcher{
 		notify:             make(chan *announce),
 		inject:             make(chan *inject),
@@ -185,6 +190,7 @@ func New(getBlock blockRetrievalFn, verifyHeader headerVerifierFn, broadcastBloc
 		chainHeight:        chainHeight,
 		insertChain:        insertChain,
 		dropPeer:           dropPeer,
+		reportPeer:         reportPeer,
 		insertTasks:        make(chan insertTask, numInsertTasks),
 	}
 }
@@ -369,6 +375,9 @@ func (f *Fetcher) loop() {
 				if dist := int64(notification.number) - int64(f.chainHeight()); dist <= 0 || dist > maxQueueDist {
 					logger.Debug("Peer discarded announcement", "peer", notification.origin, "number", notification.number, "hash", notification.hash, "distance", dist)
 					propAnnounceDropMeter.Mark(1)
+					if dist < 0 {
+						f.reportPeer(notification.origin, p2p.RepLateAnnouncement)
+					}
 					break
 				}
 			}
@@ -488,6 +497,7 @@ func (f *Fetcher) loop() {
 					// If the delivered header does not match the promised number, drop the announcer
 					if header.Number.Uint64() != announce.number {
 						logger.Trace("Invalid block number fetched", "peer", announce.origin, "hash", header.Hash(), "announced", announce.number, "provided", header.Number)
+						f.reportPeer(announce.origin, p2p.RepInvalidAnnouncement)
 						f.dropPeer(announce.origin)
 						f.forgetHash(hash)
 						continue
@@ -713,6 +723,7 @@ func (f *Fetcher) insertWork(peer string, block *types.Block) {
 	default:
 		// Something went very wrong, drop the peer
 		logger.Debug("Propagated block verification failed", "peer", peer, "number", blockNum, "hash", hash, "err", err)
+		f.reportPeer(peer, p2p.RepInvalidAnnouncement)
 		f.dropPeer(peer)
 		return
 	}
@@ -36,6 +36,7 @@ import (
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/consensus/faker"
 	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/networks/p2p"
 	"github.com/kaiachain/kaia/params"
 	"github.com/kaiachain/kaia/storage/database"
 )
@@ -80,9 +81,10 @@ func makeChain(n int, seed byte, parent *types.Block) ([]common.Hash, map[common
 type fetcherTester struct {
 	fetcher *Fetcher
 
-	hashes []common.Hash                // Hash chain belonging to the tester
-	blocks map[common.Hash]*types.Block // Blocks belonging to the tester
-	drops  map[string]bool              // Map of peers dropped by the fetcher
+	hashes  []common.Hash                    // Hash chain belonging to the tester
+	blocks  map[common.Hash]*types.Block     // Blocks belonging to the tester
+	drops   map[string]bool                  // Map of peers dropped by the fetcher
+	reports map[string][]p2p.ReputationEvent // Map of events reported by the fetcher
 
 	lock sync.RWMutex
 }
@@ -90,11 +92,12 @@ type fetcherTester struct {
 // newTester creates a new fetcher test mocker.
 func newTester() *fetcherTester {
 	tester := &fetcherTester{
-		hashes: []common.Hash{genesis.Hash()},
-		blocks: map[common.Hash]*types.Block{genesis.Hash(): genesis},
-		drops:  make(map[string]bool),
+		hashes:  []common.Hash{genesis.Hash()},
+		blocks:  map[common.Hash]*types.Block{genesis.Hash(): genesis},
+		drops:   make(map[string]bool),
+		reports: make(map[string][]p2p.ReputationEvent),
 	}
-	tester.fetcher = New(tester.getBlock, tester.verifyHeader, tester.broadcastBlock, tester.broadcastBlockHash, tester.chainHeight, tester.insertChain, tester.dropPeer)
+	tester.fetcher = New(tester.getBlock, tester.verifyHeader, tester.broadcastBlock, tester.broadcastBlockHash, tester.chainHeight, tester.insertChain, tester.dropPeer, tester.reportPeer)
 	tester.fetcher.Start()
 
 	return tester
@@ -159,6 +162,15 @@ func (f *fetcherTester) dropPeer(peer string) {
 	f.drops[peer] = true
 }
 
+// reportPeer is an emulator for the peer scoring, simply accumulating the
+// events reported by the fetcher.
+func (f *fetcherTester) reportPeer(peer string, ev p2p.ReputationEvent) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.reports[peer] = append(f.reports[peer], ev)
+}
+
 // makeHeaderFetcher retrieves a block header fetcher associated with a simulated peer.
 func (f *fetcherTester) makeHeaderFetcher(peer string, blocks map[common.Hash]*types.Block, drift time.Duration) HeaderRequesterFn {
 	closure := make(map[common.Hash]*types.Block)
@@ -599,6 +611,35 @@ func testDistantAnnouncementDiscarding(t *testing.T, protocol int) {
 	}
 }
 
+// Tests that announcements of blocks older than the local head count against
+// the reputation of the announcer, while announcing the head itself does not.
+func TestLateAnnouncementReporting(t *testing.T) {
+	hashes, blocks := makeChain(3, 0, genesis)
+	head := hashes[1]
+
+	tester := newTester()
+	tester.lock.Lock()
+	tester.hashes = []common.Hash{head}
+	tester.blocks = map[common.Hash]*types.Block{head: blocks[head]}
+	tester.lock.Unlock()
+
+	headerFetcher := tester.makeHeaderFetcher("peer", blocks, -gatherSlack)
+	bodyFetcher := tester.makeBodyFetcher("peer", blocks, 0)
+
+	tester.fetcher.Notify("head", hashes[1], blocks[hashes[1]].NumberU64(), time.Now().Add(-arriveTimeout), headerFetcher, bodyFetcher)
+	tester.fetcher.Notify("late", hashes[2], blocks[hashes[2]].NumberU64(), time.Now().Add(-arriveTimeout), headerFetcher, bodyFetcher)
+	time.Sleep(50 * time.Millisecond)
+
+	tester.lock.RLock()
+	defer tester.lock.RUnlock()
+	if reports := tester.reports["head"]; len(reports) != 0 {
+		t.Fatalf("head announcement reported: %v", reports)
+	}
+	if reports := tester.reports["late"]; len(reports) != 1 || reports[0] != p2p.RepLateAnnouncement {
+		t.Fatalf("late announcement reports mismatch: have %v, want [%v]", reports, p2p.RepLateAnnouncement)
+	}
+}
+
 // Tests that peers announcing blocks with invalid numbers (i.e. not matching
 // the headers provided afterwards) get dropped as malicious.
 func TestInvalidNumberAnnouncement62(t *testing.T) { testInvalidNumberAnnouncement(t, 62) }
@@ -627,6 +668,13 @@ func testInvalidNumberAnnouncement(t *testing.T, protocol int) {
 	if !dropped {
 		t.Fatalf("peer with invalid numbered announcement not dropped")
 	}
+	tester.lock.RLock()
+	reports := tester.reports["bad"]
+	tester.lock.RUnlock()
+
+	if len(reports) != 1 || reports[0] != p2p.RepInvalidAnnouncement {
+		t.Fatalf("invalid announcement reports mismatch: have %v, want [%v]", reports, p2p.RepInvalidAnnouncement)
+	}
 
 	goodHeaderFetcher := tester.makeHeaderFetcher("good", blocks, -gatherSlack)
 	goodBodyFetcher := tester.makeBodyFetcher("good", blocks, 0)
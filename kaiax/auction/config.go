@@ -28,6 +28,8 @@ import (
 const (
 	DefaultMaxBidPoolSize = math.MaxInt64
 	DefaultEDOffset       = 200 * time.Millisecond
+
+	DefaultBidJournalRetention = uint64(86400)
 )
 
 var (
@@ -58,12 +60,21 @@ var (
 			return nil
 		},
 	}
+	BidJournalRetentionFlag = &cli.Uint64Flag{
+		Name:     "auction.bid-journal-retention",
+		Usage:    "Number of blocks to keep the status of included or dropped bids",
+		Value:    DefaultBidJournalRetention,
+		Aliases:  []string{"kaiax.module.auction.bid-journal-retention"},
+		Category: "KAIAX",
+	}
 )
 
 type AuctionConfig struct {
 	Disable        bool
 	MaxBidPoolSize int64
 	EDOffset       time.Duration
+
+	BidJournalRetention uint64
 }
 
 func DefaultAuctionConfig() *AuctionConfig {
@@ -71,6 +82,8 @@ func DefaultAuctionConfig() *AuctionConfig {
 		Disable:        false,
 		MaxBidPoolSize: DefaultMaxBidPoolSize,
 		EDOffset:       DefaultEDOffset,
+
+		BidJournalRetention: DefaultBidJournalRetention,
 	}
 }
 
@@ -90,4 +103,8 @@ func SetAuctionConfig(ctx *cli.Context, cfg *AuctionConfig, nodeType common.Conn
 	if ctx.IsSet(EDOffsetFlag.Name) {
 		cfg.EDOffset = ctx.Duration(EDOffsetFlag.Name)
 	}
+	cfg.BidJournalRetention = DefaultBidJournalRetention
+	if ctx.IsSet(BidJournalRetentionFlag.Name) {
+		cfg.BidJournalRetention = ctx.Uint64(BidJournalRetentionFlag.Name)
+	}
 }
@@ -39,6 +39,7 @@ var (
 	ErrLowBid                  = errors.New("low bid")
 	ErrZeroBid                 = errors.New("zero bid")
 	ErrBidPoolFull             = errors.New("bid pool is full")
+	ErrBidNotFound             = errors.New("bid not found")
 
 	ErrAuctionPaused = errors.New("auction is paused")
 )
@@ -137,6 +137,12 @@ func (api *AuctionAPI) SubmitBid(ctx context.Context, bidInput BidInput) RPCOutp
 	return makeRPCOutput(bidHash, errValidateBid)
 }
 
+// GetBidStatus returns the status of a bid submitted to this node.
+// Included and dropped bids are forgotten after BidJournalRetention blocks.
+func (api *AuctionAPI) GetBidStatus(bidHash common.Hash) (*auction.BidStatus, error) {
+	return api.a.bidPool.getBidStatus(bidHash)
+}
+
 func (api *AuctionAPI) NewPendingTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error) {
 	return api.f.NewPendingTransactions(ctx, fullTx)
 }
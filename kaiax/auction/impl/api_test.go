@@ -58,6 +58,7 @@ func prep(t *testing.T) *AuctionModule {
 		Chain:         backend.BlockChain(),
 		Backend:       apiBackend,
 		Downloader:    fakeDownloader,
+		ChainKv:       database.NewMemoryDBManager().GetMiscDB(),
 		NodeKey:       testNodeKey,
 	})
 	mAuction.bidPool.running = 1
@@ -17,6 +17,7 @@
 package impl
 
 import (
+	"bytes"
 	"maps"
 	"math/big"
 	"sync"
@@ -27,10 +28,12 @@ import (
 	"github.com/kaiachain/kaia/blockchain/system"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/event"
 	"github.com/kaiachain/kaia/kaiax/auction"
 	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
 	"golang.org/x/time/rate"
 )
 
@@ -59,6 +62,11 @@ type BidPool struct {
 	bidTargetMap map[uint64]map[common.Hash]*auction.Bid   // (blockNum, targetTxHash) -> Bid
 	bidWinnerMap map[uint64]map[common.Address]common.Hash // (blockNum, sender) -> bidHash
 
+	// The statuses of the included or dropped bids are journaled to db, if set,
+	// and kept for bidJournalRetention blocks after their target block.
+	db                  database.Database
+	bidJournalRetention uint64
+
 	// Rate limiting per peer
 	peerRateLimiterMu sync.RWMutex
 	peerRateLimiter   map[string]*rate.Limiter // peerID -> rate limiter
@@ -89,8 +97,10 @@ func NewBidPool(chainConfig *params.ChainConfig, chain backends.BlockChainForCal
 		bidMsgCh:        make(chan *auction.Bid, bidChSize),
 		newBidCh:        make(chan *auction.Bid, bidChSize),
 		maxBidPoolSize:  auctionConfig.MaxBidPoolSize,
-		running:         0, // not running yet
-		stopped:         0, // not stopped
+
+		bidJournalRetention: auctionConfig.BidJournalRetention,
+		running:             0, // not running yet
+		stopped:             0, // not stopped
 	}
 
 	return bp
@@ -122,7 +132,7 @@ func (bp *BidPool) start() {
 func (bp *BidPool) stop() {
 	// Stop the bid pool.
 	atomic.CompareAndSwapUint32(&bp.running, 1, 0)
-	bp.clearBidPool()
+	bp.clearBidPool(auction.DropReasonStopped)
 
 	// Only close channels if they haven't been closed before
 	if atomic.CompareAndSwapUint32(&bp.stopped, 0, 1) {
@@ -132,11 +142,61 @@ func (bp *BidPool) stop() {
 	bp.wg.Wait()
 }
 
+// markIncludedBids journals and removes the bids whose bid tx is included in the given block.
+// A bid tx is placed right after its target tx. It returns the number of the included bids.
+func (bp *BidPool) markIncludedBids(block *types.Block) int {
+	auctionEntryPoint := bp.GetAuctionEntryPoint()
+
+	bp.bidMu.Lock()
+	defer bp.bidMu.Unlock()
+
+	var (
+		num       = block.NumberU64()
+		txs       = block.Transactions()
+		targetMap = bp.bidTargetMap[num]
+		statuses  []*auction.BidStatus
+	)
+	for i := 0; i+1 < len(txs) && len(targetMap) > 0; i++ {
+		bid, ok := targetMap[txs[i].Hash()]
+		if !ok {
+			continue
+		}
+
+		bidTx := txs[i+1]
+		if bidTx.To() == nil || *bidTx.To() != auctionEntryPoint {
+			continue
+		}
+		data, err := system.EncodeAuctionCallData(bid)
+		if err != nil || !bytes.Equal(bidTx.Data(), data) {
+			continue
+		}
+
+		var (
+			txHash  = bidTx.Hash()
+			txIndex = hexutil.Uint(i + 1)
+		)
+		status := newBidStatus(bid, auction.BidStatusIncluded)
+		status.TxHash = &txHash
+		status.TxIndex = &txIndex
+		statuses = append(statuses, status)
+
+		delete(targetMap, bid.TargetTxHash)
+		delete(bp.bidWinnerMap[num], bid.Sender)
+		delete(bp.bidMap, bid.Hash())
+	}
+	bp.journalBids(statuses)
+
+	return len(statuses)
+}
+
 // removeOldBids removes the old bids for the given block number.
-func (bp *BidPool) removeOldBids(num uint64, txHashMap map[common.Hash]struct{}) {
+// It returns the number of the removed bids whose target block is not after the given block number.
+func (bp *BidPool) removeOldBids(num uint64, txHashMap map[common.Hash]struct{}) int {
 	bp.bidMu.Lock()
 	defer bp.bidMu.Unlock()
 
+	var statuses []*auction.BidStatus
+
 	// Remove the old bids.
 	for bn := range bp.bidWinnerMap {
 		if bn > num {
@@ -144,12 +204,25 @@ func (bp *BidPool) removeOldBids(num uint64, txHashMap map[common.Hash]struct{})
 		}
 
 		for _, bh := range bp.bidWinnerMap[bn] {
+			bid, ok := bp.bidMap[bh]
+			if !ok {
+				continue
+			}
+			// The bid is expired if its target tx is included without the bid tx. Note that the bids
+			// targeting the earlier blocks are left only if the blocks have not been processed by the pool.
+			reason := auction.DropReasonExpired
+			if _, ok := txHashMap[bid.TargetTxHash]; bn == num && !ok {
+				reason = auction.DropReasonTargetTxMissing
+			}
+			statuses = append(statuses, newDroppedBidStatus(bid, reason))
 			delete(bp.bidMap, bh)
 		}
 		delete(bp.bidTargetMap, bn)
 		delete(bp.bidWinnerMap, bn)
 	}
 
+	numOldBids := len(statuses)
+
 	// Remove the bid which target tx is in the txHashMap.
 	toBlock := num + allowFutureBlock
 	for blockNum := num + 1; blockNum <= toBlock; blockNum++ {
@@ -171,17 +244,27 @@ func (bp *BidPool) removeOldBids(num uint64, txHashMap map[common.Hash]struct{})
 			delete(targetMap, bid.TargetTxHash)
 			delete(bp.bidWinnerMap[blockNum], bid.Sender)
 			delete(bp.bidMap, bid.Hash())
+			statuses = append(statuses, newDroppedBidStatus(bid, auction.DropReasonTargetTxMissing))
 		}
 	}
 
+	bp.journalBids(statuses)
 	numBidsGauge.Update(int64(len(bp.bidMap)))
+
+	return numOldBids
 }
 
 // clearBidPool clears the bid pool.
-func (bp *BidPool) clearBidPool() {
+func (bp *BidPool) clearBidPool(reason auction.DropReason) {
 	bp.bidMu.Lock()
 	defer bp.bidMu.Unlock()
 
+	statuses := make([]*auction.BidStatus, 0, len(bp.bidMap))
+	for _, bid := range bp.bidMap {
+		statuses = append(statuses, newDroppedBidStatus(bid, reason))
+	}
+	bp.journalBids(statuses)
+
 	bp.bidMap = make(map[common.Hash]*auction.Bid)
 	bp.bidTargetMap = make(map[uint64]map[common.Hash]*auction.Bid)
 	bp.bidWinnerMap = make(map[uint64]map[common.Address]common.Hash)
@@ -197,7 +280,7 @@ func (bp *BidPool) updateAuctionInfo(auctioneer common.Address, auctionEntryPoin
 	}
 
 	// Clear the existing auction pool since the auctioneer or auction entry point address is changed.
-	bp.clearBidPool()
+	bp.clearBidPool(auction.DropReasonAuctioneerChanged)
 
 	bp.auctioneer = auctioneer
 	bp.auctionEntryPoint = auctionEntryPoint
@@ -286,6 +369,7 @@ func (bp *BidPool) insertBid(bid *auction.Bid) error {
 		logger.Trace("Replace bid", "old", existingBid.Hash(), "new", bid.Hash())
 		delete(bp.bidMap, existingBid.Hash())
 		delete(bp.bidWinnerMap[blockNumber], existingBid.Sender)
+		bp.journalBids([]*auction.BidStatus{newDroppedBidStatus(existingBid, auction.DropReasonReplaced)})
 	} else {
 		if int64(len(bp.bidMap)) >= bp.maxBidPoolSize {
 			logger.Info("Bid pool is full", "maxBidPoolSize", bp.maxBidPoolSize, "bid", bid.Hash())
@@ -308,6 +392,62 @@ func (bp *BidPool) insertBid(bid *auction.Bid) error {
 	return nil
 }
 
+// getBidStatus returns the status of the bid in the pool or in the journal.
+func (bp *BidPool) getBidStatus(bidHash common.Hash) (*auction.BidStatus, error) {
+	bp.bidMu.RLock()
+	bid, ok := bp.bidMap[bidHash]
+	bp.bidMu.RUnlock()
+
+	if ok {
+		return newBidStatus(bid, auction.BidStatusPending), nil
+	}
+	if bp.db != nil {
+		if status := ReadBidStatus(bp.db, bidHash); status != nil {
+			return status, nil
+		}
+	}
+	return nil, auction.ErrBidNotFound
+}
+
+// journalBids records the statuses of the included or dropped bids.
+func (bp *BidPool) journalBids(statuses []*auction.BidStatus) {
+	for _, status := range statuses {
+		if status.Status == auction.BidStatusIncluded {
+			bidIncludedCounter.Inc(1)
+		} else if counter, ok := bidDroppedCounters[status.Reason]; ok {
+			counter.Inc(1)
+		}
+		logger.Trace("Resolve bid", "bid", status.BidHash, "status", status.Status, "reason", status.Reason)
+	}
+
+	if bp.db != nil {
+		WriteBidStatuses(bp.db, statuses)
+	}
+}
+
+// pruneBidStatuses deletes the journaled statuses of the bids older than bidJournalRetention blocks.
+func (bp *BidPool) pruneBidStatuses(num uint64) {
+	if bp.db == nil || num <= bp.bidJournalRetention {
+		return
+	}
+	DeleteBidStatusesBefore(bp.db, num-bp.bidJournalRetention)
+}
+
+func newBidStatus(bid *auction.Bid, status auction.BidStatusCode) *auction.BidStatus {
+	return &auction.BidStatus{
+		BidHash:      bid.Hash(),
+		TargetTxHash: bid.TargetTxHash,
+		BlockNumber:  hexutil.Uint64(bid.BlockNumber),
+		Status:       status,
+	}
+}
+
+func newDroppedBidStatus(bid *auction.Bid, reason auction.DropReason) *auction.BidStatus {
+	status := newBidStatus(bid, auction.BidStatusDropped)
+	status.Reason = reason
+	return status
+}
+
 func (bp *BidPool) initializeBidMap(num uint64) {
 	if _, ok := bp.bidTargetMap[num]; !ok {
 		bp.bidTargetMap[num] = make(map[common.Hash]*auction.Bid)
@@ -24,11 +24,14 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/blockchain/system"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/kaiax/auction"
 	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
 	chain_mock "github.com/kaiachain/kaia/work/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -427,7 +430,7 @@ func TestBidPool_ClearBidPool(t *testing.T) {
 	}
 
 	// Clear the pool
-	pool.clearBidPool()
+	pool.clearBidPool(auction.DropReasonStopped)
 
 	// Verify all maps are empty
 	assert.Empty(t, pool.bidTargetMap)
@@ -604,3 +607,91 @@ func benchmarkAddBidParallel(b *testing.B, numBids int) {
 		}
 	})
 }
+
+func TestBidPool_BidStatus(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		chain    = chain_mock.NewMockBlockChain(mockCtrl)
+	)
+	defer mockCtrl.Finish()
+
+	pool := NewBidPool(testChainConfig, chain, &auction.AuctionConfig{MaxBidPoolSize: 1024, BidJournalRetention: 10})
+	require.NotNil(t, pool)
+	pool.db = database.NewMemoryDBManager().GetMiscDB()
+	pool.auctionEntryPoint = testAuctionEntryPoint
+
+	var (
+		targetTx1 = types.NewTransaction(0, testNode, common.Big1, 21000, common.Big1, nil)
+		targetTx2 = types.NewTransaction(1, testNode, common.Big1, 21000, common.Big1, nil)
+		otherTx   = types.NewTransaction(2, testNode, common.Big1, 21000, common.Big1, nil)
+	)
+	newBid := func(i int, blockNumber uint64, targetTxHash common.Hash, amount int64) *auction.Bid {
+		bid := &auction.Bid{BidData: testBids[i].BidData}
+		bid.BlockNumber = blockNumber
+		bid.TargetTxHash = targetTxHash
+		bid.Bid = big.NewInt(amount)
+		return bid
+	}
+	var (
+		includedBid = newBid(0, 3, targetTx1.Hash(), 1)
+		expiredBid  = newBid(1, 3, targetTx2.Hash(), 1)
+		replacedBid = newBid(2, 3, common.HexToHash("0x1234"), 1)
+		missingBid  = newBid(2, 3, common.HexToHash("0x1234"), 2)
+		futureBid   = newBid(0, 4, targetTx2.Hash(), 1)
+	)
+	for _, bid := range []*auction.Bid{includedBid, expiredBid, replacedBid, missingBid, futureBid} {
+		require.NoError(t, pool.insertBid(bid))
+	}
+
+	status, err := pool.getBidStatus(includedBid.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, auction.BidStatusPending, status.Status)
+
+	data, err := system.EncodeAuctionCallData(includedBid)
+	require.NoError(t, err)
+	bidTx := types.NewTransaction(0, testAuctionEntryPoint, common.Big0, 100000, common.Big1, data)
+
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(3)}).WithBody([]*types.Transaction{targetTx1, bidTx, targetTx2, otherTx})
+	assert.Equal(t, 1, pool.markIncludedBids(block))
+	txHashMap := map[common.Hash]struct{}{}
+	for _, tx := range block.Transactions() {
+		txHashMap[tx.Hash()] = struct{}{}
+	}
+	assert.Equal(t, 2, pool.removeOldBids(3, txHashMap))
+	assert.Empty(t, pool.bidMap)
+
+	testcases := []struct {
+		bid    *auction.Bid
+		status auction.BidStatusCode
+		reason auction.DropReason
+	}{
+		{includedBid, auction.BidStatusIncluded, ""},
+		{expiredBid, auction.BidStatusDropped, auction.DropReasonExpired},
+		{replacedBid, auction.BidStatusDropped, auction.DropReasonReplaced},
+		{missingBid, auction.BidStatusDropped, auction.DropReasonTargetTxMissing},
+		{futureBid, auction.BidStatusDropped, auction.DropReasonTargetTxMissing},
+	}
+	for _, tc := range testcases {
+		status, err := pool.getBidStatus(tc.bid.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, tc.bid.TargetTxHash, status.TargetTxHash)
+		assert.Equal(t, tc.status, status.Status)
+		assert.Equal(t, tc.reason, status.Reason)
+	}
+
+	status, err = pool.getBidStatus(includedBid.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, bidTx.Hash(), *status.TxHash)
+	assert.Equal(t, hexutil.Uint(1), *status.TxIndex)
+
+	// The statuses of the bids targeting block 3 are pruned at block 14.
+	pool.pruneBidStatuses(13)
+	_, err = pool.getBidStatus(includedBid.Hash())
+	assert.NoError(t, err)
+
+	pool.pruneBidStatuses(14)
+	_, err = pool.getBidStatus(includedBid.Hash())
+	assert.ErrorIs(t, err, auction.ErrBidNotFound)
+	_, err = pool.getBidStatus(futureBid.Hash())
+	assert.NoError(t, err)
+}
@@ -44,7 +44,7 @@ func (a *AuctionModule) ExtractTxBundles(txs []*types.Transaction, prevBundles [
 	for _, tx := range txs {
 		txHash := tx.Hash()
 		bid, ok := bidTargetMap[txHash]
-		if !ok {
+		if !ok || a.isPrivateTx(txHash) {
 			continue
 		}
 		b := builder.NewBundle(
@@ -99,7 +99,7 @@ func (a *AuctionModule) FilterTxs(txs map[common.Address]types.Transactions) {
 	// filter txs that are after the auction early deadline
 	for addr, list := range txs {
 		for i, tx := range list {
-			if tx.Time().After(deadline) && !a.isGaslessTx(tx) {
+			if tx.Time().After(deadline) && !a.isGaslessTx(tx) && !a.isPrivateTx(tx.Hash()) {
 				// if the tx is a target tx, skip it
 				if _, ok := targetTxHashMap[tx.Hash()]; ok {
 					continue
@@ -127,3 +127,14 @@ func (a *AuctionModule) isGaslessTx(tx *types.Transaction) bool {
 
 	return a.gaslessModule.IsBundleTx(tx)
 }
+
+// isPrivateTx returns true if the tx is submitted via the private channel.
+// Private txs are never auctioned, and bids targeting them are silently ignored
+// so that the auction does not reveal their existence.
+func (a *AuctionModule) isPrivateTx(txHash common.Hash) bool {
+	if a.privateTxModule == nil {
+		return false
+	}
+
+	return a.privateTxModule.IsPrivateTx(txHash)
+}
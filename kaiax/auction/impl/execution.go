@@ -32,6 +32,9 @@ func (a *AuctionModule) PostInsertBlock(block *types.Block) error {
 		return nil
 	}
 
+	// Mark the included bids before the auction info is updated, which may clear the bid pool.
+	numIncluded := a.bidPool.markIncludedBids(block)
+
 	if !a.updateAuctionInfo(block.Number()) {
 		logger.Debug("stop auction since auctioneer or auction entry point is not set")
 		atomic.CompareAndSwapUint32(&a.bidPool.running, 1, 0)
@@ -44,7 +47,11 @@ func (a *AuctionModule) PostInsertBlock(block *types.Block) error {
 	for _, tx := range block.Transactions() {
 		txHashMap[tx.Hash()] = struct{}{}
 	}
-	a.bidPool.removeOldBids(block.Number().Uint64(), txHashMap)
+	numDropped := a.bidPool.removeOldBids(block.Number().Uint64(), txHashMap)
+	if total := numIncluded + numDropped; total > 0 {
+		bidInclusionRateGauge.Update(int64(numIncluded * 100 / total))
+	}
+	a.bidPool.pruneBidStatuses(block.Number().Uint64())
 
 	return nil
 }
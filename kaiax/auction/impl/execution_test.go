@@ -119,6 +119,7 @@ func TestUpdateAuctionInfo(t *testing.T) {
 		Chain:         backend.BlockChain(),
 		Backend:       apiBackend,
 		Downloader:    fakeDownloader,
+		ChainKv:       database.NewMemoryDBManager().GetMiscDB(),
 		NodeKey:       testNodeKey,
 	})
 
@@ -93,6 +93,7 @@ func TestHandler_HandleBid(t *testing.T) {
 		Chain:         backend.BlockChain(),
 		Backend:       apiBackend,
 		Downloader:    fakeDownloader,
+		ChainKv:       database.NewMemoryDBManager().GetMiscDB(),
 		NodeKey:       testNodeKey,
 	}
 	err := module.Init(opts)
@@ -158,6 +159,7 @@ func TestHandler_RateLimit(t *testing.T) {
 		Chain:         backend.BlockChain(),
 		Backend:       apiBackend,
 		Downloader:    fakeDownloader,
+		ChainKv:       database.NewMemoryDBManager().GetMiscDB(),
 		NodeKey:       testNodeKey,
 	}
 	err := module.Init(opts)
@@ -220,7 +222,7 @@ func TestHandler_RateLimit(t *testing.T) {
 	assert.Equal(t, bidCount, 400)
 
 	// Test rate limit recovery after 1 second
-	module.bidPool.clearBidPool()
+	module.bidPool.clearBidPool(auction.DropReasonStopped)
 	time.Sleep(1100 * time.Millisecond) // Wait for rate limit to reset
 
 	// Send another batch from peer1
@@ -264,6 +266,7 @@ func TestHandler_SubscribeNewBid(t *testing.T) {
 		Chain:         backend.BlockChain(),
 		Backend:       apiBackend,
 		Downloader:    fakeDownloader,
+		ChainKv:       database.NewMemoryDBManager().GetMiscDB(),
 		NodeKey:       testNodeKey,
 	}
 	err := module.Init(opts)
@@ -27,9 +27,11 @@ import (
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/kaiax/auction"
 	"github.com/kaiachain/kaia/kaiax/gasless"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
 	"github.com/kaiachain/kaia/log"
 	"github.com/kaiachain/kaia/node/cn/filters"
 	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
 )
 
 var (
@@ -53,6 +55,7 @@ type InitOpts struct {
 	Chain         backends.BlockChainForCaller
 	Backend       apiBackend
 	Downloader    ProtocolManagerDownloader
+	ChainKv       database.Database
 
 	NodeKey *ecdsa.PrivateKey
 }
@@ -62,7 +65,8 @@ type AuctionModule struct {
 
 	bidPool *BidPool
 
-	gaslessModule gasless.GaslessModule
+	gaslessModule   gasless.GaslessModule
+	privateTxModule privatetx.PrivateTxModule
 }
 
 var AuctionLenderMinBal = new(big.Int).Mul(big.NewInt(10), new(big.Int).SetUint64(params.KAIA))
@@ -72,7 +76,7 @@ func NewAuctionModule() *AuctionModule {
 }
 
 func (a *AuctionModule) Init(opts *InitOpts) error {
-	if opts == nil || opts.ChainConfig == nil || opts.AuctionConfig == nil || opts.Chain == nil || opts.Backend == nil || opts.Downloader == nil || opts.NodeKey == nil {
+	if opts == nil || opts.ChainConfig == nil || opts.AuctionConfig == nil || opts.Chain == nil || opts.Backend == nil || opts.Downloader == nil || opts.ChainKv == nil || opts.NodeKey == nil {
 		return auction.ErrInitUnexpectedNil
 	}
 
@@ -97,6 +101,7 @@ func (a *AuctionModule) Init(opts *InitOpts) error {
 	if a.bidPool == nil {
 		return auction.ErrInitUnexpectedNil
 	}
+	a.bidPool.db = opts.ChainKv
 
 	return nil
 }
@@ -105,6 +110,10 @@ func (a *AuctionModule) RegisterGaslessModule(module gasless.GaslessModule) {
 	a.gaslessModule = module
 }
 
+func (a *AuctionModule) RegisterPrivateTxModule(module privatetx.PrivateTxModule) {
+	a.privateTxModule = module
+}
+
 func (a *AuctionModule) IsDisabled() bool {
 	return a.AuctionConfig.Disable
 }
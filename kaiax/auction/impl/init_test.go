@@ -46,6 +46,7 @@ func TestInit(t *testing.T) {
 		ChainConfig: testChainConfig,
 		Backend:     apiBackend,
 		Downloader:  fakeDownloader,
+		ChainKv:     db.GetMiscDB(),
 		NodeKey:     key,
 	}
 
@@ -1,10 +1,24 @@
 package impl
 
-import "github.com/rcrowley/go-metrics"
+import (
+	"github.com/kaiachain/kaia/kaiax/auction"
+	"github.com/rcrowley/go-metrics"
+)
 
 var (
 	numBidsGauge         = metrics.NewRegisteredGauge("kaiax/auction/bidpool/num/bids", nil)
 	numBidRequestCounter = metrics.NewRegisteredCounter("kaiax/auction/bidpool/num/bidreqs", nil)
 
 	edOffsetGauge = metrics.NewRegisteredGauge("kaiax/auction/edoffset", nil)
+
+	bidIncludedCounter = metrics.NewRegisteredCounter("kaiax/auction/bid/included", nil)
+	bidDroppedCounters = map[auction.DropReason]metrics.Counter{
+		auction.DropReasonReplaced:          metrics.NewRegisteredCounter("kaiax/auction/bid/dropped/replaced", nil),
+		auction.DropReasonTargetTxMissing:   metrics.NewRegisteredCounter("kaiax/auction/bid/dropped/targettxmissing", nil),
+		auction.DropReasonExpired:           metrics.NewRegisteredCounter("kaiax/auction/bid/dropped/expired", nil),
+		auction.DropReasonAuctioneerChanged: metrics.NewRegisteredCounter("kaiax/auction/bid/dropped/auctioneerchanged", nil),
+		auction.DropReasonStopped:           metrics.NewRegisteredCounter("kaiax/auction/bid/dropped/stopped", nil),
+	}
+	// bidInclusionRateGauge is the percentage of the included bids among the bids targeting the latest block.
+	bidInclusionRateGauge = metrics.NewRegisteredGauge("kaiax/auction/bid/inclusionrate", nil)
 )
@@ -0,0 +1,113 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/auction"
+	"github.com/kaiachain/kaia/storage/database"
+)
+
+var (
+	bidStatusPrefix = []byte("auctionBidStatus")
+	bidBlockPrefix  = []byte("auctionBidBlock")
+)
+
+func bidStatusKey(bidHash common.Hash) []byte {
+	return append(common.CopyBytes(bidStatusPrefix), bidHash.Bytes()...)
+}
+
+// bidBlockKey is sorted by block number first so that the old statuses can be pruned in order.
+func bidBlockKey(num uint64, bidHash common.Hash) []byte {
+	key := append(common.CopyBytes(bidBlockPrefix), common.Int64ToByteBigEndian(num)...)
+	return append(key, bidHash.Bytes()...)
+}
+
+func ReadBidStatus(db database.Database, bidHash common.Hash) *auction.BidStatus {
+	b, err := db.Get(bidStatusKey(bidHash))
+	if err != nil || len(b) == 0 {
+		return nil
+	}
+
+	status := new(auction.BidStatus)
+	if err := json.Unmarshal(b, status); err != nil {
+		logger.Error("Malformed bid status", "bidHash", bidHash, "err", err)
+		return nil
+	}
+	return status
+}
+
+// WriteBidStatuses stores the statuses of the resolved bids, indexed by their target block number.
+func WriteBidStatuses(db database.Database, statuses []*auction.BidStatus) {
+	if len(statuses) == 0 {
+		return
+	}
+
+	batch := db.NewBatch()
+	defer batch.Release()
+
+	for _, status := range statuses {
+		b, err := json.Marshal(status)
+		if err != nil {
+			logger.Error("Failed to marshal bid status", "bidHash", status.BidHash, "err", err)
+			continue
+		}
+		if err := batch.Put(bidStatusKey(status.BidHash), b); err != nil {
+			logger.Crit("Failed to write bid status", "bidHash", status.BidHash, "err", err)
+		}
+		if err := batch.Put(bidBlockKey(uint64(status.BlockNumber), status.BidHash), []byte{}); err != nil {
+			logger.Crit("Failed to write bid block index", "bidHash", status.BidHash, "err", err)
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		logger.Crit("Failed to write bid statuses", "err", err)
+	}
+}
+
+// DeleteBidStatusesBefore deletes the statuses of the bids whose target block number is less than num.
+func DeleteBidStatusesBefore(db database.Database, num uint64) {
+	it := db.NewIterator(bidBlockPrefix, nil)
+	defer it.Release()
+
+	batch := db.NewBatch()
+	defer batch.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(bidBlockPrefix)+8+common.HashLength {
+			continue
+		}
+		if binary.BigEndian.Uint64(key[len(bidBlockPrefix):]) >= num {
+			break
+		}
+		bidHash := common.BytesToHash(key[len(bidBlockPrefix)+8:])
+		if err := batch.Delete(bidStatusKey(bidHash)); err != nil {
+			logger.Crit("Failed to delete bid status", "bidHash", bidHash, "err", err)
+		}
+		if err := batch.Delete(common.CopyBytes(key)); err != nil {
+			logger.Crit("Failed to delete bid block index", "bidHash", bidHash, "err", err)
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		logger.Crit("Failed to delete bid statuses", "err", err)
+	}
+}
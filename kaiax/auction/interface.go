@@ -20,6 +20,7 @@ import (
 	"github.com/kaiachain/kaia/event"
 	"github.com/kaiachain/kaia/kaiax"
 	"github.com/kaiachain/kaia/kaiax/gasless"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
 )
 
 type AuctionModule interface {
@@ -32,6 +33,7 @@ type AuctionModule interface {
 	SubscribeNewBid(sink chan<- *Bid) event.Subscription
 
 	gasless.GaslessModuleHost
+	privatetx.PrivateTxModuleHost
 }
 
 type AuctionModuleHost interface {
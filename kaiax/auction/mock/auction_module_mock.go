@@ -13,6 +13,7 @@ import (
 	event "github.com/kaiachain/kaia/event"
 	auction "github.com/kaiachain/kaia/kaiax/auction"
 	gasless "github.com/kaiachain/kaia/kaiax/gasless"
+	privatetx "github.com/kaiachain/kaia/kaiax/privatetx"
 	rpc "github.com/kaiachain/kaia/networks/rpc"
 	builder "github.com/kaiachain/kaia/work/builder"
 )
@@ -160,6 +161,18 @@ func (mr *MockAuctionModuleMockRecorder) RegisterGaslessModule(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterGaslessModule", reflect.TypeOf((*MockAuctionModule)(nil).RegisterGaslessModule), arg0)
 }
 
+// RegisterPrivateTxModule mocks base method.
+func (m *MockAuctionModule) RegisterPrivateTxModule(arg0 privatetx.PrivateTxModule) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterPrivateTxModule", arg0)
+}
+
+// RegisterPrivateTxModule indicates an expected call of RegisterPrivateTxModule.
+func (mr *MockAuctionModuleMockRecorder) RegisterPrivateTxModule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPrivateTxModule", reflect.TypeOf((*MockAuctionModule)(nil).RegisterPrivateTxModule), arg0)
+}
+
 // Start mocks base method.
 func (m *MockAuctionModule) Start() error {
 	m.ctrl.T.Helper()
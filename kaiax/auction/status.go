@@ -0,0 +1,60 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package auction
+
+import (
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+)
+
+type BidStatusCode string
+
+const (
+	BidStatusPending  BidStatusCode = "pending"
+	BidStatusIncluded BidStatusCode = "included"
+	BidStatusDropped  BidStatusCode = "dropped"
+)
+
+type DropReason string
+
+const (
+	// DropReasonReplaced means a higher bid for the same target tx replaced the bid.
+	DropReasonReplaced DropReason = "replaced"
+	// DropReasonTargetTxMissing means the target tx was not included in the target block.
+	DropReasonTargetTxMissing DropReason = "targetTxMissing"
+	// DropReasonExpired means the target block was mined with the target tx but without the bid tx.
+	DropReasonExpired DropReason = "expired"
+	// DropReasonAuctioneerChanged means the bid pool was cleared because the auction info was changed.
+	DropReasonAuctioneerChanged DropReason = "auctioneerChanged"
+	// DropReasonStopped means the bid pool was cleared because the module was stopped.
+	DropReasonStopped DropReason = "stopped"
+)
+
+// BidStatus is the result of auction_getBidStatus.
+type BidStatus struct {
+	BidHash      common.Hash    `json:"bidHash"`
+	TargetTxHash common.Hash    `json:"targetTxHash"`
+	BlockNumber  hexutil.Uint64 `json:"blockNumber"`
+	Status       BidStatusCode  `json:"status"`
+
+	// Set if the status is included.
+	TxHash  *common.Hash  `json:"txHash,omitempty"`
+	TxIndex *hexutil.Uint `json:"txIndex,omitempty"`
+
+	// Set if the status is dropped.
+	Reason DropReason `json:"reason,omitempty"`
+}
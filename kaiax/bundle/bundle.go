@@ -0,0 +1,57 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import (
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+)
+
+// MaxBlockRange is the furthest a bundle can target from the current block.
+const MaxBlockRange = 64
+
+// SearcherBundle is a bundle of signed txs submitted by an external searcher.
+// The txs are executed in order at the top of the target block, and the bundle is discarded
+// if any tx fails except for those in RevertingTxHashes.
+type SearcherBundle struct {
+	Txs               []*types.Transaction
+	BlockNumber       uint64
+	MinTimestamp      uint64 // zero if unbounded
+	MaxTimestamp      uint64 // zero if unbounded
+	RevertingTxHashes []common.Hash
+}
+
+// Hash returns the keccak256 of the concatenated tx hashes.
+func (b *SearcherBundle) Hash() common.Hash {
+	data := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		data = append(data, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(data)
+}
+
+// IsValidAt returns if the bundle can be included in a block at the given timestamp.
+func (b *SearcherBundle) IsValidAt(timestamp uint64) bool {
+	if b.MinTimestamp != 0 && timestamp < b.MinTimestamp {
+		return false
+	}
+	if b.MaxTimestamp != 0 && timestamp > b.MaxTimestamp {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import (
+	"github.com/kaiachain/kaia/common"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	DefaultMaxBundles          = 1024
+	DefaultMaxBundleTxs        = 16
+	DefaultMaxBundlesPerSender = 16
+)
+
+var (
+	EnableFlag = &cli.BoolFlag{
+		Name:     "bundle.enable",
+		Usage:    "enable bundle module that accepts tx bundles from external searchers",
+		Value:    false,
+		Aliases:  []string{"kaiax.module.bundle.enable"},
+		Category: "KAIAX",
+	}
+	MaxBundlesFlag = &cli.IntFlag{
+		Name:     "bundle.max-bundles",
+		Usage:    "max number of bundles in bundle pool",
+		Value:    DefaultMaxBundles,
+		Aliases:  []string{"kaiax.module.bundle.max-bundles"},
+		Category: "KAIAX",
+	}
+	MaxBundleTxsFlag = &cli.IntFlag{
+		Name:     "bundle.max-bundle-txs",
+		Usage:    "max number of transactions in a bundle",
+		Value:    DefaultMaxBundleTxs,
+		Aliases:  []string{"kaiax.module.bundle.max-bundle-txs"},
+		Category: "KAIAX",
+	}
+	MaxBundlesPerSenderFlag = &cli.IntFlag{
+		Name:     "bundle.max-bundles-per-sender",
+		Usage:    "max number of bundles in bundle pool that include transactions of the same sender",
+		Value:    DefaultMaxBundlesPerSender,
+		Aliases:  []string{"kaiax.module.bundle.max-bundles-per-sender"},
+		Category: "KAIAX",
+	}
+)
+
+type BundleConfig struct {
+	Enable              bool
+	MaxBundles          int
+	MaxBundleTxs        int
+	MaxBundlesPerSender int
+}
+
+func DefaultBundleConfig() *BundleConfig {
+	return &BundleConfig{
+		Enable:              false,
+		MaxBundles:          DefaultMaxBundles,
+		MaxBundleTxs:        DefaultMaxBundleTxs,
+		MaxBundlesPerSender: DefaultMaxBundlesPerSender,
+	}
+}
+
+func SetBundleConfig(ctx *cli.Context, cfg *BundleConfig, nodeType common.ConnType) {
+	// Only block proposers build blocks with bundles.
+	cfg.Enable = ctx.Bool(EnableFlag.Name) && nodeType == common.CONSENSUSNODE
+	cfg.MaxBundles = ctx.Int(MaxBundlesFlag.Name)
+	cfg.MaxBundleTxs = ctx.Int(MaxBundleTxsFlag.Name)
+	cfg.MaxBundlesPerSender = ctx.Int(MaxBundlesPerSenderFlag.Name)
+}
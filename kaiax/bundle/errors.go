@@ -0,0 +1,35 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import "errors"
+
+var (
+	ErrInitUnexpectedNil      = errors.New("unexpected nil during module init")
+	ErrBundleDisabled         = errors.New("bundle is disabled")
+	ErrEmptyBundle            = errors.New("bundle has no transactions")
+	ErrTooManyBundleTxs       = errors.New("bundle has too many transactions")
+	ErrDuplicateBundleTx      = errors.New("bundle has duplicate transactions")
+	ErrBlockNumberPassed      = errors.New("bundle block number already passed")
+	ErrBlockNumberTooFar      = errors.New("bundle block number too far in the future")
+	ErrInvalidTimestampRange  = errors.New("bundle min timestamp is greater than max timestamp")
+	ErrUnknownRevertingTxHash = errors.New("reverting tx hash is not in the bundle")
+	ErrBundleAlreadyExists    = errors.New("bundle already exists")
+	ErrBundlePoolFull         = errors.New("bundle pool is full")
+	ErrTooManySenderBundles   = errors.New("sender has too many bundles in bundle pool")
+	ErrBundleTxReverted       = errors.New("bundle tx reverted")
+)
@@ -0,0 +1,104 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"context"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/kaiax/bundle"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+func (b *BundleModule) APIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "kaia",
+			Version:   "1.0",
+			Service:   NewBundleAPI(b),
+			Public:    true,
+		},
+		{
+			Namespace: "eth",
+			Version:   "1.0",
+			Service:   NewBundleAPI(b),
+			Public:    true,
+		},
+	}
+}
+
+type BundleAPI struct {
+	b *BundleModule
+}
+
+func NewBundleAPI(b *BundleModule) *BundleAPI {
+	return &BundleAPI{b}
+}
+
+// SendBundleArgs follows the format of eth_sendBundle.
+type SendBundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	MinTimestamp      *uint64         `json:"minTimestamp"`
+	MaxTimestamp      *uint64         `json:"maxTimestamp"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes"`
+}
+
+type SendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// SendBundle validates the bundle by simulating it on top of the current block, and adds it to the bundle pool.
+func (s *BundleAPI) SendBundle(ctx context.Context, args SendBundleArgs) (*SendBundleResult, error) {
+	if s.b.IsDisabled() {
+		return nil, bundle.ErrBundleDisabled
+	}
+
+	sb := &bundle.SearcherBundle{
+		Txs:               make([]*types.Transaction, len(args.Txs)),
+		BlockNumber:       uint64(args.BlockNumber),
+		RevertingTxHashes: args.RevertingTxHashes,
+	}
+	if args.MinTimestamp != nil {
+		sb.MinTimestamp = *args.MinTimestamp
+	}
+	if args.MaxTimestamp != nil {
+		sb.MaxTimestamp = *args.MaxTimestamp
+	}
+	for i, input := range args.Txs {
+		if len(input) == 0 {
+			return nil, bundle.ErrEmptyBundle
+		}
+		if 0 < input[0] && input[0] < 0x7f {
+			input = append([]byte{byte(types.EthereumTxTypeEnvelope)}, input...)
+		}
+		tx := new(types.Transaction)
+		if err := rlp.DecodeBytes(input, tx); err != nil {
+			return nil, err
+		}
+		sb.Txs[i] = tx
+	}
+
+	hash, err := s.b.addBundle(sb)
+	if err != nil {
+		return nil, err
+	}
+	return &SendBundleResult{BundleHash: hash}, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/misc"
+	"github.com/kaiachain/kaia/work/builder"
+)
+
+// ExtractTxBundles places the bundles targeting the mining block at the top of the block,
+// the most paying one first. Each bundle is chained after the previous one so that
+// incorporating them keeps the order.
+func (b *BundleModule) ExtractTxBundles(txs []*types.Transaction, prevBundles []*builder.Bundle) []*builder.Bundle {
+	bundles := []*builder.Bundle{}
+	curBlock := b.Chain.CurrentBlock()
+	if curBlock == nil {
+		return bundles
+	}
+
+	candidates := b.getBundles(curBlock.NumberU64()+1, uint64(time.Now().Unix()))
+	if len(candidates) == 0 {
+		return bundles
+	}
+	sortByPriority(candidates, b.nextBaseFee(curBlock.Header()))
+
+	// Bundles using the same sender nonce cannot be executed together.
+	usedNonces := make(map[common.Address]map[uint64]bool)
+	targetTxHash := common.Hash{}
+	for _, pb := range candidates {
+		if b.hasNonceConflict(pb, usedNonces) {
+			continue
+		}
+
+		txOrGens := make([]*builder.TxOrGen, len(pb.Txs))
+		for i, tx := range pb.Txs {
+			txOrGens[i] = builder.NewTxOrGenFromTx(tx)
+		}
+		newBundle := builder.NewBundle(txOrGens, targetTxHash, false)
+		newBundle.RevertingTxs = revertingTxs(pb.SearcherBundle)
+		if builder.IsConflict(append(prevBundles, bundles...), []*builder.Bundle{newBundle}) {
+			continue
+		}
+
+		for _, tx := range pb.Txs {
+			from, _ := types.Sender(b.signer, tx)
+			if usedNonces[from] == nil {
+				usedNonces[from] = make(map[uint64]bool)
+			}
+			usedNonces[from][tx.Nonce()] = true
+		}
+		bundles = append(bundles, newBundle)
+		targetTxHash = pb.Txs[len(pb.Txs)-1].Hash()
+	}
+
+	numExtractedBundlesGauge.Update(int64(len(bundles)))
+	return bundles
+}
+
+func (b *BundleModule) hasNonceConflict(pb *pooledBundle, usedNonces map[common.Address]map[uint64]bool) bool {
+	for _, tx := range pb.Txs {
+		from, err := types.Sender(b.signer, tx)
+		if err != nil || usedNonces[from][tx.Nonce()] {
+			return true
+		}
+	}
+	return false
+}
+
+// nextBaseFee returns the base fee of the block following parent, which the bundles will pay.
+// It returns nil before the Magma hardfork.
+func (b *BundleModule) nextBaseFee(parent *types.Header) *big.Int {
+	num := new(big.Int).Add(parent.Number, common.Big1)
+	if !b.ChainConfig.IsMagmaForkEnabled(num) {
+		return nil
+	}
+	pset := b.GovModule.GetParamSet(num.Uint64())
+	return misc.NextMagmaBlockBaseFee(parent, pset.ToKip71Config())
+}
+
+// getBundles returns the bundles targeting the given block that are valid at the given timestamp.
+func (b *BundleModule) getBundles(num, timestamp uint64) []*pooledBundle {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ret := make([]*pooledBundle, 0, len(b.bundles[num]))
+	for _, pb := range b.bundles[num] {
+		if pb.IsValidAt(timestamp) {
+			ret = append(ret, pb)
+		}
+	}
+	return ret
+}
+
+// sortByPriority sorts the bundles by bundlePriority, descending. Ties are broken by the arrival order.
+func sortByPriority(bundles []*pooledBundle, baseFee *big.Int) {
+	priorities := make(map[*pooledBundle]*big.Int, len(bundles))
+	for _, pb := range bundles {
+		priorities[pb] = bundlePriority(pb, baseFee)
+	}
+	sort.SliceStable(bundles, func(i, j int) bool {
+		if cmp := priorities[bundles[i]].Cmp(priorities[bundles[j]]); cmp != 0 {
+			return cmp > 0
+		}
+		return bundles[i].seq < bundles[j].seq
+	})
+}
+
+// bundlePriority returns the gas-weighted average of the effective gas tips of the bundle txs.
+func bundlePriority(pb *pooledBundle, baseFee *big.Int) *big.Int {
+	var (
+		totalTip = new(big.Int)
+		totalGas = new(big.Int)
+	)
+	for _, tx := range pb.Txs {
+		gas := new(big.Int).SetUint64(tx.Gas())
+		totalTip.Add(totalTip, new(big.Int).Mul(tx.EffectiveGasTip(baseFee), gas))
+		totalGas.Add(totalGas, gas)
+	}
+	if totalGas.Sign() == 0 {
+		return new(big.Int)
+	}
+	return totalTip.Div(totalTip, totalGas)
+}
+
+func (b *BundleModule) IsBundleTx(tx *types.Transaction) bool {
+	return false
+}
+
+func (b *BundleModule) GetMaxBundleTxsInPending() uint {
+	return 0
+}
+
+func (b *BundleModule) GetMaxBundleTxsInQueue() uint {
+	return 0
+}
+
+func (b *BundleModule) FilterTxs(txs map[common.Address]types.Transactions) {
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/bundle"
+	"github.com/kaiachain/kaia/kaiax/gov"
+	gov_mock "github.com/kaiachain/kaia/kaiax/gov/mock"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/work/builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractTxBundles(t *testing.T) {
+	var (
+		now = uint64(time.Now().Unix())
+
+		low     = newTestTx(t, testKey1, 0, testRecipient, 25e9)
+		high    = newTestTx(t, testKey2, 0, testRecipient, 50e9)
+		highNxt = newTestTx(t, testKey2, 1, testRecipient, 50e9)
+		sameNon = newTestTx(t, testKey2, 0, testRecipient, 30e9)
+	)
+
+	testcases := []struct {
+		name        string
+		bundles     []*bundle.SearcherBundle
+		prevBundles []*builder.Bundle
+		expected    [][]common.Hash
+	}{
+		{
+			name: "ordered by tip and chained",
+			bundles: []*bundle.SearcherBundle{
+				{Txs: []*types.Transaction{low}, BlockNumber: 1},
+				{Txs: []*types.Transaction{high, highNxt}, BlockNumber: 1},
+			},
+			expected: [][]common.Hash{{high.Hash(), highNxt.Hash()}, {low.Hash()}},
+		},
+		{
+			name: "same sender nonce",
+			bundles: []*bundle.SearcherBundle{
+				{Txs: []*types.Transaction{sameNon}, BlockNumber: 1},
+				{Txs: []*types.Transaction{high}, BlockNumber: 1},
+			},
+			expected: [][]common.Hash{{high.Hash()}},
+		},
+		{
+			name: "other target block",
+			bundles: []*bundle.SearcherBundle{
+				{Txs: []*types.Transaction{low}, BlockNumber: 1},
+				{Txs: []*types.Transaction{high}, BlockNumber: 2},
+			},
+			expected: [][]common.Hash{{low.Hash()}},
+		},
+		{
+			name: "outside timestamp range",
+			bundles: []*bundle.SearcherBundle{
+				{Txs: []*types.Transaction{low}, BlockNumber: 1, MaxTimestamp: now + 60},
+				{Txs: []*types.Transaction{high}, BlockNumber: 1, MinTimestamp: now + 60},
+			},
+			expected: [][]common.Hash{{low.Hash()}},
+		},
+		{
+			name: "conflict with previous bundles",
+			bundles: []*bundle.SearcherBundle{
+				{Txs: []*types.Transaction{low}, BlockNumber: 1},
+				{Txs: []*types.Transaction{high}, BlockNumber: 1},
+			},
+			prevBundles: []*builder.Bundle{
+				builder.NewBundle([]*builder.TxOrGen{builder.NewTxOrGenFromTx(high)}, common.Hash{}, false),
+			},
+			expected: [][]common.Hash{{low.Hash()}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := newTestBundleModule(t)
+			for _, sb := range tc.bundles {
+				_, err := b.addBundle(sb)
+				require.NoError(t, err)
+			}
+
+			bundles := b.ExtractTxBundles(nil, tc.prevBundles)
+			require.Len(t, bundles, len(tc.expected))
+
+			targetTxHash := common.Hash{}
+			for i, bundle := range bundles {
+				hashes := make([]common.Hash, len(bundle.BundleTxs))
+				for j, txOrGen := range bundle.BundleTxs {
+					hashes[j] = txOrGen.Id
+				}
+				assert.Equal(t, tc.expected[i], hashes)
+				assert.Equal(t, targetTxHash, bundle.TargetTxHash)
+				targetTxHash = hashes[len(hashes)-1]
+			}
+		})
+	}
+}
+
+func TestExtractTxBundles_RevertingTxs(t *testing.T) {
+	var (
+		b      = newTestBundleModule(t)
+		revert = newTestTx(t, testKey1, 0, testRevertAddr, 25e9)
+		next   = newTestTx(t, testKey1, 1, testRecipient, 25e9)
+	)
+	_, err := b.addBundle(&bundle.SearcherBundle{
+		Txs:               []*types.Transaction{revert, next},
+		BlockNumber:       1,
+		RevertingTxHashes: []common.Hash{revert.Hash()},
+	})
+	require.NoError(t, err)
+
+	bundles := b.ExtractTxBundles(nil, nil)
+	require.Len(t, bundles, 1)
+	assert.True(t, bundles[0].CanRevert(revert.Hash()))
+	assert.False(t, bundles[0].CanRevert(next.Hash()))
+}
+
+func TestNextBaseFee(t *testing.T) {
+	var (
+		mockGov = gov_mock.NewMockGovModule(gomock.NewController(t))
+		parent  = &types.Header{Number: big.NewInt(10), BaseFee: big.NewInt(25e9), GasUsed: 0}
+	)
+	mockGov.EXPECT().GetParamSet(uint64(11)).Return(gov.ParamSet{
+		LowerBoundBaseFee:  50e9,
+		UpperBoundBaseFee:  750e9,
+		GasTarget:          30000000,
+		BaseFeeDenominator: 20,
+	}).AnyTimes()
+
+	// Before Magma, the bundles are ranked by their gas price.
+	b := &BundleModule{InitOpts: InitOpts{ChainConfig: testChainConfig, GovModule: mockGov}}
+	assert.Nil(t, b.nextBaseFee(parent))
+
+	// After Magma, the base fee of the next block is used instead of the parent's.
+	b.ChainConfig = &params.ChainConfig{ChainID: testChainConfig.ChainID, MagmaCompatibleBlock: common.Big0}
+	assert.Equal(t, big.NewInt(50e9), b.nextBaseFee(parent))
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/state"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/blockchain/vm"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/bundle"
+)
+
+// addBundle validates the bundle, simulates it on top of the pending block, and adds it to the bundle pool.
+// A sender cannot have more than MaxBundlesPerSender bundles in the pool, so that a searcher cannot fill
+// the pool with variations of its bundle. When the pool is full, the least paying bundle is evicted if
+// the new bundle pays more.
+func (b *BundleModule) addBundle(sb *bundle.SearcherBundle) (common.Hash, error) {
+	if err := b.validateBundle(sb); err != nil {
+		return common.Hash{}, err
+	}
+	if err := b.simulateBundle(sb); err != nil {
+		return common.Hash{}, err
+	}
+	baseFee := b.nextBaseFee(b.Chain.CurrentBlock().Header())
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	hash := sb.Hash()
+	if _, ok := b.known[hash]; ok {
+		return common.Hash{}, bundle.ErrBundleAlreadyExists
+	}
+	pb := &pooledBundle{SearcherBundle: sb, hash: hash, senders: b.bundleSenders(sb)}
+	for _, from := range pb.senders {
+		if b.senders[from] >= b.BundleConfig.MaxBundlesPerSender {
+			return common.Hash{}, fmt.Errorf("%w: %s", bundle.ErrTooManySenderBundles, from.Hex())
+		}
+	}
+	if len(b.known) >= b.BundleConfig.MaxBundles {
+		lowest := b.lowestPriorityBundle(baseFee)
+		if lowest == nil || bundlePriority(pb, baseFee).Cmp(bundlePriority(lowest, baseFee)) <= 0 {
+			return common.Hash{}, bundle.ErrBundlePoolFull
+		}
+		b.removeBundle(lowest)
+	}
+
+	b.seq++
+	pb.seq = b.seq
+	b.bundles[sb.BlockNumber] = append(b.bundles[sb.BlockNumber], pb)
+	b.known[hash] = struct{}{}
+	for _, from := range pb.senders {
+		b.senders[from]++
+	}
+	numBundlesGauge.Update(int64(len(b.known)))
+	return hash, nil
+}
+
+// bundleSenders returns the distinct senders of the bundle txs, which must have been validated.
+func (b *BundleModule) bundleSenders(sb *bundle.SearcherBundle) []common.Address {
+	var senders []common.Address
+	seen := make(map[common.Address]bool, len(sb.Txs))
+	for _, tx := range sb.Txs {
+		from, _ := types.Sender(b.signer, tx)
+		if !seen[from] {
+			seen[from] = true
+			senders = append(senders, from)
+		}
+	}
+	return senders
+}
+
+// lowestPriorityBundle returns the least paying bundle in the pool, the latest one among equals.
+// It must be called with b.mu held.
+func (b *BundleModule) lowestPriorityBundle(baseFee *big.Int) *pooledBundle {
+	var (
+		lowest   *pooledBundle
+		priority *big.Int
+	)
+	for _, bundles := range b.bundles {
+		for _, pb := range bundles {
+			p := bundlePriority(pb, baseFee)
+			if lowest == nil {
+				lowest, priority = pb, p
+				continue
+			}
+			if cmp := p.Cmp(priority); cmp < 0 || (cmp == 0 && pb.seq > lowest.seq) {
+				lowest, priority = pb, p
+			}
+		}
+	}
+	return lowest
+}
+
+// removeBundle removes the bundle from the pool. It must be called with b.mu held.
+func (b *BundleModule) removeBundle(pb *pooledBundle) {
+	bundles := b.bundles[pb.BlockNumber]
+	for i := range bundles {
+		if bundles[i] == pb {
+			bundles = append(bundles[:i:i], bundles[i+1:]...)
+			break
+		}
+	}
+	if len(bundles) == 0 {
+		delete(b.bundles, pb.BlockNumber)
+	} else {
+		b.bundles[pb.BlockNumber] = bundles
+	}
+	b.forgetBundle(pb)
+}
+
+// forgetBundle drops the bundle from the lookup and the sender counts. It must be called with b.mu held.
+func (b *BundleModule) forgetBundle(pb *pooledBundle) {
+	delete(b.known, pb.hash)
+	for _, from := range pb.senders {
+		if b.senders[from]--; b.senders[from] <= 0 {
+			delete(b.senders, from)
+		}
+	}
+}
+
+func (b *BundleModule) validateBundle(sb *bundle.SearcherBundle) error {
+	if len(sb.Txs) == 0 {
+		return bundle.ErrEmptyBundle
+	}
+	if len(sb.Txs) > b.BundleConfig.MaxBundleTxs {
+		return bundle.ErrTooManyBundleTxs
+	}
+
+	current := b.Chain.CurrentBlock().NumberU64()
+	if sb.BlockNumber <= current {
+		return bundle.ErrBlockNumberPassed
+	}
+	if sb.BlockNumber > current+bundle.MaxBlockRange {
+		return bundle.ErrBlockNumberTooFar
+	}
+	if sb.MinTimestamp != 0 && sb.MaxTimestamp != 0 && sb.MinTimestamp > sb.MaxTimestamp {
+		return bundle.ErrInvalidTimestampRange
+	}
+
+	hashes := make(map[common.Hash]struct{}, len(sb.Txs))
+	for i, tx := range sb.Txs {
+		if _, ok := hashes[tx.Hash()]; ok {
+			return bundle.ErrDuplicateBundleTx
+		}
+		hashes[tx.Hash()] = struct{}{}
+		if _, err := types.Sender(b.signer, tx); err != nil {
+			return fmt.Errorf("tx %d: %w", i, err)
+		}
+	}
+	for _, hash := range sb.RevertingTxHashes {
+		if _, ok := hashes[hash]; !ok {
+			return fmt.Errorf("%w: %s", bundle.ErrUnknownRevertingTxHash, hash.Hex())
+		}
+	}
+	return nil
+}
+
+// simulateBundle executes the bundle txs on top of the pending block, where the bundle is
+// expected to be placed.
+func (b *BundleModule) simulateBundle(sb *bundle.SearcherBundle) error {
+	header, statedb, err := b.pendingState()
+	if err != nil {
+		return err
+	}
+	header.GasUsed = 0
+
+	reverting := revertingTxs(sb)
+	for i, tx := range sb.Txs {
+		statedb.SetTxContext(tx.Hash(), common.Hash{}, i)
+		receipt, _, err := b.Chain.ApplyTransaction(b.ChainConfig, &header.Rewardbase, statedb, header, tx, &header.GasUsed, &vm.Config{})
+		if err != nil {
+			return fmt.Errorf("tx %d (%s): %w", i, tx.Hash().Hex(), err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful && !reverting[tx.Hash()] {
+			return fmt.Errorf("%w: tx %d (%s)", bundle.ErrBundleTxReverted, i, tx.Hash().Hex())
+		}
+	}
+	return nil
+}
+
+// pendingState returns a copy of the pending block header and the pending state. Until the miner
+// prepares the pending block, the next block is approximated from the current block.
+func (b *BundleModule) pendingState() (*types.Header, *state.StateDB, error) {
+	if b.Miner != nil {
+		if block, _, statedb := b.Miner.Pending(); block != nil && statedb != nil {
+			return types.CopyHeader(block.Header()), statedb, nil
+		}
+	}
+	current := b.Chain.CurrentBlock()
+	statedb, err := b.Chain.StateAt(current.Root())
+	if err != nil {
+		return nil, nil, err
+	}
+	header := types.CopyHeader(current.Header())
+	header.ParentHash = current.Hash()
+	header.Number = new(big.Int).Add(current.Number(), common.Big1)
+	header.Time = new(big.Int).SetInt64(time.Now().Unix())
+	return header, statedb, nil
+}
+
+func revertingTxs(sb *bundle.SearcherBundle) map[common.Hash]bool {
+	reverting := make(map[common.Hash]bool, len(sb.RevertingTxHashes))
+	for _, hash := range sb.RevertingTxHashes {
+		reverting[hash] = true
+	}
+	return reverting
+}
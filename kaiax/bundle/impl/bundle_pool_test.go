@@ -0,0 +1,222 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/accounts/abi/bind/backends"
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/state"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/kaiax/bundle"
+	gov_mock "github.com/kaiachain/kaia/kaiax/gov/mock"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testChainConfig = &params.ChainConfig{
+		ChainID: big.NewInt(31337),
+	}
+
+	testKey1, _ = crypto.HexToECDSA("59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d")
+	testKey2, _ = crypto.HexToECDSA("5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a")
+	testAddr1   = crypto.PubkeyToAddress(testKey1.PublicKey)
+	testAddr2   = crypto.PubkeyToAddress(testKey2.PublicKey)
+
+	// testRevertAddr has a contract that always fails with an invalid opcode.
+	testRevertAddr = common.HexToAddress("0x000000000000000000000000000000000000fffe")
+	testRecipient  = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+)
+
+func newTestBundleModule(t *testing.T) *BundleModule {
+	alloc := blockchain.GenesisAlloc{
+		testAddr1:      {Balance: big.NewInt(params.KAIA)},
+		testAddr2:      {Balance: big.NewInt(params.KAIA)},
+		testRevertAddr: {Balance: common.Big0, Code: []byte{0xfe}},
+	}
+	backend := backends.NewSimulatedBackendWithDatabase(database.NewMemoryDBManager(), alloc, testChainConfig)
+	t.Cleanup(func() { backend.Close() })
+
+	cfg := bundle.DefaultBundleConfig()
+	cfg.Enable = true
+	cfg.MaxBundles = 4
+	cfg.MaxBundleTxs = 3
+
+	b := NewBundleModule()
+	require.NoError(t, b.Init(&InitOpts{
+		ChainConfig:  testChainConfig,
+		BundleConfig: cfg,
+		Chain:        backend.BlockChain(),
+		GovModule:    gov_mock.NewMockGovModule(gomock.NewController(t)),
+	}))
+	return b
+}
+
+func newTestTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, to common.Address, gasPrice int64) *types.Transaction {
+	tx := types.NewTransaction(nonce, to, common.Big1, 100000, big.NewInt(gasPrice), nil)
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(testChainConfig.ChainID), key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestAddBundle(t *testing.T) {
+	var (
+		tx0       = newTestTx(t, testKey1, 0, testRecipient, 25e9)
+		tx1       = newTestTx(t, testKey1, 1, testRecipient, 25e9)
+		tx2       = newTestTx(t, testKey2, 0, testRecipient, 25e9)
+		badNonce  = newTestTx(t, testKey1, 5, testRecipient, 25e9)
+		revertTx  = newTestTx(t, testKey2, 0, testRevertAddr, 25e9)
+		unknownTx = newTestTx(t, testKey2, 1, testRecipient, 25e9)
+	)
+
+	testcases := []struct {
+		name   string
+		bundle *bundle.SearcherBundle
+		err    error
+	}{
+		{"empty", &bundle.SearcherBundle{BlockNumber: 1}, bundle.ErrEmptyBundle},
+		{"too many txs", &bundle.SearcherBundle{Txs: []*types.Transaction{tx0, tx1, tx2, unknownTx}, BlockNumber: 1}, bundle.ErrTooManyBundleTxs},
+		{"block passed", &bundle.SearcherBundle{Txs: []*types.Transaction{tx0}, BlockNumber: 0}, bundle.ErrBlockNumberPassed},
+		{"block too far", &bundle.SearcherBundle{Txs: []*types.Transaction{tx0}, BlockNumber: bundle.MaxBlockRange + 1}, bundle.ErrBlockNumberTooFar},
+		{"invalid timestamp range", &bundle.SearcherBundle{Txs: []*types.Transaction{tx0}, BlockNumber: 1, MinTimestamp: 2, MaxTimestamp: 1}, bundle.ErrInvalidTimestampRange},
+		{"duplicate tx", &bundle.SearcherBundle{Txs: []*types.Transaction{tx0, tx0}, BlockNumber: 1}, bundle.ErrDuplicateBundleTx},
+		{"unknown reverting tx", &bundle.SearcherBundle{Txs: []*types.Transaction{tx0}, BlockNumber: 1, RevertingTxHashes: []common.Hash{unknownTx.Hash()}}, bundle.ErrUnknownRevertingTxHash},
+		{"reverted", &bundle.SearcherBundle{Txs: []*types.Transaction{tx0, revertTx}, BlockNumber: 1}, bundle.ErrBundleTxReverted},
+		{"nonce too high", &bundle.SearcherBundle{Txs: []*types.Transaction{badNonce}, BlockNumber: 1}, blockchain.ErrNonceTooHigh},
+		{"ok", &bundle.SearcherBundle{Txs: []*types.Transaction{tx0, tx1}, BlockNumber: 1}, nil},
+		{"ok with reverting tx", &bundle.SearcherBundle{Txs: []*types.Transaction{tx0, revertTx}, BlockNumber: 1, RevertingTxHashes: []common.Hash{revertTx.Hash()}}, nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := newTestBundleModule(t)
+			hash, err := b.addBundle(tc.bundle)
+			if tc.err != nil {
+				assert.True(t, errors.Is(err, tc.err), "expected %v, got %v", tc.err, err)
+				assert.Empty(t, b.known)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.bundle.Hash(), hash)
+			assert.Len(t, b.bundles[tc.bundle.BlockNumber], 1)
+		})
+	}
+}
+
+type testMiner struct {
+	block   *types.Block
+	statedb *state.StateDB
+}
+
+func (m *testMiner) Pending() (*types.Block, types.Receipts, *state.StateDB) {
+	return m.block, nil, m.statedb
+}
+
+func TestAddBundle_PendingState(t *testing.T) {
+	b := newTestBundleModule(t)
+	miner := &testMiner{}
+	b.Miner = miner
+	tx1 := newTestTx(t, testKey1, 1, testRecipient, 25e9)
+
+	// The current block is used until the pending block is prepared.
+	_, err := b.addBundle(&bundle.SearcherBundle{Txs: []*types.Transaction{tx1}, BlockNumber: 1})
+	assert.ErrorIs(t, err, blockchain.ErrNonceTooHigh)
+
+	// The bundle depending on a pending tx is accepted on the pending state.
+	current := b.Chain.CurrentBlock()
+	statedb, err := b.Chain.StateAt(current.Root())
+	require.NoError(t, err)
+	statedb.SetNonce(testAddr1, 1)
+	miner.block = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1), Time: big.NewInt(0), BlockScore: common.Big1})
+	miner.statedb = statedb
+
+	_, err = b.addBundle(&bundle.SearcherBundle{Txs: []*types.Transaction{tx1}, BlockNumber: 1})
+	require.NoError(t, err)
+
+	// The bundle conflicting with a pending tx is rejected.
+	_, err = b.addBundle(&bundle.SearcherBundle{Txs: []*types.Transaction{newTestTx(t, testKey1, 0, testRecipient, 25e9)}, BlockNumber: 1})
+	assert.ErrorIs(t, err, blockchain.ErrNonceTooLow)
+}
+
+func TestAddBundle_Pool(t *testing.T) {
+	b := newTestBundleModule(t)
+
+	for i := 0; i < b.BundleConfig.MaxBundles; i++ {
+		sb := &bundle.SearcherBundle{Txs: []*types.Transaction{newTestTx(t, testKey1, 0, testRecipient, int64(25e9+i))}, BlockNumber: 1}
+		_, err := b.addBundle(sb)
+		require.NoError(t, err)
+	}
+
+	// The same bundle is rejected.
+	_, err := b.addBundle(b.bundles[1][0].SearcherBundle)
+	assert.ErrorIs(t, err, bundle.ErrBundleAlreadyExists)
+
+	// A new bundle is rejected when the pool is full, unless it pays more than the least paying one.
+	_, err = b.addBundle(&bundle.SearcherBundle{Txs: []*types.Transaction{newTestTx(t, testKey2, 0, testRecipient, 25e9)}, BlockNumber: 2})
+	assert.ErrorIs(t, err, bundle.ErrBundlePoolFull)
+
+	lowest := b.bundles[1][0]
+	_, err = b.addBundle(&bundle.SearcherBundle{Txs: []*types.Transaction{newTestTx(t, testKey2, 0, testRecipient, 30e9)}, BlockNumber: 2})
+	require.NoError(t, err)
+	assert.Len(t, b.known, b.BundleConfig.MaxBundles)
+	assert.NotContains(t, b.known, lowest.hash)
+	assert.NotContains(t, b.bundles[1], lowest)
+	assert.Equal(t, b.BundleConfig.MaxBundles-1, b.senders[testAddr1])
+
+	// Bundles targeting the inserted block are removed.
+	require.NoError(t, b.PostInsertBlock(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(2)})))
+	assert.Empty(t, b.bundles)
+	assert.Empty(t, b.known)
+	assert.Empty(t, b.senders)
+}
+
+func TestAddBundle_SenderLimit(t *testing.T) {
+	b := newTestBundleModule(t)
+	b.BundleConfig.MaxBundlesPerSender = 2
+
+	// A sender cannot fill the pool by resubmitting variations of its tx for many blocks.
+	for num := uint64(1); num <= 2; num++ {
+		_, err := b.addBundle(&bundle.SearcherBundle{Txs: []*types.Transaction{newTestTx(t, testKey1, 0, testRecipient, int64(25e9+num))}, BlockNumber: num})
+		require.NoError(t, err)
+	}
+	tx := newTestTx(t, testKey1, 0, testRecipient, 25e9)
+	_, err := b.addBundle(&bundle.SearcherBundle{Txs: []*types.Transaction{tx}, BlockNumber: 3})
+	assert.ErrorIs(t, err, bundle.ErrTooManySenderBundles)
+
+	// The bundles including a tx of the sender count as well.
+	_, err = b.addBundle(&bundle.SearcherBundle{Txs: []*types.Transaction{newTestTx(t, testKey2, 0, testRecipient, 25e9), tx}, BlockNumber: 3})
+	assert.ErrorIs(t, err, bundle.ErrTooManySenderBundles)
+
+	// Other senders are not affected.
+	_, err = b.addBundle(&bundle.SearcherBundle{Txs: []*types.Transaction{newTestTx(t, testKey2, 0, testRecipient, 25e9)}, BlockNumber: 3})
+	require.NoError(t, err)
+
+	// The sender can submit again once its bundles are removed.
+	require.NoError(t, b.PostInsertBlock(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)})))
+	_, err = b.addBundle(&bundle.SearcherBundle{Txs: []*types.Transaction{tx}, BlockNumber: 3})
+	require.NoError(t, err)
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"sync"
+
+	"github.com/kaiachain/kaia/accounts/abi/bind/backends"
+	"github.com/kaiachain/kaia/blockchain/state"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/blockchain/vm"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/bundle"
+	"github.com/kaiachain/kaia/kaiax/gov"
+	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/params"
+)
+
+var (
+	_ bundle.BundleModule = (*BundleModule)(nil)
+
+	logger = log.NewModuleLogger(log.KaiaxBundle)
+)
+
+type blockChain interface {
+	backends.BlockChainForCaller
+	ApplyTransaction(config *params.ChainConfig, author *common.Address, statedb *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, cfg *vm.Config) (*types.Receipt, *vm.InternalTxTrace, error)
+}
+
+type miner interface {
+	Pending() (*types.Block, types.Receipts, *state.StateDB)
+}
+
+type InitOpts struct {
+	ChainConfig  *params.ChainConfig
+	BundleConfig *bundle.BundleConfig
+	Chain        blockChain
+	GovModule    gov.GovModule
+	Miner        miner // if nil, bundles are simulated on top of the current block
+}
+
+// pooledBundle is a searcher bundle in the bundle pool. seq is the arrival order.
+type pooledBundle struct {
+	*bundle.SearcherBundle
+	hash    common.Hash
+	seq     uint64
+	senders []common.Address // distinct senders of the txs
+}
+
+type BundleModule struct {
+	InitOpts
+
+	signer types.Signer
+
+	mu      sync.RWMutex
+	bundles map[uint64][]*pooledBundle // target block number -> bundles
+	known   map[common.Hash]struct{}
+	senders map[common.Address]int // sender -> number of pooled bundles including its txs
+	seq     uint64
+}
+
+func NewBundleModule() *BundleModule {
+	return &BundleModule{
+		bundles: make(map[uint64][]*pooledBundle),
+		known:   make(map[common.Hash]struct{}),
+		senders: make(map[common.Address]int),
+	}
+}
+
+func (b *BundleModule) Init(opts *InitOpts) error {
+	if opts == nil || opts.ChainConfig == nil || opts.BundleConfig == nil || opts.Chain == nil || opts.GovModule == nil {
+		return bundle.ErrInitUnexpectedNil
+	}
+
+	b.InitOpts = *opts
+	b.signer = types.LatestSignerForChainID(b.ChainConfig.ChainID)
+	return nil
+}
+
+func (b *BundleModule) IsDisabled() bool {
+	return !b.BundleConfig.Enable
+}
+
+func (b *BundleModule) Start() error {
+	logger.Info("BundleModule started")
+	return nil
+}
+
+func (b *BundleModule) Stop() {
+	logger.Info("BundleModule stopped")
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bundles = make(map[uint64][]*pooledBundle)
+	b.known = make(map[common.Hash]struct{})
+	b.senders = make(map[common.Address]int)
+	numBundlesGauge.Update(0)
+}
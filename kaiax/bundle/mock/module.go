@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kaiachain/kaia/kaiax/bundle (interfaces: BundleModule)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	types "github.com/kaiachain/kaia/blockchain/types"
+	common "github.com/kaiachain/kaia/common"
+	rpc "github.com/kaiachain/kaia/networks/rpc"
+	builder "github.com/kaiachain/kaia/work/builder"
+)
+
+// MockBundleModule is a mock of BundleModule interface.
+type MockBundleModule struct {
+	ctrl     *gomock.Controller
+	recorder *MockBundleModuleMockRecorder
+}
+
+// MockBundleModuleMockRecorder is the mock recorder for MockBundleModule.
+type MockBundleModuleMockRecorder struct {
+	mock *MockBundleModule
+}
+
+// NewMockBundleModule creates a new mock instance.
+func NewMockBundleModule(ctrl *gomock.Controller) *MockBundleModule {
+	mock := &MockBundleModule{ctrl: ctrl}
+	mock.recorder = &MockBundleModuleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBundleModule) EXPECT() *MockBundleModuleMockRecorder {
+	return m.recorder
+}
+
+// APIs mocks base method.
+func (m *MockBundleModule) APIs() []rpc.API {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIs")
+	ret0, _ := ret[0].([]rpc.API)
+	return ret0
+}
+
+// APIs indicates an expected call of APIs.
+func (mr *MockBundleModuleMockRecorder) APIs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIs", reflect.TypeOf((*MockBundleModule)(nil).APIs))
+}
+
+// ExtractTxBundles mocks base method.
+func (m *MockBundleModule) ExtractTxBundles(arg0 []*types.Transaction, arg1 []*builder.Bundle) []*builder.Bundle {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtractTxBundles", arg0, arg1)
+	ret0, _ := ret[0].([]*builder.Bundle)
+	return ret0
+}
+
+// ExtractTxBundles indicates an expected call of ExtractTxBundles.
+func (mr *MockBundleModuleMockRecorder) ExtractTxBundles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtractTxBundles", reflect.TypeOf((*MockBundleModule)(nil).ExtractTxBundles), arg0, arg1)
+}
+
+// FilterTxs mocks base method.
+func (m *MockBundleModule) FilterTxs(arg0 map[common.Address]types.Transactions) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "FilterTxs", arg0)
+}
+
+// FilterTxs indicates an expected call of FilterTxs.
+func (mr *MockBundleModuleMockRecorder) FilterTxs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterTxs", reflect.TypeOf((*MockBundleModule)(nil).FilterTxs), arg0)
+}
+
+// GetMaxBundleTxsInPending mocks base method.
+func (m *MockBundleModule) GetMaxBundleTxsInPending() uint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxBundleTxsInPending")
+	ret0, _ := ret[0].(uint)
+	return ret0
+}
+
+// GetMaxBundleTxsInPending indicates an expected call of GetMaxBundleTxsInPending.
+func (mr *MockBundleModuleMockRecorder) GetMaxBundleTxsInPending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxBundleTxsInPending", reflect.TypeOf((*MockBundleModule)(nil).GetMaxBundleTxsInPending))
+}
+
+// GetMaxBundleTxsInQueue mocks base method.
+func (m *MockBundleModule) GetMaxBundleTxsInQueue() uint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxBundleTxsInQueue")
+	ret0, _ := ret[0].(uint)
+	return ret0
+}
+
+// GetMaxBundleTxsInQueue indicates an expected call of GetMaxBundleTxsInQueue.
+func (mr *MockBundleModuleMockRecorder) GetMaxBundleTxsInQueue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxBundleTxsInQueue", reflect.TypeOf((*MockBundleModule)(nil).GetMaxBundleTxsInQueue))
+}
+
+// IsBundleTx mocks base method.
+func (m *MockBundleModule) IsBundleTx(arg0 *types.Transaction) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBundleTx", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsBundleTx indicates an expected call of IsBundleTx.
+func (mr *MockBundleModuleMockRecorder) IsBundleTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBundleTx", reflect.TypeOf((*MockBundleModule)(nil).IsBundleTx), arg0)
+}
+
+// PostInsertBlock mocks base method.
+func (m *MockBundleModule) PostInsertBlock(arg0 *types.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInsertBlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostInsertBlock indicates an expected call of PostInsertBlock.
+func (mr *MockBundleModuleMockRecorder) PostInsertBlock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInsertBlock", reflect.TypeOf((*MockBundleModule)(nil).PostInsertBlock), arg0)
+}
+
+// Start mocks base method.
+func (m *MockBundleModule) Start() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockBundleModuleMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockBundleModule)(nil).Start))
+}
+
+// Stop mocks base method.
+func (m *MockBundleModule) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockBundleModuleMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockBundleModule)(nil).Stop))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
@@ -147,3 +148,63 @@ func (s *GaslessAPI) GaslessInfo() *GaslessInfoResult {
 		MaxBundleTxs:  s.b.GetMaxBundleTxsInPending(),
 	}
 }
+
+// GaslessQuoteArgs is the template of the gasless txs to be quoted.
+type GaslessQuoteArgs struct {
+	From     common.Address `json:"from"`
+	Token    common.Address `json:"token"`
+	AmountIn *hexutil.Big   `json:"amountIn"`
+	GasPrice *hexutil.Big   `json:"gasPrice"` // optional, the current base fee by default
+	Deadline *hexutil.Big   `json:"deadline"` // optional, current block time + QuoteDeadlineOffset by default
+	Approve  *bool          `json:"approve"`  // optional, true if the current allowance is insufficient by default
+	Permit   bool           `json:"permit"`   // optional, quote a swap tx carrying a permit signature
+}
+
+type GaslessQuoteResult struct {
+	SwapRouter   common.Address  `json:"swapRouter"`
+	GasPrice     *hexutil.Big    `json:"gasPrice"`
+	ApproveNonce *hexutil.Uint64 `json:"approveNonce,omitempty"`
+	ApproveGas   *hexutil.Uint64 `json:"approveGas,omitempty"`
+	SwapNonce    hexutil.Uint64  `json:"swapNonce"`
+	SwapGas      hexutil.Uint64  `json:"swapGas"`
+	LendAmount   *hexutil.Big    `json:"lendAmount"`
+	RepayAmount  *hexutil.Big    `json:"amountRepay"`
+	MinAmountOut *hexutil.Big    `json:"minAmountOut"`
+	MinAmountIn  *hexutil.Big    `json:"minAmountIn"`
+	Deadline     *hexutil.Big    `json:"deadline"`
+}
+
+// GaslessQuote returns the gas limits, nonces and amounts of the gasless txs that this node accepts,
+// by simulating the gasless bundle on top of the pending block.
+func (s *GaslessAPI) GaslessQuote(ctx context.Context, args GaslessQuoteArgs) (*GaslessQuoteResult, error) {
+	if s.b.IsDisabled() {
+		return nil, errors.New("gasless module is disabled")
+	}
+
+	q, err := s.b.quote(&QuoteArgs{
+		Sender:   args.From,
+		Token:    args.Token,
+		AmountIn: (*big.Int)(args.AmountIn),
+		GasPrice: (*big.Int)(args.GasPrice),
+		Deadline: (*big.Int)(args.Deadline),
+		Approve:  args.Approve,
+		Permit:   args.Permit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GaslessQuoteResult{
+		SwapRouter:   q.SwapRouter,
+		GasPrice:     (*hexutil.Big)(q.GasPrice),
+		ApproveNonce: (*hexutil.Uint64)(q.ApproveNonce),
+		ApproveGas:   (*hexutil.Uint64)(q.ApproveGas),
+		SwapNonce:    hexutil.Uint64(q.SwapNonce),
+		SwapGas:      hexutil.Uint64(q.SwapGas),
+		LendAmount:   (*hexutil.Big)(q.LendAmount),
+		RepayAmount:  (*hexutil.Big)(q.RepayAmount),
+		MinAmountOut: (*hexutil.Big)(q.MinAmountOut),
+		MinAmountIn:  (*hexutil.Big)(q.MinAmountIn),
+		Deadline:     (*hexutil.Big)(q.Deadline),
+	}, nil
+}
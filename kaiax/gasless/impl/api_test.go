@@ -25,6 +25,7 @@ import (
 
 	"github.com/kaiachain/kaia/accounts/abi"
 	"github.com/kaiachain/kaia/accounts/abi/bind/backends"
+	"github.com/kaiachain/kaia/blockchain/state"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/common/hexutil"
@@ -255,3 +256,95 @@ func TestGaslessAPI_isGaslessTx(t *testing.T) {
 		})
 	}
 }
+
+func TestGaslessAPI_GaslessQuote_InvalidArgs(t *testing.T) {
+	dbm := database.NewMemoryDBManager()
+	backend := backends.NewSimulatedBackendWithDatabase(dbm, testAllocStorage(), testChainConfig)
+	nodeKey, _ := crypto.GenerateKey()
+
+	g := NewGaslessModule()
+	require.NoError(t, g.Init(&InitOpts{
+		ChainConfig:   testChainConfig,
+		GaslessConfig: testGaslessConfig,
+		NodeKey:       nodeKey,
+		Chain:         backend.BlockChain(),
+		NodeType:      common.ENDPOINTNODE,
+	}))
+	api := NewGaslessAPI(g)
+
+	var (
+		sender   = common.HexToAddress("0xaaaa")
+		amountIn = (*hexutil.Big)(big.NewInt(10))
+		approve  = true
+	)
+	testcases := map[string]struct {
+		args GaslessQuoteArgs
+		err  string
+	}{
+		"missing amountIn": {
+			GaslessQuoteArgs{From: sender, Token: dummyTokenAddress1},
+			"amountIn must be positive",
+		},
+		"permit with approve": {
+			GaslessQuoteArgs{From: sender, Token: dummyTokenAddress1, AmountIn: amountIn, Approve: &approve, Permit: true},
+			ErrPermitWithApproveTx.Error(),
+		},
+		"token not allowed": {
+			GaslessQuoteArgs{From: sender, Token: common.HexToAddress("0xffff"), AmountIn: amountIn},
+			ErrSwapTxInvalid.Error(),
+		},
+		"gas price lower than base fee": {
+			GaslessQuoteArgs{From: sender, Token: dummyTokenAddress1, AmountIn: amountIn, GasPrice: (*hexutil.Big)(common.Big0)},
+			"lower than the base fee",
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			_, err := api.GaslessQuote(context.Background(), tc.args)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+type testMiner struct {
+	block   *types.Block
+	statedb *state.StateDB
+}
+
+func (m *testMiner) Pending() (*types.Block, types.Receipts, *state.StateDB) {
+	return m.block, nil, m.statedb
+}
+
+func TestGaslessQuote_PendingState(t *testing.T) {
+	dbm := database.NewMemoryDBManager()
+	backend := backends.NewSimulatedBackendWithDatabase(dbm, testAllocStorage(), testChainConfig)
+	nodeKey, _ := crypto.GenerateKey()
+	miner := &testMiner{}
+
+	g := NewGaslessModule()
+	require.NoError(t, g.Init(&InitOpts{
+		ChainConfig:   testChainConfig,
+		GaslessConfig: testGaslessConfig,
+		NodeKey:       nodeKey,
+		Chain:         backend.BlockChain(),
+		NodeType:      common.ENDPOINTNODE,
+		Miner:         miner,
+	}))
+
+	// The current block is used until the pending block is prepared.
+	header, _, err := g.pendingState()
+	require.NoError(t, err)
+	assert.Equal(t, backend.BlockChain().CurrentBlock().Hash(), header.Hash())
+
+	current := backend.BlockChain().CurrentBlock()
+	statedb, err := backend.BlockChain().StateAt(current.Root())
+	require.NoError(t, err)
+	miner.block = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1), BaseFee: big.NewInt(50e9)})
+	miner.statedb = statedb
+
+	header, pendingState, err := g.pendingState()
+	require.NoError(t, err)
+	assert.Equal(t, miner.block.Hash(), header.Hash())
+	assert.Equal(t, statedb, pendingState)
+}
@@ -42,6 +42,9 @@ func (g *GaslessModule) ExtractTxBundles(txs []*types.Transaction, prevBundles [
 			if approveTxs[addr] != nil {
 				bundleTxs = append(bundleTxs, builder.NewTxOrGenFromTx(approveTxs[addr]))
 			}
+			if permitTx := g.GetPermitTxGenerator(tx); permitTx != nil {
+				bundleTxs = append(bundleTxs, permitTx)
+			}
 			bundleTxs = append(bundleTxs, builder.NewTxOrGenFromTx(tx))
 
 			b := builder.NewBundle(
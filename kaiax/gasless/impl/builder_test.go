@@ -59,6 +59,8 @@ func TestExtractTxBundles(t *testing.T) {
 
 	S3 := makeSwapTx(t, nil, 0, SwapArgs{Token: common.HexToAddress("0xabcd"), AmountIn: big.NewInt(10), MinAmountOut: big.NewInt(100), AmountRepay: big.NewInt(1021000), Deadline: big.NewInt(300)})
 
+	P6 := makePermitSwapTx(t, nil, 0, SwapArgs{Token: common.HexToAddress("0xabcd"), AmountIn: big.NewInt(10), MinAmountOut: big.NewInt(100), AmountRepay: big.NewInt(1121000), Deadline: big.NewInt(300)}, PermitArgs{Value: big.NewInt(10), Deadline: big.NewInt(300), V: 27})
+
 	T4 := makeTx(t, nil, 0, common.HexToAddress("0xAAAA"), big.NewInt(0), 1000000, big.NewInt(1), nil)
 	T5 := makeTx(t, nil, 0, common.HexToAddress("0xAAAA"), big.NewInt(0), 1000000, big.NewInt(1), nil)
 
@@ -167,6 +169,16 @@ func TestExtractTxBundles(t *testing.T) {
 				},
 			},
 		},
+		{
+			[]*types.Transaction{T4, P6, T5},
+			nil,
+			[]*builder.Bundle{
+				{
+					BundleTxs:    builder.NewTxOrGenList(g.GetLendTxGenerator(nil, P6), g.GetPermitTxGenerator(P6), P6),
+					TargetTxHash: T4.Hash(),
+				},
+			},
+		},
 		{
 			[]*types.Transaction{A1, S1, T4, T5},
 			[]*builder.Bundle{
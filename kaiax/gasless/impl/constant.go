@@ -21,4 +21,11 @@ import "math/big"
 var (
 	GaslessSwapRouterName = "GaslessSwapRouter"
 	GaslessLenderMinBal   = big.NewInt(1e18)
+
+	// PermitTxGasLimit is the gas limit of the permit tx generated for a swap tx with a permit signature.
+	// The proposer is repaid as if the whole gas limit is used.
+	PermitTxGasLimit = uint64(100000)
+
+	// QuoteDeadlineOffset is the default swap deadline of a quote, in seconds from the current block time.
+	QuoteDeadlineOffset = int64(300)
 )
@@ -38,13 +38,27 @@ const (
 	// import { erc20Abi } from 'viem';
 	erc20AbiJson = `[{"type":"event","name":"Approval","inputs":[{"indexed":true,"name":"owner","type":"address"},{"indexed":true,"name":"spender","type":"address"},{"indexed":false,"name":"value","type":"uint256"}]},{"type":"event","name":"Transfer","inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}]},{"type":"function","name":"allowance","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"type":"uint256"}]},{"type":"function","name":"approve","stateMutability":"nonpayable","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"type":"bool"}]},{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"}],"outputs":[{"type":"uint256"}]},{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"type":"uint8"}]},{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"type":"string"}]},{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"type":"string"}]},{"type":"function","name":"totalSupply","stateMutability":"view","inputs":[],"outputs":[{"type":"uint256"}]},{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"recipient","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"type":"bool"}]},{"type":"function","name":"transferFrom","stateMutability":"nonpayable","inputs":[{"name":"sender","type":"address"},{"name":"recipient","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"type":"bool"}]}]`
 	// function swapForGas(address token, uint256 amountIn, uint256 minAmountOut, uint256 amountRepay, uint256 deadline) external
-	routerAbiJson = `[{"inputs":[{"internalType":"address","name":"token","type":"address"},{"internalType":"uint256","name":"amountIn","type":"uint256"},{"internalType":"uint256","name":"minAmountOut","type":"uint256"},{"internalType":"uint256","name":"amountRepay","type":"uint256"},{"internalType":"uint256","name":"deadline","type":"uint256"}],"name":"swapForGas","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+	// function permit(address owner, address spender, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) external
+	// function nonces(address owner) external view returns (uint256)
+	// function DOMAIN_SEPARATOR() external view returns (bytes32)
+	erc2612AbiJson = `[{"type":"function","name":"permit","stateMutability":"nonpayable","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"},{"name":"value","type":"uint256"},{"name":"deadline","type":"uint256"},{"name":"v","type":"uint8"},{"name":"r","type":"bytes32"},{"name":"s","type":"bytes32"}],"outputs":[]},{"type":"function","name":"nonces","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"type":"uint256"}]},{"type":"function","name":"DOMAIN_SEPARATOR","stateMutability":"view","inputs":[],"outputs":[{"type":"bytes32"}]}]`
+	routerAbiJson  = `[{"inputs":[{"internalType":"address","name":"token","type":"address"},{"internalType":"uint256","name":"amountIn","type":"uint256"},{"internalType":"uint256","name":"minAmountOut","type":"uint256"},{"internalType":"uint256","name":"amountRepay","type":"uint256"},{"internalType":"uint256","name":"deadline","type":"uint256"}],"name":"swapForGas","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
 )
 
 var (
 	erc20BalanceOfFunc = mustParseAbi(erc20AbiJson, "balanceOf")
 	erc20ApproveFunc   = mustParseAbi(erc20AbiJson, "approve")
 	routerSwapFunc     = mustParseAbi(routerAbiJson, "swapForGas")
+
+	erc2612PermitFunc          = mustParseAbi(erc2612AbiJson, "permit")
+	erc2612NoncesFunc          = mustParseAbi(erc2612AbiJson, "nonces")
+	erc2612DomainSeparatorFunc = mustParseAbi(erc2612AbiJson, "DOMAIN_SEPARATOR")
+
+	// The permit signature appended to swapForGas arguments has the same layout as
+	// the trailing arguments of permit: (value, deadline, v, r, s).
+	permitSigArgs = erc2612PermitFunc.Inputs[2:]
+
+	permitTypeHash = crypto.Keccak256Hash([]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
 )
 
 var _ gasless.GaslessModule = (*GaslessModule)(nil)
@@ -64,6 +78,16 @@ type SwapArgs struct {
 	MinAmountOut *big.Int
 	AmountRepay  *big.Int
 	Deadline     *big.Int
+	Permit       *PermitArgs // nil if the swap relies on a prior approval
+}
+
+// PermitArgs is an EIP-2612 permit signature of the swap sender for the SwapRouter.
+type PermitArgs struct {
+	Value    *big.Int
+	Deadline *big.Int
+	V        uint8
+	R        [32]byte
+	S        [32]byte
 }
 
 // IsApproveTx checks following conditions:
@@ -87,7 +111,7 @@ func (g *GaslessModule) isApproveTx(args *ApproveArgs) bool {
 
 // IsSwapTx checks following conditions:
 // S1. tx.to is a whitelisted SwapRouter contract.
-// S2. tx.data is `swapForGas(token, amountIn, minAmountOut, amountRepay)`.
+// S2. tx.data is `swapForGas(token, amountIn, minAmountOut, amountRepay)`, optionally followed by a permit signature.
 // S3. token is a whitelisted ERC20 token.
 func (g *GaslessModule) IsSwapTx(tx *types.Transaction) bool {
 	args, ok := decodeSwapTx(tx, g.signer)
@@ -164,6 +188,10 @@ func decodeSwapTx(tx *types.Transaction, signer types.Signer) (args *SwapArgs, o
 	if !ok {
 		return nil, false
 	}
+	permit, ok := decodePermitSig(tx)
+	if !ok {
+		return nil, false
+	}
 	from, err := types.Sender(signer, tx)
 	if err != nil {
 		return nil, false
@@ -176,6 +204,49 @@ func decodeSwapTx(tx *types.Transaction, signer types.Signer) (args *SwapArgs, o
 		MinAmountOut: minAmountOut,
 		AmountRepay:  amountRepay,
 		Deadline:     deadline,
+		Permit:       permit,
+	}, true
+}
+
+// decodePermitSig decodes the permit signature appended to the swapForGas arguments.
+// It returns nil if there is no permit signature, and false if the signature is malformed.
+// The caller must ensure that tx is a swapForGas call.
+func decodePermitSig(tx *types.Transaction) (*PermitArgs, bool) {
+	swapArgsEnd := 4 + len(routerSwapFunc.Inputs)*32
+	if len(tx.Data()) != swapArgsEnd+len(permitSigArgs)*32 {
+		return nil, true
+	}
+
+	inputs := make(map[string]interface{})
+	if err := permitSigArgs.UnpackIntoMap(inputs, tx.Data()[swapArgsEnd:]); err != nil {
+		return nil, false
+	}
+	value, ok := inputs["value"].(*big.Int)
+	if !ok {
+		return nil, false
+	}
+	deadline, ok := inputs["deadline"].(*big.Int)
+	if !ok {
+		return nil, false
+	}
+	v, ok := inputs["v"].(uint8)
+	if !ok {
+		return nil, false
+	}
+	r, ok := inputs["r"].([32]byte)
+	if !ok {
+		return nil, false
+	}
+	s, ok := inputs["s"].([32]byte)
+	if !ok {
+		return nil, false
+	}
+	return &PermitArgs{
+		Value:    value,
+		Deadline: deadline,
+		V:        v,
+		R:        r,
+		S:        s,
 	}, true
 }
 
@@ -200,6 +271,8 @@ func decodeFunctionCall(tx *types.Transaction, method abi.Method) (common.Addres
 // SP2. ApproveTx.amount >= SwapTx.amountIn
 // SP3. ApproveTx.nonce+1 == SwapTx.nonce and Gasless transactions are head for nonce
 // SP4. SwapTx.amountRepay = RepayAmount(ApproveTx, SwapTx)
+// PP1. ApproveTx == nil if SwapTx has a permit signature
+// PP2. Permit.value >= SwapTx.amountIn
 func (g *GaslessModule) IsExecutable(approveTxOrNil, swapTx *types.Transaction) bool {
 	err := g.VerifyExecutable(approveTxOrNil, swapTx)
 	if err != nil {
@@ -221,6 +294,18 @@ func (g *GaslessModule) VerifyExecutable(approveTxOrNil, swapTx *types.Transacti
 		return ErrSwapTxInvalid
 	}
 
+	// Conditions involving permit
+	if swapArgs.Permit != nil {
+		// PP1.
+		if approveTxOrNil != nil {
+			return ErrPermitWithApproveTx
+		}
+		// PP2.
+		if swapArgs.Permit.Value.Cmp(swapArgs.AmountIn) < 0 {
+			return fmt.Errorf("%w: permit amount %s, required amount %s", ErrInsufficientPermitAmount, swapArgs.Permit.Value.String(), swapArgs.AmountIn.String())
+		}
+	}
+
 	// Conditions involving ApproveTx
 	if approveTxOrNil != nil {
 		// Ax.
@@ -312,6 +397,54 @@ func (g *GaslessModule) GetLendTxGenerator(approveTxOrNil, swapTx *types.Transac
 	return builder.NewTxOrGenFromGen(gen, bundleHash)
 }
 
+// GetPermitTxGenerator creates a transaction submitting the permit signature of the swap tx, with following properties:
+// PT1. PermitTx.type = 0x7802 (TxTypeEthereumDynamicFee)
+// PT2. PermitTx.from = proposer
+// PT3. PermitTx.to = SwapTx.token
+// PT4. PermitTx.data = `permit(SwapTx.from, SwapTx.to, permit.value, permit.deadline, permit.v, permit.r, permit.s)`
+// It returns nil if the swap tx does not have a permit signature.
+func (g *GaslessModule) GetPermitTxGenerator(swapTx *types.Transaction) *builder.TxOrGen {
+	swapArgs, ok := decodeSwapTx(swapTx, g.signer)
+	if !ok || swapArgs.Permit == nil {
+		return nil
+	}
+	permit := swapArgs.Permit
+	bundleHash := crypto.Keccak256Hash(swapTx.Hash().Bytes(), erc2612PermitFunc.ID)
+
+	gen := func(nonce uint64) (*types.Transaction, error) {
+		var (
+			chainId = g.InitOpts.ChainConfig.ChainID
+			signer  = types.LatestSignerForChainID(chainId)
+			key     = g.InitOpts.NodeKey
+		)
+
+		input, err := erc2612PermitFunc.Inputs.Pack(swapArgs.Sender, swapArgs.Router, permit.Value, permit.Deadline, permit.V, permit.R, permit.S)
+		if err != nil {
+			return nil, err
+		}
+
+		tx, err := types.NewTransactionWithMap(types.TxTypeEthereumDynamicFee, map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyNonce:      nonce,
+			types.TxValueKeyTo:         &swapArgs.Token,
+			types.TxValueKeyAmount:     common.Big0,
+			types.TxValueKeyData:       append(common.CopyBytes(erc2612PermitFunc.ID), input...),
+			types.TxValueKeyGasLimit:   PermitTxGasLimit,
+			types.TxValueKeyGasFeeCap:  swapTx.GasFeeCap(),
+			types.TxValueKeyGasTipCap:  swapTx.GasTipCap(),
+			types.TxValueKeyAccessList: types.AccessList{},
+			types.TxValueKeyChainID:    chainId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = tx.Sign(signer, key)
+		return tx, err
+	}
+
+	return builder.NewTxOrGenFromGen(gen, bundleHash)
+}
+
 func (g *GaslessModule) updateAddresses(header *types.Header) error {
 	g.gaslessInfoMu.Lock()
 	defer g.gaslessInfoMu.Unlock()
@@ -362,10 +495,45 @@ func repayAmount(approveTxOrNil, swapTx *types.Transaction) *big.Int {
 	// R1 = LendTx.Fee() = SwapTx.GasPrice() * TxGas
 	r1 := new(big.Int).Mul(swapTx.GasPrice(), new(big.Int).SetUint64(params.TxGas))
 
-	// RepayAmount = R1 + R2 + R3
+	// R4 = PermitTx.Fee() = SwapTx.GasPrice() * PermitTxGasLimit if SwapTx has a permit signature
+	if permit, ok := decodePermitSig(swapTx); ok && permit != nil {
+		r1.Add(r1, new(big.Int).Mul(swapTx.GasPrice(), new(big.Int).SetUint64(PermitTxGasLimit)))
+	}
+
+	// RepayAmount = R1 + R2 + R3 + R4
 	return new(big.Int).Add(r1, lendAmount(approveTxOrNil, swapTx))
 }
 
+// permitDigest returns the EIP-712 digest signed by the owner for an EIP-2612 permit.
+func permitDigest(domainSeparator common.Hash, owner, spender common.Address, value, nonce, deadline *big.Int) common.Hash {
+	structHash := crypto.Keccak256Hash(
+		permitTypeHash.Bytes(),
+		common.LeftPadBytes(owner.Bytes(), 32),
+		common.LeftPadBytes(spender.Bytes(), 32),
+		common.BigToHash(value).Bytes(),
+		common.BigToHash(nonce).Bytes(),
+		common.BigToHash(deadline).Bytes(),
+	)
+	return crypto.Keccak256Hash([]byte("\x19\x01"), domainSeparator.Bytes(), structHash.Bytes())
+}
+
+// recoverPermitSigner returns the address that signed the permit digest.
+func recoverPermitSigner(digest common.Hash, permit *PermitArgs) (common.Address, error) {
+	if permit.V != 27 && permit.V != 28 {
+		return common.Address{}, fmt.Errorf("invalid permit signature v: %d", permit.V)
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig[:32], permit.R[:])
+	copy(sig[32:64], permit.S[:])
+	sig[64] = permit.V - 27
+
+	pub, err := crypto.SigToPub(digest.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
 func getGaslessInfo(bc backends.BlockChainForCaller, header *types.Header) (common.Address, []common.Address, error) {
 	statedb, err := bc.StateAt(header.Root)
 	if err != nil {
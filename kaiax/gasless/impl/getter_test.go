@@ -17,6 +17,7 @@
 package impl
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
 
@@ -120,6 +121,14 @@ func TestIsSwapTx(t *testing.T) {
 			makeSwapTx(t, privkey, 0, SwapArgs{Token: common.HexToAddress("0xffff"), AmountIn: big.NewInt(10), MinAmountOut: big.NewInt(100), AmountRepay: big.NewInt(2021000), Deadline: big.NewInt(300)}),
 			false,
 		},
+		"correct with permit": {
+			makePermitSwapTx(t, privkey, 0, SwapArgs{Token: common.HexToAddress("0xabcd"), AmountIn: big.NewInt(10), MinAmountOut: big.NewInt(100), AmountRepay: big.NewInt(1121000), Deadline: big.NewInt(300)}, PermitArgs{Value: big.NewInt(10), Deadline: big.NewInt(300), V: 27}),
+			true,
+		},
+		"malformed permit": {
+			makeTx(t, privkey, 0, common.HexToAddress("0x1234"), big.NewInt(0), 1000000, big.NewInt(1), append(common.CopyBytes(correct.Data()), bytes.Repeat([]byte{0xff}, 160)...)),
+			false,
+		},
 	}
 
 	for name, tc := range testcases {
@@ -193,6 +202,26 @@ func TestIsExecutable(t *testing.T) {
 			makeSwapTx(t, privkey, 0, SwapArgs{Token: common.HexToAddress("0xabcd"), AmountIn: big.NewInt(10), MinAmountOut: big.NewInt(100), AmountRepay: big.NewInt(1), Deadline: big.NewInt(300)}),
 			false,
 		},
+		"correct single swap tx with permit": {
+			nil,
+			makePermitSwapTx(t, privkey, 0, SwapArgs{Token: common.HexToAddress("0xabcd"), AmountIn: big.NewInt(10), MinAmountOut: big.NewInt(100), AmountRepay: big.NewInt(1121000), Deadline: big.NewInt(300)}, PermitArgs{Value: big.NewInt(10), Deadline: big.NewInt(300), V: 27}),
+			true,
+		},
+		"single swap tx with permit without permit fee": {
+			nil,
+			makePermitSwapTx(t, privkey, 0, SwapArgs{Token: common.HexToAddress("0xabcd"), AmountIn: big.NewInt(10), MinAmountOut: big.NewInt(100), AmountRepay: big.NewInt(1021000), Deadline: big.NewInt(300)}, PermitArgs{Value: big.NewInt(10), Deadline: big.NewInt(300), V: 27}),
+			false,
+		},
+		"single swap tx with insufficient permit amount": {
+			nil,
+			makePermitSwapTx(t, privkey, 0, SwapArgs{Token: common.HexToAddress("0xabcd"), AmountIn: big.NewInt(10), MinAmountOut: big.NewInt(100), AmountRepay: big.NewInt(1121000), Deadline: big.NewInt(300)}, PermitArgs{Value: big.NewInt(9), Deadline: big.NewInt(300), V: 27}),
+			false,
+		},
+		"swap tx with permit following approve tx": {
+			makeApproveTx(t, privkey, 0, ApproveArgs{Spender: common.HexToAddress("0x1234"), Amount: abi.MaxUint256}),
+			makePermitSwapTx(t, privkey, 1, SwapArgs{Token: common.HexToAddress("0xabcd"), AmountIn: big.NewInt(10), MinAmountOut: big.NewInt(100), AmountRepay: big.NewInt(2121000), Deadline: big.NewInt(300)}, PermitArgs{Value: big.NewInt(10), Deadline: big.NewInt(300), V: 27}),
+			false,
+		},
 	}
 
 	for name, tc := range testcases {
@@ -283,3 +312,79 @@ func TestTxGeneratorHashUniqueness(t *testing.T) {
 		hashSet[generator.Id] = struct{}{}
 	}
 }
+
+func TestGetPermitTxGenerator(t *testing.T) {
+	g := NewGaslessModule()
+	db := database.NewMemoryDBManager()
+	alloc := testAllocStorage()
+	backend := backends.NewSimulatedBackendWithDatabase(db, alloc, testChainConfig)
+	nodekey, _ := crypto.GenerateKey()
+	err := g.Init(&InitOpts{
+		ChainConfig:   testChainConfig,
+		GaslessConfig: testGaslessConfig,
+		NodeKey:       nodekey,
+		Chain:         backend.BlockChain(),
+		NodeType:      common.ENDPOINTNODE,
+	})
+	require.NoError(t, err)
+
+	privkey, _ := crypto.GenerateKey()
+	swapArgs := SwapArgs{Token: common.HexToAddress("0xabcd"), AmountIn: big.NewInt(10), MinAmountOut: big.NewInt(100), AmountRepay: big.NewInt(1121000), Deadline: big.NewInt(300)}
+	permit := PermitArgs{Value: big.NewInt(10), Deadline: big.NewInt(300), V: 28, R: common.HexToHash("0x1111"), S: common.HexToHash("0x2222")}
+
+	// No permit tx for a swap tx without permit signature.
+	require.Nil(t, g.GetPermitTxGenerator(makeSwapTx(t, privkey, 0, swapArgs)))
+
+	swapTx := makePermitSwapTx(t, privkey, 0, swapArgs, permit)
+	generator := g.GetPermitTxGenerator(swapTx)
+	require.NotNil(t, generator)
+	assert.NotEqual(t, g.GetLendTxGenerator(nil, swapTx).Id, generator.Id)
+
+	tx, err := generator.GetTx(0)
+	require.NoError(t, err)
+	assert.Equal(t, swapArgs.Token, *tx.To())
+	assert.Equal(t, PermitTxGasLimit, tx.Gas())
+	from, err := types.Sender(types.LatestSignerForChainID(testChainConfig.ChainID), tx)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(nodekey.PublicKey), from)
+
+	inputs := make(map[string]interface{})
+	require.Equal(t, erc2612PermitFunc.ID, tx.Data()[:4])
+	require.NoError(t, erc2612PermitFunc.Inputs.UnpackIntoMap(inputs, tx.Data()[4:]))
+	assert.Equal(t, crypto.PubkeyToAddress(privkey.PublicKey), inputs["owner"])
+	assert.Equal(t, common.HexToAddress("0x1234"), inputs["spender"])
+	assert.Equal(t, permit.Value, inputs["value"])
+	assert.Equal(t, permit.Deadline, inputs["deadline"])
+	assert.Equal(t, permit.V, inputs["v"])
+	assert.Equal(t, permit.R, inputs["r"])
+	assert.Equal(t, permit.S, inputs["s"])
+}
+
+func TestRecoverPermitSigner(t *testing.T) {
+	var (
+		privkey, _      = crypto.GenerateKey()
+		owner           = crypto.PubkeyToAddress(privkey.PublicKey)
+		domainSeparator = common.HexToHash("0xdead")
+		digest          = permitDigest(domainSeparator, owner, common.HexToAddress("0x1234"), big.NewInt(10), big.NewInt(0), big.NewInt(300))
+	)
+	sig, err := crypto.Sign(digest.Bytes(), privkey)
+	require.NoError(t, err)
+
+	permit := &PermitArgs{Value: big.NewInt(10), Deadline: big.NewInt(300), V: sig[64] + 27}
+	copy(permit.R[:], sig[:32])
+	copy(permit.S[:], sig[32:64])
+
+	signer, err := recoverPermitSigner(digest, permit)
+	require.NoError(t, err)
+	assert.Equal(t, owner, signer)
+
+	// A different nonce gives a different signer.
+	otherDigest := permitDigest(domainSeparator, owner, common.HexToAddress("0x1234"), big.NewInt(10), big.NewInt(1), big.NewInt(300))
+	signer, err = recoverPermitSigner(otherDigest, permit)
+	require.NoError(t, err)
+	assert.NotEqual(t, owner, signer)
+
+	permit.V = 0
+	_, err = recoverPermitSigner(digest, permit)
+	assert.Error(t, err)
+}
@@ -183,6 +183,17 @@ func makeSwapTx(t *testing.T, privKey *ecdsa.PrivateKey, nonce uint64, swapArgs
 	return swapTx
 }
 
+// makePermitSwapTx makes a swap tx followed by a permit signature of the given values.
+func makePermitSwapTx(t *testing.T, privKey *ecdsa.PrivateKey, nonce uint64, swapArgs SwapArgs, permit PermitArgs) *types.Transaction {
+	swapTx := makeSwapTx(t, privKey, nonce, swapArgs)
+
+	sig, err := permitSigArgs.Pack(permit.Value, permit.Deadline, permit.V, permit.R, permit.S)
+	require.NoError(t, err)
+	data := append(common.CopyBytes(swapTx.Data()), sig...)
+
+	return makeTx(t, privKey, nonce, *swapTx.To(), big.NewInt(0), 1000000, big.NewInt(1), data)
+}
+
 func flattenPoolTxs(structured map[common.Address]types.Transactions) map[common.Hash]bool {
 	flattened := map[common.Hash]bool{}
 	for _, txs := range structured {
@@ -41,12 +41,17 @@ var (
 	_ kaiax.TxBundlingModule = (*GaslessModule)(nil)
 )
 
+type miner interface {
+	Pending() (*types.Block, types.Receipts, *state.StateDB)
+}
+
 type InitOpts struct {
 	ChainConfig   *params.ChainConfig
 	GaslessConfig *gasless.GaslessConfig
 	NodeKey       *ecdsa.PrivateKey
 	Chain         backends.BlockChainForCaller
 	NodeType      common.ConnType // if CN, minimum balance required to enable gasless module
+	Miner         miner           // if nil, quotes are simulated on top of the current block
 }
 
 type GaslessModule struct {
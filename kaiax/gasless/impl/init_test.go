@@ -46,6 +46,7 @@ func TestInitSuccessAndFailure(t *testing.T) {
 				key,
 				backend.BlockChain(),
 				common.ENDPOINTNODE,
+				nil,
 			},
 			false,
 			nil,
@@ -62,6 +63,7 @@ func TestInitSuccessAndFailure(t *testing.T) {
 				key,
 				backend.BlockChain(),
 				common.ENDPOINTNODE,
+				nil,
 			},
 			true,
 			ErrInitUnexpectedNil,
@@ -73,6 +75,7 @@ func TestInitSuccessAndFailure(t *testing.T) {
 				nil,
 				backend.BlockChain(),
 				common.ENDPOINTNODE,
+				nil,
 			},
 			true,
 			ErrInitUnexpectedNil,
@@ -84,6 +87,7 @@ func TestInitSuccessAndFailure(t *testing.T) {
 				key,
 				nil,
 				common.ENDPOINTNODE,
+				nil,
 			},
 			true,
 			ErrInitUnexpectedNil,
@@ -98,6 +102,7 @@ func TestInitSuccessAndFailure(t *testing.T) {
 				key,
 				backend.BlockChain(),
 				common.ENDPOINTNODE,
+				nil,
 			},
 			true,
 			nil,
@@ -112,6 +117,7 @@ func TestInitSuccessAndFailure(t *testing.T) {
 				key,
 				backend.BlockChain(),
 				common.CONSENSUSNODE,
+				nil,
 			},
 			true,
 			nil,
@@ -123,6 +129,7 @@ func TestInitSuccessAndFailure(t *testing.T) {
 				key,
 				backends.NewSimulatedBackendWithDatabase(database.NewMemoryDBManager(), nil, testChainConfig).BlockChain(),
 				common.ENDPOINTNODE,
+				nil,
 			},
 			false,
 			nil,
@@ -158,6 +165,7 @@ func TestInitGSRAndAllowedTokens(t *testing.T) {
 				key,
 				backend.BlockChain(),
 				common.ENDPOINTNODE,
+				nil,
 			},
 			dummyGSRAddress,
 			[]common.Address{dummyTokenAddress1, dummyTokenAddress2, dummyTokenAddress3},
@@ -172,6 +180,7 @@ func TestInitGSRAndAllowedTokens(t *testing.T) {
 				key,
 				backend.BlockChain(),
 				common.ENDPOINTNODE,
+				nil,
 			},
 			dummyGSRAddress,
 			[]common.Address{},
@@ -186,6 +195,7 @@ func TestInitGSRAndAllowedTokens(t *testing.T) {
 				key,
 				backend.BlockChain(),
 				common.ENDPOINTNODE,
+				nil,
 			},
 			dummyGSRAddress,
 			[]common.Address{dummyTokenAddress1, dummyTokenAddress2},
@@ -200,6 +210,7 @@ func TestInitGSRAndAllowedTokens(t *testing.T) {
 				key,
 				backend.BlockChain(),
 				common.ENDPOINTNODE,
+				nil,
 			},
 			dummyGSRAddress,
 			[]common.Address{},
@@ -211,6 +222,7 @@ func TestInitGSRAndAllowedTokens(t *testing.T) {
 				key,
 				backends.NewSimulatedBackendWithDatabase(database.NewMemoryDBManager(), nil, testChainConfig).BlockChain(),
 				common.ENDPOINTNODE,
+				nil,
 			},
 			common.Address{},
 			nil,
@@ -0,0 +1,309 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kaiachain/kaia"
+	"github.com/kaiachain/kaia/accounts/abi"
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/state"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/blockchain/vm"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/contracts/contracts/system_contracts/kip247"
+	"github.com/kaiachain/kaia/contracts/contracts/testing/sc_erc20"
+	"github.com/kaiachain/kaia/crypto"
+)
+
+// quoteGasCap is the maximum gas limit of a quoted approve or swap tx.
+const quoteGasCap = uint64(3000000)
+
+// quoteCoinbase is the block coinbase during the simulation. The next proposer is unknown, so an address
+// without account is used to cover the account creation when the swap router pays the proposer.
+var quoteCoinbase = common.BytesToAddress(crypto.Keccak256([]byte("kaiax/gasless/quote")))
+
+// QuoteArgs is the template of the gasless txs to be quoted.
+type QuoteArgs struct {
+	Sender   common.Address
+	Token    common.Address
+	AmountIn *big.Int
+	GasPrice *big.Int // if nil, the current base fee
+	Deadline *big.Int // if nil, current block time + QuoteDeadlineOffset
+	Approve  *bool    // if nil, an approve tx is quoted only if the current allowance is insufficient
+	Permit   bool     // quote a swap tx carrying a permit signature instead of an approve tx
+}
+
+// Quote is the values of gasless txs that the node will accept.
+type Quote struct {
+	SwapRouter   common.Address
+	GasPrice     *big.Int
+	ApproveNonce *uint64 // nil if no approve tx is needed
+	ApproveGas   *uint64 // nil if no approve tx is needed
+	SwapNonce    uint64
+	SwapGas      uint64
+	LendAmount   *big.Int
+	RepayAmount  *big.Int
+	MinAmountOut *big.Int
+	MinAmountIn  *big.Int
+	Deadline     *big.Int
+}
+
+// quote simulates the gasless bundle [LendTx, ApproveTx?, PermitTx?, SwapTx] on top of the pending block
+// and returns the gas limits and the amounts with which the txs pass IsExecutable and GetCheckBalance.
+func (g *GaslessModule) quote(args *QuoteArgs) (*Quote, error) {
+	if args.AmountIn == nil || args.AmountIn.Sign() <= 0 {
+		return nil, errors.New("amountIn must be positive")
+	}
+	if args.Permit && args.Approve != nil && *args.Approve {
+		return nil, ErrPermitWithApproveTx
+	}
+
+	g.gaslessInfoMu.RLock()
+	swapRouter, allowed := g.swapRouter, g.allowedTokens[args.Token]
+	g.gaslessInfoMu.RUnlock()
+	if swapRouter == (common.Address{}) {
+		return nil, ErrGSRNotInstalled
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: token %s is not allowed", ErrSwapTxInvalid, args.Token.Hex())
+	}
+
+	header, statedb, err := g.pendingState()
+	if err != nil {
+		return nil, err
+	}
+
+	baseFee := header.BaseFee
+	if baseFee == nil {
+		baseFee = new(big.Int).SetUint64(g.ChainConfig.UnitPrice)
+	}
+	gasPrice := args.GasPrice
+	if gasPrice == nil {
+		gasPrice = baseFee
+	} else if gasPrice.Cmp(baseFee) < 0 {
+		return nil, fmt.Errorf("gasPrice %s is lower than the base fee %s", gasPrice.String(), baseFee.String())
+	}
+	deadline := args.Deadline
+	if deadline == nil {
+		deadline = new(big.Int).Add(header.Time, big.NewInt(QuoteDeadlineOffset))
+	}
+
+	bc := &stateCaller{g: g, header: header, statedb: statedb}
+	needApprove := false
+	if args.Approve != nil {
+		needApprove = *args.Approve
+	} else if !args.Permit {
+		token, err := sc_erc20.NewERC20Caller(args.Token, bc)
+		if err != nil {
+			return nil, err
+		}
+		allowance, err := token.Allowance(nil, args.Sender, swapRouter)
+		if err != nil {
+			return nil, err
+		}
+		needApprove = allowance.Cmp(args.AmountIn) < 0
+	}
+
+	q := &Quote{
+		SwapRouter: swapRouter,
+		GasPrice:   gasPrice,
+		SwapNonce:  statedb.GetNonce(args.Sender),
+		Deadline:   deadline,
+	}
+
+	// The permit tx is submitted by the proposer, so it is simulated with an approve from the sender
+	// which has the same effect on the allowance.
+	var approveTx *types.Transaction
+	if needApprove || args.Permit {
+		approveData, err := packCall(erc20ApproveFunc, swapRouter, abi.MaxUint256)
+		if err != nil {
+			return nil, err
+		}
+		approveGas, err := g.estimateGas(header, statedb, args.Sender, args.Token, approveData)
+		if err != nil {
+			return nil, fmt.Errorf("approve tx fails: %w", err)
+		}
+		if res, err := g.applyMessage(header, statedb, args.Sender, args.Token, approveData, approveGas); err != nil {
+			return nil, err
+		} else if res.Failed() {
+			return nil, fmt.Errorf("approve tx fails: %w", res.Unwrap())
+		}
+		if needApprove {
+			approveNonce := q.SwapNonce
+			approveTx = types.NewTransaction(approveNonce, args.Token, common.Big0, approveGas, gasPrice, approveData)
+			q.ApproveNonce, q.ApproveGas = &approveNonce, &approveGas
+			q.SwapNonce++
+		}
+	}
+
+	router, err := kip247.NewGaslessSwapRouterCaller(swapRouter, bc)
+	if err != nil {
+		return nil, err
+	}
+
+	// The repay amount depends on the swap gas, which in turn depends on the calldata carrying the repay amount.
+	// Start from zero and repeat until the gas limit covers the calldata.
+	swapTx := types.NewTransaction(q.SwapNonce, swapRouter, common.Big0, 0, gasPrice, nil)
+	for i := 0; ; i++ {
+		repay := common.Big0
+		if swapTx.Gas() > 0 {
+			repay = repayAmount(approveTx, swapTx)
+			minAmountIn, err := router.GetAmountIn(nil, args.Token, repay)
+			if err != nil {
+				return nil, err
+			}
+			if args.AmountIn.Cmp(minAmountIn) < 0 {
+				return nil, fmt.Errorf("insufficient amountIn: have=%s, want=%s", args.AmountIn.String(), minAmountIn.String())
+			}
+		}
+		swapData, err := packSwapCall(args, repay, deadline)
+		if err != nil {
+			return nil, err
+		}
+		swapGas, err := g.estimateGas(header, statedb, args.Sender, swapRouter, swapData)
+		if err != nil {
+			return nil, fmt.Errorf("swap tx fails: %w", err)
+		}
+		if swapGas <= swapTx.Gas() {
+			break
+		}
+		if i == 3 {
+			return nil, errors.New("swap gas does not converge")
+		}
+		swapTx = types.NewTransaction(q.SwapNonce, swapRouter, common.Big0, swapGas, gasPrice, swapData)
+	}
+
+	q.SwapGas = swapTx.Gas()
+	q.LendAmount = lendAmount(approveTx, swapTx)
+	q.RepayAmount = repayAmount(approveTx, swapTx)
+	q.MinAmountOut = q.RepayAmount
+	q.MinAmountIn, err = router.GetAmountIn(nil, args.Token, q.MinAmountOut)
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// pendingState returns the header and the state of the pending block, where the quoted txs will be executed.
+// Until the miner prepares the pending block, the current block is used.
+func (g *GaslessModule) pendingState() (*types.Header, *state.StateDB, error) {
+	if g.Miner != nil {
+		if block, _, statedb := g.Miner.Pending(); block != nil && statedb != nil {
+			return block.Header(), statedb, nil
+		}
+	}
+	header := g.Chain.CurrentBlock().Header()
+	statedb, err := g.Chain.StateAt(header.Root)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, statedb, nil
+}
+
+// stateCaller is a bind.ContractCaller reading the state being quoted instead of the current block.
+type stateCaller struct {
+	g       *GaslessModule
+	header  *types.Header
+	statedb *state.StateDB
+}
+
+func (c *stateCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.statedb.GetCode(contract), nil
+}
+
+func (c *stateCaller) CallContract(ctx context.Context, call kaia.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if call.To == nil {
+		return nil, errors.New("contract creation is not supported")
+	}
+	res, err := c.g.applyMessage(c.header, c.statedb.Copy(), call.From, *call.To, call.Data, quoteGasCap)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Revert()) > 0 {
+		return nil, blockchain.NewRevertError(res)
+	}
+	return res.Return(), res.Unwrap()
+}
+
+// packSwapCall encodes swapForGas with minAmountOut = amountRepay. If permit is requested, a placeholder
+// permit signature is appended so that the calldata cost and the permit fee are accounted.
+func packSwapCall(args *QuoteArgs, repay, deadline *big.Int) ([]byte, error) {
+	data, err := packCall(routerSwapFunc, args.Token, args.AmountIn, repay, repay, deadline)
+	if err != nil {
+		return nil, err
+	}
+	if args.Permit {
+		var placeholder [32]byte
+		for i := range placeholder {
+			placeholder[i] = 0xff
+		}
+		sig, err := permitSigArgs.Pack(args.AmountIn, deadline, uint8(28), placeholder, placeholder)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, sig...)
+	}
+	return data, nil
+}
+
+func packCall(method abi.Method, args ...interface{}) ([]byte, error) {
+	input, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return nil, err
+	}
+	return append(common.CopyBytes(method.ID), input...), nil
+}
+
+// estimateGas returns the lowest gas limit with which the call succeeds on top of statedb.
+func (g *GaslessModule) estimateGas(header *types.Header, statedb *state.StateDB, from, to common.Address, data []byte) (uint64, error) {
+	executable := func(gas uint64) (bool, *blockchain.ExecutionResult, error) {
+		res, err := g.applyMessage(header, statedb.Copy(), from, to, data, gas)
+		if err != nil {
+			if errors.Is(err, blockchain.ErrIntrinsicGas) {
+				return true, nil, nil // Special case, raise gas limit
+			}
+			return true, nil, err // Bail out
+		}
+		return res.Failed(), res, nil
+	}
+	gas, err := blockchain.DoEstimateGas(context.Background(), quoteGasCap, 0, nil, nil, nil, executable)
+	return uint64(gas), err
+}
+
+// applyMessage executes the call on statedb with zero gas price, because the sender of a gasless tx
+// does not have the balance before the lend tx.
+func (g *GaslessModule) applyMessage(header *types.Header, statedb *state.StateDB, from, to common.Address, data []byte, gas uint64) (*blockchain.ExecutionResult, error) {
+	rules := g.ChainConfig.Rules(header.Number)
+	intrinsicGas, err := types.IntrinsicGas(data, nil, nil, false, rules)
+	if err != nil {
+		return nil, err
+	}
+	msg := types.NewMessage(from, &to, statedb.GetNonce(from), common.Big0, gas, common.Big0, nil, nil, nil, data,
+		false, intrinsicGas, nil, nil, nil, nil, nil)
+
+	txContext := blockchain.NewEVMTxContext(msg, header, g.ChainConfig)
+	txContext.GasPrice = common.Big0
+	blockContext := blockchain.NewEVMBlockContext(header, g.Chain, &quoteCoinbase)
+	evm := vm.NewEVM(blockContext, txContext, statedb, g.ChainConfig, &vm.Config{})
+
+	return blockchain.ApplyMessage(evm, msg)
+}
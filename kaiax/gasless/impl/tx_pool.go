@@ -17,12 +17,18 @@
 package impl
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"time"
 
+	"github.com/kaiachain/kaia"
+	"github.com/kaiachain/kaia/accounts/abi"
+	"github.com/kaiachain/kaia/accounts/abi/bind"
 	"github.com/kaiachain/kaia/accounts/abi/bind/backends"
+	"github.com/kaiachain/kaia/blockchain"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/contracts/contracts/system_contracts/kip247"
@@ -59,6 +65,11 @@ func (g *GaslessModule) IsModuleTx(tx *types.Transaction) bool {
 	return g.IsApproveTx(tx) || g.IsSwapTx(tx)
 }
 
+// TxJournalOrigin makes the txpool keep gasless transactions in the local journal.
+func (g *GaslessModule) TxJournalOrigin() blockchain.TxOrigin {
+	return blockchain.TxOriginGasless
+}
+
 func (g *GaslessModule) GetCheckBalance() func(tx *types.Transaction) error {
 	return func(tx *types.Transaction) error {
 		if approveArgs, ok := decodeApproveTx(tx, g.signer); ok {
@@ -101,9 +112,10 @@ func (g *GaslessModule) checkBalanceForApprove(approveArgs *ApproveArgs) error {
 
 // tx.minAmountOut >= tx.amountRepay
 // tx.amountIn >= gsr.getAmountIn(minAmountOut)
-// tx.token.approval(sender, router) >= tx.amountIn
+// tx.token.approval(sender, router) >= tx.amountIn, or tx.permit is signed by sender
 // tx.token.balanceOf(sender) >= tx.amountIn
 // tx.deadline >= currentTimestamp
+// tx.permit.deadline >= currentTimestamp
 func (g *GaslessModule) checkBalanceForSwap(swapArgs *SwapArgs, swapNonce uint64) error {
 	token := swapArgs.Token
 	bc := backends.NewBlockchainContractBackend(g.Chain, nil, nil)
@@ -151,7 +163,12 @@ func (g *GaslessModule) checkBalanceForSwap(swapArgs *SwapArgs, swapNonce uint64
 		// If SwapTx.nonce is the sender's next nonce, then there is no room for ApproveTx proceeding SwapTx.
 		senderNonce := g.getCurrentStateNonce(swapArgs.Sender)
 		noApproveTxPreceeds := swapNonce == senderNonce
-		if noApproveTxPreceeds {
+		if swapArgs.Permit != nil {
+			// The permit tx sets the allowance before the swap.
+			if err := checkPermitSigner(bc, swapArgs, swapRouter); err != nil {
+				return err
+			}
+		} else if noApproveTxPreceeds {
 			// tx.token.allowance(sender, router) >= tx.amountIn
 			approval, err := tokenContract.Allowance(nil, swapArgs.Sender, swapRouter)
 			if err != nil {
@@ -178,9 +195,57 @@ func (g *GaslessModule) checkBalanceForSwap(swapArgs *SwapArgs, swapNonce uint64
 		return fmt.Errorf("insufficient deadline: deadline=%s, want=%s", deadline.String(), g.Chain.CurrentBlock().Time().String())
 	}
 
+	// tx.permit.deadline >= currentTimestamp
+	if swapArgs.Permit != nil && swapArgs.Permit.Deadline.Cmp(g.Chain.CurrentBlock().Time()) < 0 {
+		return fmt.Errorf("insufficient permit deadline: deadline=%s, want=%s", swapArgs.Permit.Deadline.String(), g.Chain.CurrentBlock().Time().String())
+	}
+
 	return nil
 }
 
+// checkPermitSigner checks that the permit signature of the swap tx is signed by the sender
+// against the current permit nonce of the token.
+func checkPermitSigner(bc bind.ContractCaller, swapArgs *SwapArgs, swapRouter common.Address) error {
+	out, err := callView(bc, swapArgs.Token, erc2612DomainSeparatorFunc)
+	if err != nil {
+		return fmt.Errorf("token does not support permit: %w", err)
+	}
+	domainSeparator, ok := out[0].([32]byte)
+	if !ok {
+		return errors.New("token does not support permit: invalid DOMAIN_SEPARATOR")
+	}
+	out, err = callView(bc, swapArgs.Token, erc2612NoncesFunc, swapArgs.Sender)
+	if err != nil {
+		return fmt.Errorf("token does not support permit: %w", err)
+	}
+	nonce, ok := out[0].(*big.Int)
+	if !ok {
+		return errors.New("token does not support permit: invalid nonces")
+	}
+
+	digest := permitDigest(domainSeparator, swapArgs.Sender, swapRouter, swapArgs.Permit.Value, nonce, swapArgs.Permit.Deadline)
+	signer, err := recoverPermitSigner(digest, swapArgs.Permit)
+	if err != nil {
+		return err
+	}
+	if signer != swapArgs.Sender {
+		return fmt.Errorf("invalid permit signer: signer=%s, want=%s", signer.Hex(), swapArgs.Sender.Hex())
+	}
+	return nil
+}
+
+func callView(bc bind.ContractCaller, to common.Address, method abi.Method, args ...interface{}) ([]interface{}, error) {
+	input, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := bc.CallContract(context.Background(), kaia.CallMsg{To: &to, Data: append(common.CopyBytes(method.ID), input...)}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return method.Outputs.Unpack(ret)
+}
+
 // Check promotion condition and enforce pending pool flow control.
 func (g *GaslessModule) IsReady(txs map[uint64]*types.Transaction, next uint64, ready types.Transactions) bool {
 	g.knownTxsMu.Lock()
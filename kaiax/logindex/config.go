@@ -0,0 +1,59 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package logindex
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+// DefaultMinRange is the default minimum number of blocks in a log query to use the index.
+// Shorter ranges are served by the bloombits and the block headers quickly enough.
+const DefaultMinRange = 1024
+
+var (
+	EnableFlag = &cli.BoolFlag{
+		Name:     "logindex.enable",
+		Usage:    "index the logs by address and topics to serve log queries over large block ranges",
+		Value:    false,
+		Aliases:  []string{"kaiax.module.logindex.enable"},
+		Category: "KAIAX",
+	}
+	MinRangeFlag = &cli.Uint64Flag{
+		Name:     "logindex.min-range",
+		Usage:    "minimum number of blocks in a log query to use the log index",
+		Value:    DefaultMinRange,
+		Aliases:  []string{"kaiax.module.logindex.min-range"},
+		Category: "KAIAX",
+	}
+)
+
+type LogIndexConfig struct {
+	Enable   bool
+	MinRange uint64
+}
+
+func DefaultLogIndexConfig() *LogIndexConfig {
+	return &LogIndexConfig{
+		Enable:   false,
+		MinRange: DefaultMinRange,
+	}
+}
+
+func SetLogIndexConfig(ctx *cli.Context, cfg *LogIndexConfig) {
+	cfg.Enable = ctx.Bool(EnableFlag.Name)
+	cfg.MinRange = ctx.Uint64(MinRangeFlag.Name)
+}
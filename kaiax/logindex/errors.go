@@ -0,0 +1,28 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package logindex
+
+import "errors"
+
+var (
+	ErrInitUnexpectedNil = errors.New("unexpected nil during module init")
+	ErrNoBlock           = errors.New("block not found")
+	ErrNoReceipts        = errors.New("receipts not found")
+	ErrInvalidBlockRange = errors.New("invalid block number range")
+	ErrNoCriteria        = errors.New("no address or topic to look up")
+	ErrNotIndexed        = errors.New("block number range not indexed by log index")
+)
@@ -0,0 +1,110 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/logindex"
+)
+
+// PostInsertBlock will try to advance the log index by one block.
+// A failure does not abort the block insertion; the catchup thread retries the block later.
+func (l *LogIndexModule) PostInsertBlock(block *types.Block) error {
+	if err := l.indexNextBlock(block.NumberU64()); err != nil {
+		logger.Error("Failed to index logs", "num", block.NumberU64(), "err", err)
+	}
+	return nil
+}
+
+func (l *LogIndexModule) RewindTo(newBlock *types.Block) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The blocks above the new head will be re-indexed by the next PostInsertBlock or catchup.
+	newLast := newBlock.NumberU64()
+	if l.last > newLast {
+		l.last = newLast
+		WriteLogIndexLastNumber(l.ChainKv, newLast)
+	}
+	if l.start > newLast+1 {
+		l.start = newLast + 1
+		WriteLogIndexStartNumber(l.ChainKv, newLast+1)
+	}
+}
+
+func (l *LogIndexModule) RewindDelete(hash common.Hash, num uint64) {
+	DeleteLogIndexBlock(l.ChainKv, num)
+}
+
+// indexNextBlock stores the logs of the given block if it is right after the last indexed block.
+// Otherwise, it does nothing because the block is either already indexed or will be indexed by the catchup thread.
+func (l *LogIndexModule) indexNextBlock(num uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.last+1 != num {
+		return nil
+	}
+	logs, err := l.canonicalLogs(num)
+	if err != nil {
+		return err
+	}
+	WriteLogIndexBlock(l.ChainKv, num, logs)
+	WriteLogIndexLastNumber(l.ChainKv, num)
+	l.last = num
+	return nil
+}
+
+// indexPrevBlock stores the logs of the given block if it is right before the lowest indexed block.
+func (l *LogIndexModule) indexPrevBlock(num uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.start != num+1 {
+		return nil
+	}
+	logs, err := l.canonicalLogs(num)
+	if err != nil {
+		return err
+	}
+	WriteLogIndexBlock(l.ChainKv, num, logs)
+	WriteLogIndexStartNumber(l.ChainKv, num)
+	l.start = num
+	return nil
+}
+
+// canonicalLogs returns the logs of the canonical block at the given number, in the block order.
+// The block is looked up by number because PostInsertBlock is also called for side chain blocks.
+func (l *LogIndexModule) canonicalLogs(num uint64) ([]*types.Log, error) {
+	block := l.Chain.GetBlockByNumber(num)
+	if block == nil {
+		return nil, logindex.ErrNoBlock
+	}
+	if len(block.Transactions()) == 0 {
+		return nil, nil
+	}
+	receipts := l.Chain.GetReceiptsByBlockHash(block.Hash())
+	if len(receipts) != len(block.Transactions()) {
+		return nil, logindex.ErrNoReceipts
+	}
+	var logs []*types.Log
+	for _, receipt := range receipts {
+		logs = append(logs, receipt.Logs...)
+	}
+	return logs, nil
+}
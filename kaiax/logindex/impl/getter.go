@@ -0,0 +1,169 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"context"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/logindex"
+)
+
+func (l *LogIndexModule) IndexedRange() (uint64, uint64) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.start, l.last
+}
+
+func (l *LogIndexModule) MinRange() uint64 {
+	return l.LogIndexConfig.MinRange
+}
+
+func (l *LogIndexModule) FindLogs(ctx context.Context, begin, end uint64, addresses []common.Address, topics [][]common.Hash, limit int) ([]logindex.LogPosition, error) {
+	if begin > end {
+		return nil, logindex.ErrInvalidBlockRange
+	}
+	// The lock is not held while reading the entries, so that a long query does not block the indexing.
+	// The entries in the indexed range do not change unless the chain is rewound.
+	if start, last := l.IndexedRange(); begin < start || end > last {
+		return nil, logindex.ErrNotIndexed
+	}
+
+	// Each criterion is a set of terms, and a log matches the criterion if it is indexed under any of the terms.
+	var criteria [][][]byte
+	if len(addresses) > 0 {
+		terms := make([][]byte, len(addresses))
+		for i, addr := range addresses {
+			terms[i] = addressTerm(addr)
+		}
+		criteria = append(criteria, terms)
+	}
+	for pos, sub := range topics {
+		if len(sub) == 0 {
+			continue // empty rule set == wildcard
+		}
+		terms := make([][]byte, len(sub))
+		for i, topic := range sub {
+			terms[i] = topicTerm(pos, topic)
+		}
+		criteria = append(criteria, terms)
+	}
+	if len(criteria) == 0 {
+		return nil, logindex.ErrNoCriteria
+	}
+
+	// Each criterion is a union of term iterators, and the matches are the positions
+	// found by every criterion. The entries are read as the positions are matched, so
+	// that the query stops reading at the limit or the deadline.
+	var iters []*unionIterator
+	defer func() {
+		for _, it := range iters {
+			it.Release()
+		}
+	}()
+	for _, terms := range criteria {
+		it := &unionIterator{}
+		for _, term := range terms {
+			it.iters = append(it.iters, newLogPositionIterator(l.ChainKv, term, begin, end))
+		}
+		iters = append(iters, it)
+		if !it.Next() {
+			return nil, nil
+		}
+	}
+
+	var positions []logindex.LogPosition
+	for {
+		if err := ctx.Err(); err != nil {
+			return positions, err
+		}
+		// Move every criterion to the greatest of their positions.
+		target, matched := iters[0].pos, true
+		for _, it := range iters[1:] {
+			if positionLess(target, it.pos) {
+				target = it.pos
+			}
+		}
+		for _, it := range iters {
+			for positionLess(it.pos, target) {
+				if !it.Next() {
+					return positions, nil
+				}
+			}
+			matched = matched && it.pos == target
+		}
+		if !matched {
+			continue
+		}
+		positions = append(positions, target)
+		if limit > 0 && len(positions) >= limit {
+			return positions, nil
+		}
+		for _, it := range iters {
+			if !it.Next() {
+				return positions, nil
+			}
+		}
+	}
+}
+
+// unionIterator iterates over the positions found by any of its iterators, in
+// ascending order and without duplicates.
+type unionIterator struct {
+	iters   []*logPositionIterator
+	heads   []bool // whether the iterator at the same index has a current position
+	started bool
+	pos     logindex.LogPosition
+}
+
+// Next moves to the next position. It returns false once every iterator is exhausted.
+func (u *unionIterator) Next() bool {
+	if !u.started {
+		u.started = true
+		u.heads = make([]bool, len(u.iters))
+		for i, it := range u.iters {
+			u.heads[i] = it.Next()
+		}
+	} else {
+		// Advance the iterators standing at the current position.
+		for i, it := range u.iters {
+			if u.heads[i] && it.pos == u.pos {
+				u.heads[i] = it.Next()
+			}
+		}
+	}
+	found := false
+	for i, it := range u.iters {
+		if u.heads[i] && (!found || positionLess(it.pos, u.pos)) {
+			u.pos, found = it.pos, true
+		}
+	}
+	return found
+}
+
+func (u *unionIterator) Release() {
+	for _, it := range u.iters {
+		it.Release()
+	}
+}
+
+func positionLess(a, b logindex.LogPosition) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber < b.BlockNumber
+	}
+	return a.Index < b.Index
+}
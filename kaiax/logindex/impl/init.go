@@ -0,0 +1,177 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/logindex"
+	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/storage/database"
+)
+
+var (
+	_ logindex.LogIndexModule = &LogIndexModule{}
+
+	logger = log.NewModuleLogger(log.KaiaxLogIndex)
+
+	backfillBatch       = uint64(1024)   // Number of past blocks indexed before checking the head block again.
+	backfillLogInterval = uint64(102400) // Periodic log in catchup().
+)
+
+type blockChain interface {
+	CurrentBlock() *types.Block
+	GetBlockByNumber(number uint64) *types.Block
+	GetReceiptsByBlockHash(blockHash common.Hash) types.Receipts
+}
+
+type InitOpts struct {
+	ChainKv        database.Database
+	LogIndexConfig *logindex.LogIndexConfig
+	Chain          blockChain
+}
+
+type LogIndexModule struct {
+	InitOpts
+
+	// The indexed block range [start, last].
+	// The index grows upward by PostInsertBlock and catchup, and downward by the backfill in catchup.
+	mu    sync.RWMutex
+	start uint64
+	last  uint64
+
+	// Stops long-running tasks.
+	quitCh chan struct{}  // stops the catchup goroutine
+	wg     sync.WaitGroup // wait for the goroutine to finish
+}
+
+func NewLogIndexModule() *LogIndexModule {
+	return &LogIndexModule{
+		quitCh: make(chan struct{}, 1),
+	}
+}
+
+func (l *LogIndexModule) Init(opts *InitOpts) error {
+	if opts == nil || opts.ChainKv == nil || opts.LogIndexConfig == nil || opts.Chain == nil {
+		return logindex.ErrInitUnexpectedNil
+	}
+	l.InitOpts = *opts
+	return nil
+}
+
+func (l *LogIndexModule) IsDisabled() bool {
+	return !l.LogIndexConfig.Enable
+}
+
+func (l *LogIndexModule) Start() error {
+	l.loadIndexedRange()
+
+	l.quitCh = make(chan struct{}, 1)
+	l.wg.Add(1)
+	go l.catchup()
+	return nil
+}
+
+func (l *LogIndexModule) Stop() {
+	l.quitCh <- struct{}{}
+	l.wg.Wait()
+}
+
+// loadIndexedRange loads the indexed block range from the database.
+// If the index has never been initialized, it starts indexing from the next block
+// and leaves the past blocks to the backfill.
+func (l *LogIndexModule) loadIndexedRange() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var (
+		start = ReadLogIndexStartNumber(l.ChainKv)
+		last  = ReadLogIndexLastNumber(l.ChainKv)
+	)
+	if start == nil || last == nil {
+		head := l.Chain.CurrentBlock().NumberU64()
+		WriteLogIndexStartNumber(l.ChainKv, head+1)
+		WriteLogIndexLastNumber(l.ChainKv, head)
+		l.start, l.last = head+1, head
+		logger.Info("Initialized log index", "start", head+1)
+		return
+	}
+	l.start, l.last = *start, *last
+}
+
+// catchup is a long-running goroutine that indexes the blocks until the current head block,
+// and then backfills the past blocks down to the genesis block.
+func (l *LogIndexModule) catchup() {
+	defer l.wg.Done()
+
+	backfill := true
+	for {
+		head := l.Chain.CurrentBlock().NumberU64()
+		start, last := l.IndexedRange()
+
+		// A gap detected. Index up to the current block.
+		if last < head {
+			for num := last + 1; num <= head; num++ {
+				select {
+				case <-l.quitCh:
+					return
+				default:
+				}
+				if err := l.indexNextBlock(num); err != nil {
+					logger.Error("Log index catchup failed", "num", num, "err", err)
+					return
+				}
+			}
+			// Because current head may have increased while we index, we need to check again.
+			continue
+		}
+
+		// Index the past blocks in batches so that the head is not left behind for long.
+		if backfill && start > 0 {
+			for i := uint64(0); i < backfillBatch && start > 0; i++ {
+				select {
+				case <-l.quitCh:
+					return
+				default:
+				}
+				if err := l.indexPrevBlock(start - 1); err != nil {
+					// The past receipts may not be available, e.g. after a snapshot sync.
+					logger.Warn("Log index backfill stopped", "num", start-1, "err", err)
+					backfill = false
+					break
+				}
+				start--
+				if start%backfillLogInterval == 0 {
+					logger.Info("Backfilling log index", "start", start, "last", last)
+				}
+			}
+			continue
+		}
+
+		// No gap detected. Sleep a while and check again just in case.
+		// If PostInsertBlock() is filling in the gap, this loop would do nothing but waiting.
+		timer := time.NewTimer(time.Second)
+		select {
+		case <-l.quitCh:
+			return
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/logindex"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	addr1  = common.HexToAddress("0xa1")
+	addr2  = common.HexToAddress("0xa2")
+	topicA = common.HexToHash("0xaa")
+	topicB = common.HexToHash("0xbb")
+	topicC = common.HexToHash("0xcc")
+)
+
+func init() {
+	blockchain.InitDeriveSha(&params.ChainConfig{DeriveShaImpl: 0}) // DeriveSha is irrelevant for this test. Set any value.
+}
+
+// testChain is a canonical chain whose block n has the given logs in a single transaction.
+type testChain struct {
+	mu       sync.Mutex
+	blocks   []*types.Block
+	receipts map[common.Hash]types.Receipts
+}
+
+func newTestChain(logsByBlock ...[]*types.Log) *testChain {
+	c := &testChain{receipts: make(map[common.Hash]types.Receipts)}
+	for _, logs := range logsByBlock {
+		c.addBlock(logs)
+	}
+	return c
+}
+
+func (c *testChain) addBlock(logs []*types.Log) *types.Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header := &types.Header{Number: big.NewInt(int64(len(c.blocks)))}
+	if len(logs) == 0 {
+		block := types.NewBlockWithHeader(header)
+		c.blocks = append(c.blocks, block)
+		return block
+	}
+	tx := types.NewTransaction(uint64(len(c.blocks)), common.Address{}, big.NewInt(0), 21000, big.NewInt(0), nil)
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: logs}
+	block := types.NewBlock(header, []*types.Transaction{tx}, []*types.Receipt{receipt})
+	c.blocks = append(c.blocks, block)
+	c.receipts[block.Hash()] = types.Receipts{receipt}
+	return block
+}
+
+func (c *testChain) CurrentBlock() *types.Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.blocks[len(c.blocks)-1]
+}
+
+func (c *testChain) GetBlockByNumber(number uint64) *types.Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number >= uint64(len(c.blocks)) {
+		return nil
+	}
+	return c.blocks[number]
+}
+
+func (c *testChain) GetReceiptsByBlockHash(hash common.Hash) types.Receipts {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.receipts[hash]
+}
+
+func makeLog(addr common.Address, topics ...common.Hash) *types.Log {
+	return &types.Log{Address: addr, Topics: topics}
+}
+
+func makeTestLogIndexModule(t *testing.T, chain *testChain) *LogIndexModule {
+	l := NewLogIndexModule()
+	require.Nil(t, l.Init(&InitOpts{
+		ChainKv:        database.NewMemDB(),
+		LogIndexConfig: &logindex.LogIndexConfig{Enable: true, MinRange: 1},
+		Chain:          chain,
+	}))
+	return l
+}
+
+func TestFindLogs(t *testing.T) {
+	var (
+		chain = newTestChain(
+			nil, // genesis
+			[]*types.Log{makeLog(addr1, topicA, topicB), makeLog(addr2, topicB)},
+			[]*types.Log{makeLog(addr2, topicA), makeLog(addr1, topicC, topicA)},
+			nil,
+			[]*types.Log{makeLog(addr1, topicA, topicC)},
+		)
+		l = makeTestLogIndexModule(t, chain)
+	)
+	l.start, l.last = 1, 0
+	for num := uint64(1); num <= 4; num++ {
+		require.Nil(t, l.indexNextBlock(num))
+	}
+
+	pos := func(num uint64, index uint) logindex.LogPosition {
+		return logindex.LogPosition{BlockNumber: num, Index: index}
+	}
+	testcases := []struct {
+		desc       string
+		begin, end uint64
+		addresses  []common.Address
+		topics     [][]common.Hash
+		expected   []logindex.LogPosition
+	}{
+		{"address", 1, 4, []common.Address{addr1}, nil, []logindex.LogPosition{pos(1, 0), pos(2, 1), pos(4, 0)}},
+		{"addresses", 1, 2, []common.Address{addr1, addr2}, nil, []logindex.LogPosition{pos(1, 0), pos(1, 1), pos(2, 0), pos(2, 1)}},
+		{"sub range", 2, 3, []common.Address{addr1}, nil, []logindex.LogPosition{pos(2, 1)}},
+		{"topic position", 1, 4, nil, [][]common.Hash{{topicA}}, []logindex.LogPosition{pos(1, 0), pos(2, 0), pos(4, 0)}},
+		{"wildcard topic", 1, 4, nil, [][]common.Hash{{}, {topicA}}, []logindex.LogPosition{pos(2, 1)}},
+		{"address and topic", 1, 4, []common.Address{addr1}, [][]common.Hash{{topicA}, {topicB, topicC}}, []logindex.LogPosition{pos(1, 0), pos(4, 0)}},
+		{"in the same block but not the same log", 1, 4, []common.Address{addr2}, [][]common.Hash{{topicA}, {topicC}}, nil},
+		{"no match", 1, 4, []common.Address{common.HexToAddress("0xa3")}, nil, nil},
+	}
+	for _, tc := range testcases {
+		positions, err := l.FindLogs(context.Background(), tc.begin, tc.end, tc.addresses, tc.topics, 0)
+		require.Nil(t, err, tc.desc)
+		assert.Equal(t, tc.expected, positions, tc.desc)
+	}
+
+	_, err := l.FindLogs(context.Background(), 1, 5, []common.Address{addr1}, nil, 0)
+	assert.Equal(t, logindex.ErrNotIndexed, err)
+	_, err = l.FindLogs(context.Background(), 0, 4, []common.Address{addr1}, nil, 0)
+	assert.Equal(t, logindex.ErrNotIndexed, err)
+	_, err = l.FindLogs(context.Background(), 3, 2, []common.Address{addr1}, nil, 0)
+	assert.Equal(t, logindex.ErrInvalidBlockRange, err)
+	_, err = l.FindLogs(context.Background(), 1, 4, nil, [][]common.Hash{{}}, 0)
+	assert.Equal(t, logindex.ErrNoCriteria, err)
+
+	// The query stops at the limit or once the context is done.
+	positions, err := l.FindLogs(context.Background(), 1, 4, []common.Address{addr1, addr2}, nil, 3)
+	require.Nil(t, err)
+	assert.Equal(t, []logindex.LogPosition{pos(1, 0), pos(1, 1), pos(2, 0)}, positions)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = l.FindLogs(ctx, 1, 4, []common.Address{addr1}, nil, 0)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestLogIndexRewind(t *testing.T) {
+	var (
+		chain = newTestChain(
+			nil,
+			[]*types.Log{makeLog(addr1, topicA)},
+			[]*types.Log{makeLog(addr1, topicB)},
+			[]*types.Log{makeLog(addr1, topicA)},
+		)
+		l = makeTestLogIndexModule(t, chain)
+	)
+	l.start, l.last = 2, 1
+
+	// Blocks other than the next one are ignored
+	require.Nil(t, l.PostInsertBlock(chain.GetBlockByNumber(3)))
+	assert.Equal(t, uint64(1), l.last)
+
+	require.Nil(t, l.PostInsertBlock(chain.GetBlockByNumber(2)))
+	require.Nil(t, l.PostInsertBlock(chain.GetBlockByNumber(3)))
+	assert.Equal(t, uint64(3), *ReadLogIndexLastNumber(l.ChainKv))
+
+	// Backfill the past block
+	require.Nil(t, l.indexPrevBlock(1))
+	start, last := l.IndexedRange()
+	assert.Equal(t, uint64(1), start)
+	assert.Equal(t, uint64(3), last)
+	assert.Equal(t, uint64(1), *ReadLogIndexStartNumber(l.ChainKv))
+
+	positions, err := l.FindLogs(context.Background(), 1, 3, nil, [][]common.Hash{{topicA}}, 0)
+	require.Nil(t, err)
+	assert.Equal(t, []logindex.LogPosition{{BlockNumber: 1}, {BlockNumber: 3}}, positions)
+
+	// Rewind to block 1
+	l.RewindTo(chain.GetBlockByNumber(1))
+	l.RewindDelete(common.Hash{}, 3)
+	l.RewindDelete(common.Hash{}, 2)
+	start, last = l.IndexedRange()
+	assert.Equal(t, uint64(1), start)
+	assert.Equal(t, uint64(1), last)
+	assert.Equal(t, uint64(1), *ReadLogIndexLastNumber(l.ChainKv))
+	assert.Empty(t, ReadLogPositions(l.ChainKv, addressTerm(addr1), 2, 3))
+	assert.Len(t, ReadLogPositions(l.ChainKv, addressTerm(addr1), 0, 3), 1)
+
+	// Rewind below the indexed range
+	l.RewindTo(chain.GetBlockByNumber(0))
+	l.RewindDelete(common.Hash{}, 1)
+	start, last = l.IndexedRange()
+	assert.Equal(t, uint64(1), start)
+	assert.Equal(t, uint64(0), last)
+	assert.Empty(t, ReadLogPositions(l.ChainKv, addressTerm(addr1), 0, 3))
+}
+
+func TestLogIndexCatchup(t *testing.T) {
+	var (
+		chain = newTestChain(
+			nil,
+			[]*types.Log{makeLog(addr1, topicA)},
+			[]*types.Log{makeLog(addr2, topicA)},
+		)
+		l = makeTestLogIndexModule(t, chain)
+	)
+
+	// Starts indexing from the next block, and backfills the past blocks.
+	require.Nil(t, l.Start())
+	chain.addBlock([]*types.Log{makeLog(addr1, topicB)})
+	require.Eventually(t, func() bool {
+		start, last := l.IndexedRange()
+		return start == 0 && last == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	l.Stop()
+
+	positions, err := l.FindLogs(context.Background(), 0, 3, []common.Address{addr1}, nil, 0)
+	require.Nil(t, err)
+	assert.Equal(t, []logindex.LogPosition{{BlockNumber: 1}, {BlockNumber: 3}}, positions)
+
+	// The indexed range is restored on restart.
+	require.Nil(t, l.Start())
+	start, last := l.IndexedRange()
+	assert.Equal(t, uint64(0), start)
+	assert.Equal(t, uint64(3), last)
+	l.Stop()
+}
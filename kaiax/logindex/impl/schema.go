@@ -0,0 +1,211 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"encoding/binary"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/logindex"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/storage/database"
+)
+
+var (
+	logIndexStartNumberKey  = []byte("logIndexStartNumber")
+	logIndexLastNumberKey   = []byte("logIndexLastNumber")
+	logIndexEntryPrefix     = []byte("logIndexEntry")
+	logIndexBlockKeysPrefix = []byte("logIndexBlockKeys")
+)
+
+const (
+	addressTermType = byte('a')
+	topicTermType   = byte('t')
+)
+
+// addressTerm is the key prefix of the index entries of an address.
+func addressTerm(addr common.Address) []byte {
+	term := append(common.CopyBytes(logIndexEntryPrefix), addressTermType)
+	return append(term, addr.Bytes()...)
+}
+
+// topicTerm is the key prefix of the index entries of a topic at the given position.
+func topicTerm(pos int, topic common.Hash) []byte {
+	term := append(common.CopyBytes(logIndexEntryPrefix), topicTermType, byte(pos))
+	return append(term, topic.Bytes()...)
+}
+
+// logIndexEntryKey is sorted by the term first so the entries of a term can be iterated by block number.
+// The entry has no value; the log position is encoded in the key.
+func logIndexEntryKey(term []byte, num uint64, index uint) []byte {
+	key := append(common.CopyBytes(term), common.Int64ToByteBigEndian(num)...)
+	return binary.BigEndian.AppendUint32(key, uint32(index))
+}
+
+func logIndexBlockKeysKey(num uint64) []byte {
+	return append(common.CopyBytes(logIndexBlockKeysPrefix), common.Int64ToByteBigEndian(num)...)
+}
+
+func readLogIndexNumber(db database.Database, key []byte) *uint64 {
+	b, err := db.Get(key)
+	if err != nil || len(b) != 8 {
+		return nil
+	}
+	num := binary.BigEndian.Uint64(b)
+	return &num
+}
+
+func writeLogIndexNumber(db database.KeyValueWriter, key []byte, num uint64) {
+	if err := db.Put(key, common.Int64ToByteBigEndian(num)); err != nil {
+		logger.Crit("Failed to write log index number", "key", string(key), "err", err)
+	}
+}
+
+// ReadLogIndexStartNumber returns the lowest block number covered by the log index.
+// Returns nil if the index has never been initialized.
+func ReadLogIndexStartNumber(db database.Database) *uint64 {
+	return readLogIndexNumber(db, logIndexStartNumberKey)
+}
+
+func WriteLogIndexStartNumber(db database.KeyValueWriter, num uint64) {
+	writeLogIndexNumber(db, logIndexStartNumberKey, num)
+}
+
+// ReadLogIndexLastNumber returns the highest block number covered by the log index.
+// Returns nil if the index has never been initialized.
+func ReadLogIndexLastNumber(db database.Database) *uint64 {
+	return readLogIndexNumber(db, logIndexLastNumberKey)
+}
+
+func WriteLogIndexLastNumber(db database.KeyValueWriter, num uint64) {
+	writeLogIndexNumber(db, logIndexLastNumberKey, num)
+}
+
+// WriteLogIndexBlock stores the index entries of the logs at the given block, and
+// the list of the entry keys so that the block can be deleted upon rewind.
+// The logs must be in the block order so that the slice index is the log index.
+// Writing the same block again is harmless because the entries are keyed by their position.
+func WriteLogIndexBlock(db database.Database, num uint64, logs []*types.Log) {
+	batch := db.NewBatch()
+	defer batch.Release()
+
+	var keys [][]byte
+	for i, log := range logs {
+		keys = append(keys, logIndexEntryKey(addressTerm(log.Address), num, uint(i)))
+		for pos, topic := range log.Topics {
+			keys = append(keys, logIndexEntryKey(topicTerm(pos, topic), num, uint(i)))
+		}
+	}
+	for _, key := range keys {
+		if err := batch.Put(key, []byte{}); err != nil {
+			logger.Crit("Failed to write log index entry", "num", num, "err", err)
+		}
+	}
+	if len(keys) > 0 {
+		b, err := rlp.EncodeToBytes(keys)
+		if err != nil {
+			logger.Crit("Failed to serialize log index block keys", "err", err)
+		}
+		if err := batch.Put(logIndexBlockKeysKey(num), b); err != nil {
+			logger.Crit("Failed to write log index block keys", "num", num, "err", err)
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		logger.Crit("Failed to write log index block", "num", num, "err", err)
+	}
+}
+
+// logPositionIterator iterates over the positions of the logs indexed under a term
+// in the block range [begin, end], in ascending order.
+type logPositionIterator struct {
+	it   database.Iterator
+	term []byte
+	end  uint64
+	pos  logindex.LogPosition // current position, valid after Next returned true
+}
+
+func newLogPositionIterator(db database.Database, term []byte, begin, end uint64) *logPositionIterator {
+	return &logPositionIterator{
+		it:   db.NewIterator(term, common.Int64ToByteBigEndian(begin)),
+		term: term,
+		end:  end,
+	}
+}
+
+// Next moves to the next position. It returns false once the range is exhausted.
+func (i *logPositionIterator) Next() bool {
+	for i.it.Next() {
+		key := i.it.Key()
+		if len(key) != len(i.term)+8+4 {
+			continue
+		}
+		num := binary.BigEndian.Uint64(key[len(i.term):])
+		if num > i.end {
+			return false
+		}
+		i.pos = logindex.LogPosition{
+			BlockNumber: num,
+			Index:       uint(binary.BigEndian.Uint32(key[len(i.term)+8:])),
+		}
+		return true
+	}
+	return false
+}
+
+func (i *logPositionIterator) Release() {
+	i.it.Release()
+}
+
+// ReadLogPositions returns the positions of the logs indexed under the given term in the block range [begin, end].
+func ReadLogPositions(db database.Database, term []byte, begin, end uint64) []logindex.LogPosition {
+	it := newLogPositionIterator(db, term, begin, end)
+	defer it.Release()
+
+	var positions []logindex.LogPosition
+	for it.Next() {
+		positions = append(positions, it.pos)
+	}
+	return positions
+}
+
+// DeleteLogIndexBlock deletes all log index entries at the given block.
+func DeleteLogIndexBlock(db database.Database, num uint64) {
+	b, err := db.Get(logIndexBlockKeysKey(num))
+	if err != nil || len(b) == 0 {
+		return
+	}
+	var keys [][]byte
+	if err := rlp.DecodeBytes(b, &keys); err != nil {
+		logger.Crit("Failed to deserialize log index block keys", "err", err)
+	}
+
+	batch := db.NewBatch()
+	defer batch.Release()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			logger.Crit("Failed to delete log index entry", "num", num, "err", err)
+		}
+	}
+	if err := batch.Delete(logIndexBlockKeysKey(num)); err != nil {
+		logger.Crit("Failed to delete log index block keys", "num", num, "err", err)
+	}
+	if err := batch.Write(); err != nil {
+		logger.Crit("Failed to delete log index block", "num", num, "err", err)
+	}
+}
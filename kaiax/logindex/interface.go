@@ -0,0 +1,52 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package logindex
+
+import (
+	"context"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax"
+)
+
+//go:generate mockgen -destination=./mock/module.go -package=mock github.com/kaiachain/kaia/kaiax/logindex LogIndexModule
+type LogIndexModule interface {
+	kaiax.BaseModule
+	kaiax.ExecutionModule
+	kaiax.RewindableModule
+
+	// IndexedRange returns the block number range [start, last] covered by the index.
+	// The range is empty if start > last.
+	IndexedRange() (start, last uint64)
+
+	// MinRange returns the minimum number of blocks in a log query to use the index.
+	MinRange() uint64
+
+	// FindLogs returns the positions of the logs in the block range [begin, end] that match
+	// the addresses and the positional topics, in ascending order.
+	// An empty addresses or topics list is a wildcard, but at least one criterion must be given.
+	// At most limit positions are returned unless limit is 0, and the error of ctx is returned
+	// along with the positions found so far once ctx is done.
+	// Returns ErrNotIndexed if the range is not covered by the index.
+	FindLogs(ctx context.Context, begin, end uint64, addresses []common.Address, topics [][]common.Hash, limit int) ([]LogPosition, error)
+}
+
+// LogPosition locates a log by the block number and its index in the block.
+type LogPosition struct {
+	BlockNumber uint64
+	Index       uint
+}
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kaiachain/kaia/kaiax/logindex (interfaces: LogIndexModule)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	types "github.com/kaiachain/kaia/blockchain/types"
+	common "github.com/kaiachain/kaia/common"
+	logindex "github.com/kaiachain/kaia/kaiax/logindex"
+)
+
+// MockLogIndexModule is a mock of LogIndexModule interface.
+type MockLogIndexModule struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogIndexModuleMockRecorder
+}
+
+// MockLogIndexModuleMockRecorder is the mock recorder for MockLogIndexModule.
+type MockLogIndexModuleMockRecorder struct {
+	mock *MockLogIndexModule
+}
+
+// NewMockLogIndexModule creates a new mock instance.
+func NewMockLogIndexModule(ctrl *gomock.Controller) *MockLogIndexModule {
+	mock := &MockLogIndexModule{ctrl: ctrl}
+	mock.recorder = &MockLogIndexModuleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogIndexModule) EXPECT() *MockLogIndexModuleMockRecorder {
+	return m.recorder
+}
+
+// FindLogs mocks base method.
+func (m *MockLogIndexModule) FindLogs(arg0 context.Context, arg1, arg2 uint64, arg3 []common.Address, arg4 [][]common.Hash, arg5 int) ([]logindex.LogPosition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLogs", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]logindex.LogPosition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLogs indicates an expected call of FindLogs.
+func (mr *MockLogIndexModuleMockRecorder) FindLogs(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLogs", reflect.TypeOf((*MockLogIndexModule)(nil).FindLogs), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// IndexedRange mocks base method.
+func (m *MockLogIndexModule) IndexedRange() (uint64, uint64) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexedRange")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	return ret0, ret1
+}
+
+// IndexedRange indicates an expected call of IndexedRange.
+func (mr *MockLogIndexModuleMockRecorder) IndexedRange() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexedRange", reflect.TypeOf((*MockLogIndexModule)(nil).IndexedRange))
+}
+
+// MinRange mocks base method.
+func (m *MockLogIndexModule) MinRange() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinRange")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// MinRange indicates an expected call of MinRange.
+func (mr *MockLogIndexModuleMockRecorder) MinRange() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinRange", reflect.TypeOf((*MockLogIndexModule)(nil).MinRange))
+}
+
+// PostInsertBlock mocks base method.
+func (m *MockLogIndexModule) PostInsertBlock(arg0 *types.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInsertBlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostInsertBlock indicates an expected call of PostInsertBlock.
+func (mr *MockLogIndexModuleMockRecorder) PostInsertBlock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInsertBlock", reflect.TypeOf((*MockLogIndexModule)(nil).PostInsertBlock), arg0)
+}
+
+// RewindDelete mocks base method.
+func (m *MockLogIndexModule) RewindDelete(arg0 common.Hash, arg1 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RewindDelete", arg0, arg1)
+}
+
+// RewindDelete indicates an expected call of RewindDelete.
+func (mr *MockLogIndexModuleMockRecorder) RewindDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewindDelete", reflect.TypeOf((*MockLogIndexModule)(nil).RewindDelete), arg0, arg1)
+}
+
+// RewindTo mocks base method.
+func (m *MockLogIndexModule) RewindTo(arg0 *types.Block) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RewindTo", arg0)
+}
+
+// RewindTo indicates an expected call of RewindTo.
+func (mr *MockLogIndexModuleMockRecorder) RewindTo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewindTo", reflect.TypeOf((*MockLogIndexModule)(nil).RewindTo), arg0)
+}
+
+// Start mocks base method.
+func (m *MockLogIndexModule) Start() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockLogIndexModuleMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockLogIndexModule)(nil).Start))
+}
+
+// Stop mocks base method.
+func (m *MockLogIndexModule) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockLogIndexModuleMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockLogIndexModule)(nil).Stop))
+}
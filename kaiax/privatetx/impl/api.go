@@ -0,0 +1,74 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+func (p *PrivateTxModule) APIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "kaia",
+			Version:   "1.0",
+			Service:   NewPrivateTxAPI(p),
+			Public:    true,
+		},
+	}
+}
+
+type PrivateTxAPI struct {
+	p *PrivateTxModule
+}
+
+func NewPrivateTxAPI(p *PrivateTxModule) *PrivateTxAPI {
+	return &PrivateTxAPI{p}
+}
+
+// SendPrivateRawTransaction adds the signed tx to the txpool without gossiping it to ENs.
+// The tx is forwarded only towards consensus nodes and dropped if not included by maxBlockNumber.
+// Both Kaia and Ethereum typed tx encodings are accepted.
+func (s *PrivateTxAPI) SendPrivateRawTransaction(ctx context.Context, input hexutil.Bytes, maxBlockNumber hexutil.Uint64) (common.Hash, error) {
+	if len(input) == 0 {
+		return common.Hash{}, errors.New("empty input")
+	}
+	if 0 < input[0] && input[0] < 0x7f {
+		input = append([]byte{byte(types.EthereumTxTypeEnvelope)}, input...)
+	}
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(input, tx); err != nil {
+		return common.Hash{}, err
+	}
+	if err := s.p.addLocal(&privatetx.PrivateTx{Tx: tx, MaxBlockNumber: uint64(maxBlockNumber)}); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// GetPrivateTransactionStatus returns the status of a private tx known to this node.
+// Included and expired txs are forgotten after StatusRetention blocks.
+func (s *PrivateTxAPI) GetPrivateTransactionStatus(hash common.Hash) (*privatetx.TxStatus, error) {
+	return s.p.getStatus(hash)
+}
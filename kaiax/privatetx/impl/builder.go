@@ -0,0 +1,72 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
+	"github.com/kaiachain/kaia/work/builder"
+)
+
+func (p *PrivateTxModule) ExtractTxBundles(txs []*types.Transaction, prevBundles []*builder.Bundle) []*builder.Bundle {
+	return nil
+}
+
+func (p *PrivateTxModule) IsBundleTx(tx *types.Transaction) bool {
+	return false
+}
+
+func (p *PrivateTxModule) GetMaxBundleTxsInPending() uint {
+	return 0
+}
+
+func (p *PrivateTxModule) GetMaxBundleTxsInQueue() uint {
+	return 0
+}
+
+// FilterTxs removes the private txs that cannot be included in the block being built, along with
+// the following txs of the same sender. The txpool drops them on its next reset, which may come
+// after the worker has started the block.
+func (p *PrivateTxModule) FilterTxs(txs map[common.Address]types.Transactions) {
+	curBlock := p.Chain.CurrentBlock()
+	if curBlock == nil {
+		return
+	}
+	miningBlock := curBlock.NumberU64() + 1
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if len(p.txs) == 0 {
+		return
+	}
+	for addr, list := range txs {
+		for i, tx := range list {
+			ptx, ok := p.txs[tx.Hash()]
+			if !ok || ptx.status != privatetx.StatusPending || ptx.MaxBlockNumber >= miningBlock {
+				continue
+			}
+			if i == 0 {
+				delete(txs, addr)
+			} else {
+				txs[addr] = list[:i]
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterTxs(t *testing.T) {
+	env := newTestEnv(t)
+	var (
+		sender1 = common.HexToAddress("0x1")
+		sender2 = common.HexToAddress("0x2")
+		txs     = []*types.Transaction{newTestTx(0), newTestTx(1), newTestTx(2)}
+	)
+	env.pool.EXPECT().AddLocal(gomock.Any()).Return(nil).Times(2)
+	require.Nil(t, env.module.addLocal(&privatetx.PrivateTx{Tx: txs[0], MaxBlockNumber: 102}))
+	require.Nil(t, env.module.addLocal(&privatetx.PrivateTx{Tx: txs[1], MaxBlockNumber: 101}))
+
+	// Building block 101: every tx can be included.
+	pending := map[common.Address]types.Transactions{sender1: txs, sender2: {newTestTx(3)}}
+	env.module.FilterTxs(pending)
+	assert.Len(t, pending[sender1], 3)
+
+	// Building block 102 before the txpool drops txs[1]: txs[1] and the following txs are filtered.
+	env.head = 101
+	env.module.FilterTxs(pending)
+	assert.Equal(t, types.Transactions{txs[0]}, pending[sender1])
+	assert.Len(t, pending[sender2], 1)
+
+	pending = map[common.Address]types.Transactions{sender1: txs[1:]}
+	env.module.FilterTxs(pending)
+	assert.NotContains(t, pending, sender1)
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
+)
+
+func (p *PrivateTxModule) PostInsertBlock(block *types.Block) error {
+	num := block.NumberU64()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// The txpool may have expired the tx before the block arrived, hence not checking the status.
+	for _, tx := range block.Transactions() {
+		if ptx, ok := p.txs[tx.Hash()]; ok {
+			ptx.status = privatetx.StatusIncluded
+			ptx.blockNumber = num
+		}
+	}
+
+	for hash, ptx := range p.txs {
+		if ptx.status != privatetx.StatusPending && ptx.blockNumber+privatetx.StatusRetention < num {
+			delete(p.txs, hash)
+		}
+	}
+	numPrivateTxsGauge.Update(int64(len(p.txs)))
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/event"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
+)
+
+// IsPrivateTx does not look at the status, because an included private tx
+// returns to the txpool if its block is reorged out.
+func (p *PrivateTxModule) IsPrivateTx(hash common.Hash) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	_, ok := p.txs[hash]
+	return ok
+}
+
+// HandlePrivateTxs adds the private txs received from a peer to the txpool.
+// Only the newly added ones are announced to the subscribers to be forwarded further.
+func (p *PrivateTxModule) HandlePrivateTxs(peerID string, ptxs []*privatetx.PrivateTx) {
+	tracked := make([]*privatetx.PrivateTx, 0, len(ptxs))
+	for _, ptx := range ptxs {
+		if ptx == nil || ptx.Tx == nil {
+			continue
+		}
+		if err := p.track(ptx); err != nil {
+			logger.Trace("Discarding private tx", "peer", peerID, "hash", ptx.Tx.Hash(), "err", err)
+			continue
+		}
+		tracked = append(tracked, ptx)
+	}
+	if len(tracked) == 0 {
+		return
+	}
+
+	txs := make([]*types.Transaction, len(tracked))
+	for i, ptx := range tracked {
+		txs[i] = ptx.Tx
+	}
+	added := make([]*privatetx.PrivateTx, 0, len(tracked))
+	for i, err := range p.TxPool.AddRemotes(txs) {
+		if err != nil {
+			logger.Trace("Failed to add private tx", "peer", peerID, "hash", txs[i].Hash(), "err", err)
+			p.untrack(txs[i].Hash())
+			continue
+		}
+		added = append(added, tracked[i])
+	}
+	remotePrivateTxCounter.Inc(int64(len(added)))
+
+	if len(added) > 0 {
+		p.feed.Send(added)
+	}
+}
+
+func (p *PrivateTxModule) SubscribeNewPrivateTxs(sink chan<- []*privatetx.PrivateTx) event.Subscription {
+	return p.scope.Track(p.feed.Subscribe(sink))
+}
+
+// addLocal adds the private tx submitted to this node to the txpool.
+// The tx is tracked before entering the txpool so that it never takes the public broadcast path.
+func (p *PrivateTxModule) addLocal(ptx *privatetx.PrivateTx) error {
+	if err := p.track(ptx); err != nil {
+		return err
+	}
+	if err := p.TxPool.AddLocal(ptx.Tx); err != nil {
+		p.untrack(ptx.Tx.Hash())
+		return err
+	}
+	localPrivateTxCounter.Inc(1)
+
+	p.feed.Send([]*privatetx.PrivateTx{ptx})
+	return nil
+}
+
+func (p *PrivateTxModule) track(ptx *privatetx.PrivateTx) error {
+	current := p.Chain.CurrentBlock().NumberU64()
+	if ptx.MaxBlockNumber <= current {
+		return privatetx.ErrMaxBlockNumberPassed
+	}
+	if ptx.MaxBlockNumber > current+privatetx.MaxBlockRange {
+		return privatetx.ErrMaxBlockNumberTooFar
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	hash := ptx.Tx.Hash()
+	if _, ok := p.txs[hash]; ok {
+		return privatetx.ErrPrivateTxAlreadyKnown
+	}
+	p.txs[hash] = &privateTx{PrivateTx: ptx, status: privatetx.StatusPending}
+	numPrivateTxsGauge.Update(int64(len(p.txs)))
+	return nil
+}
+
+func (p *PrivateTxModule) untrack(hash common.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.txs, hash)
+	numPrivateTxsGauge.Update(int64(len(p.txs)))
+}
+
+func (p *PrivateTxModule) getStatus(hash common.Hash) (*privatetx.TxStatus, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	ptx, ok := p.txs[hash]
+	if !ok {
+		return nil, privatetx.ErrPrivateTxNotFound
+	}
+	status := &privatetx.TxStatus{
+		Hash:           hash,
+		Status:         ptx.status,
+		MaxBlockNumber: hexutil.Uint64(ptx.MaxBlockNumber),
+	}
+	if ptx.status == privatetx.StatusIncluded {
+		num := hexutil.Uint64(ptx.blockNumber)
+		status.BlockNumber = &num
+	}
+	return status, nil
+}
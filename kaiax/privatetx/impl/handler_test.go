@@ -0,0 +1,173 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
+	"github.com/kaiachain/kaia/work/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEnv struct {
+	module *PrivateTxModule
+	pool   *mocks.MockTxPool
+	head   uint64
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	var (
+		ctrl  = gomock.NewController(t)
+		chain = mocks.NewMockBlockChain(ctrl)
+		env   = &testEnv{module: NewPrivateTxModule(), pool: mocks.NewMockTxPool(ctrl), head: 100}
+	)
+	chain.EXPECT().CurrentBlock().DoAndReturn(func() *types.Block {
+		return types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(env.head)})
+	}).AnyTimes()
+
+	require.Nil(t, env.module.Init(&InitOpts{Chain: chain, TxPool: env.pool}))
+	return env
+}
+
+func newTestTx(nonce uint64) *types.Transaction {
+	return types.NewTransaction(nonce, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(1), nil)
+}
+
+func TestAddLocal(t *testing.T) {
+	env := newTestEnv(t)
+	sink := make(chan []*privatetx.PrivateTx, 1)
+	sub := env.module.SubscribeNewPrivateTxs(sink)
+	defer sub.Unsubscribe()
+
+	// Invalid max block numbers are rejected before reaching the txpool.
+	assert.ErrorIs(t, env.module.addLocal(&privatetx.PrivateTx{Tx: newTestTx(0), MaxBlockNumber: 100}), privatetx.ErrMaxBlockNumberPassed)
+	assert.ErrorIs(t, env.module.addLocal(&privatetx.PrivateTx{Tx: newTestTx(0), MaxBlockNumber: 100 + privatetx.MaxBlockRange + 1}), privatetx.ErrMaxBlockNumberTooFar)
+
+	// A tx rejected by the txpool is not tracked.
+	rejected := newTestTx(1)
+	env.pool.EXPECT().AddLocal(rejected).Return(errors.New("nonce too low"))
+	assert.NotNil(t, env.module.addLocal(&privatetx.PrivateTx{Tx: rejected, MaxBlockNumber: 101}))
+	assert.False(t, env.module.IsPrivateTx(rejected.Hash()))
+
+	tx := newTestTx(2)
+	ptx := &privatetx.PrivateTx{Tx: tx, MaxBlockNumber: 105}
+	env.pool.EXPECT().AddLocal(tx).Return(nil)
+	assert.Nil(t, env.module.addLocal(ptx))
+	assert.True(t, env.module.IsPrivateTx(tx.Hash()))
+	assert.Equal(t, []*privatetx.PrivateTx{ptx}, <-sink)
+
+	assert.ErrorIs(t, env.module.addLocal(ptx), privatetx.ErrPrivateTxAlreadyKnown)
+
+	status, err := env.module.getStatus(tx.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, privatetx.StatusPending, status.Status)
+	assert.Equal(t, uint64(105), uint64(status.MaxBlockNumber))
+	assert.Nil(t, status.BlockNumber)
+
+	_, err = env.module.getStatus(rejected.Hash())
+	assert.ErrorIs(t, err, privatetx.ErrPrivateTxNotFound)
+}
+
+func TestHandlePrivateTxs(t *testing.T) {
+	env := newTestEnv(t)
+	sink := make(chan []*privatetx.PrivateTx, 1)
+	sub := env.module.SubscribeNewPrivateTxs(sink)
+	defer sub.Unsubscribe()
+
+	var (
+		ok      = &privatetx.PrivateTx{Tx: newTestTx(0), MaxBlockNumber: 101}
+		failed  = &privatetx.PrivateTx{Tx: newTestTx(1), MaxBlockNumber: 101}
+		expired = &privatetx.PrivateTx{Tx: newTestTx(2), MaxBlockNumber: 99}
+	)
+	env.pool.EXPECT().AddRemotes([]*types.Transaction{ok.Tx, failed.Tx}).Return([]error{nil, errors.New("known transaction")})
+	env.module.HandlePrivateTxs("peer", []*privatetx.PrivateTx{ok, failed, expired, nil})
+
+	// Only the newly added tx is forwarded.
+	assert.Equal(t, []*privatetx.PrivateTx{ok}, <-sink)
+	assert.True(t, env.module.IsPrivateTx(ok.Tx.Hash()))
+	assert.False(t, env.module.IsPrivateTx(failed.Tx.Hash()))
+	assert.False(t, env.module.IsPrivateTx(expired.Tx.Hash()))
+
+	// Already known txs are neither added nor forwarded again.
+	env.module.HandlePrivateTxs("peer", []*privatetx.PrivateTx{ok})
+	assert.Len(t, sink, 0)
+}
+
+func TestLifecycle(t *testing.T) {
+	env := newTestEnv(t)
+	var (
+		included = &privatetx.PrivateTx{Tx: newTestTx(0), MaxBlockNumber: 102}
+		expiring = &privatetx.PrivateTx{Tx: newTestTx(1), MaxBlockNumber: 101}
+	)
+	env.pool.EXPECT().AddLocal(gomock.Any()).Return(nil).Times(2)
+	require.Nil(t, env.module.addLocal(included))
+	require.Nil(t, env.module.addLocal(expiring))
+
+	// Block 101 includes neither. `expiring` can no longer be included.
+	env.head = 101
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(101)})
+	assert.Nil(t, env.module.PostInsertBlock(block))
+	assert.Equal(t, []common.Hash{expiring.Tx.Hash()}, env.module.PreReset(nil, block.Header()))
+	assert.True(t, env.module.IsPrivateTx(expiring.Tx.Hash()))
+
+	status, err := env.module.getStatus(expiring.Tx.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, privatetx.StatusExpired, status.Status)
+
+	// Block 102 includes `included`.
+	env.head = 102
+	block = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(102)}).WithBody(types.Transactions{included.Tx})
+	assert.Nil(t, env.module.PostInsertBlock(block))
+	assert.Empty(t, env.module.PreReset(nil, block.Header()))
+	assert.True(t, env.module.IsPrivateTx(included.Tx.Hash()))
+
+	status, err = env.module.getStatus(included.Tx.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, privatetx.StatusIncluded, status.Status)
+	assert.Equal(t, uint64(102), uint64(*status.BlockNumber))
+
+	// Block 102 is rewound. `included` is back in the txpool and must stay private.
+	env.head = 101
+	block = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(101)})
+	assert.Empty(t, env.module.PreReset(nil, block.Header()))
+	assert.True(t, env.module.IsPrivateTx(included.Tx.Hash()))
+
+	status, err = env.module.getStatus(included.Tx.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, privatetx.StatusPending, status.Status)
+	assert.Nil(t, status.BlockNumber)
+
+	// Block 102' includes `included` again.
+	env.head = 102
+	block = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(102)}).WithBody(types.Transactions{included.Tx})
+	assert.Nil(t, env.module.PostInsertBlock(block))
+
+	// The statuses are forgotten after StatusRetention blocks.
+	block = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(102 + privatetx.StatusRetention + 1)})
+	assert.Nil(t, env.module.PostInsertBlock(block))
+	_, err = env.module.getStatus(included.Tx.Hash())
+	assert.ErrorIs(t, err, privatetx.ErrPrivateTxNotFound)
+	_, err = env.module.getStatus(expiring.Tx.Hash())
+	assert.ErrorIs(t, err, privatetx.ErrPrivateTxNotFound)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"sync"
+
+	"github.com/kaiachain/kaia/accounts/abi/bind/backends"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/event"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
+	"github.com/kaiachain/kaia/log"
+)
+
+var (
+	_ privatetx.PrivateTxModule = (*PrivateTxModule)(nil)
+
+	logger = log.NewModuleLogger(log.KaiaxPrivateTx)
+)
+
+type txPool interface {
+	AddLocal(tx *types.Transaction) error
+	AddRemotes(txs []*types.Transaction) []error
+}
+
+type InitOpts struct {
+	Chain  backends.BlockChainForCaller
+	TxPool txPool
+}
+
+// privateTx is a tracked private tx. blockNumber is the block that included the tx,
+// or the head block at which the tx expired.
+type privateTx struct {
+	*privatetx.PrivateTx
+	status      privatetx.Status
+	blockNumber uint64
+}
+
+type PrivateTxModule struct {
+	InitOpts
+
+	mu  sync.RWMutex
+	txs map[common.Hash]*privateTx
+
+	feed  event.Feed
+	scope event.SubscriptionScope
+}
+
+func NewPrivateTxModule() *PrivateTxModule {
+	return &PrivateTxModule{
+		txs: make(map[common.Hash]*privateTx),
+	}
+}
+
+func (p *PrivateTxModule) Init(opts *InitOpts) error {
+	if opts == nil || opts.Chain == nil || opts.TxPool == nil {
+		return privatetx.ErrInitUnexpectedNil
+	}
+
+	p.InitOpts = *opts
+	return nil
+}
+
+func (p *PrivateTxModule) Start() error {
+	return nil
+}
+
+func (p *PrivateTxModule) Stop() {
+	p.scope.Close()
+}
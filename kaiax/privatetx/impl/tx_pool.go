@@ -0,0 +1,67 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
+)
+
+// Private txs are ordinary txs to the txpool. The module only limits their lifetime.
+func (p *PrivateTxModule) PreAddTx(tx *types.Transaction, local bool) error {
+	return nil
+}
+
+func (p *PrivateTxModule) IsModuleTx(tx *types.Transaction) bool {
+	return false
+}
+
+func (p *PrivateTxModule) GetCheckBalance() func(tx *types.Transaction) error {
+	return nil
+}
+
+func (p *PrivateTxModule) IsReady(txs map[uint64]*types.Transaction, next uint64, ready types.Transactions) bool {
+	return true
+}
+
+// PreReset drops the private txs that cannot be included after newHead.
+// The txs included above newHead are pending again, as their blocks have been rewound.
+func (p *PrivateTxModule) PreReset(oldHead, newHead *types.Header) []common.Hash {
+	num := newHead.Number.Uint64()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var drops []common.Hash
+	for hash, ptx := range p.txs {
+		if ptx.status == privatetx.StatusIncluded && ptx.blockNumber > num {
+			ptx.status = privatetx.StatusPending
+			ptx.blockNumber = 0
+		}
+		if ptx.status == privatetx.StatusPending && ptx.MaxBlockNumber <= num {
+			ptx.status = privatetx.StatusExpired
+			ptx.blockNumber = num
+			drops = append(drops, hash)
+		}
+	}
+	expiredPrivateTxCounter.Inc(int64(len(drops)))
+	return drops
+}
+
+func (p *PrivateTxModule) PostReset(oldHead, newHead *types.Header, queue, pending map[common.Address]types.Transactions) {
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package privatetx
+
+import (
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/event"
+	"github.com/kaiachain/kaia/kaiax"
+)
+
+//go:generate mockgen -destination=./mock/module.go -package=mock github.com/kaiachain/kaia/kaiax/privatetx PrivateTxModule
+type PrivateTxModule interface {
+	kaiax.BaseModule
+	kaiax.JsonRpcModule
+	kaiax.ExecutionModule
+	kaiax.TxPoolModule
+	kaiax.TxBundlingModule
+
+	// IsPrivateTx returns true if the tx is a tracked private tx. Such txs must not be gossiped to non-CN peers.
+	IsPrivateTx(hash common.Hash) bool
+
+	HandlePrivateTxs(peerID string, txs []*PrivateTx)
+	SubscribeNewPrivateTxs(sink chan<- []*PrivateTx) event.Subscription
+}
+
+type PrivateTxModuleHost interface {
+	RegisterPrivateTxModule(module PrivateTxModule)
+}
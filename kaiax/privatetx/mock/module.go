@@ -0,0 +1,284 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/kaiachain/kaia/kaiax/privatetx (interfaces: PrivateTxModule)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	types "github.com/kaiachain/kaia/blockchain/types"
+	common "github.com/kaiachain/kaia/common"
+	event "github.com/kaiachain/kaia/event"
+	privatetx "github.com/kaiachain/kaia/kaiax/privatetx"
+	rpc "github.com/kaiachain/kaia/networks/rpc"
+	builder "github.com/kaiachain/kaia/work/builder"
+)
+
+// MockPrivateTxModule is a mock of PrivateTxModule interface.
+type MockPrivateTxModule struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivateTxModuleMockRecorder
+}
+
+// MockPrivateTxModuleMockRecorder is the mock recorder for MockPrivateTxModule.
+type MockPrivateTxModuleMockRecorder struct {
+	mock *MockPrivateTxModule
+}
+
+// NewMockPrivateTxModule creates a new mock instance.
+func NewMockPrivateTxModule(ctrl *gomock.Controller) *MockPrivateTxModule {
+	mock := &MockPrivateTxModule{ctrl: ctrl}
+	mock.recorder = &MockPrivateTxModuleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivateTxModule) EXPECT() *MockPrivateTxModuleMockRecorder {
+	return m.recorder
+}
+
+// APIs mocks base method.
+func (m *MockPrivateTxModule) APIs() []rpc.API {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIs")
+	ret0, _ := ret[0].([]rpc.API)
+	return ret0
+}
+
+// APIs indicates an expected call of APIs.
+func (mr *MockPrivateTxModuleMockRecorder) APIs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIs", reflect.TypeOf((*MockPrivateTxModule)(nil).APIs))
+}
+
+// ExtractTxBundles mocks base method.
+func (m *MockPrivateTxModule) ExtractTxBundles(arg0 []*types.Transaction, arg1 []*builder.Bundle) []*builder.Bundle {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtractTxBundles", arg0, arg1)
+	ret0, _ := ret[0].([]*builder.Bundle)
+	return ret0
+}
+
+// ExtractTxBundles indicates an expected call of ExtractTxBundles.
+func (mr *MockPrivateTxModuleMockRecorder) ExtractTxBundles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtractTxBundles", reflect.TypeOf((*MockPrivateTxModule)(nil).ExtractTxBundles), arg0, arg1)
+}
+
+// FilterTxs mocks base method.
+func (m *MockPrivateTxModule) FilterTxs(arg0 map[common.Address]types.Transactions) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "FilterTxs", arg0)
+}
+
+// FilterTxs indicates an expected call of FilterTxs.
+func (mr *MockPrivateTxModuleMockRecorder) FilterTxs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterTxs", reflect.TypeOf((*MockPrivateTxModule)(nil).FilterTxs), arg0)
+}
+
+// GetCheckBalance mocks base method.
+func (m *MockPrivateTxModule) GetCheckBalance() func(*types.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckBalance")
+	ret0, _ := ret[0].(func(*types.Transaction) error)
+	return ret0
+}
+
+// GetCheckBalance indicates an expected call of GetCheckBalance.
+func (mr *MockPrivateTxModuleMockRecorder) GetCheckBalance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckBalance", reflect.TypeOf((*MockPrivateTxModule)(nil).GetCheckBalance))
+}
+
+// GetMaxBundleTxsInPending mocks base method.
+func (m *MockPrivateTxModule) GetMaxBundleTxsInPending() uint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxBundleTxsInPending")
+	ret0, _ := ret[0].(uint)
+	return ret0
+}
+
+// GetMaxBundleTxsInPending indicates an expected call of GetMaxBundleTxsInPending.
+func (mr *MockPrivateTxModuleMockRecorder) GetMaxBundleTxsInPending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxBundleTxsInPending", reflect.TypeOf((*MockPrivateTxModule)(nil).GetMaxBundleTxsInPending))
+}
+
+// GetMaxBundleTxsInQueue mocks base method.
+func (m *MockPrivateTxModule) GetMaxBundleTxsInQueue() uint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxBundleTxsInQueue")
+	ret0, _ := ret[0].(uint)
+	return ret0
+}
+
+// GetMaxBundleTxsInQueue indicates an expected call of GetMaxBundleTxsInQueue.
+func (mr *MockPrivateTxModuleMockRecorder) GetMaxBundleTxsInQueue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxBundleTxsInQueue", reflect.TypeOf((*MockPrivateTxModule)(nil).GetMaxBundleTxsInQueue))
+}
+
+// HandlePrivateTxs mocks base method.
+func (m *MockPrivateTxModule) HandlePrivateTxs(arg0 string, arg1 []*privatetx.PrivateTx) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandlePrivateTxs", arg0, arg1)
+}
+
+// HandlePrivateTxs indicates an expected call of HandlePrivateTxs.
+func (mr *MockPrivateTxModuleMockRecorder) HandlePrivateTxs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlePrivateTxs", reflect.TypeOf((*MockPrivateTxModule)(nil).HandlePrivateTxs), arg0, arg1)
+}
+
+// IsBundleTx mocks base method.
+func (m *MockPrivateTxModule) IsBundleTx(arg0 *types.Transaction) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBundleTx", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsBundleTx indicates an expected call of IsBundleTx.
+func (mr *MockPrivateTxModuleMockRecorder) IsBundleTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBundleTx", reflect.TypeOf((*MockPrivateTxModule)(nil).IsBundleTx), arg0)
+}
+
+// IsModuleTx mocks base method.
+func (m *MockPrivateTxModule) IsModuleTx(arg0 *types.Transaction) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsModuleTx", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsModuleTx indicates an expected call of IsModuleTx.
+func (mr *MockPrivateTxModuleMockRecorder) IsModuleTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsModuleTx", reflect.TypeOf((*MockPrivateTxModule)(nil).IsModuleTx), arg0)
+}
+
+// IsPrivateTx mocks base method.
+func (m *MockPrivateTxModule) IsPrivateTx(arg0 common.Hash) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPrivateTx", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPrivateTx indicates an expected call of IsPrivateTx.
+func (mr *MockPrivateTxModuleMockRecorder) IsPrivateTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPrivateTx", reflect.TypeOf((*MockPrivateTxModule)(nil).IsPrivateTx), arg0)
+}
+
+// IsReady mocks base method.
+func (m *MockPrivateTxModule) IsReady(arg0 map[uint64]*types.Transaction, arg1 uint64, arg2 types.Transactions) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReady", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsReady indicates an expected call of IsReady.
+func (mr *MockPrivateTxModuleMockRecorder) IsReady(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReady", reflect.TypeOf((*MockPrivateTxModule)(nil).IsReady), arg0, arg1, arg2)
+}
+
+// PostInsertBlock mocks base method.
+func (m *MockPrivateTxModule) PostInsertBlock(arg0 *types.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInsertBlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostInsertBlock indicates an expected call of PostInsertBlock.
+func (mr *MockPrivateTxModuleMockRecorder) PostInsertBlock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInsertBlock", reflect.TypeOf((*MockPrivateTxModule)(nil).PostInsertBlock), arg0)
+}
+
+// PostReset mocks base method.
+func (m *MockPrivateTxModule) PostReset(arg0, arg1 *types.Header, arg2, arg3 map[common.Address]types.Transactions) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PostReset", arg0, arg1, arg2, arg3)
+}
+
+// PostReset indicates an expected call of PostReset.
+func (mr *MockPrivateTxModuleMockRecorder) PostReset(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostReset", reflect.TypeOf((*MockPrivateTxModule)(nil).PostReset), arg0, arg1, arg2, arg3)
+}
+
+// PreAddTx mocks base method.
+func (m *MockPrivateTxModule) PreAddTx(arg0 *types.Transaction, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreAddTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PreAddTx indicates an expected call of PreAddTx.
+func (mr *MockPrivateTxModuleMockRecorder) PreAddTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreAddTx", reflect.TypeOf((*MockPrivateTxModule)(nil).PreAddTx), arg0, arg1)
+}
+
+// PreReset mocks base method.
+func (m *MockPrivateTxModule) PreReset(arg0, arg1 *types.Header) []common.Hash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreReset", arg0, arg1)
+	ret0, _ := ret[0].([]common.Hash)
+	return ret0
+}
+
+// PreReset indicates an expected call of PreReset.
+func (mr *MockPrivateTxModuleMockRecorder) PreReset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreReset", reflect.TypeOf((*MockPrivateTxModule)(nil).PreReset), arg0, arg1)
+}
+
+// Start mocks base method.
+func (m *MockPrivateTxModule) Start() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockPrivateTxModuleMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockPrivateTxModule)(nil).Start))
+}
+
+// Stop mocks base method.
+func (m *MockPrivateTxModule) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockPrivateTxModuleMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockPrivateTxModule)(nil).Stop))
+}
+
+// SubscribeNewPrivateTxs mocks base method.
+func (m *MockPrivateTxModule) SubscribeNewPrivateTxs(arg0 chan<- []*privatetx.PrivateTx) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeNewPrivateTxs", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeNewPrivateTxs indicates an expected call of SubscribeNewPrivateTxs.
+func (mr *MockPrivateTxModuleMockRecorder) SubscribeNewPrivateTxs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeNewPrivateTxs", reflect.TypeOf((*MockPrivateTxModule)(nil).SubscribeNewPrivateTxs), arg0)
+}
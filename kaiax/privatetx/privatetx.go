@@ -0,0 +1,54 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package privatetx
+
+import (
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+)
+
+const (
+	// MaxBlockRange is the furthest a private tx can set its max block number from the current block.
+	MaxBlockRange = 256
+
+	// StatusRetention is the number of blocks the status of an included or expired private tx is kept.
+	StatusRetention = 128
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusIncluded Status = "included"
+	StatusExpired  Status = "expired"
+)
+
+// PrivateTx is a tx submitted via kaia_sendPrivateRawTransaction. It is exchanged with PrivateTxMsg
+// so that every node on the way knows not to gossip it to ENs.
+type PrivateTx struct {
+	Tx             *types.Transaction
+	MaxBlockNumber uint64
+}
+
+// TxStatus is the result of kaia_getPrivateTransactionStatus.
+type TxStatus struct {
+	Hash           common.Hash     `json:"hash"`
+	Status         Status          `json:"status"`
+	MaxBlockNumber hexutil.Uint64  `json:"maxBlockNumber"`
+	BlockNumber    *hexutil.Uint64 `json:"blockNumber,omitempty"`
+}
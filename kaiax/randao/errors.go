@@ -19,12 +19,14 @@ package randao
 import "errors"
 
 var (
-	ErrInitUnexpectedNil   = errors.New("unexpected nil during module init")
-	ErrZeroBlockNumber     = errors.New("block number cannot be zero")
-	ErrMissingKIP113       = errors.New("kip113 address not set in ChainConfig")
-	ErrBeforeRandaoFork    = errors.New("cannot read kip113 address from registry before randao fork")
-	ErrNoBlsKey            = errors.New("bls key not configured")
-	ErrNoBlsPub            = errors.New("bls pubkey not found for the proposer")
-	ErrInvalidRandaoFields = errors.New("invalid randao fields")
-	ErrUnexpectedRandao    = errors.New("unexpected randao fields")
+	ErrInitUnexpectedNil    = errors.New("unexpected nil during module init")
+	ErrZeroBlockNumber      = errors.New("block number cannot be zero")
+	ErrMissingKIP113        = errors.New("kip113 address not set in ChainConfig")
+	ErrBeforeRandaoFork     = errors.New("cannot read kip113 address from registry before randao fork")
+	ErrNoBlsKey             = errors.New("bls key not configured")
+	ErrNoBlsPub             = errors.New("bls pubkey not found for the proposer")
+	ErrInvalidRandaoFields  = errors.New("invalid randao fields")
+	ErrUnexpectedRandao     = errors.New("unexpected randao fields")
+	ErrTooFewHeaders        = errors.New("at least two headers are required")
+	ErrNonContiguousHeaders = errors.New("headers are not contiguous")
 )
@@ -68,3 +68,11 @@ func (api *RandaoAPI) GetBlsInfos(number rpc.BlockNumber) (map[string]interface{
 	}
 	return blsInfos, nil
 }
+
+func (api *RandaoAPI) GetRandomness(number rpc.BlockNumber) (*randao.Randomness, error) {
+	num := uint64(number.Int64())
+	if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
+		num = api.r.Chain.CurrentBlock().NumberU64()
+	}
+	return api.r.getRandomness(num)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/kaiachain/kaia/blockchain/system"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/consensus"
+	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/crypto/bls"
 	"github.com/kaiachain/kaia/kaiax/randao"
 )
@@ -157,3 +158,63 @@ func (r *RandaoModule) getAllCached(num *big.Int) (system.BlsPublicKeyInfos, err
 
 	return res.(system.BlsPublicKeyInfos), nil
 }
+
+// getRandomness returns the randao fields of the given block along with the proof to verify them.
+func (r *RandaoModule) getRandomness(num uint64) (*randao.Randomness, error) {
+	bn := new(big.Int).SetUint64(num)
+	if !r.ChainConfig.IsRandaoForkEnabled(bn) {
+		return nil, randao.ErrBeforeRandaoFork
+	}
+	if num == 0 {
+		return nil, randao.ErrZeroBlockNumber
+	}
+
+	header := r.Chain.GetHeaderByNumber(num)
+	if header == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	parent := r.Chain.GetHeader(header.ParentHash, num-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	proposer, err := r.Chain.Engine().Author(header)
+	if err != nil {
+		return nil, err
+	}
+
+	infos, err := r.getAllCached(bn)
+	if err != nil {
+		return nil, err
+	}
+	info, ok := infos[proposer]
+	if !ok {
+		return nil, randao.ErrNoBlsPub
+	}
+
+	var (
+		msg         = randao.CalcRandaoMsg(bn)
+		prevMixHash = randao.PrevMixHash(r.ChainConfig, parent)
+		verified    = false
+	)
+	if info.VerifyErr == nil {
+		if pub, err := bls.PublicKeyFromBytes(info.PublicKey); err == nil {
+			verified = randao.VerifyHeaderRandao(pub, header, prevMixHash) == nil
+		}
+	}
+
+	return &randao.Randomness{
+		Number:       num,
+		Hash:         header.Hash(),
+		Proposer:     proposer,
+		MixHash:      header.MixHash,
+		RandomReveal: header.RandomReveal,
+		BlsPublicKey: info.PublicKey,
+		Proof: &randao.RandomnessProof{
+			Message:     msg,
+			PrevMixHash: prevMixHash,
+			RevealHash:  crypto.Keccak256Hash(header.RandomReveal),
+			Pop:         info.Pop,
+			Verified:    verified,
+		},
+	}, nil
+}
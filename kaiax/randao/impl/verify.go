@@ -0,0 +1,42 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"math/big"
+
+	"github.com/kaiachain/kaia/blockchain/system"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/kaiax/randao"
+)
+
+// NewStaticBlsPubkeyReader returns a BlsPubkeyReader backed by a snapshot of the KIP-113 registry,
+// such as the result of kaia_getBlsInfos. The snapshot is used for every block, so the verified
+// range should not span a registry update.
+func NewStaticBlsPubkeyReader(infos system.BlsPublicKeyInfos) randao.BlsPubkeyReader {
+	return func(proposer common.Address, _ *big.Int) (bls.PublicKey, error) {
+		info, ok := infos[proposer]
+		if !ok {
+			return nil, randao.ErrNoBlsPub
+		}
+		if info.VerifyErr != nil {
+			return nil, info.VerifyErr
+		}
+		return bls.PublicKeyFromBytes(info.PublicKey)
+	}
+}
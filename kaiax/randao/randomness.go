@@ -0,0 +1,45 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package randao
+
+import (
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+)
+
+// Randomness is the response type for the kaia_getRandomness API.
+type Randomness struct {
+	Number       uint64           `json:"number"`
+	Hash         common.Hash      `json:"hash"`
+	Proposer     common.Address   `json:"proposer"`
+	MixHash      hexutil.Bytes    `json:"mixHash"`
+	RandomReveal hexutil.Bytes    `json:"randomReveal"`
+	BlsPublicKey hexutil.Bytes    `json:"blsPublicKey"`
+	Proof        *RandomnessProof `json:"proof"`
+}
+
+// RandomnessProof holds the values needed to verify the Randomness without trusting the node:
+//
+//	bls_verify(BlsPublicKey, Message, RandomReveal) and pop_verify(BlsPublicKey, Pop)
+//	MixHash == xor(PrevMixHash, RevealHash) where RevealHash == keccak256(RandomReveal)
+type RandomnessProof struct {
+	Message     common.Hash   `json:"message"`
+	PrevMixHash hexutil.Bytes `json:"prevMixHash"`
+	RevealHash  common.Hash   `json:"revealHash"`
+	Pop         hexutil.Bytes `json:"pop"`
+	Verified    bool          `json:"verified"`
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package randao
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/params"
+)
+
+// BlsPubkeyReader returns the BLS public key of the proposer registered in KIP-113, which is effective
+// at the given block. RandaoModule.GetBlsPubkey satisfies this type.
+type BlsPubkeyReader func(proposer common.Address, num *big.Int) (bls.PublicKey, error)
+
+// CalcRandaoMsg returns the message signed by the proposer.
+// block_num_to_bytes() = num.to_bytes(32, byteorder="big")
+func CalcRandaoMsg(number *big.Int) common.Hash {
+	return common.BytesToHash(number.Bytes())
+}
+
+// CalcMixHash returns the next mixHash.
+// calc_mix_hash() = xor(prevMixHash, keccak256(randomReveal))
+func CalcMixHash(randomReveal, prevMixHash []byte) []byte {
+	mixHash := make([]byte, 32)
+	revealHash := crypto.Keccak256(randomReveal)
+	for i := 0; i < 32; i++ {
+		mixHash[i] = prevMixHash[i] ^ revealHash[i]
+	}
+	return mixHash
+}
+
+// PrevMixHash returns the mixHash that the child of the given header refers to.
+// At the fork block's parent, pretend that prevMixHash is ZeroMixHash.
+func PrevMixHash(config *params.ChainConfig, parent *types.Header) []byte {
+	if config.IsRandaoForkBlockParent(parent.Number) {
+		return params.ZeroMixHash
+	}
+	return parent.MixHash
+}
+
+// HeaderProposer recovers the proposer address from the seal of the header.
+func HeaderProposer(header *types.Header) (common.Address, error) {
+	extra, err := types.ExtractIstanbulExtra(header)
+	if err != nil {
+		return common.Address{}, err
+	}
+	sigHash := istanbul.RLPHash(types.IstanbulFilteredHeader(header, false))
+	return istanbul.GetSignatureAddress(sigHash.Bytes(), extra.Seal)
+}
+
+// VerifyHeaderRandao verifies the KIP-114 fields of the header against the proposer's BLS public key
+// and the mixHash of the parent block.
+func VerifyHeaderRandao(pub bls.PublicKey, header *types.Header, prevMixHash []byte) error {
+	if len(prevMixHash) != 32 || header.RandomReveal == nil || header.MixHash == nil {
+		return ErrInvalidRandaoFields
+	}
+
+	// if not verify(proposerPubkey, newHeader.number, newHeader.randomReveal): return False
+	ok, err := bls.VerifySignature(header.RandomReveal, CalcRandaoMsg(header.Number), pub)
+	if err != nil {
+		return err
+	} else if !ok {
+		return ErrInvalidRandaoFields
+	}
+
+	// if not newHeader.mixHash == calc_mix_hash(prevMixHash, newHeader.randomReveal): return False
+	if !bytes.Equal(header.MixHash, CalcMixHash(header.RandomReveal, prevMixHash)) {
+		return ErrInvalidRandaoFields
+	}
+	return nil
+}
+
+// VerifyRandaoChain validates the randomness of a contiguous chain of headers without accessing a node.
+// The first header is trusted as the starting point, and every following header is checked that
+// it links to its parent, its randomReveal is signed by the proposer's registered BLS key,
+// and its mixHash is derived from the parent's mixHash. Headers before the Randao hardfork
+// must not have the randao fields.
+func VerifyRandaoChain(config *params.ChainConfig, headers []*types.Header, readBlsPubkey BlsPubkeyReader) error {
+	if len(headers) < 2 {
+		return ErrTooFewHeaders
+	}
+
+	for i := 1; i < len(headers); i++ {
+		parent, header := headers[i-1], headers[i]
+		num := header.Number.Uint64()
+		if num != parent.Number.Uint64()+1 || header.ParentHash != parent.Hash() {
+			return fmt.Errorf("block %d: %w", num, ErrNonContiguousHeaders)
+		}
+
+		if !config.IsRandaoForkEnabled(header.Number) {
+			if header.RandomReveal != nil || header.MixHash != nil {
+				return fmt.Errorf("block %d: %w", num, ErrUnexpectedRandao)
+			}
+			continue
+		}
+
+		if err := verifyChainedHeader(config, parent, header, readBlsPubkey); err != nil {
+			return fmt.Errorf("block %d: %w", num, err)
+		}
+	}
+	return nil
+}
+
+func verifyChainedHeader(config *params.ChainConfig, parent, header *types.Header, readBlsPubkey BlsPubkeyReader) error {
+	proposer, err := HeaderProposer(header)
+	if err != nil {
+		return err
+	}
+
+	// [proposerPubkey, proposerPop] = get_proposer_pubkey_pop()
+	// if not pop_verify(proposerPubkey, proposerPop): return False
+	pub, err := readBlsPubkey(proposer, header.Number)
+	if err != nil {
+		return err
+	}
+
+	return VerifyHeaderRandao(pub, header, PrevMixHash(config, parent))
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package randao
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testProposer struct {
+	key    *ecdsa.PrivateKey
+	blsKey bls.SecretKey
+}
+
+func newTestProposer(t *testing.T) *testProposer {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	blsKey, err := bls.DeriveFromECDSA(key)
+	require.Nil(t, err)
+	return &testProposer{key: key, blsKey: blsKey}
+}
+
+func (p *testProposer) addr() common.Address {
+	return crypto.PubkeyToAddress(p.key.PublicKey)
+}
+
+func encodeTestExtra(t *testing.T, seal []byte) []byte {
+	extra, err := rlp.EncodeToBytes(&types.IstanbulExtra{Seal: seal})
+	require.Nil(t, err)
+	return append(make([]byte, types.IstanbulExtraVanity), extra...)
+}
+
+// makeTestHeaders creates a chain of sealed headers from the genesis block,
+// filling in the randao fields after the Randao hardfork.
+func makeTestHeaders(t *testing.T, config *params.ChainConfig, proposers []*testProposer, count int) []*types.Header {
+	headers := []*types.Header{{Number: big.NewInt(0), Extra: encodeTestExtra(t, nil)}}
+	for i := 1; i < count; i++ {
+		var (
+			parent = headers[i-1]
+			p      = proposers[i%len(proposers)]
+			header = &types.Header{ParentHash: parent.Hash(), Number: big.NewInt(int64(i)), Extra: encodeTestExtra(t, nil)}
+		)
+		if config.IsRandaoForkEnabled(header.Number) {
+			msg := CalcRandaoMsg(header.Number)
+			header.RandomReveal = bls.Sign(p.blsKey, msg[:]).Marshal()
+			header.MixHash = CalcMixHash(header.RandomReveal, PrevMixHash(config, parent))
+		}
+
+		sealTestHeader(t, header, p)
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+func sealTestHeader(t *testing.T, header *types.Header, p *testProposer) {
+	sigHash := istanbul.RLPHash(types.IstanbulFilteredHeader(header, false))
+	seal, err := crypto.Sign(crypto.Keccak256(sigHash.Bytes()), p.key)
+	require.Nil(t, err)
+	header.Extra = encodeTestExtra(t, seal)
+}
+
+func TestVerifyRandaoChain(t *testing.T) {
+	var (
+		config    = &params.ChainConfig{RandaoCompatibleBlock: big.NewInt(3)}
+		proposers = []*testProposer{newTestProposer(t), newTestProposer(t), newTestProposer(t)}
+		stranger  = newTestProposer(t)
+		pubkeys   = make(map[common.Address]bls.PublicKey)
+	)
+	for _, p := range proposers {
+		pubkeys[p.addr()] = p.blsKey.PublicKey()
+	}
+	readBlsPubkey := func(proposer common.Address, num *big.Int) (bls.PublicKey, error) {
+		if pub, ok := pubkeys[proposer]; ok {
+			return pub, nil
+		}
+		return nil, ErrNoBlsPub
+	}
+
+	headers := makeTestHeaders(t, config, proposers, 8)
+	for i, header := range headers[1:] {
+		proposer, err := HeaderProposer(header)
+		require.Nil(t, err)
+		assert.Equal(t, proposers[(i+1)%len(proposers)].addr(), proposer)
+	}
+
+	// Valid chains, including the ones starting from the middle.
+	assert.Nil(t, VerifyRandaoChain(config, headers, readBlsPubkey))
+	assert.Nil(t, VerifyRandaoChain(config, headers[4:], readBlsPubkey))
+	assert.Equal(t, ErrTooFewHeaders, VerifyRandaoChain(config, headers[:1], readBlsPubkey))
+
+	// Missing header
+	broken := append([]*types.Header{}, headers[:4]...)
+	broken = append(broken, headers[5:]...)
+	assert.True(t, errors.Is(VerifyRandaoChain(config, broken, readBlsPubkey), ErrNonContiguousHeaders))
+
+	// Tampered mixHash, even if the proposer re-sealed the header.
+	tampered := makeTestHeaders(t, config, proposers, 8)
+	tampered[5].MixHash = CalcMixHash(tampered[5].RandomReveal, tampered[5].MixHash)
+	sealTestHeader(t, tampered[5], proposers[5%len(proposers)])
+	assert.True(t, errors.Is(VerifyRandaoChain(config, tampered[:6], readBlsPubkey), ErrInvalidRandaoFields))
+
+	// Unregistered proposer
+	unknown := makeTestHeaders(t, config, []*testProposer{stranger}, 5)
+	assert.True(t, errors.Is(VerifyRandaoChain(config, unknown, readBlsPubkey), ErrNoBlsPub))
+
+	// Randao fields before the hardfork
+	early := makeTestHeaders(t, &params.ChainConfig{RandaoCompatibleBlock: big.NewInt(1)}, proposers, 3)
+	assert.True(t, errors.Is(VerifyRandaoChain(config, early, readBlsPubkey), ErrUnexpectedRandao))
+}
@@ -28,6 +28,8 @@ var (
 	ErrNoReceipts            = errors.New("receipts not found")
 	ErrInvalidBlockRange     = errors.New("invalid block number range")
 	ErrBlockRangeLimit       = errors.New("exceeds block number range limit")
+	ErrLedgerDisabled        = errors.New("reward ledger is disabled")
+	ErrLedgerNotIndexed      = errors.New("block number range not indexed by reward ledger")
 )
 
 func errMalformedRewardRatio(ratio string) error {
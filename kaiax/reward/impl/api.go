@@ -21,6 +21,7 @@ import (
 	"runtime"
 	"sync"
 
+	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/kaiax/reward"
 	"github.com/kaiachain/kaia/networks/rpc"
 )
@@ -64,6 +65,23 @@ func (api *RewardKaiaAPI) GetRewards(num *rpc.BlockNumber) (*reward.RewardRespon
 	return api.r.GetBlockReward(blockNum)
 }
 
+// GetRewardsByAddress returns the rewards received by the given address in the block range [lower, upper].
+// Served from the reward ledger, which must be enabled by the reward.ledger flag.
+func (api *RewardKaiaAPI) GetRewardsByAddress(addr common.Address, lower, upper rpc.BlockNumber) (*reward.RewardsByAddressResponse, error) {
+	currentNum := api.chain.CurrentBlock().NumberU64()
+	lowerNum := normalizeBlockNumber(lower, currentNum)
+	upperNum := normalizeBlockNumber(upper, currentNum)
+	if lowerNum > upperNum || upperNum > currentNum {
+		return nil, reward.ErrInvalidBlockRange
+	}
+
+	entries, err := api.r.GetRewardsByAddress(addr, lowerNum, upperNum)
+	if err != nil {
+		return nil, err
+	}
+	return reward.ToRewardsByAddressResponse(addr, lowerNum, upperNum, entries), nil
+}
+
 // RewardGovAPI defines the governance namespace APIs.
 type RewardGovAPI struct {
 	r     reward.RewardModule
@@ -77,18 +95,8 @@ func NewRewardGovAPI(r reward.RewardModule, chain blockChain) *RewardGovAPI {
 func (api *RewardGovAPI) GetRewardsAccumulated(lower, upper rpc.BlockNumber) (*reward.AccumulatedRewardsResponse, error) {
 	// normalize block numbers
 	currentNum := api.chain.CurrentBlock().NumberU64()
-	var lowerNum uint64
-	var upperNum uint64
-	if lower == rpc.LatestBlockNumber || lower == rpc.PendingBlockNumber {
-		lowerNum = currentNum
-	} else {
-		lowerNum = lower.Uint64()
-	}
-	if upper == rpc.LatestBlockNumber || upper == rpc.PendingBlockNumber {
-		upperNum = currentNum
-	} else {
-		upperNum = upper.Uint64()
-	}
+	lowerNum := normalizeBlockNumber(lower, currentNum)
+	upperNum := normalizeBlockNumber(upper, currentNum)
 	if lowerNum > upperNum || upperNum > currentNum {
 		return nil, reward.ErrInvalidBlockRange
 	}
@@ -171,3 +179,11 @@ func (api *RewardGovAPI) accumulateRewards(lower, upper uint64) (*reward.RewardS
 		return accSpec, nil
 	}
 }
+
+// normalizeBlockNumber converts latest and pending block numbers to the current block number.
+func normalizeBlockNumber(num rpc.BlockNumber, currentNum uint64) uint64 {
+	if num == rpc.LatestBlockNumber || num == rpc.PendingBlockNumber {
+		return currentNum
+	}
+	return num.Uint64()
+}
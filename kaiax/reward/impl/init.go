@@ -17,6 +17,8 @@
 package impl
 
 import (
+	"sync"
+
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/consensus"
@@ -24,6 +26,7 @@ import (
 	"github.com/kaiachain/kaia/kaiax/staking"
 	"github.com/kaiachain/kaia/log"
 	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
 )
 
 var (
@@ -45,27 +48,59 @@ type InitOpts struct {
 	Chain         blockChain
 	GovModule     reward.GovModule // TODO-kaiax: Restore to gov.GovModule after introducing kaiax/gov
 	StakingModule staking.StakingModule
+
+	// Optional. Required only if the reward ledger is enabled.
+	ChainKv      database.Database
+	LedgerConfig *reward.LedgerConfig
 }
 
 type RewardModule struct {
 	InitOpts
+
+	// Reward ledger index range. Valid only if the ledger is enabled.
+	mu          sync.RWMutex
+	ledgerStart uint64 // Lowest indexed block number
+	ledgerLast  uint64 // Highest indexed block number
+
+	// Stops the ledger catchup goroutine.
+	quitCh chan struct{}
+	wg     sync.WaitGroup
 }
 
 func NewRewardModule() *RewardModule {
-	return &RewardModule{}
+	return &RewardModule{
+		quitCh: make(chan struct{}, 1),
+	}
 }
 
 func (r *RewardModule) Init(opts *InitOpts) error {
 	if opts == nil || opts.ChainConfig == nil || opts.Chain == nil || opts.GovModule == nil || opts.StakingModule == nil {
 		return reward.ErrInitUnexpectedNil
 	}
+	if opts.LedgerConfig != nil && opts.LedgerConfig.Enable && opts.ChainKv == nil {
+		return reward.ErrInitUnexpectedNil
+	}
 	r.InitOpts = *opts
 	return nil
 }
 
 func (r *RewardModule) Start() error {
+	if !r.isLedgerEnabled() {
+		return nil
+	}
+
+	r.loadLedgerRange()
+
+	r.quitCh = make(chan struct{}, 1)
+	r.wg.Add(1)
+	go r.catchupLedger()
 	return nil
 }
 
 func (r *RewardModule) Stop() {
+	if !r.isLedgerEnabled() {
+		return
+	}
+	r.quitCh <- struct{}{}
+	r.wg.Wait()
 }
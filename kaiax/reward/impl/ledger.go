@@ -0,0 +1,270 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/reward"
+	"github.com/kaiachain/kaia/kaiax/staking"
+)
+
+var (
+	ledgerBackfillBatch       = uint64(1024)   // Number of past blocks indexed before checking the head block again.
+	ledgerBackfillLogInterval = uint64(102400) // Periodic log in catchupLedger().
+)
+
+func (r *RewardModule) isLedgerEnabled() bool {
+	return r.LedgerConfig != nil && r.LedgerConfig.Enable
+}
+
+// loadLedgerRange loads the indexed block range from the database.
+// If the ledger has never been initialized, it starts indexing from the next block
+// and leaves the past blocks to the backfill.
+func (r *RewardModule) loadLedgerRange() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		start = ReadLedgerStartNumber(r.ChainKv)
+		last  = ReadLedgerLastNumber(r.ChainKv)
+	)
+	if start == nil || last == nil {
+		head := r.Chain.CurrentBlock().NumberU64()
+		WriteLedgerStartNumber(r.ChainKv, head+1)
+		WriteLedgerLastNumber(r.ChainKv, head)
+		r.ledgerStart, r.ledgerLast = head+1, head
+		logger.Info("Initialized reward ledger", "start", head+1)
+		return
+	}
+	r.ledgerStart, r.ledgerLast = *start, *last
+}
+
+// catchupLedger is a long-running goroutine that indexes the blocks until the current head block,
+// and then backfills the past blocks down to the genesis block.
+func (r *RewardModule) catchupLedger() {
+	defer r.wg.Done()
+
+	backfill := true
+	for {
+		head := r.Chain.CurrentBlock().NumberU64()
+		r.mu.RLock()
+		start, last := r.ledgerStart, r.ledgerLast
+		r.mu.RUnlock()
+
+		// A gap detected. Index up to the current block.
+		if last < head {
+			for num := last + 1; num <= head; num++ {
+				select {
+				case <-r.quitCh:
+					return
+				default:
+				}
+				if err := r.indexLedgerBlock(num); err != nil {
+					logger.Error("Reward ledger catchup failed", "num", num, "err", err)
+					return
+				}
+			}
+			// Because current head may have increased while we index, we need to check again.
+			continue
+		}
+
+		// Index the past blocks in batches so that the head is not left behind for long.
+		if backfill && start > 0 {
+			for i := uint64(0); i < ledgerBackfillBatch && start > 0; i++ {
+				select {
+				case <-r.quitCh:
+					return
+				default:
+				}
+				if err := r.indexPrevLedgerBlock(start - 1); err != nil {
+					// The past receipts may not be available, e.g. after a snapshot sync.
+					logger.Warn("Reward ledger backfill stopped", "num", start-1, "err", err)
+					backfill = false
+					break
+				}
+				start--
+				if start%ledgerBackfillLogInterval == 0 {
+					logger.Info("Backfilling reward ledger", "start", start, "last", last)
+				}
+			}
+			continue
+		}
+
+		// No gap detected. Sleep a while and check again just in case.
+		// If PostInsertBlock() is filling in the gap, this loop would do nothing but waiting.
+		timer := time.NewTimer(time.Second)
+		select {
+		case <-r.quitCh:
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// indexLedgerBlock stores the rewards of the given block if it is right after the last indexed block.
+// Otherwise, it does nothing because the block is either already indexed or will be indexed by the catchup thread.
+func (r *RewardModule) indexLedgerBlock(num uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ledgerLast+1 != num {
+		return nil
+	}
+	rewards, err := r.getRecipientRewards(num)
+	if err != nil {
+		return err
+	}
+	WriteLedgerBlock(r.ChainKv, num, rewards)
+	WriteLedgerLastNumber(r.ChainKv, num)
+	r.ledgerLast = num
+	return nil
+}
+
+// indexPrevLedgerBlock stores the rewards of the given block if it is right before the lowest indexed block.
+func (r *RewardModule) indexPrevLedgerBlock(num uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ledgerStart != num+1 {
+		return nil
+	}
+	var rewards map[common.Address]*reward.RecipientReward
+	if num > 0 { // The genesis block has no reward.
+		var err error
+		if rewards, err = r.getRecipientRewards(num); err != nil {
+			return err
+		}
+	}
+	WriteLedgerBlock(r.ChainKv, num, rewards)
+	WriteLedgerStartNumber(r.ChainKv, num)
+	r.ledgerStart = num
+	return nil
+}
+
+// PostInsertBlock will try to advance the reward ledger by one block.
+func (r *RewardModule) PostInsertBlock(block *types.Block) error {
+	if !r.isLedgerEnabled() {
+		return nil
+	}
+	return r.indexLedgerBlock(block.NumberU64())
+}
+
+func (r *RewardModule) RewindTo(newBlock *types.Block) {
+	if !r.isLedgerEnabled() {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The blocks above the new head will be re-indexed by the next PostInsertBlock or catchup.
+	newLast := newBlock.NumberU64()
+	if r.ledgerLast > newLast {
+		r.ledgerLast = newLast
+		WriteLedgerLastNumber(r.ChainKv, newLast)
+	}
+	if r.ledgerStart > newLast+1 {
+		r.ledgerStart = newLast + 1
+		WriteLedgerStartNumber(r.ChainKv, newLast+1)
+	}
+}
+
+func (r *RewardModule) RewindDelete(hash common.Hash, num uint64) {
+	if !r.isLedgerEnabled() {
+		return
+	}
+	DeleteLedgerBlock(r.ChainKv, num)
+}
+
+func (r *RewardModule) GetRewardsByAddress(addr common.Address, lower, upper uint64) ([]*reward.LedgerEntry, error) {
+	if !r.isLedgerEnabled() {
+		return nil, reward.ErrLedgerDisabled
+	}
+	if lower > upper {
+		return nil, reward.ErrInvalidBlockRange
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if lower < r.ledgerStart || upper > r.ledgerLast {
+		return nil, reward.ErrLedgerNotIndexed
+	}
+	return ReadLedgerEntries(r.ChainKv, addr, lower, upper), nil
+}
+
+// getRecipientRewards retrospectively calculates the block reward of the given block number,
+// and breaks down the reward of each recipient into proposer, stakers, KIF and KEF.
+func (r *RewardModule) getRecipientRewards(num uint64) (map[common.Address]*reward.RecipientReward, error) {
+	config, header, totalFee, err := r.loadBlockData(num)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := r.getDeferredReward(config, header, totalFee)
+	if err != nil {
+		return nil, err
+	}
+	spec, err = r.specWithNonDeferredFee(spec, config, header, totalFee)
+	if err != nil {
+		return nil, err
+	}
+
+	var si *staking.StakingInfo
+	if !config.IsSimple {
+		if si, err = r.StakingModule.GetStakingInfo(num); err != nil {
+			return nil, err
+		}
+	}
+	return splitRecipientRewards(config, spec, si), nil
+}
+
+// splitRecipientRewards breaks down the spec.Rewards into the reward components.
+// The staking rewards and the funds are identified first, and the rest is considered as the proposer reward.
+// si must not be nil unless config.IsSimple.
+func splitRecipientRewards(config *reward.RewardConfig, spec *reward.RewardSpec, si *staking.StakingInfo) map[common.Address]*reward.RecipientReward {
+	rewards := make(map[common.Address]*reward.RecipientReward)
+	for addr := range spec.Rewards {
+		rewards[addr] = reward.NewRecipientReward()
+	}
+
+	if !config.IsSimple {
+		if config.Rules.IsKore {
+			validators, _, _ := config.RewardRatio.Split(config.MintingAmount)
+			_, stakers := config.Kip82Ratio.Split(validators)
+			alloc, _ := assignStakingRewards(config, stakers, si)
+			for addr, amount := range alloc {
+				if rr, ok := rewards[addr]; ok {
+					rr.Stakers.Add(rr.Stakers, amount)
+				}
+			}
+		}
+		if rr, ok := rewards[si.KIFAddr]; ok && !common.EmptyAddress(si.KIFAddr) {
+			rr.KIF.Add(rr.KIF, spec.KIF)
+		}
+		if rr, ok := rewards[si.KEFAddr]; ok && !common.EmptyAddress(si.KEFAddr) {
+			rr.KEF.Add(rr.KEF, spec.KEF)
+		}
+	}
+
+	for addr, amount := range spec.Rewards {
+		rr := rewards[addr]
+		rr.Proposer = calcRemainder(amount, rr.Stakers, rr.KIF, rr.KEF)
+	}
+	return rewards
+}
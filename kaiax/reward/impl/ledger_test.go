@@ -0,0 +1,191 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/reward"
+	"github.com/kaiachain/kaia/storage/database"
+	chain_mock "github.com/kaiachain/kaia/work/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeRecipientReward(proposer, stakers, kif, kef int64) *reward.RecipientReward {
+	return &reward.RecipientReward{
+		Proposer: big.NewInt(proposer),
+		Stakers:  big.NewInt(stakers),
+		KIF:      big.NewInt(kif),
+		KEF:      big.NewInt(kef),
+	}
+}
+
+func assertRecipientReward(t *testing.T, expected, actual *reward.RecipientReward, msg interface{}) {
+	require.NotNil(t, actual, msg)
+	assert.Equal(t, expected.Proposer.String(), actual.Proposer.String(), msg)
+	assert.Equal(t, expected.Stakers.String(), actual.Stakers.String(), msg)
+	assert.Equal(t, expected.KIF.String(), actual.KIF.String(), msg)
+	assert.Equal(t, expected.KEF.String(), actual.KEF.String(), msg)
+}
+
+func TestGetRecipientRewards(t *testing.T) {
+	testcases := []struct {
+		desc     string
+		simple   bool
+		deferred bool
+		prague   bool
+		expected map[common.Address]*reward.RecipientReward
+	}{
+		{
+			"simple deferred", true, true, false,
+			map[common.Address]*reward.RecipientReward{
+				common.HexToAddress("0xfff"): makeRecipientReward(6.4188e18, 0, 0, 0),
+			},
+		},
+		{
+			"full non-deferred", false, false, false,
+			map[common.Address]*reward.RecipientReward{
+				common.HexToAddress("0xfff"): makeRecipientReward(0.6588e18+1, 0, 0, 0),
+				common.HexToAddress("0xd01"): makeRecipientReward(0, 0, 1.28e18, 0),
+				common.HexToAddress("0xd02"): makeRecipientReward(0, 0, 0, 1.92e18),
+				common.HexToAddress("0xc01"): makeRecipientReward(0, 426666666666666666, 0, 0),
+				common.HexToAddress("0xc02"): makeRecipientReward(0, 853333333333333333, 0, 0),
+				common.HexToAddress("0xc03"): makeRecipientReward(0, 1280000000000000000, 0, 0),
+			},
+		},
+		{
+			"full deferred with CL", false, true, true,
+			map[common.Address]*reward.RecipientReward{
+				common.HexToAddress("0xfff"): makeRecipientReward(0.64e18+1, 0, 0, 0),
+				common.HexToAddress("0xd01"): makeRecipientReward(0, 0, 1.28e18, 0),
+				common.HexToAddress("0xd02"): makeRecipientReward(0, 0, 0, 1.92e18),
+				common.HexToAddress("0xc01"): makeRecipientReward(0, 355555626666666667, 0, 0),
+				common.HexToAddress("0xc02"): makeRecipientReward(0, 609524053333333334, 0, 0),
+				common.HexToAddress("0xc03"): makeRecipientReward(0, 800000480000000000, 0, 0),
+				common.HexToAddress("0xe01"): makeRecipientReward(0, 71111039999999999, 0, 0),
+				common.HexToAddress("0xe02"): makeRecipientReward(0, 243809279999999999, 0, 0),
+				common.HexToAddress("0xe03"): makeRecipientReward(0, 479999520000000000, 0, 0),
+			},
+		},
+	}
+	for _, tc := range testcases {
+		header, txs, receipts := makeTestKaiaBlock(61)
+		r := makeTestRewardModule(t, tc.simple, tc.deferred, tc.prague, header, txs, receipts)
+
+		rewards, err := r.getRecipientRewards(header.Number.Uint64())
+		require.Nil(t, err)
+		require.Equal(t, len(tc.expected), len(rewards), tc.desc)
+		for addr, expected := range tc.expected {
+			assertRecipientReward(t, expected, rewards[addr], tc.desc)
+		}
+
+		// The breakdown must sum up to the block reward of each recipient.
+		spec, err := r.GetBlockReward(header.Number.Uint64())
+		require.Nil(t, err)
+		for addr, amount := range spec.Rewards {
+			assert.Equal(t, amount.String(), rewards[addr].Total().String(), tc.desc)
+		}
+	}
+}
+
+func TestLedger(t *testing.T) {
+	var (
+		header, txs, receipts = makeTestKaiaBlock(61)
+		r                     = makeTestRewardModule(t, false, true, false, header, txs, receipts)
+		db                    = database.NewMemDB()
+		kif                   = common.HexToAddress("0xd01")
+	)
+	r.ChainKv = db
+	r.LedgerConfig = &reward.LedgerConfig{Enable: true}
+	r.ledgerStart, r.ledgerLast = 61, 60
+
+	// Not indexed yet
+	_, err := r.GetRewardsByAddress(kif, 61, 61)
+	assert.Equal(t, reward.ErrLedgerNotIndexed, err)
+
+	// Blocks other than the next one are ignored
+	require.Nil(t, r.PostInsertBlock(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(62)})))
+	assert.Equal(t, uint64(60), r.ledgerLast)
+
+	require.Nil(t, r.PostInsertBlock(types.NewBlock(header, txs, receipts)))
+	assert.Equal(t, uint64(61), r.ledgerLast)
+	assert.Equal(t, uint64(61), *ReadLedgerLastNumber(db))
+
+	entries, err := r.GetRewardsByAddress(kif, 61, 61)
+	require.Nil(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, uint64(61), entries[0].BlockNumber)
+	assert.Equal(t, big.NewInt(1.28e18).String(), entries[0].Total.String())
+	assertRecipientReward(t, makeRecipientReward(0, 0, 1.28e18, 0), &entries[0].RecipientReward, "kif entry")
+
+	entries, err = r.GetRewardsByAddress(common.HexToAddress("0xc04"), 61, 61)
+	require.Nil(t, err)
+	assert.Empty(t, entries)
+
+	// Rewind below the indexed range
+	r.RewindTo(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(59)}))
+	r.RewindDelete(common.Hash{}, 61)
+	assert.Equal(t, uint64(59), r.ledgerLast)
+	assert.Equal(t, uint64(60), r.ledgerStart)
+	assert.Equal(t, uint64(60), *ReadLedgerStartNumber(db))
+	assert.Empty(t, ReadLedgerEntries(db, kif, 0, 100))
+
+	// Disabled ledger
+	r.LedgerConfig.Enable = false
+	_, err = r.GetRewardsByAddress(kif, 61, 61)
+	assert.Equal(t, reward.ErrLedgerDisabled, err)
+}
+
+func TestLedgerBackfill(t *testing.T) {
+	var (
+		header, txs, receipts = makeTestKaiaBlock(61)
+		r                     = makeTestRewardModule(t, false, true, false, header, txs, receipts)
+		db                    = database.NewMemDB()
+		kif                   = common.HexToAddress("0xd01")
+		chain                 = r.Chain.(*chain_mock.MockBlockChain)
+	)
+	r.ChainKv = db
+	r.LedgerConfig = &reward.LedgerConfig{Enable: true}
+
+	// The ledger was enabled after block 61, and block 60 lacks its receipts.
+	WriteLedgerStartNumber(db, 62)
+	WriteLedgerLastNumber(db, 61)
+	chain.EXPECT().CurrentBlock().Return(types.NewBlock(header, txs, receipts)).AnyTimes()
+	chain.EXPECT().GetBlockByNumber(uint64(60)).Return(nil).AnyTimes()
+
+	require.Nil(t, r.Start())
+	require.Eventually(t, func() bool {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		return r.ledgerStart == 61
+	}, 5*time.Second, 10*time.Millisecond)
+	r.Stop()
+
+	// The block before the enable point is served, and the backfill stopped at the missing block.
+	assert.Equal(t, uint64(61), *ReadLedgerStartNumber(db))
+	entries, err := r.GetRewardsByAddress(kif, 61, 61)
+	require.Nil(t, err)
+	require.Len(t, entries, 1)
+	assertRecipientReward(t, makeRecipientReward(0, 0, 1.28e18, 0), &entries[0].RecipientReward, "backfilled kif entry")
+	_, err = r.GetRewardsByAddress(kif, 60, 61)
+	assert.Equal(t, reward.ErrLedgerNotIndexed, err)
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/reward"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/storage/database"
+)
+
+var (
+	ledgerStartNumberKey   = []byte("rewardLedgerStartNumber")
+	ledgerLastNumberKey    = []byte("rewardLedgerLastNumber")
+	ledgerEntryPrefix      = []byte("rewardLedgerEntry")
+	ledgerRecipientsPrefix = []byte("rewardLedgerRecipients")
+)
+
+// ledgerEntryStorage is the disk format for a recipient's reward at a block.
+type ledgerEntryStorage struct {
+	Proposer []byte
+	Stakers  []byte
+	KIF      []byte
+	KEF      []byte
+}
+
+// ledgerEntryKey is sorted by address first so the entries of an address can be iterated by block number.
+func ledgerEntryKey(addr common.Address, num uint64) []byte {
+	key := append(common.CopyBytes(ledgerEntryPrefix), addr.Bytes()...)
+	return append(key, common.Int64ToByteBigEndian(num)...)
+}
+
+func ledgerRecipientsKey(num uint64) []byte {
+	return append(common.CopyBytes(ledgerRecipientsPrefix), common.Int64ToByteBigEndian(num)...)
+}
+
+func readLedgerNumber(db database.Database, key []byte) *uint64 {
+	b, err := db.Get(key)
+	if err != nil || len(b) != 8 {
+		return nil
+	}
+	num := binary.BigEndian.Uint64(b)
+	return &num
+}
+
+func writeLedgerNumber(db database.KeyValueWriter, key []byte, num uint64) {
+	if err := db.Put(key, common.Int64ToByteBigEndian(num)); err != nil {
+		logger.Crit("Failed to write reward ledger number", "key", string(key), "err", err)
+	}
+}
+
+// ReadLedgerStartNumber returns the lowest block number indexed by the reward ledger.
+// Returns nil if the ledger has never been initialized.
+func ReadLedgerStartNumber(db database.Database) *uint64 {
+	return readLedgerNumber(db, ledgerStartNumberKey)
+}
+
+func WriteLedgerStartNumber(db database.KeyValueWriter, num uint64) {
+	writeLedgerNumber(db, ledgerStartNumberKey, num)
+}
+
+// ReadLedgerLastNumber returns the highest block number indexed by the reward ledger.
+// Returns nil if the ledger has never been initialized.
+func ReadLedgerLastNumber(db database.Database) *uint64 {
+	return readLedgerNumber(db, ledgerLastNumberKey)
+}
+
+func WriteLedgerLastNumber(db database.KeyValueWriter, num uint64) {
+	writeLedgerNumber(db, ledgerLastNumberKey, num)
+}
+
+func encodeLedgerEntry(rr *reward.RecipientReward) []byte {
+	stored := &ledgerEntryStorage{
+		Proposer: rr.Proposer.Bytes(),
+		Stakers:  rr.Stakers.Bytes(),
+		KIF:      rr.KIF.Bytes(),
+		KEF:      rr.KEF.Bytes(),
+	}
+	b, err := rlp.EncodeToBytes(stored)
+	if err != nil {
+		logger.Crit("Failed to serialize reward ledger entry", "err", err)
+	}
+	return b
+}
+
+func decodeLedgerEntry(b []byte) *reward.RecipientReward {
+	stored := &ledgerEntryStorage{}
+	if err := rlp.DecodeBytes(b, stored); err != nil {
+		logger.Crit("Failed to deserialize reward ledger entry", "err", err)
+	}
+	return &reward.RecipientReward{
+		Proposer: new(big.Int).SetBytes(stored.Proposer),
+		Stakers:  new(big.Int).SetBytes(stored.Stakers),
+		KIF:      new(big.Int).SetBytes(stored.KIF),
+		KEF:      new(big.Int).SetBytes(stored.KEF),
+	}
+}
+
+// WriteLedgerBlock stores the rewards of all recipients at the given block, and
+// the list of the recipients so that the block can be deleted upon rewind.
+func WriteLedgerBlock(db database.Database, num uint64, rewards map[common.Address]*reward.RecipientReward) {
+	batch := db.NewBatch()
+	defer batch.Release()
+
+	recipients := make([]common.Address, 0, len(rewards))
+	for addr, rr := range rewards {
+		recipients = append(recipients, addr)
+		if err := batch.Put(ledgerEntryKey(addr, num), encodeLedgerEntry(rr)); err != nil {
+			logger.Crit("Failed to write reward ledger entry", "num", num, "addr", addr, "err", err)
+		}
+	}
+	b, err := rlp.EncodeToBytes(recipients)
+	if err != nil {
+		logger.Crit("Failed to serialize reward ledger recipients", "err", err)
+	}
+	if err := batch.Put(ledgerRecipientsKey(num), b); err != nil {
+		logger.Crit("Failed to write reward ledger recipients", "num", num, "err", err)
+	}
+	if err := batch.Write(); err != nil {
+		logger.Crit("Failed to write reward ledger block", "num", num, "err", err)
+	}
+}
+
+// ReadLedgerEntries returns the ledger entries of the given address in the block range [lower, upper].
+func ReadLedgerEntries(db database.Database, addr common.Address, lower, upper uint64) []*reward.LedgerEntry {
+	prefix := append(common.CopyBytes(ledgerEntryPrefix), addr.Bytes()...)
+	it := db.NewIterator(prefix, common.Int64ToByteBigEndian(lower))
+	defer it.Release()
+
+	var entries []*reward.LedgerEntry
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8 {
+			continue
+		}
+		num := binary.BigEndian.Uint64(key[len(prefix):])
+		if num > upper {
+			break
+		}
+		rr := decodeLedgerEntry(it.Value())
+		entries = append(entries, &reward.LedgerEntry{
+			BlockNumber:     num,
+			RecipientReward: *rr,
+			Total:           rr.Total(),
+		})
+	}
+	return entries
+}
+
+// DeleteLedgerBlock deletes all ledger entries at the given block.
+func DeleteLedgerBlock(db database.Database, num uint64) {
+	b, err := db.Get(ledgerRecipientsKey(num))
+	if err != nil || len(b) == 0 {
+		return
+	}
+	var recipients []common.Address
+	if err := rlp.DecodeBytes(b, &recipients); err != nil {
+		logger.Crit("Failed to deserialize reward ledger recipients", "err", err)
+	}
+	for _, addr := range recipients {
+		if err := db.Delete(ledgerEntryKey(addr, num)); err != nil {
+			logger.Crit("Failed to delete reward ledger entry", "num", num, "addr", addr, "err", err)
+		}
+	}
+	if err := db.Delete(ledgerRecipientsKey(num)); err != nil {
+		logger.Crit("Failed to delete reward ledger recipients", "num", num, "err", err)
+	}
+}
@@ -18,6 +18,7 @@ package reward
 
 import (
 	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/kaiax"
 )
 
@@ -27,6 +28,8 @@ type RewardModule interface {
 	kaiax.JsonRpcModule
 	kaiax.ConsensusModule
 	kaiax.TxProcessModule
+	kaiax.ExecutionModule
+	kaiax.RewindableModule
 
 	// GetDeferredReward returns the deferred reward specification to be distributed at the given block that is being created.
 	GetDeferredReward(header *types.Header, txs []*types.Transaction, receipts []*types.Receipt) (*RewardSpec, error)
@@ -36,4 +39,8 @@ type RewardModule interface {
 
 	// GetRewardSummary retrospectively calculates the reward summary at the given block number.
 	GetRewardSummary(num uint64) (*RewardSummary, error)
+
+	// GetRewardsByAddress returns the rewards received by the given address in the block range [lower, upper]
+	// from the reward ledger. The ledger must be enabled and the range must have been indexed.
+	GetRewardsByAddress(addr common.Address, lower, upper uint64) ([]*LedgerEntry, error)
 }
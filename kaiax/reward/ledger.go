@@ -0,0 +1,111 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/urfave/cli/v2"
+)
+
+var LedgerEnableFlag = &cli.BoolFlag{
+	Name:     "reward.ledger",
+	Usage:    "index the block rewards by recipient address to serve kaia_getRewardsByAddress",
+	Value:    false,
+	Aliases:  []string{"kaiax.module.reward.ledger"},
+	Category: "KAIAX",
+}
+
+type LedgerConfig struct {
+	Enable bool
+}
+
+func DefaultLedgerConfig() *LedgerConfig {
+	return &LedgerConfig{
+		Enable: false,
+	}
+}
+
+func SetLedgerConfig(ctx *cli.Context, cfg *LedgerConfig) {
+	cfg.Enable = ctx.Bool(LedgerEnableFlag.Name)
+}
+
+// RecipientReward is the breakdown of the reward that a recipient received in a block.
+// Proposer + Stakers + KIF + KEF equals to the RewardSpec.Rewards entry of the recipient.
+type RecipientReward struct {
+	Proposer *big.Int `json:"proposer"`
+	Stakers  *big.Int `json:"stakers"`
+	KIF      *big.Int `json:"kif"`
+	KEF      *big.Int `json:"kef"`
+}
+
+func NewRecipientReward() *RecipientReward {
+	return &RecipientReward{
+		Proposer: big.NewInt(0),
+		Stakers:  big.NewInt(0),
+		KIF:      big.NewInt(0),
+		KEF:      big.NewInt(0),
+	}
+}
+
+func (rr *RecipientReward) Add(delta *RecipientReward) {
+	rr.Proposer.Add(rr.Proposer, delta.Proposer)
+	rr.Stakers.Add(rr.Stakers, delta.Stakers)
+	rr.KIF.Add(rr.KIF, delta.KIF)
+	rr.KEF.Add(rr.KEF, delta.KEF)
+}
+
+func (rr *RecipientReward) Total() *big.Int {
+	total := new(big.Int).Add(rr.Proposer, rr.Stakers)
+	total.Add(total, rr.KIF)
+	return total.Add(total, rr.KEF)
+}
+
+// LedgerEntry is the reward that a recipient received at the given block.
+type LedgerEntry struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	RecipientReward
+	Total *big.Int `json:"total"`
+}
+
+// RewardsByAddressResponse is the response type for the kaia_getRewardsByAddress API.
+// TODO-kaiax: RewardsByAddressResponse to use hexutil.Big for big.Int fields.
+type RewardsByAddressResponse struct {
+	Address   common.Address   `json:"address"`
+	FromBlock *big.Int         `json:"fromBlock"`
+	ToBlock   *big.Int         `json:"toBlock"`
+	Total     *RecipientReward `json:"total"`
+	Rewards   []*LedgerEntry   `json:"rewards"`
+}
+
+func ToRewardsByAddressResponse(addr common.Address, lower, upper uint64, entries []*LedgerEntry) *RewardsByAddressResponse {
+	total := NewRecipientReward()
+	for _, entry := range entries {
+		total.Add(&entry.RecipientReward)
+	}
+	if entries == nil {
+		entries = []*LedgerEntry{}
+	}
+	return &RewardsByAddressResponse{
+		Address:   addr,
+		FromBlock: new(big.Int).SetUint64(lower),
+		ToBlock:   new(big.Int).SetUint64(upper),
+		Total:     total,
+		Rewards:   entries,
+	}
+}
@@ -11,6 +11,7 @@ import (
 	state "github.com/kaiachain/kaia/blockchain/state"
 	types "github.com/kaiachain/kaia/blockchain/types"
 	vm "github.com/kaiachain/kaia/blockchain/vm"
+	common "github.com/kaiachain/kaia/common"
 	reward "github.com/kaiachain/kaia/kaiax/reward"
 	rpc "github.com/kaiachain/kaia/networks/rpc"
 )
@@ -111,6 +112,35 @@ func (mr *MockRewardModuleMockRecorder) GetRewardSummary(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardSummary", reflect.TypeOf((*MockRewardModule)(nil).GetRewardSummary), arg0)
 }
 
+// GetRewardsByAddress mocks base method.
+func (m *MockRewardModule) GetRewardsByAddress(arg0 common.Address, arg1, arg2 uint64) ([]*reward.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRewardsByAddress", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*reward.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRewardsByAddress indicates an expected call of GetRewardsByAddress.
+func (mr *MockRewardModuleMockRecorder) GetRewardsByAddress(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardsByAddress", reflect.TypeOf((*MockRewardModule)(nil).GetRewardsByAddress), arg0, arg1, arg2)
+}
+
+// PostInsertBlock mocks base method.
+func (m *MockRewardModule) PostInsertBlock(arg0 *types.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInsertBlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostInsertBlock indicates an expected call of PostInsertBlock.
+func (mr *MockRewardModuleMockRecorder) PostInsertBlock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInsertBlock", reflect.TypeOf((*MockRewardModule)(nil).PostInsertBlock), arg0)
+}
+
 // PostRunTx mocks base method.
 func (m *MockRewardModule) PostRunTx(arg0 *vm.EVM, arg1 *types.Transaction) error {
 	m.ctrl.T.Helper()
@@ -154,6 +184,30 @@ func (mr *MockRewardModuleMockRecorder) PrepareHeader(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareHeader", reflect.TypeOf((*MockRewardModule)(nil).PrepareHeader), arg0)
 }
 
+// RewindDelete mocks base method.
+func (m *MockRewardModule) RewindDelete(arg0 common.Hash, arg1 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RewindDelete", arg0, arg1)
+}
+
+// RewindDelete indicates an expected call of RewindDelete.
+func (mr *MockRewardModuleMockRecorder) RewindDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewindDelete", reflect.TypeOf((*MockRewardModule)(nil).RewindDelete), arg0, arg1)
+}
+
+// RewindTo mocks base method.
+func (m *MockRewardModule) RewindTo(arg0 *types.Block) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RewindTo", arg0)
+}
+
+// RewindTo indicates an expected call of RewindTo.
+func (mr *MockRewardModuleMockRecorder) RewindTo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewindTo", reflect.TypeOf((*MockRewardModule)(nil).RewindTo), arg0)
+}
+
 // Start mocks base method.
 func (m *MockRewardModule) Start() error {
 	m.ctrl.T.Helper()
@@ -26,6 +26,8 @@ var (
 	ErrZeroStakingInterval = errors.New("staking interval cannot be zero")
 	ErrAddressBookResult   = errors.New("invalid result from AddressBook")
 	ErrCLRegistryResult    = errors.New("invalid result from CLRegistry")
+	ErrInvalidBlockRange   = errors.New("invalid block number range")
+	ErrStakingInfoLimit    = errors.New("exceeds the number of staking infos to query")
 )
 
 func ErrMultiCallCall(err error) error {
@@ -17,6 +17,7 @@
 package impl
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/kaiachain/kaia/kaiax/staking"
@@ -68,3 +69,49 @@ func (api *stakingAPI) GetStakingInfo(num rpc.BlockNumber) (*staking.StakingInfo
 	// Calculate Gini coefficient regardless of useGini flag
 	return si.ToResponse(useGini, api.s.minimumStake.Uint64()), nil
 }
+
+// GetStakingInfoTimeline returns the staking info used by the block `lower` and its changes up to the block `upper`.
+func (api *stakingAPI) GetStakingInfoTimeline(lower, upper rpc.BlockNumber) (*staking.StakingInfoTimeline, error) {
+	currentNum := api.s.Chain.CurrentBlock().NumberU64()
+	lowerNum, upperNum := lower.Uint64(), upper.Uint64()
+	if lower == rpc.LatestBlockNumber || lower == rpc.PendingBlockNumber {
+		lowerNum = currentNum
+	}
+	if upper == rpc.LatestBlockNumber || upper == rpc.PendingBlockNumber {
+		upperNum = currentNum
+	}
+	if lowerNum > upperNum || upperNum > currentNum+1 {
+		return nil, staking.ErrInvalidBlockRange
+	}
+	return api.s.GetStakingInfoTimeline(lowerNum, upperNum)
+}
+
+// StakingInfoChanges creates a subscription that is notified when the staking info changes.
+func (api *stakingAPI) StakingInfoChanges(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		changes := make(chan *staking.StakingInfoChange)
+		sub := api.s.SubscribeStakingInfoChange(changes)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case change := <-changes:
+				notifier.Notify(rpcSub.ID, change)
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
@@ -30,6 +30,11 @@ func (s *StakingModule) PostInsertBlock(block *types.Block) error {
 			return err
 		}
 	}
+
+	// The notification is best-effort and must not fail the block insertion.
+	if err := s.notifyStakingInfoChange(block); err != nil {
+		logger.Warn("Failed to notify staking info change", "num", block.NumberU64(), "err", err)
+	}
 	return nil
 }
 
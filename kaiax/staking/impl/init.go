@@ -21,6 +21,7 @@ import (
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/kaiachain/kaia/accounts/abi/bind/backends"
+	"github.com/kaiachain/kaia/event"
 	"github.com/kaiachain/kaia/kaiax/staking"
 	"github.com/kaiachain/kaia/log"
 	"github.com/kaiachain/kaia/params"
@@ -49,6 +50,9 @@ type StakingModule struct {
 
 	stakingInfoCache *lru.ARCCache // cached by sourceNum
 	preloadBuffer    *PreloadBuffer
+
+	changeFeed event.Feed // StakingInfoChange notifications
+	scope      event.SubscriptionScope
 }
 
 func NewStakingModule() *StakingModule {
@@ -80,8 +84,11 @@ func (s *StakingModule) Init(opts *InitOpts) error {
 
 func (s *StakingModule) Start() error {
 	// If the module is restarted after a rewind, the cache is already purged by the RewindTo.
+	// The subscriptions were closed by Stop, so start over with a fresh scope.
+	s.scope = event.SubscriptionScope{}
 	return nil
 }
 
 func (s *StakingModule) Stop() {
+	s.scope.Close()
 }
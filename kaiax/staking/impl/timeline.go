@@ -0,0 +1,106 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"math/big"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/event"
+	"github.com/kaiachain/kaia/kaiax/staking"
+)
+
+// Naive resource protection. Since Kaia hardfork, every block has a distinct staking info
+// which has to be read from the state.
+var stakingTimelineSourceLimit = 3600
+
+func (s *StakingModule) sourceBlockNum(num uint64) uint64 {
+	isKaia := s.ChainConfig.IsKaiaForkEnabled(new(big.Int).SetUint64(num))
+	return sourceBlockNum(num, isKaia, s.stakingInterval)
+}
+
+func (s *StakingModule) GetStakingInfoTimeline(lower, upper uint64) (*staking.StakingInfoTimeline, error) {
+	if lower > upper {
+		return nil, staking.ErrInvalidBlockRange
+	}
+
+	prev, err := s.GetStakingInfo(lower)
+	if err != nil {
+		return nil, err
+	}
+	timeline := &staking.StakingInfoTimeline{
+		FromBlock: lower,
+		ToBlock:   upper,
+		Initial:   prev,
+		Changes:   []*staking.StakingInfoChange{},
+	}
+
+	var (
+		prevSourceNum = s.sourceBlockNum(lower)
+		sources       = 1
+	)
+	for num := lower + 1; num <= upper; num++ {
+		// Skip the blocks sharing the same source block. They have the identical staking info.
+		sourceNum := s.sourceBlockNum(num)
+		if sourceNum == prevSourceNum {
+			continue
+		}
+		if sources++; sources > stakingTimelineSourceLimit {
+			return nil, staking.ErrStakingInfoLimit
+		}
+
+		si, err := s.GetStakingInfo(num)
+		if err != nil {
+			return nil, err
+		}
+		if diff := staking.DiffStakingInfo(prev, si); !diff.IsEmpty() {
+			timeline.Changes = append(timeline.Changes, &staking.StakingInfoChange{BlockNum: num, StakingInfoDiff: *diff})
+		}
+		prev, prevSourceNum = si, sourceNum
+	}
+	return timeline, nil
+}
+
+func (s *StakingModule) SubscribeStakingInfoChange(ch chan<- *staking.StakingInfoChange) event.Subscription {
+	return s.scope.Track(s.changeFeed.Subscribe(ch))
+}
+
+// notifyStakingInfoChange sends a StakingInfoChange if the block after the given block
+// uses a different staking info from the given block. Does nothing if there is no subscriber.
+func (s *StakingModule) notifyStakingInfoChange(block *types.Block) error {
+	if s.scope.Count() == 0 {
+		return nil
+	}
+
+	num := block.NumberU64()
+	if s.sourceBlockNum(num) == s.sourceBlockNum(num+1) {
+		return nil
+	}
+
+	prev, err := s.GetStakingInfo(num)
+	if err != nil {
+		return err
+	}
+	next, err := s.GetStakingInfo(num + 1)
+	if err != nil {
+		return err
+	}
+	if diff := staking.DiffStakingInfo(prev, next); !diff.IsEmpty() {
+		s.changeFeed.Send(&staking.StakingInfoChange{BlockNum: num + 1, StakingInfoDiff: *diff})
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/staking"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
+	chain_mock "github.com/kaiachain/kaia/work/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeTimelineTestModule returns a pre-Kaia StakingModule with interval 10, whose cache is filled with
+// the staking infos at source blocks 0, 10, 20 and 30.
+// - 0 -> 10: the staking amount of n1 changes
+// - 10 -> 20: no change
+// - 20 -> 30: n2 is added
+func makeTimelineTestModule(t *testing.T) *StakingModule {
+	var (
+		n1, n2 = common.HexToAddress("0xa01"), common.HexToAddress("0xa02")
+		s1, s2 = common.HexToAddress("0xb01"), common.HexToAddress("0xb02")
+		r1, r2 = common.HexToAddress("0xc01"), common.HexToAddress("0xc02")
+		config = &params.ChainConfig{
+			ChainID: common.Big1,
+			Governance: &params.GovernanceConfig{
+				Reward: &params.RewardConfig{StakingUpdateInterval: 10},
+			},
+		}
+		makeInfo = func(num uint64, nodeIds, stakingContracts, rewardAddrs []common.Address, amounts []uint64) *staking.StakingInfo {
+			return &staking.StakingInfo{
+				SourceBlockNum:   num,
+				NodeIds:          nodeIds,
+				StakingContracts: stakingContracts,
+				RewardAddrs:      rewardAddrs,
+				StakingAmounts:   amounts,
+			}
+		}
+	)
+
+	s := NewStakingModule()
+	require.Nil(t, s.Init(&InitOpts{
+		ChainKv:     database.NewMemDB(),
+		ChainConfig: config,
+		Chain:       chain_mock.NewMockBlockChain(gomock.NewController(t)),
+	}))
+	s.stakingInfoCache.Add(uint64(0), makeInfo(0, []common.Address{n1}, []common.Address{s1}, []common.Address{r1}, []uint64{5_000_000}))
+	s.stakingInfoCache.Add(uint64(10), makeInfo(10, []common.Address{n1}, []common.Address{s1}, []common.Address{r1}, []uint64{6_000_000}))
+	s.stakingInfoCache.Add(uint64(20), makeInfo(20, []common.Address{n1}, []common.Address{s1}, []common.Address{r1}, []uint64{6_000_000}))
+	s.stakingInfoCache.Add(uint64(30), makeInfo(30, []common.Address{n1, n2}, []common.Address{s1, s2}, []common.Address{r1, r2}, []uint64{6_000_000, 7_000_000}))
+	return s
+}
+
+func TestGetStakingInfoTimeline(t *testing.T) {
+	s := makeTimelineTestModule(t)
+
+	timeline, err := s.GetStakingInfoTimeline(1, 50)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(0), timeline.Initial.SourceBlockNum)
+	require.Len(t, timeline.Changes, 2)
+
+	// Block 21 is the first block to use the staking info from block 10.
+	assert.Equal(t, uint64(21), timeline.Changes[0].BlockNum)
+	assert.Equal(t, uint64(0), timeline.Changes[0].FromSourceBlockNum)
+	assert.Equal(t, uint64(10), timeline.Changes[0].ToSourceBlockNum)
+	require.Len(t, timeline.Changes[0].Nodes, 1)
+	assert.Equal(t, staking.NodeModified, timeline.Changes[0].Nodes[0].Change)
+
+	// Block 41 is the first block to use the staking info from block 30.
+	assert.Equal(t, uint64(41), timeline.Changes[1].BlockNum)
+	assert.Equal(t, uint64(20), timeline.Changes[1].FromSourceBlockNum)
+	require.Len(t, timeline.Changes[1].Nodes, 1)
+	assert.Equal(t, staking.NodeAdded, timeline.Changes[1].Nodes[0].Change)
+
+	_, err = s.GetStakingInfoTimeline(2, 1)
+	assert.Equal(t, staking.ErrInvalidBlockRange, err)
+}
+
+func TestSubscribeStakingInfoChange(t *testing.T) {
+	s := makeTimelineTestModule(t)
+
+	ch := make(chan *staking.StakingInfoChange, 10)
+	sub := s.SubscribeStakingInfoChange(ch)
+	defer sub.Unsubscribe()
+
+	for num := int64(25); num <= 45; num++ {
+		require.Nil(t, s.PostInsertBlock(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(num)})))
+	}
+
+	// Only the change at block 41 is notified. The source block changes at block 31 without any change.
+	require.Len(t, ch, 1)
+	change := <-ch
+	assert.Equal(t, uint64(41), change.BlockNum)
+	assert.Equal(t, uint64(30), change.ToSourceBlockNum)
+}
+
+func TestSubscribeStakingInfoChangeStop(t *testing.T) {
+	s := makeTimelineTestModule(t)
+
+	ch := make(chan *staking.StakingInfoChange, 10)
+	sub := s.SubscribeStakingInfoChange(ch)
+
+	// Stop closes the subscriptions.
+	s.Stop()
+	select {
+	case <-sub.Err():
+	case <-time.After(time.Second):
+		t.Fatal("subscription not closed by Stop")
+	}
+
+	// Subscribing works again after a restart.
+	require.Nil(t, s.Start())
+	sub = s.SubscribeStakingInfoChange(ch)
+	require.NotNil(t, sub)
+	sub.Unsubscribe()
+}
@@ -19,6 +19,7 @@ package staking
 import (
 	"github.com/kaiachain/kaia/blockchain/state"
 	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/event"
 	"github.com/kaiachain/kaia/kaiax"
 )
 
@@ -46,6 +47,14 @@ type StakingModule interface {
 	AllocPreloadRef() uint64
 	FreePreloadRef(refId uint64)
 	PreloadFromState(refId uint64, header *types.Header, statedb *state.StateDB) error
+
+	// GetStakingInfoTimeline returns the staking info used by the block `lower`,
+	// followed by its changes over the blocks up to `upper`.
+	GetStakingInfoTimeline(lower, upper uint64) (*StakingInfoTimeline, error)
+
+	// SubscribeStakingInfoChange subscribes to the staking info changes.
+	// A change is sent when a new block is inserted and the next block will use a different staking info.
+	SubscribeStakingInfoChange(ch chan<- *StakingInfoChange) event.Subscription
 }
 
 type StakingModuleHost interface {
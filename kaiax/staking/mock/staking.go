@@ -11,6 +11,7 @@ import (
 	state "github.com/kaiachain/kaia/blockchain/state"
 	types "github.com/kaiachain/kaia/blockchain/types"
 	common "github.com/kaiachain/kaia/common"
+	event "github.com/kaiachain/kaia/event"
 	staking "github.com/kaiachain/kaia/kaiax/staking"
 	rpc "github.com/kaiachain/kaia/networks/rpc"
 )
@@ -107,6 +108,21 @@ func (mr *MockStakingModuleMockRecorder) GetStakingInfoFromDB(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakingInfoFromDB", reflect.TypeOf((*MockStakingModule)(nil).GetStakingInfoFromDB), arg0)
 }
 
+// GetStakingInfoTimeline mocks base method.
+func (m *MockStakingModule) GetStakingInfoTimeline(arg0, arg1 uint64) (*staking.StakingInfoTimeline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakingInfoTimeline", arg0, arg1)
+	ret0, _ := ret[0].(*staking.StakingInfoTimeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakingInfoTimeline indicates an expected call of GetStakingInfoTimeline.
+func (mr *MockStakingModuleMockRecorder) GetStakingInfoTimeline(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakingInfoTimeline", reflect.TypeOf((*MockStakingModule)(nil).GetStakingInfoTimeline), arg0, arg1)
+}
+
 // PostInsertBlock mocks base method.
 func (m *MockStakingModule) PostInsertBlock(arg0 *types.Block) error {
 	m.ctrl.T.Helper()
@@ -196,3 +212,17 @@ func (mr *MockStakingModuleMockRecorder) Stop() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockStakingModule)(nil).Stop))
 }
+
+// SubscribeStakingInfoChange mocks base method.
+func (m *MockStakingModule) SubscribeStakingInfoChange(arg0 chan<- *staking.StakingInfoChange) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeStakingInfoChange", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeStakingInfoChange indicates an expected call of SubscribeStakingInfoChange.
+func (mr *MockStakingModuleMockRecorder) SubscribeStakingInfoChange(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeStakingInfoChange", reflect.TypeOf((*MockStakingModule)(nil).SubscribeStakingInfoChange), arg0)
+}
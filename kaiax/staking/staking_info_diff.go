@@ -0,0 +1,140 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package staking
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/kaiachain/kaia/common"
+)
+
+const (
+	NodeAdded    = "added"
+	NodeRemoved  = "removed"
+	NodeModified = "modified"
+)
+
+// NodeStakingEntry is the staking status of a single NodeId.
+// CL fields are empty if the node has no consensus liquidity.
+type NodeStakingEntry struct {
+	StakingContract common.Address `json:"stakingContract"`
+	RewardAddr      common.Address `json:"rewardAddr"`
+	StakingAmount   uint64         `json:"stakingAmount"`
+	CLPoolAddr      common.Address `json:"clPoolAddr"`
+	CLStakingAmount uint64         `json:"clStakingAmount"`
+}
+
+// NodeDiff describes how a NodeId has changed between two StakingInfos.
+// Old is nil if the node is added, and New is nil if the node is removed.
+type NodeDiff struct {
+	NodeId common.Address    `json:"nodeId"`
+	Change string            `json:"change"`
+	Old    *NodeStakingEntry `json:"old"`
+	New    *NodeStakingEntry `json:"new"`
+}
+
+// AddressChange describes a changed address field.
+type AddressChange struct {
+	Old common.Address `json:"old"`
+	New common.Address `json:"new"`
+}
+
+// StakingInfoDiff describes the difference between two StakingInfos.
+type StakingInfoDiff struct {
+	FromSourceBlockNum uint64         `json:"fromSourceBlockNum"`
+	ToSourceBlockNum   uint64         `json:"toSourceBlockNum"`
+	KEFAddr            *AddressChange `json:"kefAddr,omitempty"`
+	KIFAddr            *AddressChange `json:"kifAddr,omitempty"`
+	Nodes              []*NodeDiff    `json:"nodes"`
+}
+
+// StakingInfoChange is a StakingInfoDiff that takes effect from BlockNum.
+// That is, the block BlockNum uses the StakingInfo different from the one of the block BlockNum-1.
+type StakingInfoChange struct {
+	BlockNum uint64 `json:"blockNum"`
+	StakingInfoDiff
+}
+
+// StakingInfoTimeline is the response type for the kaia_getStakingInfoTimeline API.
+type StakingInfoTimeline struct {
+	FromBlock uint64               `json:"fromBlock"`
+	ToBlock   uint64               `json:"toBlock"`
+	Initial   *StakingInfo         `json:"initial"`
+	Changes   []*StakingInfoChange `json:"changes"`
+}
+
+func (d *StakingInfoDiff) IsEmpty() bool {
+	return d.KEFAddr == nil && d.KIFAddr == nil && len(d.Nodes) == 0
+}
+
+// NodeEntries returns the staking status of each NodeId, including the CL staking info if any.
+func (si *StakingInfo) NodeEntries() map[common.Address]*NodeStakingEntry {
+	entries := make(map[common.Address]*NodeStakingEntry, len(si.NodeIds))
+	for i, n := range si.NodeIds {
+		entries[n] = &NodeStakingEntry{
+			StakingContract: si.StakingContracts[i],
+			RewardAddr:      si.RewardAddrs[i],
+			StakingAmount:   si.StakingAmounts[i],
+		}
+	}
+	for _, clsi := range si.CLStakingInfos {
+		// CLStakingInfo without a matching NodeId is ignored as in ConsolidatedNodes().
+		if entry, ok := entries[clsi.CLNodeId]; ok {
+			entry.CLPoolAddr = clsi.CLPoolAddr
+			entry.CLStakingAmount = clsi.CLStakingAmount
+		}
+	}
+	return entries
+}
+
+// DiffStakingInfo compares two StakingInfos by NodeId. The nodes are sorted by NodeId.
+func DiffStakingInfo(from, to *StakingInfo) *StakingInfoDiff {
+	diff := &StakingInfoDiff{
+		FromSourceBlockNum: from.SourceBlockNum,
+		ToSourceBlockNum:   to.SourceBlockNum,
+		Nodes:              []*NodeDiff{},
+	}
+	if from.KEFAddr != to.KEFAddr {
+		diff.KEFAddr = &AddressChange{Old: from.KEFAddr, New: to.KEFAddr}
+	}
+	if from.KIFAddr != to.KIFAddr {
+		diff.KIFAddr = &AddressChange{Old: from.KIFAddr, New: to.KIFAddr}
+	}
+
+	var (
+		oldEntries = from.NodeEntries()
+		newEntries = to.NodeEntries()
+	)
+	for n, oldEntry := range oldEntries {
+		newEntry, ok := newEntries[n]
+		if !ok {
+			diff.Nodes = append(diff.Nodes, &NodeDiff{NodeId: n, Change: NodeRemoved, Old: oldEntry})
+		} else if *oldEntry != *newEntry {
+			diff.Nodes = append(diff.Nodes, &NodeDiff{NodeId: n, Change: NodeModified, Old: oldEntry, New: newEntry})
+		}
+	}
+	for n, newEntry := range newEntries {
+		if _, ok := oldEntries[n]; !ok {
+			diff.Nodes = append(diff.Nodes, &NodeDiff{NodeId: n, Change: NodeAdded, New: newEntry})
+		}
+	}
+	sort.Slice(diff.Nodes, func(i, j int) bool {
+		return bytes.Compare(diff.Nodes[i].NodeId.Bytes(), diff.Nodes[j].NodeId.Bytes()) < 0
+	})
+	return diff
+}
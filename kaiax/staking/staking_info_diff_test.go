@@ -0,0 +1,82 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package staking
+
+import (
+	"testing"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffStakingInfo(t *testing.T) {
+	var (
+		n1, n2, n3 = common.HexToAddress("0xa01"), common.HexToAddress("0xa02"), common.HexToAddress("0xa03")
+		s1, s2, s3 = common.HexToAddress("0xb01"), common.HexToAddress("0xb02"), common.HexToAddress("0xb03")
+		r1, r2, r3 = common.HexToAddress("0xc01"), common.HexToAddress("0xc02"), common.HexToAddress("0xc03")
+		kef, kif   = common.HexToAddress("0xd01"), common.HexToAddress("0xd02")
+		newKif     = common.HexToAddress("0xd03")
+		clPool     = common.HexToAddress("0xe01")
+
+		from = &StakingInfo{
+			SourceBlockNum:   100,
+			NodeIds:          []common.Address{n1, n2},
+			StakingContracts: []common.Address{s1, s2},
+			RewardAddrs:      []common.Address{r1, r2},
+			KEFAddr:          kef,
+			KIFAddr:          kif,
+			StakingAmounts:   []uint64{5_000_000, 6_000_000},
+		}
+	)
+
+	// Identical
+	assert.True(t, DiffStakingInfo(from, from).IsEmpty())
+
+	// n1 gets CL, n2 removed, n3 added, KIF changed
+	to := &StakingInfo{
+		SourceBlockNum:   200,
+		NodeIds:          []common.Address{n1, n3},
+		StakingContracts: []common.Address{s1, s3},
+		RewardAddrs:      []common.Address{r1, r3},
+		KEFAddr:          kef,
+		KIFAddr:          newKif,
+		StakingAmounts:   []uint64{5_000_000, 7_000_000},
+		CLStakingInfos: CLStakingInfos{
+			{CLNodeId: n1, CLPoolAddr: clPool, CLStakingAmount: 1_000_000},
+		},
+	}
+	assert.Equal(t, &StakingInfoDiff{
+		FromSourceBlockNum: 100,
+		ToSourceBlockNum:   200,
+		KIFAddr:            &AddressChange{Old: kif, New: newKif},
+		Nodes: []*NodeDiff{
+			{
+				NodeId: n1, Change: NodeModified,
+				Old: &NodeStakingEntry{StakingContract: s1, RewardAddr: r1, StakingAmount: 5_000_000},
+				New: &NodeStakingEntry{StakingContract: s1, RewardAddr: r1, StakingAmount: 5_000_000, CLPoolAddr: clPool, CLStakingAmount: 1_000_000},
+			},
+			{
+				NodeId: n2, Change: NodeRemoved,
+				Old: &NodeStakingEntry{StakingContract: s2, RewardAddr: r2, StakingAmount: 6_000_000},
+			},
+			{
+				NodeId: n3, Change: NodeAdded,
+				New: &NodeStakingEntry{StakingContract: s3, RewardAddr: r3, StakingAmount: 7_000_000},
+			},
+		},
+	}, DiffStakingInfo(from, to))
+}
@@ -27,6 +27,8 @@ var (
 	ErrNoRebalanceMemo    = errors.New("rebalance memo empty")
 	ErrSupplyModuleQuit   = errors.New("supply module quit")
 	ErrNoSupplyCheckpoint = errors.New("supply checkpoint not found")
+	ErrInvalidBlockRange  = errors.New("invalid block range")
+	ErrSupplyRangeLimit   = errors.New("too many blocks in the supply range")
 )
 
 func ErrNoCanonicalBurn(err error) error {
@@ -17,6 +17,8 @@
 package supply
 
 import (
+	"errors"
+
 	"github.com/kaiachain/kaia/kaiax/supply"
 	"github.com/kaiachain/kaia/networks/rpc"
 )
@@ -76,3 +78,41 @@ func (api *SupplyAPI) GetTotalSupply(num *rpc.BlockNumber, showPartial *bool) (*
 	// 3. Deliver full result
 	return response, nil
 }
+
+// Naive resource protection. Each block may require a state lookup and a re-accumulation from the nearest checkpoint.
+var supplyRangeLimit = uint64(1000)
+
+func (api *SupplyAPI) GetTotalSupplyRange(from, to rpc.BlockNumber, interval uint64, showPartial *bool) ([]*supply.TotalSupplyResponse, error) {
+	var (
+		fromNum = api.resolveBlockNumber(from)
+		toNum   = api.resolveBlockNumber(to)
+	)
+	if fromNum > toNum || interval == 0 {
+		return nil, supply.ErrInvalidBlockRange
+	}
+	if (toNum-fromNum)/interval+1 > supplyRangeLimit {
+		return nil, supply.ErrSupplyRangeLimit
+	}
+	shouldShowPartial := showPartial != nil && *showPartial
+
+	responses, err := api.s.GetTotalSupplyRange(fromNum, toNum, interval)
+	if err != nil {
+		return nil, err
+	}
+	if !shouldShowPartial {
+		// Fail on any partial (incomplete) result, like GetTotalSupply.
+		for _, response := range responses {
+			if response.Error != nil {
+				return nil, errors.New(*response.Error)
+			}
+		}
+	}
+	return responses, nil
+}
+
+func (api *SupplyAPI) resolveBlockNumber(num rpc.BlockNumber) uint64 {
+	if num == rpc.LatestBlockNumber || num == rpc.PendingBlockNumber {
+		return api.s.Chain.CurrentBlock().NumberU64()
+	}
+	return num.Uint64()
+}
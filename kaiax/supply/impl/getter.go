@@ -82,6 +82,40 @@ func (s *SupplyModule) GetTotalSupply(num uint64) (*supply.TotalSupply, error) {
 	return ts, errors.Join(errs...)
 }
 
+// GetTotalSupplyRange returns the total supply sampled every interval blocks in [from, to].
+// The last block of the range is always included.
+func (s *SupplyModule) GetTotalSupplyRange(from, to, interval uint64) ([]*supply.TotalSupplyResponse, error) {
+	nums, err := sampleBlockNums(from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*supply.TotalSupplyResponse, 0, len(nums))
+	for _, num := range nums {
+		ts, err := s.GetTotalSupply(num)
+		if ts == nil {
+			return nil, err
+		}
+		responses = append(responses, ts.ToResponse(num, err))
+	}
+	return responses, nil
+}
+
+// sampleBlockNums returns from, from+interval, from+2*interval, ... up to `to`. `to` is always included.
+func sampleBlockNums(from, to, interval uint64) ([]uint64, error) {
+	if from > to || interval == 0 {
+		return nil, supply.ErrInvalidBlockRange
+	}
+	nums := make([]uint64, 0, (to-from)/interval+2)
+	for num := from; num < to; num += interval {
+		nums = append(nums, num)
+		if num+interval < num { // overflow
+			break
+		}
+	}
+	return append(nums, to), nil
+}
+
 // totalSupplyFromState exhausitively traverses all accounts in the state at the given block number.
 func (s *SupplyModule) totalSupplyFromState(num uint64) (*big.Int, error) {
 	header := s.Chain.GetHeaderByNumber(num)
@@ -17,7 +17,9 @@
 package supply
 
 import (
+	"math"
 	"math/big"
+	"testing"
 
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/kaiax/supply"
@@ -188,3 +190,53 @@ func (s *SupplyTestSuite) TestGetTotalSupply_PartialInfo() {
 	assert.ErrorIs(t, err, supply.ErrNoSupplyCheckpoint)
 	assert.Nil(t, ts)
 }
+
+func (s *SupplyTestSuite) TestGetTotalSupplyRange() {
+	t := s.T()
+	require.Nil(t, s.s.loadLastAccReward())
+	s.insertBlocks()
+
+	expected := make(map[uint64]*supply.TotalSupply)
+	for _, tc := range s.testcases() {
+		expected[tc.number] = tc.expectTotalSupply
+	}
+
+	responses, err := s.s.GetTotalSupplyRange(0, 399, 100)
+	require.NoError(t, err)
+	nums := []uint64{0, 100, 200, 300, 399}
+	require.Len(t, responses, len(nums))
+	for i, num := range nums {
+		assert.Equal(t, expected[num].ToResponse(num, nil), responses[i], num)
+	}
+
+	// The state balance sum should match the total supply.
+	for _, tc := range s.testcases() {
+		fromState, err := s.s.totalSupplyFromState(tc.number)
+		require.NoError(t, err)
+		bigEqual(t, fromState, tc.expectTotalSupply.ExpectedBalanceSum(), tc.number)
+	}
+
+	_, err = s.s.GetTotalSupplyRange(100, 99, 1)
+	assert.ErrorIs(t, err, supply.ErrInvalidBlockRange)
+}
+
+func TestSampleBlockNums(t *testing.T) {
+	testcases := []struct {
+		from, to, interval uint64
+		expected           []uint64
+		err                error
+	}{
+		{0, 0, 1, []uint64{0}, nil},
+		{0, 10, 5, []uint64{0, 5, 10}, nil},
+		{1, 10, 4, []uint64{1, 5, 9, 10}, nil},
+		{3, 5, 100, []uint64{3, 5}, nil},
+		{math.MaxUint64 - 1, math.MaxUint64, 3, []uint64{math.MaxUint64 - 1, math.MaxUint64}, nil},
+		{10, 0, 1, nil, supply.ErrInvalidBlockRange},
+		{0, 10, 0, nil, supply.ErrInvalidBlockRange},
+	}
+	for _, tc := range testcases {
+		nums, err := sampleBlockNums(tc.from, tc.to, tc.interval)
+		assert.Equal(t, tc.err, err)
+		assert.Equal(t, tc.expected, nums)
+	}
+}
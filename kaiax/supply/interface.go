@@ -32,4 +32,9 @@ type SupplyModule interface {
 	// Returns (ts, err) if partial components (e.g. canonical burn amounts) are missing.
 	// Otherwise, returns (ts, nil).
 	GetTotalSupply(num uint64) (*TotalSupply, error)
+
+	// GetTotalSupplyRange returns the total supply at every `interval` blocks from `from` to `to`, including `to`.
+	// Returns (nil, err) if essential components are missing at any block.
+	// The partial components at each block are reported in TotalSupplyResponse.Error.
+	GetTotalSupplyRange(from, to, interval uint64) ([]*TotalSupplyResponse, error)
 }
@@ -66,6 +66,21 @@ func (mr *MockSupplyModuleMockRecorder) GetTotalSupply(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalSupply", reflect.TypeOf((*MockSupplyModule)(nil).GetTotalSupply), arg0)
 }
 
+// GetTotalSupplyRange mocks base method.
+func (m *MockSupplyModule) GetTotalSupplyRange(arg0, arg1, arg2 uint64) ([]*supply.TotalSupplyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotalSupplyRange", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*supply.TotalSupplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotalSupplyRange indicates an expected call of GetTotalSupplyRange.
+func (mr *MockSupplyModuleMockRecorder) GetTotalSupplyRange(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalSupplyRange", reflect.TypeOf((*MockSupplyModule)(nil).GetTotalSupplyRange), arg0, arg1, arg2)
+}
+
 // PostInsertBlock mocks base method.
 func (m *MockSupplyModule) PostInsertBlock(arg0 *types.Block) error {
 	m.ctrl.T.Helper()
@@ -77,6 +77,19 @@ type TotalSupply struct {
 	Kip160Burn *big.Int // RebalanceBurn[n] by KIP-160
 }
 
+// ExpectedBalanceSum returns the sum of all account balances implied by the total supply.
+// The canonical burn addresses still hold their balances in the state, whereas the other burnt amounts are removed from the state.
+// Returns nil if the total supply is incomplete.
+func (ts *TotalSupply) ExpectedBalanceSum() *big.Int {
+	if ts.TotalSupply == nil || ts.ZeroBurn == nil || ts.DeadBurn == nil {
+		return nil
+	}
+	sum := new(big.Int).Set(ts.TotalSupply)
+	sum.Add(sum, ts.ZeroBurn)
+	sum.Add(sum, ts.DeadBurn)
+	return sum
+}
+
 type TotalSupplyResponse struct {
 	// Block number in which the total supply was calculated.
 	Number *hexutil.Big `json:"number"`
@@ -8,6 +8,7 @@ import (
 	"github.com/kaiachain/kaia/accounts/abi/bind/backends"
 	"github.com/kaiachain/kaia/blockchain/system"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/valset"
 	"github.com/kaiachain/kaia/networks/rpc"
 )
 
@@ -72,6 +73,22 @@ func (api *ValsetAPI) GetCommitteeSize(number *rpc.BlockNumber) (int, error) {
 	return len(committee), nil
 }
 
+// GetProposerSchedule retrieves the expected proposer and committee of `count` blocks from `fromBlock`,
+// for each round up to `maxRound`. Use "pending" to preview the upcoming blocks.
+func (api *ValsetAPI) GetProposerSchedule(fromBlock rpc.BlockNumber, count uint64, maxRound uint64) ([]*valset.BlockSchedule, error) {
+	headNum := api.vs.Chain.CurrentBlock().NumberU64()
+	var from uint64
+	switch fromBlock {
+	case rpc.LatestBlockNumber:
+		from = headNum
+	case rpc.PendingBlockNumber:
+		from = headNum + 1
+	default:
+		from = uint64(fromBlock.Int64())
+	}
+	return api.vs.GetProposerSchedule(from, count, maxRound)
+}
+
 func (api *ValsetAPI) GetAllRecordsFromRegistry(name string, number rpc.BlockNumber) ([]interface{}, error) {
 	bn := big.NewInt(number.Int64())
 	if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
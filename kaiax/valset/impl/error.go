@@ -37,6 +37,8 @@ var (
 	errEndLargetThanLatest     = errors.New("end block number should be smaller than the latest block number")
 	errStartLargerThanEnd      = errors.New("start should be smaller than end")
 	errRequestedBlocksTooLarge = errors.New("number of requested blocks should be smaller than 50")
+	errScheduleTooLarge        = errors.New("number of scheduled blocks should be between 1 and 1000")
+	errScheduleRoundTooLarge   = errors.New("maxRound should not be larger than 15")
 	errRangeNil                = errors.New("range values should not be nil")
 	errNoBlockNumber           = errors.New("block number is not assigned")
 	errUnknownBlock            = errors.New("unknown block")
@@ -0,0 +1,151 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"math/big"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/kaiax/valset"
+)
+
+// Naive resource protection. Each (block, round) pair may require a committee selection.
+var (
+	proposerScheduleBlockLimit = uint64(1000)
+	proposerScheduleRoundLimit = uint64(15)
+)
+
+// GetProposerSchedule returns the expected proposer and committee of the blocks [from, from+count)
+// at the rounds [0, maxRound]. The blocks after the pending block (head+1) are projected under the assumptions that
+// the qualified validators and the parameters stay the same as the pending block, and every block is proposed at round 0.
+func (v *ValsetModule) GetProposerSchedule(from, count, maxRound uint64) ([]*valset.BlockSchedule, error) {
+	if from == 0 {
+		return nil, errStartNotPositive
+	}
+	if maxRound > proposerScheduleRoundLimit {
+		return nil, errScheduleRoundTooLarge
+	}
+
+	var (
+		headNum = v.Chain.CurrentBlock().NumberU64()
+		start   = min(from, headNum+1) // projection has to start from the pending block.
+		end     = from + count - 1
+	)
+	if count == 0 || end < from || end-start+1 > proposerScheduleBlockLimit {
+		return nil, errScheduleTooLarge
+	}
+
+	var (
+		schedule     = make([]*valset.BlockSchedule, 0, count)
+		pending      *blockContext
+		prevProposer *common.Address // expected round 0 proposer of the previous block
+	)
+	for num := start; num <= end; num++ {
+		var (
+			c   *blockContext
+			err error
+		)
+		if num <= headNum+1 {
+			if c, err = v.getBlockContext(num); err != nil {
+				return nil, err
+			}
+			pending = c
+		} else {
+			c = v.projectBlockContext(pending, num, prevProposer)
+		}
+
+		bs := &valset.BlockSchedule{Number: num, Rounds: make([]*valset.RoundSchedule, 0, maxRound+1)}
+		for round := uint64(0); round <= maxRound; round++ {
+			rs, err := v.getRoundSchedule(c, prevProposer != nil, round)
+			if err != nil {
+				return nil, err
+			}
+			bs.Rounds = append(bs.Rounds, rs)
+		}
+		prevProposer = bs.Rounds[0].Proposer
+
+		if num >= from {
+			schedule = append(schedule, bs)
+		}
+	}
+	return schedule, nil
+}
+
+// projectBlockContext creates the blockContext of a block that is not produced yet, based on the pending block context.
+// The previous block is unknown, hence prevHeader is nil. prevProposer is the expected one, if any.
+func (v *ValsetModule) projectBlockContext(pending *blockContext, num uint64, prevProposer *common.Address) *blockContext {
+	c := &blockContext{
+		num:       num,
+		qualified: pending.qualified,
+		rules:     v.Chain.Config().Rules(new(big.Int).SetUint64(num)),
+		pset:      pending.pset,
+	}
+	if prevProposer != nil {
+		c.prevProposer = *prevProposer
+	}
+	return c
+}
+
+// getRoundSchedule returns the proposer and committee of the block context at the given round.
+// If the context is projected (i.e. no prevHeader), the fields relying on the previous block are left empty.
+func (v *ValsetModule) getRoundSchedule(c *blockContext, prevProposerKnown bool, round uint64) (*valset.RoundSchedule, error) {
+	rs := &valset.RoundSchedule{Round: round}
+
+	if c.prevHeader != nil {
+		proposer, err := v.getProposer(c, round)
+		if err != nil {
+			return nil, err
+		}
+		committee, err := v.getCommittee(c, round)
+		if err != nil {
+			return nil, err
+		}
+		rs.Proposer, rs.Committee = &proposer, committee
+		return rs, nil
+	}
+
+	switch istanbul.ProposerPolicy(c.pset.ProposerPolicy) {
+	case istanbul.RoundRobin, istanbul.Sticky:
+		if prevProposerKnown {
+			proposer, err := v.getProposer(c, round)
+			if err != nil {
+				return nil, err
+			}
+			rs.Proposer = &proposer
+		}
+	case istanbul.WeightedRandom:
+		// Randao proposer depends on the mixHash of the previous block.
+		// Legacy proposer depends on the proposer list, which is unknown if its update block is not produced yet.
+		if !c.rules.IsRandao {
+			proposer, err := v.getProposer(c, round)
+			if err == nil {
+				rs.Proposer = &proposer
+			} else if err != errNoHeader {
+				return nil, err
+			}
+		}
+	default:
+		return nil, errInvalidProposerPolicy
+	}
+
+	// Random committees depend on the previous block, unless every qualified validator is in the committee.
+	if c.qualified.Len() <= int(c.pset.CommitteeSize) {
+		rs.Committee = c.qualified.List()
+	}
+	return rs, nil
+}
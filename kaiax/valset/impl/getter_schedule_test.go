@@ -0,0 +1,170 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/kaiax/gov"
+	gov_mock "github.com/kaiachain/kaia/kaiax/gov/mock"
+	"github.com/kaiachain/kaia/kaiax/staking"
+	staking_mock "github.com/kaiachain/kaia/kaiax/staking/mock"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
+	chain_mock "github.com/kaiachain/kaia/work/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProposerSchedule(t *testing.T) {
+	var (
+		council  = numsToAddrs(1, 2, 3, 4)
+		genesis  = makeGenesisBlock(council)
+		aM       = uint64(2000000)
+		zero     = big.NewInt(0)
+		legacy   = &params.ChainConfig{}
+		randao   = &params.ChainConfig{IstanbulCompatibleBlock: zero, LondonCompatibleBlock: zero, EthTxTypeCompatibleBlock: zero, MagmaCompatibleBlock: zero, KoreCompatibleBlock: zero, ShanghaiCompatibleBlock: zero, CancunCompatibleBlock: zero, KaiaCompatibleBlock: zero, RandaoCompatibleBlock: zero}
+		si       = &staking.StakingInfo{NodeIds: council, StakingContracts: council, RewardAddrs: council, StakingAmounts: []uint64{aM, aM, aM, aM}}
+		addrsPtr = func(n int) *common.Address { addr := numToAddr(n); return &addr }
+	)
+
+	testcases := []struct {
+		desc          string
+		config        *params.ChainConfig
+		policy        istanbul.ProposerPolicy
+		committeeSize uint64
+		// expected round 0 and round 1 proposers of the block 1,2,3. nil if unknown.
+		proposers [][2]*common.Address
+		// expected committee size of the block 1,2,3. -1 if unknown.
+		committeeSizes []int
+	}{
+		{
+			"RoundRobin", legacy, istanbul.RoundRobin, 4,
+			[][2]*common.Address{
+				{addrsPtr(2), addrsPtr(3)}, // prev=1
+				{addrsPtr(3), addrsPtr(4)}, // prev=2
+				{addrsPtr(4), addrsPtr(1)}, // prev=3
+			},
+			[]int{4, 4, 4},
+		},
+		{
+			"Sticky, random committee", legacy, istanbul.Sticky, 3,
+			[][2]*common.Address{
+				{addrsPtr(1), addrsPtr(2)}, // prev=1
+				{addrsPtr(1), addrsPtr(2)}, // prev=1
+				{addrsPtr(1), addrsPtr(2)}, // prev=1
+			},
+			[]int{3, -1, -1},
+		},
+		{
+			"WeightedRandom after Randao", randao, istanbul.WeightedRandom, 2,
+			[][2]*common.Address{
+				{addrsPtr(3), addrsPtr(2)}, // committee=[3,2]
+				{nil, nil},
+				{nil, nil},
+			},
+			[]int{2, -1, -1},
+		},
+	}
+
+	for _, tc := range testcases {
+		var (
+			ctrl          = gomock.NewController(t)
+			db            = database.NewMemDB()
+			mockChain     = chain_mock.NewMockBlockChain(ctrl)
+			mockGov       = gov_mock.NewMockGovModule(ctrl)
+			mockStaking   = staking_mock.NewMockStakingModule(ctrl)
+			pListCache, _ = lru.New(128)
+			rVoteCache, _ = lru.New(128)
+			v             = &ValsetModule{
+				InitOpts: InitOpts{
+					ChainKv:       db,
+					Chain:         mockChain,
+					GovModule:     mockGov,
+					StakingModule: mockStaking,
+				},
+				proposerListCache: pListCache,
+				removeVotesCache:  rVoteCache,
+			}
+			pset = gov.ParamSet{
+				ProposerPolicy:         uint64(tc.policy),
+				CommitteeSize:          tc.committeeSize,
+				ProposerUpdateInterval: 100,
+				MinimumStake:           big.NewInt(int64(aM)),
+			}
+		)
+		writeCouncil(db, 0, council)
+		writeValidatorVoteBlockNums(db, []uint64{0})
+		writeLowestScannedVoteNum(db, 0)
+		mockChain.EXPECT().CurrentBlock().Return(genesis).AnyTimes()
+		mockChain.EXPECT().GetHeaderByNumber(uint64(0)).Return(genesis.Header()).AnyTimes()
+		mockChain.EXPECT().Config().Return(tc.config).AnyTimes()
+		mockGov.EXPECT().GetParamSet(gomock.Any()).Return(pset).AnyTimes()
+		mockStaking.EXPECT().GetStakingInfo(gomock.Any()).Return(si, nil).AnyTimes()
+
+		schedule, err := v.GetProposerSchedule(1, 3, 1)
+		require.NoError(t, err, tc.desc)
+		require.Len(t, schedule, 3, tc.desc)
+		for i, bs := range schedule {
+			assert.Equal(t, uint64(i+1), bs.Number, tc.desc)
+			require.Len(t, bs.Rounds, 2, tc.desc)
+			for round, rs := range bs.Rounds {
+				assert.Equal(t, uint64(round), rs.Round, tc.desc)
+				assert.Equal(t, tc.proposers[i][round], rs.Proposer, "%s block=%d round=%d", tc.desc, bs.Number, round)
+				if tc.committeeSizes[i] < 0 {
+					assert.Nil(t, rs.Committee, tc.desc)
+				} else {
+					assert.Len(t, rs.Committee, tc.committeeSizes[i], tc.desc)
+					if rs.Proposer != nil {
+						assert.Contains(t, rs.Committee, *rs.Proposer, tc.desc)
+					}
+				}
+			}
+		}
+
+		// Only the requested blocks are returned, while the projection starts from the pending block.
+		schedule, err = v.GetProposerSchedule(3, 1, 0)
+		require.NoError(t, err, tc.desc)
+		require.Len(t, schedule, 1, tc.desc)
+		assert.Equal(t, tc.proposers[2][0], schedule[0].Rounds[0].Proposer, tc.desc)
+	}
+}
+
+func TestGetProposerSchedule_InvalidArgs(t *testing.T) {
+	var (
+		ctrl      = gomock.NewController(t)
+		mockChain = chain_mock.NewMockBlockChain(ctrl)
+		v         = &ValsetModule{InitOpts: InitOpts{Chain: mockChain}}
+	)
+	mockChain.EXPECT().CurrentBlock().Return(makeEmptyBlock(100)).AnyTimes()
+
+	_, err := v.GetProposerSchedule(0, 1, 0)
+	assert.Equal(t, errStartNotPositive, err)
+	_, err = v.GetProposerSchedule(101, 0, 0)
+	assert.Equal(t, errScheduleTooLarge, err)
+	_, err = v.GetProposerSchedule(101, 1001, 0)
+	assert.Equal(t, errScheduleTooLarge, err)
+	_, err = v.GetProposerSchedule(1101, 1, 0) // has to project from the block 101
+	assert.Equal(t, errScheduleTooLarge, err)
+	_, err = v.GetProposerSchedule(101, 1, 16)
+	assert.Equal(t, errScheduleRoundTooLarge, err)
+}
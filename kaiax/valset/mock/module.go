@@ -10,6 +10,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	types "github.com/kaiachain/kaia/blockchain/types"
 	common "github.com/kaiachain/kaia/common"
+	valset "github.com/kaiachain/kaia/kaiax/valset"
 	rpc "github.com/kaiachain/kaia/networks/rpc"
 )
 
@@ -110,6 +111,21 @@ func (mr *MockValsetModuleMockRecorder) GetProposer(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposer", reflect.TypeOf((*MockValsetModule)(nil).GetProposer), arg0, arg1)
 }
 
+// GetProposerSchedule mocks base method.
+func (m *MockValsetModule) GetProposerSchedule(arg0, arg1, arg2 uint64) ([]*valset.BlockSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProposerSchedule", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*valset.BlockSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProposerSchedule indicates an expected call of GetProposerSchedule.
+func (mr *MockValsetModuleMockRecorder) GetProposerSchedule(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposerSchedule", reflect.TypeOf((*MockValsetModule)(nil).GetProposerSchedule), arg0, arg1, arg2)
+}
+
 // PostInsertBlock mocks base method.
 func (m *MockValsetModule) PostInsertBlock(arg0 *types.Block) error {
 	m.ctrl.T.Helper()
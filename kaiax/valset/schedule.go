@@ -0,0 +1,33 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package valset
+
+import "github.com/kaiachain/kaia/common"
+
+// RoundSchedule is the expected proposer and committee of a block at a round.
+// Proposer and Committee are nil if they depend on a block that is not produced yet.
+type RoundSchedule struct {
+	Round     uint64           `json:"round"`
+	Proposer  *common.Address  `json:"proposer"`
+	Committee []common.Address `json:"committee"`
+}
+
+// BlockSchedule is the response type for the kaia_getProposerSchedule API.
+type BlockSchedule struct {
+	Number uint64           `json:"number"`
+	Rounds []*RoundSchedule `json:"rounds"`
+}
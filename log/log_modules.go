@@ -139,6 +139,13 @@ const (
 	KaiaxGasless
 	Builder
 	KaiaxAuction
+	KaiaxPrivateTx
+	KaiaxBundle
+	NodeCNGraphQL
+	NodeCNGRPCAPI
+
+	// 71~80
+	KaiaxLogIndex
 
 	// ModuleNameLen should be placed at the end of the list.
 	ModuleNameLen
@@ -227,4 +234,11 @@ var moduleNames = [ModuleNameLen]string{
 	"kaiax/gasless",
 	"builder",
 	"kaiax/auction",
+	"kaiax/privatetx",
+	"kaiax/bundle",
+	"node/cn/graphql",
+	"node/cn/grpcapi",
+
+	// 71~80
+	"kaiax/logindex",
 }
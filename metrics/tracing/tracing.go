@@ -0,0 +1,167 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package tracing records OpenTelemetry spans of RPC handling, block import and
+// transaction execution, and exports them over OTLP/HTTP to a collector.
+//
+// Spans are only recorded once Start has been called. Until then StartSpan returns a
+// no-op span, so instrumented code paths cost little when tracing is disabled.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaiachain/kaia/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/kaiachain/kaia"
+
+// shutdownTimeout bounds the flush of the pending spans on Stop.
+const shutdownTimeout = 5 * time.Second
+
+var (
+	logger = log.NewModuleLogger(log.Metrics)
+
+	enabled  atomic.Bool
+	tracer   trace.Tracer = noop.NewTracerProvider().Tracer(instrumentationName)
+	provider *sdktrace.TracerProvider
+
+	propagator = propagation.TraceContext{}
+	noopSpan   = trace.SpanFromContext(context.Background())
+)
+
+// Config configures the export of the spans.
+type Config struct {
+	Endpoint    string  // host:port of the OTLP/HTTP collector
+	Insecure    bool    // use plain HTTP instead of HTTPS
+	SampleRatio float64 // fraction of the root spans to record, child spans follow their parent
+	ServiceName string  // service.name resource attribute
+}
+
+// DefaultConfig exports every trace to a collector running on the local host.
+var DefaultConfig = Config{
+	Endpoint:    "localhost:4318",
+	Insecure:    true,
+	SampleRatio: 1,
+	ServiceName: "kaia",
+}
+
+// Start installs a tracer provider exporting to the collector of cfg. It must be called
+// at most once, before the traced components start.
+func Start(cfg Config) error {
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return fmt.Errorf("invalid tracing sample ratio %v, want [0, 1]", cfg.SampleRatio)
+	}
+	if provider != nil {
+		return errors.New("tracing already started")
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	// The exporter connects lazily, so an unreachable collector does not fail here.
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return err
+	}
+	res := resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName))
+	install(sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	))
+	logger.Info("Started OpenTelemetry tracing", "endpoint", cfg.Endpoint, "sampleRatio", cfg.SampleRatio)
+	return nil
+}
+
+// install makes p the provider of the spans.
+func install(p *sdktrace.TracerProvider) {
+	provider = p
+	tracer = p.Tracer(instrumentationName)
+	otel.SetTracerProvider(p)
+	otel.SetTextMapPropagator(propagator)
+	enabled.Store(true)
+}
+
+// Stop flushes the pending spans and stops recording new ones.
+func Stop() {
+	if provider == nil {
+		return
+	}
+	enabled.Store(false)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := provider.Shutdown(ctx); err != nil {
+		logger.Warn("Failed to flush the spans", "err", err)
+	}
+	provider = nil
+}
+
+// Enabled reports whether spans are recorded.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// StartSpan starts a span as a child of the span in ctx, if any.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !enabled.Load() {
+		return ctx, noopSpan
+	}
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartSpanFunc is like StartSpan, but the span name and attributes are built by fn
+// only if spans are recorded, so that expensive ones are not computed for nothing.
+func StartSpanFunc(ctx context.Context, fn func() (string, []attribute.KeyValue)) (context.Context, trace.Span) {
+	if !enabled.Load() {
+		return ctx, noopSpan
+	}
+	name, attrs := fn()
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err, if any, and ends span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ExtractHTTP returns ctx with the remote span context propagated in the W3C
+// traceparent header of h, so that the spans of a request join the trace of the caller.
+func ExtractHTTP(ctx context.Context, h http.Header) context.Context {
+	if !enabled.Load() {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.HeaderCarrier(h))
+}
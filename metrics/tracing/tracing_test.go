@@ -0,0 +1,86 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStartSpan(t *testing.T) {
+	// Disabled tracing records nothing.
+	ctx, span := StartSpan(context.Background(), "disabled")
+	assert.False(t, span.IsRecording())
+	assert.Equal(t, context.Background(), ctx)
+
+	exporter := tracetest.NewInMemoryExporter()
+	install(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer Stop()
+
+	ctx, parent := StartSpan(context.Background(), "parent")
+	_, child := StartSpan(ctx, "child")
+	EndSpan(child, errors.New("failed"))
+	EndSpan(parent, nil)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "child", spans[0].Name)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+		assert.Equal(t, codes.Unset, spans[1].Status.Code)
+	}
+}
+
+func TestStartSpanFunc(t *testing.T) {
+	built := false
+	fn := func() (string, []attribute.KeyValue) {
+		built = true
+		return "lazy", []attribute.KeyValue{attribute.Int("n", 1)}
+	}
+
+	// Disabled tracing does not build the span.
+	_, span := StartSpanFunc(context.Background(), fn)
+	EndSpan(span, nil)
+	assert.False(t, built)
+
+	exporter := tracetest.NewInMemoryExporter()
+	install(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer Stop()
+
+	_, span = StartSpanFunc(context.Background(), fn)
+	EndSpan(span, nil)
+	assert.True(t, built)
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "lazy", spans[0].Name)
+		assert.Equal(t, []attribute.KeyValue{attribute.Int("n", 1)}, spans[0].Attributes)
+	}
+}
+
+func TestStartInvalidRatio(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.SampleRatio = 1.5
+	assert.Error(t, Start(cfg))
+	assert.False(t, Enabled())
+}
@@ -17,7 +17,9 @@
 /*
 Package grpc implements the gRPC protocol for Kaia.
 
-This package allows you to use Kaia's RPC API using gRPC.
+This package allows you to use Kaia's RPC API using gRPC. The KlaytnNode service
+tunnels JSON-RPC requests, while the KaiaChain service serves the core chain reads,
+transaction submission and the newHeads/logs streams with typed protobuf messages.
 See below for gRPC: https://grpc.io/docs/quickstart/go/
 
 # Source files
@@ -27,5 +29,7 @@ Each file provides the following features
   - gServer.go : gRPC server implementation.
   - klaytn.proto : Define a interface and messages to use in gRPC server and clients.
   - klaytn.pb.go : the generated Go file from klaytn.proto by protoc-gen-go.
+  - kaia.proto : Define the typed KaiaChain service and its messages.
+  - kaia.pb.go, kaia_grpc.pb.go : the generated Go files from kaia.proto by protoc-gen-go and protoc-gen-go-grpc.
 */
 package grpc
@@ -43,9 +43,16 @@ var logger = log.NewModuleLogger(log.NetworksGRPC)
 type Listener struct {
 	Addr       string
 	handler    *rpc.Server
+	services   []service
 	grpcServer *grpc.Server
 }
 
+// service is a typed gRPC service served next to the JSON-RPC tunnel.
+type service struct {
+	desc *grpc.ServiceDesc
+	impl interface{}
+}
+
 // grpcReadWriteNopCloser wraps an io.Reader and io.Writer with a NOP Close method.
 type grpcReadWriteNopCloser struct {
 	io.Reader
@@ -254,6 +261,13 @@ func (gs *Listener) SetRPCServer(handler *rpc.Server) {
 	gs.handler = handler
 }
 
+// RegisterService registers a typed gRPC service, such as KaiaChain, to be served
+// next to the JSON-RPC tunnel. It implements grpc.ServiceRegistrar and must be
+// called before Start.
+func (gs *Listener) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	gs.services = append(gs.services, service{desc: desc, impl: impl})
+}
+
 func (gs *Listener) Start() {
 	lis, err := net.Listen("tcp", gs.Addr)
 	if err != nil {
@@ -263,6 +277,9 @@ func (gs *Listener) Start() {
 	gs.grpcServer = grpc.NewServer()
 
 	RegisterKlaytnNodeServer(gs.grpcServer, &kaiaServer{handler: gs.handler})
+	for _, s := range gs.services {
+		gs.grpcServer.RegisterService(s.desc, s.impl)
+	}
 
 	// Register reflection service on gRPC server.
 	reflection.Register(gs.grpcServer)
@@ -0,0 +1,622 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: kaia.proto
+
+package grpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	KaiaChain_BlockNumber_FullMethodName           = "/kaia.KaiaChain/BlockNumber"
+	KaiaChain_GetHeader_FullMethodName             = "/kaia.KaiaChain/GetHeader"
+	KaiaChain_GetBlock_FullMethodName              = "/kaia.KaiaChain/GetBlock"
+	KaiaChain_GetBlockReceipts_FullMethodName      = "/kaia.KaiaChain/GetBlockReceipts"
+	KaiaChain_GetTransaction_FullMethodName        = "/kaia.KaiaChain/GetTransaction"
+	KaiaChain_GetTransactionReceipt_FullMethodName = "/kaia.KaiaChain/GetTransactionReceipt"
+	KaiaChain_GetLogs_FullMethodName               = "/kaia.KaiaChain/GetLogs"
+	KaiaChain_GetBalance_FullMethodName            = "/kaia.KaiaChain/GetBalance"
+	KaiaChain_GetTransactionCount_FullMethodName   = "/kaia.KaiaChain/GetTransactionCount"
+	KaiaChain_GetCode_FullMethodName               = "/kaia.KaiaChain/GetCode"
+	KaiaChain_GetStorageAt_FullMethodName          = "/kaia.KaiaChain/GetStorageAt"
+	KaiaChain_SendRawTransaction_FullMethodName    = "/kaia.KaiaChain/SendRawTransaction"
+	KaiaChain_SubscribeNewHeads_FullMethodName     = "/kaia.KaiaChain/SubscribeNewHeads"
+	KaiaChain_SubscribeLogs_FullMethodName         = "/kaia.KaiaChain/SubscribeLogs"
+)
+
+// KaiaChainClient is the client API for KaiaChain service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type KaiaChainClient interface {
+	BlockNumber(ctx context.Context, in *BlockNumberRequest, opts ...grpc.CallOption) (*BlockNumberResponse, error)
+	GetHeader(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Header, error)
+	GetBlock(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Block, error)
+	GetBlockReceipts(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Receipts, error)
+	GetTransaction(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*Transaction, error)
+	GetTransactionReceipt(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*Receipt, error)
+	GetLogs(ctx context.Context, in *LogFilter, opts ...grpc.CallOption) (*Logs, error)
+	GetBalance(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
+	GetTransactionCount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*NonceResponse, error)
+	GetCode(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*CodeResponse, error)
+	GetStorageAt(ctx context.Context, in *StorageRequest, opts ...grpc.CallOption) (*StorageResponse, error)
+	SendRawTransaction(ctx context.Context, in *RawTransaction, opts ...grpc.CallOption) (*TransactionHash, error)
+	SubscribeNewHeads(ctx context.Context, in *SubscribeNewHeadsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Header], error)
+	SubscribeLogs(ctx context.Context, in *LogFilter, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Logs], error)
+}
+
+type kaiaChainClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKaiaChainClient(cc grpc.ClientConnInterface) KaiaChainClient {
+	return &kaiaChainClient{cc}
+}
+
+func (c *kaiaChainClient) BlockNumber(ctx context.Context, in *BlockNumberRequest, opts ...grpc.CallOption) (*BlockNumberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockNumberResponse)
+	err := c.cc.Invoke(ctx, KaiaChain_BlockNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaiaChainClient) GetHeader(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Header, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Header)
+	err := c.cc.Invoke(ctx, KaiaChain_GetHeader_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaiaChainClient) GetBlock(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Block)
+	err := c.cc.Invoke(ctx, KaiaChain_GetBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaiaChainClient) GetBlockReceipts(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Receipts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Receipts)
+	err := c.cc.Invoke(ctx, KaiaChain_GetBlockReceipts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaiaChainClient) GetTransaction(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, KaiaChain_GetTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaiaChainClient) GetTransactionReceipt(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*Receipt, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Receipt)
+	err := c.cc.Invoke(ctx, KaiaChain_GetTransactionReceipt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaiaChainClient) GetLogs(ctx context.Context, in *LogFilter, opts ...grpc.CallOption) (*Logs, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Logs)
+	err := c.cc.Invoke(ctx, KaiaChain_GetLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaiaChainClient) GetBalance(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*BalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BalanceResponse)
+	err := c.cc.Invoke(ctx, KaiaChain_GetBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaiaChainClient) GetTransactionCount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*NonceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NonceResponse)
+	err := c.cc.Invoke(ctx, KaiaChain_GetTransactionCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaiaChainClient) GetCode(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*CodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CodeResponse)
+	err := c.cc.Invoke(ctx, KaiaChain_GetCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaiaChainClient) GetStorageAt(ctx context.Context, in *StorageRequest, opts ...grpc.CallOption) (*StorageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StorageResponse)
+	err := c.cc.Invoke(ctx, KaiaChain_GetStorageAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaiaChainClient) SendRawTransaction(ctx context.Context, in *RawTransaction, opts ...grpc.CallOption) (*TransactionHash, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionHash)
+	err := c.cc.Invoke(ctx, KaiaChain_SendRawTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kaiaChainClient) SubscribeNewHeads(ctx context.Context, in *SubscribeNewHeadsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Header], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KaiaChain_ServiceDesc.Streams[0], KaiaChain_SubscribeNewHeads_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeNewHeadsRequest, Header]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KaiaChain_SubscribeNewHeadsClient = grpc.ServerStreamingClient[Header]
+
+func (c *kaiaChainClient) SubscribeLogs(ctx context.Context, in *LogFilter, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Logs], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KaiaChain_ServiceDesc.Streams[1], KaiaChain_SubscribeLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LogFilter, Logs]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KaiaChain_SubscribeLogsClient = grpc.ServerStreamingClient[Logs]
+
+// KaiaChainServer is the server API for KaiaChain service.
+// All implementations must embed UnimplementedKaiaChainServer
+// for forward compatibility.
+type KaiaChainServer interface {
+	BlockNumber(context.Context, *BlockNumberRequest) (*BlockNumberResponse, error)
+	GetHeader(context.Context, *BlockRequest) (*Header, error)
+	GetBlock(context.Context, *BlockRequest) (*Block, error)
+	GetBlockReceipts(context.Context, *BlockRequest) (*Receipts, error)
+	GetTransaction(context.Context, *HashRequest) (*Transaction, error)
+	GetTransactionReceipt(context.Context, *HashRequest) (*Receipt, error)
+	GetLogs(context.Context, *LogFilter) (*Logs, error)
+	GetBalance(context.Context, *AccountRequest) (*BalanceResponse, error)
+	GetTransactionCount(context.Context, *AccountRequest) (*NonceResponse, error)
+	GetCode(context.Context, *AccountRequest) (*CodeResponse, error)
+	GetStorageAt(context.Context, *StorageRequest) (*StorageResponse, error)
+	SendRawTransaction(context.Context, *RawTransaction) (*TransactionHash, error)
+	SubscribeNewHeads(*SubscribeNewHeadsRequest, grpc.ServerStreamingServer[Header]) error
+	SubscribeLogs(*LogFilter, grpc.ServerStreamingServer[Logs]) error
+	mustEmbedUnimplementedKaiaChainServer()
+}
+
+// UnimplementedKaiaChainServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKaiaChainServer struct{}
+
+func (UnimplementedKaiaChainServer) BlockNumber(context.Context, *BlockNumberRequest) (*BlockNumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockNumber not implemented")
+}
+func (UnimplementedKaiaChainServer) GetHeader(context.Context, *BlockRequest) (*Header, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeader not implemented")
+}
+func (UnimplementedKaiaChainServer) GetBlock(context.Context, *BlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedKaiaChainServer) GetBlockReceipts(context.Context, *BlockRequest) (*Receipts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockReceipts not implemented")
+}
+func (UnimplementedKaiaChainServer) GetTransaction(context.Context, *HashRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedKaiaChainServer) GetTransactionReceipt(context.Context, *HashRequest) (*Receipt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionReceipt not implemented")
+}
+func (UnimplementedKaiaChainServer) GetLogs(context.Context, *LogFilter) (*Logs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
+}
+func (UnimplementedKaiaChainServer) GetBalance(context.Context, *AccountRequest) (*BalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedKaiaChainServer) GetTransactionCount(context.Context, *AccountRequest) (*NonceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionCount not implemented")
+}
+func (UnimplementedKaiaChainServer) GetCode(context.Context, *AccountRequest) (*CodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCode not implemented")
+}
+func (UnimplementedKaiaChainServer) GetStorageAt(context.Context, *StorageRequest) (*StorageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStorageAt not implemented")
+}
+func (UnimplementedKaiaChainServer) SendRawTransaction(context.Context, *RawTransaction) (*TransactionHash, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendRawTransaction not implemented")
+}
+func (UnimplementedKaiaChainServer) SubscribeNewHeads(*SubscribeNewHeadsRequest, grpc.ServerStreamingServer[Header]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeNewHeads not implemented")
+}
+func (UnimplementedKaiaChainServer) SubscribeLogs(*LogFilter, grpc.ServerStreamingServer[Logs]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeLogs not implemented")
+}
+func (UnimplementedKaiaChainServer) mustEmbedUnimplementedKaiaChainServer() {}
+func (UnimplementedKaiaChainServer) testEmbeddedByValue()                   {}
+
+// UnsafeKaiaChainServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KaiaChainServer will
+// result in compilation errors.
+type UnsafeKaiaChainServer interface {
+	mustEmbedUnimplementedKaiaChainServer()
+}
+
+func RegisterKaiaChainServer(s grpc.ServiceRegistrar, srv KaiaChainServer) {
+	// If the following call pancis, it indicates UnimplementedKaiaChainServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KaiaChain_ServiceDesc, srv)
+}
+
+func _KaiaChain_BlockNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaiaChainServer).BlockNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KaiaChain_BlockNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaiaChainServer).BlockNumber(ctx, req.(*BlockNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KaiaChain_GetHeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaiaChainServer).GetHeader(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KaiaChain_GetHeader_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaiaChainServer).GetHeader(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KaiaChain_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaiaChainServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KaiaChain_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaiaChainServer).GetBlock(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KaiaChain_GetBlockReceipts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaiaChainServer).GetBlockReceipts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KaiaChain_GetBlockReceipts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaiaChainServer).GetBlockReceipts(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KaiaChain_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaiaChainServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KaiaChain_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaiaChainServer).GetTransaction(ctx, req.(*HashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KaiaChain_GetTransactionReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaiaChainServer).GetTransactionReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KaiaChain_GetTransactionReceipt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaiaChainServer).GetTransactionReceipt(ctx, req.(*HashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KaiaChain_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaiaChainServer).GetLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KaiaChain_GetLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaiaChainServer).GetLogs(ctx, req.(*LogFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KaiaChain_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaiaChainServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KaiaChain_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaiaChainServer).GetBalance(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KaiaChain_GetTransactionCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaiaChainServer).GetTransactionCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KaiaChain_GetTransactionCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaiaChainServer).GetTransactionCount(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KaiaChain_GetCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaiaChainServer).GetCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KaiaChain_GetCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaiaChainServer).GetCode(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KaiaChain_GetStorageAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StorageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaiaChainServer).GetStorageAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KaiaChain_GetStorageAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaiaChainServer).GetStorageAt(ctx, req.(*StorageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KaiaChain_SendRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RawTransaction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KaiaChainServer).SendRawTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KaiaChain_SendRawTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KaiaChainServer).SendRawTransaction(ctx, req.(*RawTransaction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KaiaChain_SubscribeNewHeads_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeNewHeadsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KaiaChainServer).SubscribeNewHeads(m, &grpc.GenericServerStream[SubscribeNewHeadsRequest, Header]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KaiaChain_SubscribeNewHeadsServer = grpc.ServerStreamingServer[Header]
+
+func _KaiaChain_SubscribeLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogFilter)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KaiaChainServer).SubscribeLogs(m, &grpc.GenericServerStream[LogFilter, Logs]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KaiaChain_SubscribeLogsServer = grpc.ServerStreamingServer[Logs]
+
+// KaiaChain_ServiceDesc is the grpc.ServiceDesc for KaiaChain service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KaiaChain_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kaia.KaiaChain",
+	HandlerType: (*KaiaChainServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BlockNumber",
+			Handler:    _KaiaChain_BlockNumber_Handler,
+		},
+		{
+			MethodName: "GetHeader",
+			Handler:    _KaiaChain_GetHeader_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _KaiaChain_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetBlockReceipts",
+			Handler:    _KaiaChain_GetBlockReceipts_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _KaiaChain_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetTransactionReceipt",
+			Handler:    _KaiaChain_GetTransactionReceipt_Handler,
+		},
+		{
+			MethodName: "GetLogs",
+			Handler:    _KaiaChain_GetLogs_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _KaiaChain_GetBalance_Handler,
+		},
+		{
+			MethodName: "GetTransactionCount",
+			Handler:    _KaiaChain_GetTransactionCount_Handler,
+		},
+		{
+			MethodName: "GetCode",
+			Handler:    _KaiaChain_GetCode_Handler,
+		},
+		{
+			MethodName: "GetStorageAt",
+			Handler:    _KaiaChain_GetStorageAt_Handler,
+		},
+		{
+			MethodName: "SendRawTransaction",
+			Handler:    _KaiaChain_SendRawTransaction_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeNewHeads",
+			Handler:       _KaiaChain_SubscribeNewHeads_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeLogs",
+			Handler:       _KaiaChain_SubscribeLogs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "kaia.proto",
+}
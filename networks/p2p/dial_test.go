@@ -127,9 +127,19 @@ func (t fakeTable) IsAuthorized(id discover.NodeID, ntype discover.NodeType) boo
 	return true
 }
 
-func (t fakeTable) GetAuthorizedNodes() []*discover.Node         { return nil }
-func (t fakeTable) PutAuthorizedNodes(nodes []*discover.Node)    {}
-func (t fakeTable) DeleteAuthorizedNodes(nodes []*discover.Node) {}
+func (t fakeTable) GetAuthorizedNodes() []*discover.Node              { return nil }
+func (t fakeTable) PutAuthorizedNodes(nodes []*discover.Node)         {}
+func (t fakeTable) DeleteAuthorizedNodes(nodes []*discover.Node)      {}
+func (t fakeTable) BanNode(id discover.NodeID, until time.Time) error { return nil }
+func (t fakeTable) GetBannedNodes() map[discover.NodeID]time.Time     { return nil }
+
+func (t fakeTable) LocalRecord() *discover.Record                             { return new(discover.Record) }
+func (t fakeTable) SetLocalRecordAttribute(key string, val interface{}) error { return nil }
+func (t fakeTable) AddLocalRecordService(name string) error                   { return nil }
+func (t fakeTable) NodeRecord(id discover.NodeID) *discover.Record            { return nil }
+func (t fakeTable) LookupByRecord(discover.NodeType, ...discover.RecordFilter) []*discover.Node {
+	return nil
+}
 
 // This test checks that dynamic dials are launched from discovery results.
 func TestDialStateDynDial(t *testing.T) {
@@ -968,3 +978,31 @@ func (t *resolveMock) PutAuthorizedNodes(nodes []*discover.Node) {
 func (t *resolveMock) DeleteAuthorizedNodes(nodes []*discover.Node) {
 	panic("implement me")
 }
+
+func (t *resolveMock) BanNode(id discover.NodeID, until time.Time) error {
+	panic("implement me")
+}
+
+func (t *resolveMock) GetBannedNodes() map[discover.NodeID]time.Time {
+	panic("implement me")
+}
+
+func (t *resolveMock) LocalRecord() *discover.Record {
+	panic("implement me")
+}
+
+func (t *resolveMock) SetLocalRecordAttribute(key string, val interface{}) error {
+	panic("implement me")
+}
+
+func (t *resolveMock) AddLocalRecordService(name string) error {
+	panic("implement me")
+}
+
+func (t *resolveMock) NodeRecord(id discover.NodeID) *discover.Record {
+	panic("implement me")
+}
+
+func (t *resolveMock) LookupByRecord(targetType discover.NodeType, filters ...discover.RecordFilter) []*discover.Node {
+	panic("implement me")
+}
@@ -68,6 +68,9 @@ var (
 	nodeDBDiscoverPing      = nodeDBDiscoverRoot + ":lastping"
 	nodeDBDiscoverPong      = nodeDBDiscoverRoot + ":lastpong"
 	nodeDBDiscoverFindFails = nodeDBDiscoverRoot + ":findfail"
+	nodeDBDiscoverRecord    = nodeDBDiscoverRoot + ":record"
+
+	nodeDBReputationBan = ":reputation:ban"
 )
 
 // newNodeDB creates a new node database for storing and retrieving infos about
@@ -311,6 +314,75 @@ func (db *nodeDB) updateFindFails(id NodeID, fails int) error {
 	return db.storeInt64(makeKey(id, nodeDBDiscoverFindFails), int64(fails))
 }
 
+// record retrieves the last known node record of a node.
+func (db *nodeDB) record(id NodeID) *Record {
+	blob, err := db.lvl.Get(makeKey(id, nodeDBDiscoverRecord), nil)
+	if err != nil {
+		return nil
+	}
+	r := new(Record)
+	if err := rlp.DecodeBytes(blob, r); err != nil {
+		logger.Debug("Failed to decode node record, It removed in the node database", "id", id, "err", err)
+		db.lvl.Delete(makeKey(id, nodeDBDiscoverRecord), nil)
+		return nil
+	}
+	return r
+}
+
+// recordSeq returns the sequence number of the last known node record of a
+// node, or zero if no record is known.
+func (db *nodeDB) recordSeq(id NodeID) uint64 {
+	if r := db.record(id); r != nil {
+		return r.Seq()
+	}
+	return 0
+}
+
+// updateRecord stores the node record of a node. The signature must have been
+// verified by the caller.
+func (db *nodeDB) updateRecord(id NodeID, r *Record) error {
+	blob, err := rlp.EncodeToBytes(r)
+	if err != nil {
+		return err
+	}
+	return db.lvl.Put(makeKey(id, nodeDBDiscoverRecord), blob, nil)
+}
+
+// banTime retrieves the time until which a node is banned from connecting.
+func (db *nodeDB) banTime(id NodeID) time.Time {
+	return time.Unix(db.fetchInt64(makeKey(id, nodeDBReputationBan)), 0)
+}
+
+// updateBanTime updates the time until which a node is banned. A zero time
+// lifts the ban.
+func (db *nodeDB) updateBanTime(id NodeID, until time.Time) error {
+	if until.IsZero() {
+		return db.lvl.Delete(makeKey(id, nodeDBReputationBan), nil)
+	}
+	return db.storeInt64(makeKey(id, nodeDBReputationBan), until.Unix())
+}
+
+// bannedNodes retrieves all nodes whose ban has not expired yet.
+func (db *nodeDB) bannedNodes() map[NodeID]time.Time {
+	var (
+		now    = time.Now()
+		banned = make(map[NodeID]time.Time)
+		it     = db.lvl.NewIterator(util.BytesPrefix(nodeDBItemPrefix), nil)
+	)
+	defer it.Release()
+
+	for it.Next() {
+		id, field := splitKey(it.Key())
+		if field != nodeDBReputationBan {
+			continue
+		}
+		if until := db.banTime(id); until.After(now) {
+			banned[id] = until
+		}
+	}
+	return banned
+}
+
 // querySeeds retrieves random nodes to be used as potential seed nodes
 // for bootstrapping.
 func (db *nodeDB) querySeeds(n int, maxAge time.Duration) []*Node {
@@ -315,6 +315,43 @@ func TestNodeDBPersistency(t *testing.T) {
 	db.close()
 }
 
+func TestNodeDBBans(t *testing.T) {
+	db, _ := newNodeDB("", Version, NodeID{})
+	defer db.close()
+
+	var (
+		active  = MustHexID("0x01d9d65c4552b5eb43d5ad55a2ee3f56c6cbc1c64a5c8d659f51fcd51bace24351232b8d7821617d2b29b54b81cdefb9b3e9c37d7fd5f63270bcc9e1a6f6a439")
+		expired = MustHexID("0x02d9d65c4552b5eb43d5ad55a2ee3f56c6cbc1c64a5c8d659f51fcd51bace24351232b8d7821617d2b29b54b81cdefb9b3e9c37d7fd5f63270bcc9e1a6f6a439")
+		until   = time.Now().Add(time.Hour)
+	)
+	if stored := db.banTime(active); stored.Unix() != 0 {
+		t.Errorf("ban: non-existing object: %v", stored)
+	}
+	if err := db.updateBanTime(active, until); err != nil {
+		t.Fatalf("ban: failed to update: %v", err)
+	}
+	if err := db.updateBanTime(expired, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("ban: failed to update: %v", err)
+	}
+	if stored := db.banTime(active); stored.Unix() != until.Unix() {
+		t.Errorf("ban: value mismatch: have %v, want %v", stored, until)
+	}
+
+	// Only the active ban is listed
+	banned := db.bannedNodes()
+	if len(banned) != 1 || banned[active].Unix() != until.Unix() {
+		t.Errorf("banned nodes mismatch: have %v, want %v until %v", banned, active, until)
+	}
+
+	// A zero time lifts the ban
+	if err := db.updateBanTime(active, time.Time{}); err != nil {
+		t.Fatalf("ban: failed to lift: %v", err)
+	}
+	if banned := db.bannedNodes(); len(banned) != 0 {
+		t.Errorf("banned nodes after lift: have %v, want none", banned)
+	}
+}
+
 var nodeDBExpirationNodes = []struct {
 	node *Node
 	pong time.Time
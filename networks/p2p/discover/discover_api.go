@@ -20,6 +20,8 @@ package discover
 
 import (
 	"errors"
+	"sort"
+	"time"
 )
 
 func (tab *Table) Name() string { return "TableDiscovery" }
@@ -110,3 +112,67 @@ func (tab *Table) DeleteAuthorizedNodes(nodes []*Node) {
 		}
 	}
 }
+
+// BanNode records in the peer database that the node is banned until the given
+// time. A zero time lifts the ban.
+func (tab *Table) BanNode(id NodeID, until time.Time) error {
+	return tab.db.updateBanTime(id, until)
+}
+
+// GetBannedNodes returns the nodes whose ban has not expired yet.
+func (tab *Table) GetBannedNodes() map[NodeID]time.Time {
+	return tab.db.bannedNodes()
+}
+
+// LocalRecord returns a copy of the signed node record of the local node.
+func (tab *Table) LocalRecord() *Record {
+	tab.recordMu.Lock()
+	defer tab.recordMu.Unlock()
+	return tab.localRecord.Copy()
+}
+
+// SetLocalRecordAttribute sets an attribute of the local node record and
+// publishes the record with an increased sequence number.
+func (tab *Table) SetLocalRecordAttribute(key string, val interface{}) error {
+	tab.recordMu.Lock()
+	defer tab.recordMu.Unlock()
+	r := tab.localRecord.Copy()
+	if err := r.Set(key, val); err != nil {
+		return err
+	}
+	return tab.publishLocalRecord(r)
+}
+
+// AddLocalRecordService adds a service to the RecordKeyServices attribute of
+// the local node record.
+func (tab *Table) AddLocalRecordService(name string) error {
+	tab.recordMu.Lock()
+	defer tab.recordMu.Unlock()
+	var services []string
+	if tab.localRecord.Has(RecordKeyServices) {
+		if err := tab.localRecord.Load(RecordKeyServices, &services); err != nil {
+			return err
+		}
+	}
+	for _, s := range services {
+		if s == name {
+			return nil
+		}
+	}
+	services = append(services, name)
+	sort.Strings(services)
+	r := tab.localRecord.Copy()
+	if err := r.Set(RecordKeyServices, services); err != nil {
+		return err
+	}
+	return tab.publishLocalRecord(r)
+}
+
+// NodeRecord returns the last known node record of the given node, or nil if
+// it is not known.
+func (tab *Table) NodeRecord(id NodeID) *Record {
+	if id == tab.self.ID {
+		return tab.LocalRecord()
+	}
+	return tab.db.record(id)
+}
@@ -122,6 +122,10 @@ func (*simpleTestnet) close()                                      {}
 func (*simpleTestnet) waitping(from NodeID) error                  { return nil }
 func (*simpleTestnet) ping(toid NodeID, toaddr *net.UDPAddr) error { return nil }
 
+func (*simpleTestnet) requestRecord(toid NodeID, toaddr *net.UDPAddr) (*Record, error) {
+	return nil, errTimeout
+}
+
 func isIn(candidate *Node, list []*Node) bool {
 	for _, node := range list {
 		if candidate.CompareNode(node) {
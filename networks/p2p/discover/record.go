@@ -0,0 +1,365 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package discover
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+// Well-known node record keys.
+const (
+	RecordKeyChainID   = "chainid"   // uint64 chain id the node is following
+	RecordKeyProtocols = "protocols" // []string of "name/version" entries the node runs
+	RecordKeyServices  = "services"  // []string of optional services the node provides
+)
+
+// Well-known values of the RecordKeyServices attribute.
+const (
+	ServiceSnap   = "snap"   // the node serves snap sync requests
+	ServiceBridge = "bridge" // the node runs a service chain bridge
+)
+
+// maxRecordSize is the maximum encoded size of a node record. It keeps the
+// record response well below the 1280 byte discovery packet limit.
+const maxRecordSize = 512
+
+var (
+	errRecordKeyMissing   = errors.New("record key missing")
+	errRecordTooBig       = errors.New("record too big")
+	errRecordNotSigned    = errors.New("record not signed")
+	errRecordBadSignature = errors.New("record signature does not match node id")
+	errRecordNotSorted    = errors.New("record keys are not sorted")
+)
+
+// Record is a signed, versioned set of key/value attributes which a node
+// publishes about itself through the discovery protocol. Values are stored
+// RLP encoded, so arbitrary attributes can be carried without the discovery
+// layer knowing their type.
+//
+// The zero value is an empty, unsigned record. Modifying a record invalidates
+// its signature; call Sign again before handing it out.
+type Record struct {
+	seq       uint64
+	signature []byte
+	pairs     []recordPair // sorted by key
+}
+
+type recordPair struct {
+	k string
+	v rlp.RawValue
+}
+
+// Seq returns the sequence number of the record. A node increments it
+// whenever the content of its record changes.
+func (r *Record) Seq() uint64 {
+	return r.seq
+}
+
+// SetSeq updates the sequence number. It invalidates the signature.
+func (r *Record) SetSeq(seq uint64) {
+	r.seq = seq
+	r.signature = nil
+}
+
+// Signed reports whether the record carries a signature.
+func (r *Record) Signed() bool {
+	return r.signature != nil
+}
+
+// Set stores the RLP encoding of val under the given key, replacing any
+// existing value. It invalidates the signature.
+func (r *Record) Set(key string, val interface{}) error {
+	blob, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		return err
+	}
+	r.signature = nil
+	i := sort.Search(len(r.pairs), func(i int) bool { return r.pairs[i].k >= key })
+	if i < len(r.pairs) && r.pairs[i].k == key {
+		r.pairs[i].v = blob
+		return nil
+	}
+	r.pairs = append(r.pairs, recordPair{})
+	copy(r.pairs[i+1:], r.pairs[i:])
+	r.pairs[i] = recordPair{k: key, v: blob}
+	return nil
+}
+
+// Load decodes the value stored under the given key into val, which must be
+// a pointer.
+func (r *Record) Load(key string, val interface{}) error {
+	blob := r.get(key)
+	if blob == nil {
+		return fmt.Errorf("%w: %s", errRecordKeyMissing, key)
+	}
+	if err := rlp.DecodeBytes(blob, val); err != nil {
+		return fmt.Errorf("invalid record value for key %q: %v", key, err)
+	}
+	return nil
+}
+
+// Has reports whether the record contains the given key.
+func (r *Record) Has(key string) bool {
+	return r.get(key) != nil
+}
+
+// Keys returns the keys of the record in sorted order.
+func (r *Record) Keys() []string {
+	keys := make([]string, len(r.pairs))
+	for i, p := range r.pairs {
+		keys[i] = p.k
+	}
+	return keys
+}
+
+func (r *Record) get(key string) rlp.RawValue {
+	i := sort.Search(len(r.pairs), func(i int) bool { return r.pairs[i].k >= key })
+	if i < len(r.pairs) && r.pairs[i].k == key {
+		return r.pairs[i].v
+	}
+	return nil
+}
+
+// Copy returns a deep copy of the record.
+func (r *Record) Copy() *Record {
+	cpy := &Record{seq: r.seq, pairs: make([]recordPair, len(r.pairs))}
+	if r.signature != nil {
+		cpy.signature = common.CopyBytes(r.signature)
+	}
+	for i, p := range r.pairs {
+		cpy.pairs[i] = recordPair{k: p.k, v: common.CopyBytes(p.v)}
+	}
+	return cpy
+}
+
+// Sign signs the record with the given node key.
+func (r *Record) Sign(priv *ecdsa.PrivateKey) error {
+	content, err := r.content()
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(crypto.Keccak256(content), priv)
+	if err != nil {
+		return err
+	}
+	r.signature = sig
+	if size, err := r.size(); err != nil {
+		r.signature = nil
+		return err
+	} else if size > maxRecordSize {
+		r.signature = nil
+		return errRecordTooBig
+	}
+	return nil
+}
+
+// Verify checks that the record was signed by the node with the given id.
+func (r *Record) Verify(id NodeID) error {
+	if r.signature == nil {
+		return errRecordNotSigned
+	}
+	content, err := r.content()
+	if err != nil {
+		return err
+	}
+	signer, err := recoverNodeID(crypto.Keccak256(content), r.signature)
+	if err != nil {
+		return err
+	}
+	if signer != id {
+		return errRecordBadSignature
+	}
+	return nil
+}
+
+// content returns the signed part of the record, [seq, k1, v1, k2, v2, ...].
+func (r *Record) content() ([]byte, error) {
+	return rlp.EncodeToBytes(r.list(false))
+}
+
+func (r *Record) list(withSig bool) []interface{} {
+	list := make([]interface{}, 0, 2+2*len(r.pairs))
+	if withSig {
+		list = append(list, r.signature)
+	}
+	list = append(list, r.seq)
+	for _, p := range r.pairs {
+		list = append(list, p.k, p.v)
+	}
+	return list
+}
+
+func (r *Record) size() (int, error) {
+	size, _, err := rlp.EncodeToReader(r)
+	return size, err
+}
+
+// EncodeRLP implements rlp.Encoder. The record is encoded as
+// [signature, seq, k1, v1, k2, v2, ...].
+func (r *Record) EncodeRLP(w io.Writer) error {
+	if r.signature == nil {
+		return errRecordNotSigned
+	}
+	return rlp.Encode(w, r.list(true))
+}
+
+// DecodeRLP implements rlp.Decoder. It does not verify the signature.
+func (r *Record) DecodeRLP(s *rlp.Stream) error {
+	size, err := s.List()
+	if err != nil {
+		return err
+	}
+	if size > maxRecordSize {
+		return errRecordTooBig
+	}
+	var dec Record
+	if dec.signature, err = s.Bytes(); err != nil {
+		return err
+	}
+	if err = s.Decode(&dec.seq); err != nil {
+		return err
+	}
+	var prev string
+	for i := 0; ; i++ {
+		var p recordPair
+		if err := s.Decode(&p.k); err == rlp.EOL {
+			break
+		} else if err != nil {
+			return err
+		}
+		if p.v, err = s.Raw(); err != nil {
+			return err
+		}
+		if i > 0 && p.k <= prev {
+			return errRecordNotSorted
+		}
+		prev = p.k
+		dec.pairs = append(dec.pairs, p)
+	}
+	if err := s.ListEnd(); err != nil {
+		return err
+	}
+	*r = dec
+	return nil
+}
+
+// MarshalJSON renders the record for the RPC API. Values of well-known keys
+// are decoded, all other values are shown as their raw RLP encoding.
+func (r *Record) MarshalJSON() ([]byte, error) {
+	attrs := make(map[string]interface{}, len(r.pairs))
+	for _, p := range r.pairs {
+		var (
+			val interface{}
+			err error
+		)
+		switch p.k {
+		case RecordKeyChainID:
+			var id uint64
+			err = rlp.DecodeBytes(p.v, &id)
+			val = id
+		case RecordKeyProtocols, RecordKeyServices:
+			var list []string
+			err = rlp.DecodeBytes(p.v, &list)
+			val = list
+		default:
+			err = errors.New("unknown key")
+		}
+		if err != nil {
+			val = hexutil.Bytes(p.v)
+		}
+		attrs[p.k] = val
+	}
+	return json.Marshal(struct {
+		Seq        uint64                 `json:"seq"`
+		Signature  hexutil.Bytes          `json:"signature"`
+		Attributes map[string]interface{} `json:"attributes"`
+	}{r.seq, r.signature, attrs})
+}
+
+// RecordFilter reports whether a node record matches some criteria.
+type RecordFilter func(r *Record) bool
+
+// WithAttribute matches records whose value under key is equal to the RLP
+// encoding of val.
+func WithAttribute(key string, val interface{}) RecordFilter {
+	want, err := rlp.EncodeToBytes(val)
+	return func(r *Record) bool {
+		return err == nil && bytes.Equal(r.get(key), want)
+	}
+}
+
+// WithChainID matches records of nodes following the given chain.
+func WithChainID(id uint64) RecordFilter {
+	return WithAttribute(RecordKeyChainID, id)
+}
+
+// WithService matches records of nodes providing the given service.
+func WithService(name string) RecordFilter {
+	return func(r *Record) bool {
+		return r.hasListEntry(RecordKeyServices, name)
+	}
+}
+
+// WithProtocol matches records of nodes running the given protocol version.
+func WithProtocol(name string, version uint) RecordFilter {
+	entry := ProtocolEntry(name, version)
+	return func(r *Record) bool {
+		return r.hasListEntry(RecordKeyProtocols, entry)
+	}
+}
+
+// ProtocolEntry returns the RecordKeyProtocols list entry of a protocol version.
+func ProtocolEntry(name string, version uint) string {
+	return fmt.Sprintf("%s/%d", name, version)
+}
+
+func (r *Record) hasListEntry(key, entry string) bool {
+	var list []string
+	if r.Load(key, &list) != nil {
+		return false
+	}
+	for _, e := range list {
+		if e == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRecord reports whether r is non-nil and satisfies all filters.
+func matchRecord(r *Record, filters []RecordFilter) bool {
+	if r == nil {
+		return false
+	}
+	for _, f := range filters {
+		if !f(r) {
+			return false
+		}
+	}
+	return true
+}
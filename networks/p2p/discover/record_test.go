@@ -0,0 +1,134 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package discover
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/kaiachain/kaia/rlp"
+)
+
+func TestRecordSignVerify(t *testing.T) {
+	key := newkey()
+	id := PubkeyID(&key.PublicKey)
+
+	var r Record
+	r.Set(RecordKeyServices, []string{ServiceBridge, ServiceSnap})
+	r.Set(RecordKeyChainID, uint64(8217))
+	r.Set("custom", []byte{1, 2, 3})
+	r.SetSeq(7)
+	if keys, want := r.Keys(), []string{"chainid", "custom", "services"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys mismatch: got %v, want %v", keys, want)
+	}
+
+	// An unsigned record can neither be encoded nor verified.
+	if _, err := rlp.EncodeToBytes(&r); !errors.Is(err, errRecordNotSigned) {
+		t.Errorf("encoding unsigned record: got %v, want %v", err, errRecordNotSigned)
+	}
+	if err := r.Verify(id); !errors.Is(err, errRecordNotSigned) {
+		t.Errorf("verifying unsigned record: got %v, want %v", err, errRecordNotSigned)
+	}
+
+	if err := r.Sign(key); err != nil {
+		t.Fatalf("failed to sign record: %v", err)
+	}
+	if err := r.Verify(id); err != nil {
+		t.Errorf("failed to verify record: %v", err)
+	}
+	if err := r.Verify(PubkeyID(&newkey().PublicKey)); !errors.Is(err, errRecordBadSignature) {
+		t.Errorf("verifying with other id: got %v, want %v", err, errRecordBadSignature)
+	}
+
+	// The record survives an encoding round trip.
+	blob, err := rlp.EncodeToBytes(&r)
+	if err != nil {
+		t.Fatalf("failed to encode record: %v", err)
+	}
+	var dec Record
+	if err := rlp.DecodeBytes(blob, &dec); err != nil {
+		t.Fatalf("failed to decode record: %v", err)
+	}
+	if err := dec.Verify(id); err != nil {
+		t.Errorf("failed to verify decoded record: %v", err)
+	}
+	if dec.Seq() != 7 {
+		t.Errorf("seq mismatch: got %d, want 7", dec.Seq())
+	}
+	var chainID uint64
+	if err := dec.Load(RecordKeyChainID, &chainID); err != nil || chainID != 8217 {
+		t.Errorf("chain id mismatch: got %d (err %v), want 8217", chainID, err)
+	}
+	if err := dec.Load("missing", &chainID); !errors.Is(err, errRecordKeyMissing) {
+		t.Errorf("loading missing key: got %v, want %v", err, errRecordKeyMissing)
+	}
+
+	// Any modification invalidates the signature.
+	dec.Set(RecordKeyChainID, uint64(1001))
+	if dec.Signed() {
+		t.Error("modified record is still signed")
+	}
+	dec.signature = r.signature
+	if err := dec.Verify(id); !errors.Is(err, errRecordBadSignature) {
+		t.Errorf("verifying modified record: got %v, want %v", err, errRecordBadSignature)
+	}
+}
+
+func TestRecordDecodeUnsorted(t *testing.T) {
+	blob, _ := rlp.EncodeToBytes([]interface{}{[]byte{1}, uint64(1), "b", uint(1), "a", uint(2)})
+	var r Record
+	if err := rlp.DecodeBytes(blob, &r); !errors.Is(err, errRecordNotSorted) {
+		t.Errorf("got %v, want %v", err, errRecordNotSorted)
+	}
+}
+
+func TestRecordTooBig(t *testing.T) {
+	var r Record
+	r.Set("blob", make([]byte, maxRecordSize))
+	if err := r.Sign(newkey()); !errors.Is(err, errRecordTooBig) {
+		t.Errorf("got %v, want %v", err, errRecordTooBig)
+	}
+	if r.Signed() {
+		t.Error("oversized record is signed")
+	}
+}
+
+func TestRecordFilters(t *testing.T) {
+	var r Record
+	r.Set(RecordKeyChainID, uint64(8217))
+	r.Set(RecordKeyProtocols, []string{ProtocolEntry("istanbul", 65), ProtocolEntry("snap", 1)})
+	r.Set(RecordKeyServices, []string{ServiceSnap})
+
+	tests := []struct {
+		record  *Record
+		filters []RecordFilter
+		want    bool
+	}{
+		{&r, nil, true},
+		{&r, []RecordFilter{WithChainID(8217), WithService(ServiceSnap), WithProtocol("snap", 1)}, true},
+		{&r, []RecordFilter{WithChainID(1001)}, false},
+		{&r, []RecordFilter{WithService(ServiceBridge)}, false},
+		{&r, []RecordFilter{WithProtocol("istanbul", 64)}, false},
+		{nil, nil, false},
+	}
+	for i, tt := range tests {
+		if got := matchRecord(tt.record, tt.filters); got != tt.want {
+			t.Errorf("test %d: got %v, want %v", i, got, tt.want)
+		}
+	}
+}
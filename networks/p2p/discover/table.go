@@ -23,6 +23,7 @@
 package discover
 
 import (
+	"crypto/ecdsa"
 	crand "crypto/rand"
 	"encoding/binary"
 	"errors"
@@ -100,6 +101,15 @@ type Discovery interface {
 	GetAuthorizedNodes() []*Node
 	PutAuthorizedNodes(nodes []*Node)
 	DeleteAuthorizedNodes(nodes []*Node)
+
+	BanNode(id NodeID, until time.Time) error
+	GetBannedNodes() map[NodeID]time.Time
+
+	LocalRecord() *Record
+	SetLocalRecordAttribute(key string, val interface{}) error
+	AddLocalRecordService(name string) error
+	NodeRecord(id NodeID) *Record
+	LookupByRecord(targetType NodeType, filters ...RecordFilter) []*Node
 }
 
 type Table struct {
@@ -126,6 +136,11 @@ type Table struct {
 	storages   map[NodeType]discoverStorage
 	storagesMu sync.RWMutex
 
+	priv        *ecdsa.PrivateKey // signs the local node record
+	localRecord *Record
+	fetching    map[NodeID]struct{} // nodes whose record is being requested
+	recordMu    sync.Mutex
+
 	localLogger log.Logger
 }
 
@@ -142,6 +157,7 @@ type transport interface {
 	ping(toid NodeID, toaddr *net.UDPAddr) error
 	waitping(NodeID) error
 	findnode(toid NodeID, toaddr *net.UDPAddr, target NodeID, targetNT NodeType, max int) ([]*Node, error)
+	requestRecord(toid NodeID, toaddr *net.UDPAddr) (*Record, error)
 	close()
 }
 
@@ -164,8 +180,14 @@ func newTable(cfg *Config) (Discovery, error) {
 		closed:      make(chan struct{}),
 		rand:        mrand.New(mrand.NewSource(0)),
 		storages:    make(map[NodeType]discoverStorage),
+		priv:        cfg.PrivateKey,
+		localRecord: new(Record),
+		fetching:    make(map[NodeID]struct{}),
 		localLogger: logger.NewWith("Discover", "Table"),
 	}
+	if err := tab.publishLocalRecord(new(Record)); err != nil {
+		return nil, err
+	}
 
 	// Supported discovery types for each NodeType
 	// - CN: CN, BN
@@ -244,6 +266,63 @@ func (tab *Table) IsAuthorized(fromID NodeID, nType NodeType) bool {
 	return true
 }
 
+// updateRecord requests the node record of the given node if the advertised
+// sequence number is newer than the locally known one, and stores it.
+func (tab *Table) updateRecord(id NodeID, addr *net.UDPAddr, seq uint64) {
+	if tab.db.recordSeq(id) >= seq {
+		return
+	}
+	tab.fetchRecord(id, addr)
+}
+
+// fetchRecord requests the node record of the given node and stores it. It
+// does nothing if a request to the node is already in flight.
+func (tab *Table) fetchRecord(id NodeID, addr *net.UDPAddr) {
+	tab.recordMu.Lock()
+	if _, ok := tab.fetching[id]; ok {
+		tab.recordMu.Unlock()
+		return
+	}
+	tab.fetching[id] = struct{}{}
+	tab.recordMu.Unlock()
+
+	defer func() {
+		tab.recordMu.Lock()
+		delete(tab.fetching, id)
+		tab.recordMu.Unlock()
+	}()
+
+	record, err := tab.net.requestRecord(id, addr)
+	if err != nil {
+		tab.localLogger.Trace("Failed to fetch node record", "id", id, "addr", addr, "err", err)
+		return
+	}
+	if record.Seq() < tab.db.recordSeq(id) {
+		return
+	}
+	if err := tab.db.updateRecord(id, record); err != nil {
+		tab.localLogger.Warn("Failed to store node record", "id", id, "err", err)
+	}
+}
+
+// publishLocalRecord signs r with a fresh sequence number and makes it the
+// local node record. The sequence number is derived from the wall clock so
+// that it keeps increasing across restarts.
+func (tab *Table) publishLocalRecord(r *Record) error {
+	seq := uint64(time.Now().UnixMilli())
+	if seq <= tab.localRecord.Seq() {
+		seq = tab.localRecord.Seq() + 1
+	}
+	r.SetSeq(seq)
+	if tab.priv != nil {
+		if err := r.Sign(tab.priv); err != nil {
+			return err
+		}
+	}
+	tab.localRecord = r
+	return nil
+}
+
 // setFallbackNodes sets the initial points of contact. These nodes
 // are used to connect to the network if the table is empty and there
 // are no known nodes in the database.
@@ -459,6 +538,50 @@ func (tab *Table) GetNodes(targetNT NodeType, max int) []*Node {
 	return tab.storages[targetNT].getNodes(max)
 }
 
+// LookupByRecord searches the network for nodes of the given type and
+// returns those whose node record satisfies all filters. Records which are
+// not known yet are requested from the candidates.
+func (tab *Table) LookupByRecord(targetType NodeType, filters ...RecordFilter) []*Node {
+	var target NodeID
+	crand.Read(target[:])
+
+	var (
+		seen       = make(map[NodeID]bool)
+		candidates []*Node
+	)
+	for _, n := range append(tab.Lookup(target, targetType), tab.GetNodes(targetType, bucketSize)...) {
+		if !seen[n.ID] && n.ID != tab.self.ID {
+			seen[n.ID] = true
+			candidates = append(candidates, n)
+		}
+	}
+
+	var (
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, alpha)
+	)
+	for _, n := range candidates {
+		if tab.db.record(n.ID) != nil {
+			continue
+		}
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(n *Node) {
+			defer func() { <-slots; wg.Done() }()
+			tab.fetchRecord(n.ID, n.addr())
+		}(n)
+	}
+	wg.Wait()
+
+	var result []*Node
+	for _, n := range candidates {
+		if matchRecord(tab.db.record(n.ID), filters) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
 func removeBn(nodes []*Node) []*Node {
 	tmp := nodes[:0]
 	for _, n := range nodes {
@@ -150,6 +150,56 @@ func TestBucket_bumpNoDuplicates(t *testing.T) {
 	}
 }
 
+// This checks that LookupByRecord filters nodes by their node records and
+// requests the records it does not know yet.
+func TestTable_LookupByRecord(t *testing.T) {
+	transport := newPingRecorder()
+	conf := Config{
+		udp:        transport,
+		Id:         NodeID{},
+		Addr:       &net.UDPAddr{},
+		Bootnodes:  nil,
+		NodeDBPath: "",
+	}
+	discv, _ := newTable(&conf)
+	tab := discv.(*Table)
+	tab.addStorage(NodeTypeEN, &KademliaStorage{targetType: NodeTypeEN})
+	go tab.loop()
+	defer tab.Close()
+
+	makeRecord := func(services ...string) *Record {
+		r := new(Record)
+		r.Set(RecordKeyServices, services)
+		r.Sign(newkey())
+		return r
+	}
+	var (
+		known   = nodeAtDistance(tab.self.sha, 250, NodeTypeEN) // record already in the database
+		fetched = nodeAtDistance(tab.self.sha, 251, NodeTypeEN) // record served by the node
+		silent  = nodeAtDistance(tab.self.sha, 252, NodeTypeEN) // record unavailable
+	)
+	tab.stuff([]*Node{known, fetched, silent}, NodeTypeEN)
+	tab.db.updateRecord(known.ID, makeRecord(ServiceSnap))
+	transport.records[fetched.ID] = makeRecord(ServiceBridge, ServiceSnap)
+
+	nodeIDs := func(nodes []*Node) map[NodeID]bool {
+		ids := make(map[NodeID]bool)
+		for _, n := range nodes {
+			ids[n.ID] = true
+		}
+		return ids
+	}
+	if got, want := nodeIDs(tab.LookupByRecord(NodeTypeEN, WithService(ServiceSnap))), nodeIDs([]*Node{known, fetched}); !reflect.DeepEqual(got, want) {
+		t.Errorf("snap nodes mismatch: got %v, want %v", got, want)
+	}
+	if got, want := nodeIDs(tab.LookupByRecord(NodeTypeEN, WithService(ServiceBridge))), nodeIDs([]*Node{fetched}); !reflect.DeepEqual(got, want) {
+		t.Errorf("bridge nodes mismatch: got %v, want %v", got, want)
+	}
+	if tab.NodeRecord(fetched.ID) == nil {
+		t.Error("fetched record was not stored")
+	}
+}
+
 // This checks that the table-wide IP limit is applied correctly.
 func TestTable_IPLimit(t *testing.T) {
 	transport := newPingRecorder()
@@ -230,12 +280,14 @@ func nodeAtDistance(base common.Hash, ld int, nType NodeType) (n *Node) {
 type pingRecorder struct {
 	mu           sync.Mutex
 	dead, pinged map[NodeID]bool
+	records      map[NodeID]*Record
 }
 
 func newPingRecorder() *pingRecorder {
 	return &pingRecorder{
-		dead:   make(map[NodeID]bool),
-		pinged: make(map[NodeID]bool),
+		dead:    make(map[NodeID]bool),
+		pinged:  make(map[NodeID]bool),
+		records: make(map[NodeID]*Record),
 	}
 }
 
@@ -243,6 +295,15 @@ func (t *pingRecorder) findnode(toid NodeID, toaddr *net.UDPAddr, target NodeID,
 	return nil, nil
 }
 func (t *pingRecorder) close() {}
+func (t *pingRecorder) requestRecord(toid NodeID, toaddr *net.UDPAddr) (*Record, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if r := t.records[toid]; r != nil {
+		return r, nil
+	}
+	return nil, errTimeout
+}
 func (t *pingRecorder) waitping(from NodeID) error {
 	return nil // remote always pings
 }
@@ -645,6 +706,10 @@ func (*preminedTestnet) close()                                      {}
 func (*preminedTestnet) waitping(from NodeID) error                  { return nil }
 func (*preminedTestnet) ping(toid NodeID, toaddr *net.UDPAddr) error { return nil }
 
+func (*preminedTestnet) requestRecord(toid NodeID, toaddr *net.UDPAddr) (*Record, error) {
+	return nil, errTimeout
+}
+
 // mine generates a testnet struct literal with nodes at
 // various distances to the given target.
 func (tn *preminedTestnet) mine(target NodeID) {
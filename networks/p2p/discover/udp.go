@@ -69,6 +69,8 @@ const (
 	pongPacket
 	findnodePacket
 	neighborsPacket
+	recordRequestPacket
+	recordResponsePacket
 )
 
 // Node types
@@ -122,6 +124,21 @@ type (
 		Rest []rlp.RawValue `rlp:"tail"`
 	}
 
+	// recordRequest queries the current node record of the recipient.
+	recordRequest struct {
+		Expiration uint64
+		// Ignore additional fields (for forward compatibility).
+		Rest []rlp.RawValue `rlp:"tail"`
+	}
+
+	// reply to recordRequest
+	recordResponse struct {
+		ReplyTok []byte // This contains the hash of the recordRequest packet.
+		Record   Record
+		// Ignore additional fields (for forward compatibility).
+		Rest []rlp.RawValue `rlp:"tail"`
+	}
+
 	rpcNode struct {
 		IP    net.IP // len 4 for IPv4 or 16 for IPv6
 		UDP   uint16 // for discovery protocol
@@ -147,6 +164,24 @@ type (
 	}
 )
 
+// recordSeqRest encodes the sequence number of the local node record as the
+// first additional field of ping and pong. Nodes which do not know about node
+// records ignore it like any other forward-compatible field.
+func recordSeqRest(seq uint64) []rlp.RawValue {
+	blob, _ := rlp.EncodeToBytes(seq)
+	return []rlp.RawValue{blob}
+}
+
+// recordSeqFromRest returns the node record sequence number carried in the
+// additional fields of ping and pong, or zero if there is none.
+func recordSeqFromRest(rest []rlp.RawValue) uint64 {
+	var seq uint64
+	if len(rest) == 0 || rlp.DecodeBytes(rest[0], &seq) != nil {
+		return 0
+	}
+	return seq
+}
+
 func makeEndpoint(addr *net.UDPAddr, tcpPort uint16, nType NodeType) rpcEndpoint {
 	ip := addr.IP.To4()
 	if ip == nil {
@@ -243,6 +278,10 @@ func (p *pending) String() string {
 		typeStr = "FINDNODE"
 	case neighborsPacket:
 		typeStr = "NEIGHBORS"
+	case recordRequestPacket:
+		typeStr = "RECORDREQUEST"
+	case recordResponsePacket:
+		typeStr = "RECORDRESPONSE"
 	default:
 		typeStr = "UNKNOWN"
 	}
@@ -345,23 +384,70 @@ func (t *udp) ping(toid NodeID, toaddr *net.UDPAddr) error {
 		From:       t.ourEndpoint,
 		To:         makeEndpoint(toaddr, 0, NodeTypeUnknown), // TODO: maybe use known TCP port from DB
 		Expiration: uint64(time.Now().Add(expiration).Unix()),
+		Rest:       recordSeqRest(t.LocalRecord().Seq()),
 	}
 	packet, hash, err := encodePacket(t.priv, pingPacket, req)
 	if err != nil {
 		return err
 	}
+	var recordSeq uint64
 	errc := t.pending(toid, pongPacket, NodeTypeUnknown, func(p interface{}) bool {
-		return bytes.Equal(p.(*pong).ReplyTok, hash)
+		if !bytes.Equal(p.(*pong).ReplyTok, hash) {
+			return false
+		}
+		recordSeq = recordSeqFromRest(p.(*pong).Rest)
+		return true
 	})
 	pingMeter.Mark(1)
 	t.write(toaddr, req.name(), packet)
 	err = <-errc
 	if err == nil {
 		pongMeter.Mark(1)
+		t.checkRecord(toid, toaddr, recordSeq)
 	}
 	return err
 }
 
+// requestRecord sends a recordRequest to the given node and waits for its
+// signed node record.
+func (t *udp) requestRecord(toid NodeID, toaddr *net.UDPAddr) (*Record, error) {
+	req := &recordRequest{
+		Expiration: uint64(time.Now().Add(expiration).Unix()),
+	}
+	packet, hash, err := encodePacket(t.priv, recordRequestPacket, req)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		record    *Record
+		verifyErr error
+	)
+	errc := t.pending(toid, recordResponsePacket, NodeTypeUnknown, func(r interface{}) bool {
+		reply := r.(*recordResponse)
+		if !bytes.Equal(reply.ReplyTok, hash) {
+			return false
+		}
+		record, verifyErr = &reply.Record, reply.Record.Verify(toid)
+		return true
+	})
+	t.write(toaddr, req.name(), packet)
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+	if verifyErr != nil {
+		return nil, verifyErr
+	}
+	return record, nil
+}
+
+// checkRecord fetches the node record of the given node in the background if
+// the advertised sequence number is newer than the one already known.
+func (t *udp) checkRecord(id NodeID, addr *net.UDPAddr, seq uint64) {
+	if tab, ok := t.Discovery.(*Table); ok && seq > 0 {
+		go tab.updateRecord(id, addr, seq)
+	}
+}
+
 func (t *udp) waitping(from NodeID) error {
 	return <-t.pending(from, pingPacket, NodeTypeUnknown, func(interface{}) bool { return true })
 }
@@ -672,6 +758,10 @@ func decodePacket(buf []byte) (packet, NodeID, []byte, error) {
 		req = new(findnode)
 	case neighborsPacket:
 		req = new(neighbors)
+	case recordRequestPacket:
+		req = new(recordRequest)
+	case recordResponsePacket:
+		req = new(recordResponse)
 	default:
 		return nil, fromID, hash, fmt.Errorf("unknown type: %d", ptype)
 	}
@@ -707,11 +797,13 @@ func (req *ping) handle(t *udp, from *net.UDPAddr, fromID NodeID, mac []byte) er
 		To:         makeEndpoint(from, req.From.TCP, req.From.NType),
 		ReplyTok:   mac,
 		Expiration: uint64(time.Now().Add(expiration).Unix()),
+		Rest:       recordSeqRest(t.LocalRecord().Seq()),
 	})
 	if !t.handleReply(fromID, pingPacket, req) {
 		// Note: we're ignoring the provided IP address right now
 		go t.Bond(true, fromID, from, req.From.TCP, req.From.NType)
 	}
+	t.checkRecord(fromID, from, recordSeqFromRest(req.Rest))
 	return nil
 }
 
@@ -783,6 +875,37 @@ func (req *neighbors) handle(t *udp, from *net.UDPAddr, fromID NodeID, mac []byt
 
 func (req *neighbors) name() string { return "NEIGHBORS/v4" }
 
+func (req *recordRequest) handle(t *udp, from *net.UDPAddr, fromID NodeID, mac []byte) error {
+	if expired(req.Expiration) {
+		return errExpired
+	}
+	if !t.HasBond(fromID) {
+		// Same as findnode, only bonded nodes are answered to avoid
+		// being used for traffic amplification.
+		return errUnknownNode
+	}
+	record := t.LocalRecord()
+	if !record.Signed() {
+		return errRecordNotSigned
+	}
+	t.send(from, recordResponsePacket, &recordResponse{
+		ReplyTok: mac,
+		Record:   *record,
+	})
+	return nil
+}
+
+func (req *recordRequest) name() string { return "RECORDREQUEST/v4" }
+
+func (req *recordResponse) handle(t *udp, from *net.UDPAddr, fromID NodeID, mac []byte) error {
+	if !t.handleReply(fromID, recordResponsePacket, req) {
+		return errUnsolicitedReply
+	}
+	return nil
+}
+
+func (req *recordResponse) name() string { return "RECORDRESPONSE/v4" }
+
 func findnodeRetrieveSize(nType NodeType) int {
 	// Returning too small value will make CNs unable to find each other.
 	if nType == NodeTypeCN {
@@ -400,6 +400,88 @@ func TestUDP_successfulPing(t *testing.T) {
 	}
 }
 
+func TestUDP_recordRequest(t *testing.T) {
+	test := newUDPTest(t)
+	defer test.table.Close()
+
+	if err := test.table.AddLocalRecordService(ServiceSnap); err != nil {
+		t.Fatal(err)
+	}
+
+	// record requests of unknown nodes are not answered.
+	test.packetIn(errUnknownNode, recordRequestPacket, &recordRequest{Expiration: futureExp})
+	test.table.db.updateBondTime(PubkeyID(&test.remotekey.PublicKey), time.Now())
+
+	test.packetIn(nil, recordRequestPacket, &recordRequest{Expiration: futureExp})
+	test.waitPacketOut(func(p *recordResponse) {
+		if reqhash := test.sent[len(test.sent)-1][:macSize]; !bytes.Equal(p.ReplyTok, reqhash) {
+			t.Errorf("got ReplyTok %x, want %x", p.ReplyTok, reqhash)
+		}
+		if err := p.Record.Verify(test.table.self.ID); err != nil {
+			t.Errorf("invalid record signature: %v", err)
+		}
+		if p.Record.Seq() != test.table.LocalRecord().Seq() {
+			t.Errorf("got record seq %d, want %d", p.Record.Seq(), test.table.LocalRecord().Seq())
+		}
+		if !WithService(ServiceSnap)(&p.Record) {
+			t.Error("record does not contain the snap service")
+		}
+	})
+}
+
+func TestUDP_requestRecord(t *testing.T) {
+	test := newUDPTest(t)
+	defer test.table.Close()
+
+	rid := PubkeyID(&test.remotekey.PublicKey)
+	var remote Record
+	remote.Set(RecordKeyServices, []string{ServiceBridge})
+	remote.SetSeq(3)
+	if err := remote.Sign(test.remotekey); err != nil {
+		t.Fatal(err)
+	}
+
+	// the remote node advertises a newer record in its pong, the table
+	// requests it and stores it.
+	go test.udp.ping(rid, test.remoteaddr)
+	hash, _ := test.waitPacketOut(func(p *ping) {})
+	test.packetIn(nil, pongPacket, &pong{ReplyTok: hash, Expiration: futureExp, Rest: recordSeqRest(remote.Seq())})
+
+	hash, _ = test.waitPacketOut(func(p *recordRequest) {})
+	test.packetIn(nil, recordResponsePacket, &recordResponse{ReplyTok: hash, Record: remote})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for test.table.NodeRecord(rid) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("record was not stored within 2 seconds")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := test.table.NodeRecord(rid); got.Seq() != remote.Seq() || !WithService(ServiceBridge)(got) {
+		t.Errorf("stored record mismatch: seq %d, keys %v", got.Seq(), got.Keys())
+	}
+}
+
+func TestUDP_requestRecordBadSignature(t *testing.T) {
+	test := newUDPTest(t)
+	defer test.table.Close()
+
+	// a record signed by some other key is rejected.
+	var forged Record
+	forged.Sign(newkey())
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := test.udp.requestRecord(PubkeyID(&test.remotekey.PublicKey), test.remoteaddr)
+		errc <- err
+	}()
+	hash, _ := test.waitPacketOut(func(p *recordRequest) {})
+	test.packetIn(nil, recordResponsePacket, &recordResponse{ReplyTok: hash, Record: forged})
+	if err := <-errc; !errors.Is(err, errRecordBadSignature) {
+		t.Errorf("got %v, want %v", err, errRecordBadSignature)
+	}
+}
+
 var testPackets = []struct {
 	input      string
 	wantPacket interface{}
@@ -125,6 +125,9 @@ type Peer struct {
 
 	// events receives message send / receive events if set
 	events *event.Feed
+
+	// reputation scores the peer's misbehaviour if set
+	reputation *reputationTracker
 }
 
 // NewPeer returns a peer for testing purposes.
@@ -732,6 +735,17 @@ func (p *Peer) ConnType() common.ConnType {
 	return p.rws[ConnDefault].conntype
 }
 
+// Report lowers the reputation of the peer for the given misbehaviour. The peer
+// is disconnected and banned for a while once its score reaches the threshold
+// of its node type. Trusted and static peers are never banned.
+func (p *Peer) Report(ev ReputationEvent) {
+	if p == nil || p.reputation == nil {
+		return
+	}
+	c := p.rws[ConnDefault]
+	p.reputation.report(c.id, c.conntype, c.is(trustedConn|staticDialedConn), ev)
+}
+
 type PeerTypeValidator interface {
 	// ValidatePeerType returns nil if successful. Otherwise, it returns an error object.
 	ValidatePeerType(addr common.Address) error
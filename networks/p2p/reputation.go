@@ -0,0 +1,268 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/networks/p2p/discover"
+)
+
+// ReputationEvent is a kind of peer misbehaviour reported by the protocol handlers.
+type ReputationEvent int
+
+const (
+	RepUselessResponse     ReputationEvent = iota // response without any usable data
+	RepInvalidResponse                            // response failing verification
+	RepLateAnnouncement                           // announcement of an already known block
+	RepInvalidAnnouncement                        // announcement of a block that failed verification
+	RepInvalidTx                                  // transaction that can never be valid
+	RepProtocolError                              // malformed or unexpected protocol message
+	numReputationEvents
+)
+
+// reputationEvents holds the name and penalty of every event. Late announcements
+// also come from honest peers that are slightly behind, so they only weigh a
+// fraction of a point.
+var reputationEvents = [numReputationEvents]struct {
+	name    string
+	penalty float64
+}{
+	RepUselessResponse:     {"uselessResponse", 2},
+	RepInvalidResponse:     {"invalidResponse", 20},
+	RepLateAnnouncement:    {"lateAnnouncement", 0.1},
+	RepInvalidAnnouncement: {"invalidAnnouncement", 20},
+	RepInvalidTx:           {"invalidTx", 5},
+	RepProtocolError:       {"protocolError", 50},
+}
+
+func (ev ReputationEvent) String() string {
+	if ev < 0 || ev >= numReputationEvents {
+		return "unknown"
+	}
+	return reputationEvents[ev].name
+}
+
+// reputationHalfLife is the time it takes for a peer score to recover half way
+// back to zero.
+const reputationHalfLife = 10 * time.Minute
+
+// ReputationConfig holds the peer scoring options.
+type ReputationConfig struct {
+	// Disable turns off the peer scoring entirely.
+	Disable bool
+
+	// CNBanThreshold, PNBanThreshold and ENBanThreshold are the penalties a
+	// peer of the given type may accumulate before it is banned.
+	// Zero means peers of the type are never banned.
+	CNBanThreshold int
+	PNBanThreshold int
+	ENBanThreshold int
+
+	// BanDuration is how long a banned peer is refused. Zero defaults to
+	// DefaultReputationConfig.BanDuration.
+	BanDuration time.Duration
+}
+
+// DefaultReputationConfig never bans consensus nodes since losing one of them
+// hurts the network more than a misbehaving one.
+var DefaultReputationConfig = ReputationConfig{
+	CNBanThreshold: 0,
+	PNBanThreshold: 400,
+	ENBanThreshold: 200,
+	BanDuration:    time.Hour,
+}
+
+// PeerScore is the reputation of a peer as reported by the admin API.
+type PeerScore struct {
+	ID           string            `json:"id"`
+	ConnType     string            `json:"connType"`
+	Score        float64           `json:"score"`
+	BanThreshold int               `json:"banThreshold"`
+	Events       map[string]uint64 `json:"events,omitempty"`
+	BannedUntil  *time.Time        `json:"bannedUntil,omitempty"`
+}
+
+type peerScore struct {
+	value    float64
+	updated  time.Time
+	connType common.ConnType
+	events   [numReputationEvents]uint64
+}
+
+// decay moves the score towards zero according to the time passed since the
+// last update.
+func (s *peerScore) decay(now time.Time) {
+	if elapsed := now.Sub(s.updated); elapsed > 0 {
+		s.value *= math.Pow(0.5, float64(elapsed)/float64(reputationHalfLife))
+		s.updated = now
+	}
+}
+
+// reputationTracker keeps the scores of peers and the list of banned nodes.
+// Bans are persisted to the discovery database if discovery is enabled.
+type reputationTracker struct {
+	config     ReputationConfig
+	ntab       discover.Discovery
+	disconnect func(discover.NodeID)
+	now        func() time.Time
+
+	mu     sync.Mutex
+	scores map[discover.NodeID]*peerScore
+	banned map[discover.NodeID]time.Time
+}
+
+func newReputationTracker(config ReputationConfig, ntab discover.Discovery, disconnect func(discover.NodeID)) *reputationTracker {
+	if config.BanDuration == 0 {
+		config.BanDuration = DefaultReputationConfig.BanDuration
+	}
+	t := &reputationTracker{
+		config:     config,
+		ntab:       ntab,
+		disconnect: disconnect,
+		now:        time.Now,
+		scores:     make(map[discover.NodeID]*peerScore),
+		banned:     make(map[discover.NodeID]time.Time),
+	}
+	if ntab != nil {
+		t.banned = ntab.GetBannedNodes()
+	}
+	return t
+}
+
+func (t *reputationTracker) threshold(ct common.ConnType) int {
+	switch ct {
+	case common.CONSENSUSNODE:
+		return t.config.CNBanThreshold
+	case common.PROXYNODE:
+		return t.config.PNBanThreshold
+	case common.ENDPOINTNODE:
+		return t.config.ENBanThreshold
+	default:
+		return 0
+	}
+}
+
+// report applies the penalty of the event to the peer and bans it once its
+// score falls to the threshold of its node type. Exempt peers are scored but
+// never banned.
+func (t *reputationTracker) report(id discover.NodeID, ct common.ConnType, exempt bool, ev ReputationEvent) {
+	if t == nil || t.config.Disable || ev < 0 || ev >= numReputationEvents {
+		return
+	}
+	t.mu.Lock()
+	now := t.now()
+	s, ok := t.scores[id]
+	if !ok {
+		s = &peerScore{updated: now}
+		t.scores[id] = s
+	}
+	s.decay(now)
+	s.value -= reputationEvents[ev].penalty
+	s.connType = ct
+	s.events[ev]++
+
+	threshold := t.threshold(ct)
+	ban := !exempt && threshold > 0 && s.value <= -float64(threshold)
+	until := now.Add(t.config.BanDuration)
+	if ban {
+		t.banned[id] = until
+		delete(t.scores, id)
+	}
+	score := s.value
+	t.mu.Unlock()
+
+	logger.Trace("Peer misbehaved", "id", id, "event", ev, "score", score)
+	if !ban {
+		return
+	}
+	logger.Warn("Banning peer with low reputation", "id", id, "connType", ct, "score", score, "until", until)
+	if t.ntab != nil {
+		if err := t.ntab.BanNode(id, until); err != nil {
+			logger.Error("Failed to persist peer ban", "id", id, "err", err)
+		}
+	}
+	if t.disconnect != nil {
+		t.disconnect(id)
+	}
+}
+
+// isBanned reports whether the node is refused at the moment.
+func (t *reputationTracker) isBanned(id discover.NodeID) bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	until, ok := t.banned[id]
+	if !ok {
+		return false
+	}
+	if !t.now().Before(until) {
+		delete(t.banned, id)
+		return false
+	}
+	return true
+}
+
+// peerScores returns the scores of the misbehaving peers and the banned nodes,
+// sorted by node id. Scores that have recovered are dropped.
+func (t *reputationTracker) peerScores() []*PeerScore {
+	if t == nil {
+		return []*PeerScore{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	res := make([]*PeerScore, 0, len(t.scores)+len(t.banned))
+	for id, s := range t.scores {
+		s.decay(now)
+		if s.value > -1 {
+			delete(t.scores, id)
+			continue
+		}
+		events := make(map[string]uint64)
+		for ev, n := range s.events {
+			if n > 0 {
+				events[ReputationEvent(ev).String()] = n
+			}
+		}
+		res = append(res, &PeerScore{
+			ID:           id.String(),
+			ConnType:     ConvertConnTypeToString(s.connType),
+			Score:        s.value,
+			BanThreshold: t.threshold(s.connType),
+			Events:       events,
+		})
+	}
+	for id, until := range t.banned {
+		if !now.Before(until) {
+			delete(t.banned, id)
+			continue
+		}
+		until := until
+		res = append(res, &PeerScore{ID: id.String(), ConnType: "unknown", BannedUntil: &until})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/networks/p2p/discover"
+	"github.com/stretchr/testify/assert"
+)
+
+// banTable is a discovery table that only remembers the bans.
+type banTable struct {
+	fakeTable
+	bans map[discover.NodeID]time.Time
+}
+
+func (t *banTable) BanNode(id discover.NodeID, until time.Time) error {
+	t.bans[id] = until
+	return nil
+}
+
+func (t *banTable) GetBannedNodes() map[discover.NodeID]time.Time {
+	banned := make(map[discover.NodeID]time.Time)
+	for id, until := range t.bans {
+		banned[id] = until
+	}
+	return banned
+}
+
+func newTestReputationTracker(config ReputationConfig, ntab discover.Discovery) (*reputationTracker, *time.Time, *[]discover.NodeID) {
+	var (
+		now          = time.Unix(1700000000, 0)
+		disconnected []discover.NodeID
+	)
+	t := newReputationTracker(config, ntab, func(id discover.NodeID) { disconnected = append(disconnected, id) })
+	t.now = func() time.Time { return now }
+	return t, &now, &disconnected
+}
+
+func TestReputationBan(t *testing.T) {
+	var (
+		tab    = &banTable{bans: make(map[discover.NodeID]time.Time)}
+		config = ReputationConfig{CNBanThreshold: 0, PNBanThreshold: 100, ENBanThreshold: 60, BanDuration: time.Hour}
+		en     = discover.NodeID{1}
+		cn     = discover.NodeID{2}
+		static = discover.NodeID{3}
+	)
+	tracker, now, disconnected := newTestReputationTracker(config, tab)
+
+	// The first protocol error keeps the EN above its threshold, the second bans it.
+	tracker.report(en, common.ENDPOINTNODE, false, RepProtocolError)
+	assert.False(t, tracker.isBanned(en))
+	assert.Empty(t, *disconnected)
+
+	tracker.report(en, common.ENDPOINTNODE, false, RepProtocolError)
+	assert.True(t, tracker.isBanned(en))
+	assert.Equal(t, []discover.NodeID{en}, *disconnected)
+	assert.Equal(t, now.Add(time.Hour), tab.bans[en])
+
+	// CNs are never banned with a zero threshold, exempt peers are never banned at all.
+	for i := 0; i < 10; i++ {
+		tracker.report(cn, common.CONSENSUSNODE, false, RepProtocolError)
+		tracker.report(static, common.ENDPOINTNODE, true, RepProtocolError)
+	}
+	assert.False(t, tracker.isBanned(cn))
+	assert.False(t, tracker.isBanned(static))
+	assert.Len(t, *disconnected, 1)
+
+	// Bans survive a restart through the discovery table and expire afterwards.
+	restarted, restartNow, _ := newTestReputationTracker(config, tab)
+	*restartNow = *now
+	assert.True(t, restarted.isBanned(en))
+	*restartNow = now.Add(time.Hour)
+	assert.False(t, restarted.isBanned(en))
+}
+
+func TestReputationDecay(t *testing.T) {
+	var (
+		config = ReputationConfig{ENBanThreshold: 40}
+		id     = discover.NodeID{1}
+	)
+	tracker, now, disconnected := newTestReputationTracker(config, nil)
+
+	tracker.report(id, common.ENDPOINTNODE, false, RepInvalidResponse)
+	tracker.report(id, common.ENDPOINTNODE, false, RepInvalidTx)
+
+	scores := tracker.peerScores()
+	assert.Len(t, scores, 1)
+	assert.Equal(t, id.String(), scores[0].ID)
+	assert.Equal(t, "en", scores[0].ConnType)
+	assert.Equal(t, -25.0, scores[0].Score)
+	assert.Equal(t, 40, scores[0].BanThreshold)
+	assert.Equal(t, map[string]uint64{"invalidResponse": 1, "invalidTx": 1}, scores[0].Events)
+
+	// After a half-life the penalty is halved, so another one does not ban the peer.
+	*now = now.Add(reputationHalfLife)
+	tracker.report(id, common.ENDPOINTNODE, false, RepInvalidResponse)
+	assert.InDelta(t, -32.5, tracker.peerScores()[0].Score, 1e-9)
+	assert.False(t, tracker.isBanned(id))
+	assert.Empty(t, *disconnected)
+
+	// Fully recovered peers are dropped from the scores.
+	*now = now.Add(10 * reputationHalfLife)
+	assert.Empty(t, tracker.peerScores())
+}
+
+func TestReputationDisabled(t *testing.T) {
+	id := discover.NodeID{1}
+	tracker, _, disconnected := newTestReputationTracker(ReputationConfig{Disable: true, ENBanThreshold: 1}, nil)
+
+	tracker.report(id, common.ENDPOINTNODE, false, RepProtocolError)
+	assert.False(t, tracker.isBanned(id))
+	assert.Empty(t, tracker.peerScores())
+	assert.Empty(t, *disconnected)
+
+	// A peer without a tracker, e.g. in tests, can be reported safely.
+	var peer *Peer
+	peer.Report(RepProtocolError)
+	NewPeer(id, "test", nil).Report(RepProtocolError)
+}
@@ -169,6 +169,10 @@ type Config struct {
 	// It checks if a rw successfully writes its task in given time.
 	RWTimerConfig RWTimerConfig
 
+	// Reputation configures the scoring of misbehaving peers and their
+	// temporary bans.
+	Reputation ReputationConfig
+
 	// NetworkID to use for selecting peers to connect to
 	NetworkID uint64
 }
@@ -233,6 +237,17 @@ type Server interface {
 	// It returns nil if the node could not be found.
 	Resolve(target discover.NodeID, nType discover.NodeType) *discover.Node
 
+	// LookupByRecord searches the network for nodes of the given type whose
+	// node record satisfies all filters.
+	LookupByRecord(nType discover.NodeType, filters ...discover.RecordFilter) []*discover.Node
+
+	// SetRecordAttribute sets an attribute of the node record advertised
+	// through discovery.
+	SetRecordAttribute(key string, val interface{}) error
+
+	// AddRecordService advertises a service in the node record.
+	AddRecordService(name string) error
+
 	// Start starts running the server.
 	// Servers can not be re-used after stopping.
 	Start() (err error)
@@ -279,6 +294,9 @@ type Server interface {
 	// Peers returns all connected peers.
 	Peers() []*Peer
 
+	// PeerScores returns the reputation of misbehaving peers and banned nodes.
+	PeerScores() []*PeerScore
+
 	// NodeDialer is used to connect to nodes in the network, typically by using
 	// an underlying net.Dialer but also using net.Pipe in tests.
 	NodeDialer
@@ -382,6 +400,10 @@ func (srv *MultiChannelServer) Start() (err error) {
 		}
 		srv.ntab = ntab
 	}
+	srv.reputation = newReputationTracker(srv.Reputation, srv.ntab, srv.disconnectBanned)
+	if err := srv.publishProtocols(); err != nil {
+		srv.logger.Warn("Failed to advertise protocols in the node record", "err", err)
+	}
 
 	dialer := newDialState(srv.StaticNodes, srv.BootstrapNodes, srv.ntab, srv.maxDialedConns(), srv.NetRestrict, srv.PrivateKey, srv.getTypeStatics())
 
@@ -745,6 +767,7 @@ running:
 					if srv.EnableMsgEvents {
 						p.events = &srv.peerFeed
 					}
+					p.reputation = srv.reputation
 					name := truncateName(c.name)
 					srv.logger.Debug("Adding p2p peer", "name", name, "addr", c.fd.RemoteAddr(), "peers", len(peers)+1)
 					go srv.runPeer(p)
@@ -925,6 +948,7 @@ type BaseServer struct {
 	running bool
 
 	ntab         discover.Discovery
+	reputation   *reputationTracker
 	listener     net.Listener
 	ourHandshake *protoHandshake
 	lastLookup   time.Time
@@ -1288,6 +1312,10 @@ func (srv *BaseServer) Start() (err error) {
 		}
 		srv.ntab = ntab
 	}
+	srv.reputation = newReputationTracker(srv.Reputation, srv.ntab, srv.disconnectBanned)
+	if err := srv.publishProtocols(); err != nil {
+		srv.logger.Warn("Failed to advertise protocols in the node record", "err", err)
+	}
 
 	dialer := newDialState(srv.StaticNodes, srv.BootstrapNodes, srv.ntab, srv.maxDialedConns(), srv.NetRestrict, srv.PrivateKey, srv.getTypeStatics())
 
@@ -1454,6 +1482,7 @@ running:
 					if srv.EnableMsgEvents {
 						p.events = &srv.peerFeed
 					}
+					p.reputation = srv.reputation
 					name := truncateName(c.name)
 					srv.logger.Debug("Adding p2p peer", "name", name, "addr", c.fd.RemoteAddr(), "peers", len(peers)+1)
 					go srv.runPeer(p)
@@ -1539,6 +1568,8 @@ func (srv *BaseServer) encHandshakeChecks(peers map[discover.NodeID]*Peer, inbou
 		return DiscAlreadyConnected
 	case c.id == srv.Self().ID:
 		return DiscSelf
+	case !c.is(trustedConn|staticDialedConn) && srv.reputation.isBanned(c.id):
+		return DiscUselessPeer
 	default:
 		return nil
 	}
@@ -1808,6 +1839,7 @@ type NodeInfo struct {
 	} `json:"ports"`
 	ListenAddr string                 `json:"listenAddr"`
 	Protocols  map[string]interface{} `json:"protocols"`
+	Record     *discover.Record       `json:"record,omitempty"` // Node record advertised through discovery
 }
 
 // NodeInfo gathers and returns a collection of metadata known about the host.
@@ -1825,6 +1857,9 @@ func (srv *BaseServer) NodeInfo() *NodeInfo {
 	}
 	info.Ports.Discovery = int(node.UDP)
 	info.Ports.Listener = int(node.TCP)
+	if srv.ntab != nil {
+		info.Record = srv.ntab.LocalRecord()
+	}
 
 	// Gather all the running protocol infos (only once per protocol type)
 	for _, proto := range srv.Protocols {
@@ -1864,6 +1899,22 @@ func (srv *BaseServer) Disconnect(destID discover.NodeID) {
 	srv.discpeer <- destID
 }
 
+// PeerScores returns the reputation of misbehaving peers and banned nodes.
+func (srv *BaseServer) PeerScores() []*PeerScore {
+	return srv.reputation.peerScores()
+}
+
+// disconnectBanned asks the run loop to drop a peer that has just been banned
+// without blocking the protocol handler reporting it.
+func (srv *BaseServer) disconnectBanned(id discover.NodeID) {
+	go func() {
+		select {
+		case srv.discpeer <- id:
+		case <-srv.quit:
+		}
+	}()
+}
+
 // CheckNilNetworkTable returns whether network table is nil.
 func (srv *BaseServer) CheckNilNetworkTable() bool {
 	return srv.ntab == nil
@@ -1888,6 +1939,49 @@ func (srv *BaseServer) GetNodes(nType discover.NodeType, max int) []*discover.No
 	return srv.ntab.GetNodes(nType, max)
 }
 
+// LookupByRecord searches the network for nodes of the given type whose
+// node record satisfies all filters.
+func (srv *BaseServer) LookupByRecord(nType discover.NodeType, filters ...discover.RecordFilter) []*discover.Node {
+	if srv.ntab == nil {
+		return nil
+	}
+	return srv.ntab.LookupByRecord(nType, filters...)
+}
+
+// SetRecordAttribute sets an attribute of the node record advertised through
+// discovery. It does nothing if discovery is disabled.
+func (srv *BaseServer) SetRecordAttribute(key string, val interface{}) error {
+	if srv.ntab == nil {
+		return nil
+	}
+	return srv.ntab.SetLocalRecordAttribute(key, val)
+}
+
+// AddRecordService advertises a service in the node record. It does nothing
+// if discovery is disabled.
+func (srv *BaseServer) AddRecordService(name string) error {
+	if srv.ntab == nil {
+		return nil
+	}
+	return srv.ntab.AddLocalRecordService(name)
+}
+
+// publishProtocols advertises the versions of the running protocols in the
+// node record.
+func (srv *BaseServer) publishProtocols() error {
+	var (
+		seen      = make(map[string]bool)
+		protocols []string
+	)
+	for _, p := range srv.Protocols {
+		if entry := discover.ProtocolEntry(p.Name, p.Version); !seen[entry] {
+			seen[entry] = true
+			protocols = append(protocols, entry)
+		}
+	}
+	return srv.SetRecordAttribute(discover.RecordKeyProtocols, protocols)
+}
+
 // Name returns name of server.
 func (srv *BaseServer) Name() string {
 	return srv.Config.Name
@@ -24,10 +24,13 @@ package rpc
 
 import (
 	"net"
+	"net/http"
 )
 
-// StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules
-func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []string, vhosts []string, timeouts HTTPTimeouts) (net.Listener, *Server, error) {
+// StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules.
+// Requests to the paths of handlers are served by the handlers instead of the JSON-RPC server,
+// under the same rate and response size limits.
+func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []string, vhosts []string, timeouts HTTPTimeouts, handlers map[string]http.Handler) (net.Listener, *Server, error) {
 	// Generate the whitelist based on the allowed modules
 	whitelist := make(map[string]bool)
 	for _, module := range modules {
@@ -59,7 +62,16 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []str
 	if listener, err = net.Listen("tcp", endpoint); err != nil {
 		return nil, nil, err
 	}
-	go NewHTTPServer(cors, vhosts, timeouts, handler).Serve(listener)
+	var srv http.Handler = handler
+	if len(handlers) > 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/", handler)
+		for path, h := range handlers {
+			mux.Handle(path, handler.limitHandler(path, h))
+		}
+		srv = mux
+	}
+	go NewHTTPServer(cors, vhosts, timeouts, srv).Serve(listener)
 	return listener, handler, err
 }
 
@@ -100,6 +112,57 @@ func StartWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []
 	return listener, handler, err
 }
 
+// StartAuthEndpoint starts an HTTP and websocket RPC endpoint whose requests must carry
+// an HS256 JWT signed with secret. Since callers are authenticated, the modules listed
+// are exposed even if they are restricted to IPC, and requests are not rate limited.
+func StartAuthEndpoint(endpoint string, apis []API, modules []string, vhosts []string, timeouts HTTPTimeouts, secret []byte) (net.Listener, *Server, error) {
+	if err := checkJWTSecret(secret); err != nil {
+		return nil, nil, err
+	}
+	// Generate the whitelist based on the allowed modules
+	whitelist := make(map[string]bool)
+	for _, module := range modules {
+		// for backward compatibility
+		if module == "klay" {
+			module = "kaia"
+		}
+		whitelist[module] = true
+	}
+	// Register all the APIs exposed by the services
+	handler := NewServer()
+	handler.limiter = nil
+	for _, api := range apis {
+		if api.Namespace == "klay" {
+			api.Namespace = "kaia"
+		}
+
+		if whitelist[api.Namespace] {
+			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+				return nil, nil, err
+			}
+			logger.Debug("Authenticated RPC registered", "namespace", api.Namespace)
+		}
+	}
+	// All APIs registered, start the HTTP listener
+	var (
+		listener net.Listener
+		err      error
+	)
+	if listener, err = net.Listen("tcp", endpoint); err != nil {
+		return nil, nil, err
+	}
+	// Read and write timeouts would also cut the websocket connections served on the
+	// same port, so only the header read is bounded.
+	timeouts = sanitizeTimeouts(timeouts)
+	server := &http.Server{
+		Handler:           newAuthHandler(handler, vhosts, timeouts, secret),
+		ReadHeaderTimeout: timeouts.ReadTimeout,
+		IdleTimeout:       timeouts.IdleTimeout,
+	}
+	go server.Serve(listener)
+	return listener, handler, err
+}
+
 // StartIPCEndpoint starts an IPC endpoint.
 func StartIPCEndpoint(ipcEndpoint string, apis []API) (net.Listener, *Server, error) {
 	// Register all the APIs exposed by the services.
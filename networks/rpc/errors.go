@@ -16,7 +16,10 @@
 
 package rpc
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 const defaultErrorCode = -32000
 
@@ -77,3 +80,25 @@ type shutdownError struct{}
 func (e *shutdownError) ErrorCode() int { return defaultErrorCode }
 
 func (e *shutdownError) Error() string { return "server is shutting down" }
+
+// issued when a client exceeds its request quota.
+type rateLimitedError struct{ retryAfter time.Duration }
+
+func (e *rateLimitedError) ErrorCode() int { return -32005 }
+
+func (e *rateLimitedError) Error() string {
+	return fmt.Sprintf("request rate limit exceeded, retry after %d seconds", retryAfterSeconds(e.retryAfter))
+}
+
+func (e *rateLimitedError) ErrorData() interface{} {
+	return map[string]int{"retryAfter": retryAfterSeconds(e.retryAfter)}
+}
+
+// issued when the results of a request exceed the response size limit.
+type responseTooLargeError struct{ limit int }
+
+func (e *responseTooLargeError) ErrorCode() int { return -32003 }
+
+func (e *responseTooLargeError) Error() string {
+	return fmt.Sprintf("response too large, limit is %d bytes", e.limit)
+}
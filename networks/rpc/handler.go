@@ -35,7 +35,9 @@ import (
 	"time"
 
 	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/metrics/tracing"
 	"github.com/kaiachain/kaia/storage/statedb"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // handler handles JSON-RPC messages. There is one handler per connection. Note that
@@ -68,6 +70,7 @@ type handler struct {
 	rootCtx        context.Context                // canceled by close()
 	cancelRoot     func()                         // cancel function for rootCtx
 	conn           jsonWriter                     // where responses will be sent
+	limits         *limitedCodec                  // limits of public endpoints, nil if unlimited
 	allowSubscribe bool
 
 	subLock    sync.Mutex
@@ -92,6 +95,9 @@ func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *
 		allowSubscribe: true,
 		serverSubs:     make(map[ID]*Subscription),
 	}
+	if limits, ok := conn.(*limitedCodec); ok {
+		h.limits = limits
+	}
 	h.unsubscribeCb = newCallback(reflect.Value{}, reflect.ValueOf(h.unsubscribe))
 	return h
 }
@@ -109,6 +115,15 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 
 	rpcTotalRequestsCounter.Inc(int64(len(msgs)))
 
+	if h.limits != nil && h.limits.limiter.cfg.MaxBatchSize > 0 && len(msgs) > h.limits.limiter.cfg.MaxBatchSize {
+		rpcErrorResponsesCounter.Inc(int64(len(msgs)))
+		err := &invalidRequestError{fmt.Sprintf("batch too large, %d requests over limit %d", len(msgs), h.limits.limiter.cfg.MaxBatchSize)}
+		h.startCallProc(func(cp *callProc) {
+			h.conn.writeJSON(cp.ctx, errorMessage(err))
+		})
+		return
+	}
+
 	// Handle non-call messages first:
 	calls := make([]*jsonrpcMessage, 0, len(msgs))
 	for _, msg := range msgs {
@@ -130,11 +145,15 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 		return
 	}
 
+	calls, rejected := h.rateLimit(calls)
+
 	// Process calls on a goroutine because they may block indefinitely:
 	h.startCallProc(func(cp *callProc) {
 		answers := make([]*jsonrpcMessage, 0, len(msgs))
+		answers = append(answers, rejected...)
+		size := 0
 		for _, msg := range calls {
-			if answer := h.handleCallMsg(cp, msg); answer != nil {
+			if answer := h.limitResponse(msg, h.handleCallMsg(cp, msg), &size); answer != nil {
 				answers = append(answers, answer)
 			}
 		}
@@ -165,8 +184,18 @@ func (h *handler) handleMsg(msg *jsonrpcMessage) {
 		return
 	}
 
+	if admitted, rejected := h.rateLimit([]*jsonrpcMessage{msg}); len(admitted) == 0 {
+		if len(rejected) > 0 {
+			h.startCallProc(func(cp *callProc) {
+				h.conn.writeJSON(cp.ctx, rejected[0])
+			})
+		}
+		return
+	}
+
 	h.startCallProc(func(cp *callProc) {
-		answer := h.handleCallMsg(cp, msg)
+		size := 0
+		answer := h.limitResponse(msg, h.handleCallMsg(cp, msg), &size)
 		h.addSubscriptions(cp.notifiers)
 		if answer != nil {
 			h.conn.writeJSON(cp.ctx, answer)
@@ -177,6 +206,50 @@ func (h *handler) handleMsg(msg *jsonrpcMessage) {
 	})
 }
 
+// rateLimit takes a token for each call from the buckets of the client. It returns the
+// admitted calls and the error responses of the rejected ones.
+func (h *handler) rateLimit(calls []*jsonrpcMessage) (admitted, rejected []*jsonrpcMessage) {
+	if h.limits == nil {
+		return calls, nil
+	}
+	var retryAfter time.Duration
+	admitted = make([]*jsonrpcMessage, 0, len(calls))
+	for _, msg := range calls {
+		if !msg.isCall() && !msg.isNotification() {
+			admitted = append(admitted, msg)
+			continue
+		}
+		delay, ok := h.limits.limiter.allow(h.limits.client, msg.Method)
+		if ok {
+			admitted = append(admitted, msg)
+			continue
+		}
+		rpcErrorResponsesCounter.Inc(1)
+		retryAfter = max(retryAfter, delay)
+		if msg.isCall() {
+			rejected = append(rejected, msg.errorResponse(&rateLimitedError{delay}))
+		}
+	}
+	if len(admitted) == 0 && h.limits.onLimited != nil {
+		h.limits.onLimited(retryAfter)
+	}
+	return admitted, rejected
+}
+
+// limitResponse replaces answer by an error once the results written for the request
+// exceed the response size limit. size accumulates the result sizes of a batch.
+func (h *handler) limitResponse(msg, answer *jsonrpcMessage, size *int) *jsonrpcMessage {
+	if answer == nil || h.limits == nil || h.limits.limiter.cfg.MaxResponseSize == 0 {
+		return answer
+	}
+	*size += len(answer.Result)
+	if *size > h.limits.limiter.cfg.MaxResponseSize {
+		rpcErrorResponsesCounter.Inc(1)
+		return msg.errorResponse(&responseTooLargeError{h.limits.limiter.cfg.MaxResponseSize})
+	}
+	return answer
+}
+
 // close cancels all requests except for inflightReq and waits for
 // call goroutines to shut down.
 func (h *handler) close(err error, inflightReq *requestOp) {
@@ -362,6 +435,9 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
+	if h.reg.upstreams != nil && !msg.isUnsubscribe() && h.reg.upstreams.proxies(msg.Method) {
+		return h.reg.upstreams.forward(cp.ctx, msg)
+	}
 	var callb *callback
 	if msg.isUnsubscribe() {
 		callb = h.unsubscribeCb
@@ -430,15 +506,14 @@ func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMes
 
 // runMethod runs the Go callback for an RPC method.
 func (h *handler) runMethod(ctx context.Context, msg *jsonrpcMessage, callb *callback, args []reflect.Value) *jsonrpcMessage {
+	ctx, span := tracing.StartSpanFunc(ctx, func() (string, []attribute.KeyValue) {
+		return "rpc." + msg.Method, []attribute.KeyValue{attribute.String("rpc.system", "jsonrpc"), attribute.String("rpc.method", msg.Method)}
+	})
 	result, err := callb.call(ctx, msg.Method, args)
+	tracing.EndSpan(span, err)
 	if err != nil {
-		// TODO-Kaia:
-		// 1. The single URL may be extended to the list of URL.
-		// 2. The URL (list or single) seems not modifiable at runtime.
-		// 3. Any request can be relayed as well as the state lack.
-		// 4. Make a new rpcErrorResponse struct.
-		if UpstreamArchiveEN != "" && shouldRequestUpstream(err) {
-			return requestUpstream(ctx, msg, args)
+		if h.reg.upstreams != nil && shouldRequestUpstream(err) {
+			return h.reg.upstreams.forward(ctx, msg)
 		}
 		rpcErrorResponsesCounter.Inc(1)
 		return msg.errorResponse(err)
@@ -456,30 +531,6 @@ func shouldRequestUpstream(err error) bool {
 	return errors.As(err, &missingNodeError)
 }
 
-// requestUpstream is the function to request upstream archive en
-func requestUpstream(ctx context.Context, msg *jsonrpcMessage, args []reflect.Value) *jsonrpcMessage {
-	ctx, cancel := context.WithTimeout(ctx, DefaultHTTPTimeouts.ExecutionTimeout)
-	defer cancel()
-
-	var result interface{}
-	c, err := DialContext(ctx, UpstreamArchiveEN)
-	if err == nil {
-		defer c.Close()
-
-		var params []interface{}
-		for _, a := range args {
-			params = append(params, a.Interface())
-		}
-		if err = c.CallContext(ctx, &result, msg.Method, params...); err == nil {
-			rpcSuccessResponsesCounter.Inc(1)
-			return msg.response(result)
-		}
-	}
-
-	rpcErrorResponsesCounter.Inc(1)
-	return msg.errorResponse(fmt.Errorf("from upstream rpc endpoint: %w", err))
-}
-
 // unsubscribe is the callback function for all *_unsubscribe calls.
 func (h *handler) unsubscribe(ctx context.Context, id ID) (bool, error) {
 	h.subLock.Lock()
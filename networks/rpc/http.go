@@ -34,11 +34,13 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/metrics/tracing"
 	"github.com/rs/cors"
 	"github.com/valyala/fasthttp"
 	"github.com/valyala/fasthttp/fasthttpadaptor"
@@ -362,6 +364,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx = context.WithValue(ctx, "remote", r.RemoteAddr)
 	ctx = context.WithValue(ctx, "scheme", r.Proto)
 	ctx = context.WithValue(ctx, "local", r.Host)
+	ctx = tracing.ExtractHTTP(ctx, r.Header)
 	if ua := r.Header.Get("User-Agent"); ua != "" {
 		ctx = context.WithValue(ctx, "User-Agent", ua)
 	}
@@ -372,7 +375,10 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("content-type", contentType)
 	codec := newHTTPServerConn(r, w)
 	defer codec.close()
-	s.ServeSingleRequest(ctx, codec)
+	s.ServeSingleRequest(ctx, s.limitCodec(codec, r.Header.Get(s.apiKeyHeader()), r.RemoteAddr, func(retryAfter time.Duration) {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(retryAfter)))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
 }
 
 func (srv *Server) HandleFastHTTP(requestCtx *fasthttp.RequestCtx) {
@@ -404,7 +410,11 @@ func (srv *Server) HandleFastHTTP(requestCtx *fasthttp.RequestCtx) {
 	defer codec.close()
 
 	w.Header.SetContentType(contentType)
-	srv.ServeSingleRequest(ctx, codec)
+	apiKey := string(r.Header.Peek(srv.apiKeyHeader()))
+	srv.ServeSingleRequest(ctx, srv.limitCodec(codec, apiKey, requestCtx.RemoteAddr().String(), func(retryAfter time.Duration) {
+		w.Header.Set("Retry-After", strconv.Itoa(retryAfterSeconds(retryAfter)))
+		w.SetStatusCode(http.StatusTooManyRequests)
+	}))
 }
 
 // validateRequest returns a non-zero response code and error message if the
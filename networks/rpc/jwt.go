@@ -0,0 +1,188 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// JWTSecretLength is the length in bytes of the HS256 secret of authenticated endpoints.
+const JWTSecretLength = 32
+
+// jwtExpiryTimeout is the maximum difference between the issue time of a token and the
+// local clock. Like the engine API of Ethereum, tokens are short-lived and re-created
+// for every request instead of being refreshed.
+const jwtExpiryTimeout = 60 * time.Second
+
+var (
+	errMissingToken      = errors.New("missing token")
+	errInvalidToken      = errors.New("invalid token")
+	errInvalidSignature  = errors.New("invalid token signature")
+	errUnsupportedJWTAlg = errors.New("unsupported token algorithm, only HS256 is accepted")
+	errMissingIssuedAt   = errors.New("missing issued-at")
+	errStaleToken        = errors.New("stale token")
+	errFutureToken       = errors.New("token issued in the future")
+	errExpiredToken      = errors.New("token is expired")
+)
+
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+type jwtClaims struct {
+	IssuedAt  *int64 `json:"iat"`
+	ExpiresAt *int64 `json:"exp,omitempty"`
+}
+
+// NewJWTToken returns an HS256 token signed with secret and issued at iat, as expected
+// in the "Authorization: Bearer" header by authenticated endpoints.
+func NewJWTToken(secret []byte, iat time.Time) string {
+	issuedAt := iat.Unix()
+	claims, _ := json.Marshal(jwtClaims{IssuedAt: &issuedAt})
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(claims)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(jwtSign(secret, unsigned))
+}
+
+func jwtSign(secret []byte, unsigned string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+// verifyJWT checks the signature of token and that it was issued around now.
+func verifyJWT(secret []byte, token string, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errInvalidToken
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if raw, err := base64.RawURLEncoding.DecodeString(parts[0]); err != nil || json.Unmarshal(raw, &header) != nil {
+		return errInvalidToken
+	}
+	if header.Alg != "HS256" {
+		return errUnsupportedJWTAlg
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, jwtSign(secret, parts[0]+"."+parts[1])) {
+		return errInvalidSignature
+	}
+	var claims jwtClaims
+	if raw, err := base64.RawURLEncoding.DecodeString(parts[1]); err != nil || json.Unmarshal(raw, &claims) != nil {
+		return errInvalidToken
+	}
+	if claims.IssuedAt == nil {
+		return errMissingIssuedAt
+	}
+	iat := time.Unix(*claims.IssuedAt, 0)
+	switch {
+	case now.Sub(iat) > jwtExpiryTimeout:
+		return errStaleToken
+	case iat.Sub(now) > jwtExpiryTimeout:
+		return errFutureToken
+	case claims.ExpiresAt != nil && now.After(time.Unix(*claims.ExpiresAt, 0)):
+		return errExpiredToken
+	}
+	return nil
+}
+
+// jwtHandler rejects requests without a valid bearer token.
+type jwtHandler struct {
+	secret []byte
+	next   http.Handler
+}
+
+func newJWTHandler(secret []byte, next http.Handler) http.Handler {
+	return &jwtHandler{secret: secret, next: next}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *jwtHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		http.Error(w, errMissingToken.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := verifyJWT(h.secret, token, time.Now()); err != nil {
+		logger.Debug("Rejected unauthenticated RPC request", "remote", r.RemoteAddr, "err", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// jwtTransport adds a fresh bearer token to every request.
+type jwtTransport struct {
+	secret []byte
+	base   http.RoundTripper
+}
+
+func newJWTTransport(secret []byte, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &jwtTransport{secret: secret, base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *jwtTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+NewJWTToken(t.secret, time.Now()))
+	return t.base.RoundTrip(req)
+}
+
+// DialAuthHTTP creates a new RPC client that connects to a JWT authenticated RPC server
+// over HTTP. Every request carries a fresh token signed with secret.
+func DialAuthHTTP(endpoint string, secret []byte) (*Client, error) {
+	return DialHTTPWithClient(endpoint, &http.Client{Transport: newJWTTransport(secret, nil)})
+}
+
+// newAuthHandler serves JSON-RPC over HTTP and WebSocket on the same port, behind the
+// virtual host check and the JWT authentication.
+func newAuthHandler(srv *Server, vhosts []string, timeouts HTTPTimeouts, secret []byte) http.Handler {
+	ws := srv.WebsocketHandler([]string{"*"})
+	rpc := http.TimeoutHandler(srv, timeouts.ExecutionTimeout, "timeout")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebsocket(r) {
+			ws.ServeHTTP(w, r)
+			return
+		}
+		rpc.ServeHTTP(w, r)
+	})
+	return newVHostHandler(vhosts, newJWTHandler(secret, handler))
+}
+
+// isWebsocket checks whether r is a websocket upgrade request.
+func isWebsocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
+// checkJWTSecret validates the length of an HS256 secret.
+func checkJWTSecret(secret []byte) error {
+	if len(secret) != JWTSecretLength {
+		return fmt.Errorf("invalid jwt secret length %d, want %d", len(secret), JWTSecretLength)
+	}
+	return nil
+}
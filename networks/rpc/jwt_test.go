@@ -0,0 +1,121 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyJWT(t *testing.T) {
+	var (
+		secret = []byte(strings.Repeat("s", JWTSecretLength))
+		now    = time.Unix(1700000000, 0)
+		claims = func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+		sign   = func(header, payload string) string {
+			unsigned := claims(header) + "." + claims(payload)
+			return unsigned + "." + base64.RawURLEncoding.EncodeToString(jwtSign(secret, unsigned))
+		}
+		hs256 = `{"alg":"HS256","typ":"JWT"}`
+	)
+	testcases := []struct {
+		token string
+		err   error
+	}{
+		{NewJWTToken(secret, now), nil},
+		{NewJWTToken(secret, now.Add(-jwtExpiryTimeout)), nil},
+		{NewJWTToken(secret, now.Add(jwtExpiryTimeout)), nil},
+		{NewJWTToken(secret, now.Add(-jwtExpiryTimeout-time.Second)), errStaleToken},
+		{NewJWTToken(secret, now.Add(jwtExpiryTimeout+time.Second)), errFutureToken},
+		{NewJWTToken([]byte(strings.Repeat("x", JWTSecretLength)), now), errInvalidSignature},
+		{sign(hs256, `{"iat":1700000000,"exp":1699999999}`), errExpiredToken},
+		{sign(hs256, `{"exp":1700000001}`), errMissingIssuedAt},
+		{sign(`{"alg":"none","typ":"JWT"}`, `{"iat":1700000000}`), errUnsupportedJWTAlg},
+		{claims(hs256) + "." + claims(`{"iat":1700000000}`) + ".", errInvalidSignature},
+		{"not-a-token", errInvalidToken},
+	}
+	for i, tc := range testcases {
+		assert.Equal(t, tc.err, verifyJWT(secret, tc.token, now), "testcase %d", i)
+	}
+}
+
+func TestAuthEndpoint(t *testing.T) {
+	var (
+		secret = []byte(strings.Repeat("s", JWTSecretLength))
+		apis   = []API{
+			{Namespace: "service", Service: new(Service), Public: true},
+			{Namespace: "private", Service: new(Service), IPCOnly: true},
+		}
+	)
+	_, _, err := StartAuthEndpoint("127.0.0.1:0", apis, []string{"private"}, []string{"*"}, DefaultHTTPTimeouts, secret[:16])
+	require.Error(t, err, "short secret")
+
+	listener, srv, err := StartAuthEndpoint("127.0.0.1:0", apis, []string{"private"}, []string{"*"}, DefaultHTTPTimeouts, secret)
+	require.NoError(t, err)
+	defer listener.Close()
+	defer srv.Stop()
+	url := "http://" + listener.Addr().String()
+
+	// Requests without a valid token are rejected before reaching the server.
+	resp, err := http.Post(url, contentType, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"private_rets"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	client, err := DialHTTP(url)
+	require.NoError(t, err)
+	assert.ErrorContains(t, client.Call(nil, "private_rets"), "401")
+	client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = DialWebsocket(ctx, "ws://"+listener.Addr().String(), "")
+	assert.Error(t, err)
+
+	// Authenticated clients reach the listed modules only, even IPC-only ones.
+	client, err = DialAuthHTTP(url, secret)
+	require.NoError(t, err)
+	defer client.Close()
+	var result string
+	assert.NoError(t, client.Call(&result, "private_rets"))
+	assert.Error(t, client.Call(&result, "service_rets"))
+
+	wsClient, err := DialAuthWebsocket(ctx, "ws://"+listener.Addr().String(), secret)
+	require.NoError(t, err)
+	defer wsClient.Close()
+	assert.NoError(t, wsClient.Call(&result, "private_rets"))
+
+	// Virtual hosts are checked before the token.
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "http://evil.com", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"private_rets"}`))
+	req.Header.Set("content-type", contentType)
+	req.Header.Set("Authorization", "Bearer "+NewJWTToken(secret, time.Now()))
+	newAuthHandler(srv, []string{"localhost"}, DefaultHTTPTimeouts, secret).ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	newJWTHandler(secret, http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodPost, url, nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
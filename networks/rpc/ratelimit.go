@@ -0,0 +1,391 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+	"golang.org/x/time/rate"
+)
+
+// DefaultAPIKeyHeader is the request header carrying the API key of a client.
+const DefaultAPIKeyHeader = "X-API-Key"
+
+const (
+	// rateLimitAnyMethod is the rule target matching every method.
+	rateLimitAnyMethod = "*"
+
+	// anonymousRateLimitLabel is the metrics label of clients without a registered API key.
+	anonymousRateLimitLabel = "anonymous"
+
+	// rateLimitIdleTimeout is how long the buckets of a silent client are kept.
+	rateLimitIdleTimeout = 10 * time.Minute
+
+	// rateLimitSweepInterval is the minimum interval between two idle bucket sweeps.
+	rateLimitSweepInterval = time.Minute
+)
+
+// sharedRateLimiter is used by the servers created after SetRateLimitConfig, so that a
+// client draws from the same buckets over HTTP and WebSocket.
+var sharedRateLimiter *rateLimiter
+
+// RateLimitRule is a token-bucket quota granted to every client for a method, a
+// namespace or, with the "*" target, any method without a more specific rule.
+type RateLimitRule struct {
+	Target string  // method (eth_call), namespace (debug), HTTP handler path (graphql) or "*"
+	Rate   float64 // requests per second
+	Burst  int     // bucket size
+}
+
+// ParseRateLimitRule parses a rule in the form <target>=<rate>[:<burst>]. The burst
+// defaults to the rate rounded up.
+func ParseRateLimitRule(s string) (RateLimitRule, error) {
+	target, quota, ok := strings.Cut(strings.TrimSpace(s), "=")
+	if !ok || target == "" {
+		return RateLimitRule{}, fmt.Errorf("invalid rate limit rule %q, want <target>=<rate>[:<burst>]", s)
+	}
+	rateStr, burstStr, hasBurst := strings.Cut(quota, ":")
+	r, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil || r <= 0 || math.IsInf(r, 0) {
+		return RateLimitRule{}, fmt.Errorf("invalid rate in rate limit rule %q", s)
+	}
+	rule := RateLimitRule{Target: target, Rate: r, Burst: int(math.Ceil(r))}
+	if hasBurst {
+		if rule.Burst, err = strconv.Atoi(burstStr); err != nil || rule.Burst <= 0 {
+			return RateLimitRule{}, fmt.Errorf("invalid burst in rate limit rule %q", s)
+		}
+	}
+	return rule, nil
+}
+
+// APIKeyQuota registers an API key. Clients presenting a registered key are accounted
+// by the key instead of their IP address, and their quotas are scaled by Factor. A
+// factor of 0 exempts the key from rate limiting.
+type APIKeyQuota struct {
+	Label  string // used in metrics and logs instead of the key itself
+	Key    string
+	Factor float64
+}
+
+// ParseAPIKeyQuota parses an API key in the form <label>:<key>[=<factor>]. The factor
+// defaults to 1.
+func ParseAPIKeyQuota(s string) (APIKeyQuota, error) {
+	label, rest, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || label == "" || rest == "" {
+		return APIKeyQuota{}, errors.New("invalid api key, want <label>:<key>[=<factor>]")
+	}
+	quota := APIKeyQuota{Label: label, Key: rest, Factor: 1}
+	if key, factor, ok := strings.Cut(rest, "="); ok {
+		f, err := strconv.ParseFloat(factor, 64)
+		if err != nil || f < 0 || math.IsInf(f, 0) || key == "" {
+			return APIKeyQuota{}, fmt.Errorf("invalid factor of api key %q", label)
+		}
+		quota.Key, quota.Factor = key, f
+	}
+	return quota, nil
+}
+
+// RateLimitConfig configures the limits enforced on the HTTP and WebSocket endpoints.
+// IPC and in-process connections are never limited.
+type RateLimitConfig struct {
+	Rules           []RateLimitRule
+	APIKeyHeader    string // header carrying the API key, DefaultAPIKeyHeader if empty
+	APIKeys         []APIKeyQuota
+	MaxBatchSize    int // maximum number of requests in a batch, 0 means unlimited
+	MaxResponseSize int // maximum size of the results of a request in bytes, 0 means unlimited
+}
+
+// SetRateLimitConfig validates cfg and installs it for the servers created afterwards.
+func SetRateLimitConfig(cfg RateLimitConfig) error {
+	l, err := newRateLimiter(cfg)
+	if err != nil {
+		return err
+	}
+	sharedRateLimiter = l
+	return nil
+}
+
+// SetRateLimitConfig replaces the limits of the server with a private set of buckets.
+// It must be called before the server starts serving requests.
+func (s *Server) SetRateLimitConfig(cfg RateLimitConfig) error {
+	l, err := newRateLimiter(cfg)
+	if err != nil {
+		return err
+	}
+	s.limiter = l
+	return nil
+}
+
+// rateLimiter keeps the token buckets of the clients of one or more servers.
+type rateLimiter struct {
+	cfg   RateLimitConfig
+	rules map[string]RateLimitRule
+	keys  map[string]APIKeyQuota
+
+	mu        sync.Mutex
+	clients   map[string]*clientBuckets
+	lastSweep time.Time
+}
+
+// clientBuckets holds the buckets of a client, one per rule target.
+type clientBuckets struct {
+	buckets  map[string]*rate.Limiter
+	lastSeen time.Time
+}
+
+// newRateLimiter validates cfg. It returns nil if cfg does not limit anything.
+func newRateLimiter(cfg RateLimitConfig) (*rateLimiter, error) {
+	if cfg.MaxBatchSize < 0 || cfg.MaxResponseSize < 0 {
+		return nil, errors.New("negative rpc batch or response size limit")
+	}
+	if len(cfg.Rules) == 0 && cfg.MaxBatchSize == 0 && cfg.MaxResponseSize == 0 {
+		return nil, nil
+	}
+	if cfg.APIKeyHeader == "" {
+		cfg.APIKeyHeader = DefaultAPIKeyHeader
+	}
+	l := &rateLimiter{
+		cfg:     cfg,
+		rules:   make(map[string]RateLimitRule, len(cfg.Rules)),
+		keys:    make(map[string]APIKeyQuota, len(cfg.APIKeys)),
+		clients: make(map[string]*clientBuckets),
+	}
+	for _, rule := range cfg.Rules {
+		if rule.Rate <= 0 || rule.Burst <= 0 {
+			return nil, fmt.Errorf("invalid quota of rate limit rule %q", rule.Target)
+		}
+		if _, ok := l.rules[rule.Target]; ok {
+			return nil, fmt.Errorf("duplicate rate limit rule %q", rule.Target)
+		}
+		l.rules[rule.Target] = rule
+	}
+	labels := make(map[string]bool, len(cfg.APIKeys))
+	for _, key := range cfg.APIKeys {
+		if key.Label == anonymousRateLimitLabel || labels[key.Label] {
+			return nil, fmt.Errorf("duplicate api key label %q", key.Label)
+		}
+		if _, ok := l.keys[key.Key]; ok {
+			return nil, fmt.Errorf("duplicate api key of %q", key.Label)
+		}
+		if key.Factor < 0 {
+			return nil, fmt.Errorf("negative factor of api key %q", key.Label)
+		}
+		labels[key.Label] = true
+		l.keys[key.Key] = key
+	}
+	return l, nil
+}
+
+// rateLimitClient identifies the client of a connection.
+type rateLimitClient struct {
+	id      string  // bucket owner, either the API key label or the IP address
+	factor  float64 // quota multiplier, 0 means unlimited
+	allowed metrics.Counter
+	limited metrics.Counter
+}
+
+// identify returns the client presenting apiKey from remote. Unregistered keys are
+// ignored, otherwise any client could get fresh buckets by making up keys.
+func (l *rateLimiter) identify(apiKey, remote string) rateLimitClient {
+	label, client := anonymousRateLimitLabel, rateLimitClient{factor: 1}
+	if key, ok := l.keys[apiKey]; ok && apiKey != "" {
+		label, client.id, client.factor = key.Label, "key/"+key.Label, key.Factor
+	} else if host, _, err := net.SplitHostPort(remote); err == nil {
+		client.id = host
+	} else {
+		client.id = remote
+	}
+	client.allowed = metrics.GetOrRegisterCounter("rpc/ratelimit/"+label+"/allowed", nil)
+	client.limited = metrics.GetOrRegisterCounter("rpc/ratelimit/"+label+"/limited", nil)
+	return client
+}
+
+// rule returns the most specific rule governing method.
+func (l *rateLimiter) rule(method string) (RateLimitRule, bool) {
+	if rule, ok := l.rules[method]; ok {
+		return rule, true
+	}
+	if namespace, _, ok := strings.Cut(method, serviceMethodSeparator); ok {
+		if rule, ok := l.rules[namespace]; ok {
+			return rule, true
+		}
+	}
+	rule, ok := l.rules[rateLimitAnyMethod]
+	return rule, ok
+}
+
+// allow takes a token from the bucket of the client governing method. If the bucket is
+// empty, it returns how long the client has to wait for the next token.
+func (l *rateLimiter) allow(client rateLimitClient, method string) (time.Duration, bool) {
+	rule, ok := l.rule(method)
+	if !ok || client.factor == 0 {
+		client.allowed.Inc(1)
+		return 0, true
+	}
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+	c := l.clients[client.id]
+	if c == nil {
+		c = &clientBuckets{buckets: make(map[string]*rate.Limiter)}
+		l.clients[client.id] = c
+	}
+	c.lastSeen = now
+	bucket := c.buckets[rule.Target]
+	if bucket == nil {
+		burst := int(math.Ceil(float64(rule.Burst) * client.factor))
+		bucket = rate.NewLimiter(rate.Limit(rule.Rate*client.factor), max(burst, 1))
+		c.buckets[rule.Target] = bucket
+	}
+	if bucket.AllowN(now, 1) {
+		client.allowed.Inc(1)
+		return 0, true
+	}
+	r := bucket.ReserveN(now, 1)
+	delay := r.DelayFrom(now)
+	r.CancelAt(now)
+	client.limited.Inc(1)
+	return delay, false
+}
+
+// sweep drops the buckets of clients idle for rateLimitIdleTimeout. It must be called
+// with l.mu held.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for id, c := range l.clients {
+		if now.Sub(c.lastSeen) > rateLimitIdleTimeout {
+			delete(l.clients, id)
+		}
+	}
+}
+
+// limitedCodec attaches the client identity to a codec of a public endpoint so that
+// the handler can enforce the limits of the server.
+type limitedCodec struct {
+	ServerCodec
+	limiter *rateLimiter
+	client  rateLimitClient
+
+	// onLimited is called before writing a response in which every call was rate
+	// limited. HTTP uses it to answer with 429 Too Many Requests.
+	onLimited func(retryAfter time.Duration)
+}
+
+// apiKeyHeader returns the header carrying the API key, or "" if the server is not limited.
+func (s *Server) apiKeyHeader() string {
+	if s.limiter == nil {
+		return ""
+	}
+	return s.limiter.cfg.APIKeyHeader
+}
+
+// limitCodec wraps codec with the limits of the server, if any.
+func (s *Server) limitCodec(codec ServerCodec, apiKey, remote string, onLimited func(time.Duration)) ServerCodec {
+	if s.limiter == nil {
+		return codec
+	}
+	return &limitedCodec{
+		ServerCodec: codec,
+		limiter:     s.limiter,
+		client:      s.limiter.identify(apiKey, remote),
+		onLimited:   onLimited,
+	}
+}
+
+// retryAfterSeconds rounds d up to whole seconds as used by the Retry-After header.
+func retryAfterSeconds(d time.Duration) int {
+	return max(int(math.Ceil(d.Seconds())), 1)
+}
+
+// limitHandler applies the limits of the server to h, a handler served at path next to
+// the JSON-RPC API. Every request is a call of the path without slashes, e.g. "graphql",
+// against the rate limits, and a response exceeding the size limit is replaced by an error.
+func (s *Server) limitHandler(path string, h http.Handler) http.Handler {
+	if s.limiter == nil {
+		return h
+	}
+	method := strings.Trim(path, "/")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := s.limiter.identify(r.Header.Get(s.limiter.cfg.APIKeyHeader), r.RemoteAddr)
+		if delay, ok := s.limiter.allow(client, method); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(delay)))
+			http.Error(w, (&rateLimitedError{delay}).Error(), http.StatusTooManyRequests)
+			return
+		}
+		if s.limiter.cfg.MaxResponseSize == 0 {
+			h.ServeHTTP(w, r)
+			return
+		}
+		lw := &limitedResponseWriter{ResponseWriter: w, limit: s.limiter.cfg.MaxResponseSize}
+		h.ServeHTTP(lw, r)
+		lw.flush()
+	})
+}
+
+// limitedResponseWriter buffers a response until the handler returns, so that it can
+// be replaced by an error if it exceeds the response size limit.
+type limitedResponseWriter struct {
+	http.ResponseWriter
+	limit  int
+	status int
+	buf    bytes.Buffer
+	tooBig bool
+}
+
+func (w *limitedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *limitedResponseWriter) Write(b []byte) (int, error) {
+	if w.tooBig || w.buf.Len()+len(b) > w.limit {
+		w.tooBig = true
+		w.buf.Reset()
+		return 0, &responseTooLargeError{w.limit}
+	}
+	return w.buf.Write(b)
+}
+
+// flush writes the buffered response, or the error if it was too large.
+func (w *limitedResponseWriter) flush() {
+	if w.tooBig {
+		w.Header().Del("Content-Length")
+		http.Error(w.ResponseWriter, (&responseTooLargeError{w.limit}).Error(), http.StatusInternalServerError)
+		return
+	}
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	w.ResponseWriter.Write(w.buf.Bytes())
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimitRule(t *testing.T) {
+	rule, err := ParseRateLimitRule("eth_call=10:20")
+	require.NoError(t, err)
+	assert.Equal(t, RateLimitRule{Target: "eth_call", Rate: 10, Burst: 20}, rule)
+
+	rule, err = ParseRateLimitRule("*=0.5")
+	require.NoError(t, err)
+	assert.Equal(t, RateLimitRule{Target: "*", Rate: 0.5, Burst: 1}, rule)
+
+	for _, s := range []string{"debug", "=1", "debug=0", "debug=x", "debug=1:0", "debug=1:x"} {
+		_, err := ParseRateLimitRule(s)
+		assert.Error(t, err, s)
+	}
+
+	key, err := ParseAPIKeyQuota("partner:secret=2.5")
+	require.NoError(t, err)
+	assert.Equal(t, APIKeyQuota{Label: "partner", Key: "secret", Factor: 2.5}, key)
+
+	key, err = ParseAPIKeyQuota("partner:secret")
+	require.NoError(t, err)
+	assert.Equal(t, APIKeyQuota{Label: "partner", Key: "secret", Factor: 1}, key)
+
+	for _, s := range []string{"secret", ":secret", "partner:", "partner:secret=-1", "partner:=1"} {
+		_, err := ParseAPIKeyQuota(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestRateLimiterRules(t *testing.T) {
+	_, err := newRateLimiter(RateLimitConfig{Rules: []RateLimitRule{{"eth", 1, 1}, {"eth", 2, 2}}})
+	assert.Error(t, err, "duplicate rule")
+	_, err = newRateLimiter(RateLimitConfig{APIKeys: []APIKeyQuota{{"a", "k", 1}, {"b", "k", 1}}, MaxBatchSize: 1})
+	assert.Error(t, err, "duplicate key")
+
+	l, err := newRateLimiter(RateLimitConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, l, "nothing to limit")
+
+	l, err = newRateLimiter(RateLimitConfig{
+		Rules: []RateLimitRule{
+			{Target: "eth_call", Rate: 1, Burst: 1},
+			{Target: "eth", Rate: 1, Burst: 2},
+			{Target: "*", Rate: 1, Burst: 3},
+		},
+		APIKeys: []APIKeyQuota{{"partner", "secret", 2}, {"internal", "trusted", 0}},
+	})
+	require.NoError(t, err)
+
+	burst := func(client rateLimitClient, method string) int {
+		n := 0
+		for ; n < 100; n++ {
+			if _, ok := l.allow(client, method); !ok {
+				return n
+			}
+		}
+		return n
+	}
+	anon := l.identify("", "10.0.0.1:1234")
+	assert.Equal(t, 1, burst(anon, "eth_call"))
+	assert.Equal(t, 2, burst(anon, "eth_blockNumber"))
+	assert.Equal(t, 0, burst(anon, "eth_chainId"), "namespace bucket is shared")
+	assert.Equal(t, 3, burst(anon, "kaia_blockNumber"))
+
+	// The port is ignored and unknown keys fall back to the address.
+	assert.Equal(t, 0, burst(l.identify("made-up", "10.0.0.1:4321"), "net_version"))
+	assert.Equal(t, 3, burst(l.identify("", "10.0.0.2:1234"), "net_version"))
+
+	assert.Equal(t, 2, burst(l.identify("secret", "10.0.0.1:1234"), "eth_call"))
+	assert.Equal(t, 100, burst(l.identify("trusted", "10.0.0.1:1234"), "eth_call"))
+
+	delay, ok := l.allow(anon, "eth_call")
+	assert.False(t, ok)
+	assert.True(t, delay > 0 && delay <= time.Second, delay)
+
+	// Idle clients are dropped.
+	l.sweep(time.Now().Add(rateLimitIdleTimeout + time.Minute))
+	assert.Empty(t, l.clients)
+}
+
+func TestHTTPRateLimit(t *testing.T) {
+	srv := newTestServer("service", new(Service))
+	defer srv.Stop()
+	require.NoError(t, srv.SetRateLimitConfig(RateLimitConfig{
+		Rules:           []RateLimitRule{{Target: "service_noArgsRets", Rate: 0.1, Burst: 1}},
+		APIKeys:         []APIKeyQuota{{"partner", "secret", 0}},
+		MaxBatchSize:    3,
+		MaxResponseSize: 100,
+	}))
+
+	post := func(body, apiKey string) (*httptest.ResponseRecorder, string) {
+		req := httptest.NewRequest(http.MethodPost, "http://url.com", strings.NewReader(body))
+		req.Header.Set("content-type", contentType)
+		req.RemoteAddr = "10.0.0.1:1234"
+		if apiKey != "" {
+			req.Header.Set(DefaultAPIKeyHeader, apiKey)
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec, rec.Body.String()
+	}
+	call := `{"jsonrpc":"2.0","id":1,"method":"service_noArgsRets"}`
+
+	rec, body := post(call, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, body, "error")
+
+	rec, body = post(call, "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get("Retry-After"))
+	var resp jsonrpcMessage
+	require.NoError(t, json.Unmarshal([]byte(body), &resp))
+	assert.Equal(t, -32005, resp.Error.Code)
+	assert.Equal(t, map[string]interface{}{"retryAfter": float64(10)}, resp.Error.Data)
+
+	// Partially limited batches are answered normally.
+	rec, body = post(`[`+call+`,{"jsonrpc":"2.0","id":2,"method":"service_rets"}]`, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, body, "-32005")
+	assert.Contains(t, body, `"result":""`)
+
+	// Exempted keys are not limited.
+	rec, body = post(call, "secret")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, body, "error")
+
+	_, body = post(`[`+strings.Repeat(call+`,`, 3)+call+`]`, "secret")
+	assert.Contains(t, body, "batch too large, 4 requests over limit 3")
+
+	_, body = post(`{"jsonrpc":"2.0","id":1,"method":"service_echo","params":["`+strings.Repeat("x", 100)+`",1]}`, "secret")
+	assert.Contains(t, body, "-32003")
+}
+
+func TestHTTPHandlerRateLimit(t *testing.T) {
+	srv := newTestServer("service", new(Service))
+	defer srv.Stop()
+	require.NoError(t, srv.SetRateLimitConfig(RateLimitConfig{
+		Rules:           []RateLimitRule{{Target: "graphql", Rate: 0.1, Burst: 1}},
+		MaxResponseSize: 10,
+	}))
+	h := srv.limitHandler("/graphql", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(r.URL.Query().Get("reply")))
+	}))
+
+	get := func(reply, remote string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://url.com/graphql?reply="+reply, nil)
+		req.RemoteAddr = remote
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("ok", "10.0.0.1:1234")
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "ok", rec.Body.String())
+
+	rec = get("ok", "10.0.0.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get("Retry-After"))
+
+	rec = get(strings.Repeat("x", 11), "10.0.0.2:1234")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "response too large, limit is 10 bytes")
+}
+
+func TestWebsocketRateLimit(t *testing.T) {
+	srv := newTestServer("service", new(Service))
+	defer srv.Stop()
+	require.NoError(t, srv.SetRateLimitConfig(RateLimitConfig{
+		Rules: []RateLimitRule{{Target: "service", Rate: 0.1, Burst: 2}},
+	}))
+	httpsrv := httptest.NewServer(srv.WebsocketHandler([]string{"*"}))
+	defer httpsrv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := DialWebsocket(ctx, "ws:"+strings.TrimPrefix(httpsrv.URL, "http:"), "")
+	require.NoError(t, err)
+	defer client.Close()
+
+	var result string
+	for i := 0; i < 2; i++ {
+		require.NoError(t, client.Call(&result, "service_rets"))
+	}
+	err = client.Call(&result, "service_rets")
+	var rpcErr Error
+	require.True(t, errors.As(err, &rpcErr), err)
+	assert.Equal(t, -32005, rpcErr.ErrorCode())
+
+	// Methods of other namespaces are not limited.
+	require.NoError(t, client.Call(nil, "rpc_modules"))
+}
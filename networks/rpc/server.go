@@ -72,9 +72,6 @@ var (
 	// NonEthCompatible is a bool value that determines whether to use return formatting of the eth namespace API  provided for compatibility.
 	// It can be overwritten by rpc.eth.noncompatible flag
 	NonEthCompatible = false
-
-	// UpstreamArchiveEN is the upstream archive mode EN endpoint
-	UpstreamArchiveEN string
 )
 
 // Server is an RPC server.
@@ -84,11 +81,13 @@ type Server struct {
 	codecs      mapset.Set
 	run         int32
 	wsConnCount int32
+	limiter     *rateLimiter
 }
 
 // NewServer creates a new server instance with no registered handlers.
 func NewServer() *Server {
-	server := &Server{idgen: randomIDGenerator(), codecs: mapset.NewSet(), run: 1, wsConnCount: 0}
+	server := &Server{idgen: randomIDGenerator(), codecs: mapset.NewSet(), run: 1, wsConnCount: 0, limiter: sharedRateLimiter}
+	server.services.upstreams = sharedUpstreams
 	// Register the default service providing meta information about the RPC service such
 	// as the services and methods it offers.
 	rpcService := &RPCService{server}
@@ -42,8 +42,9 @@ var (
 )
 
 type serviceRegistry struct {
-	mu       sync.Mutex
-	services map[string]service
+	mu        sync.Mutex
+	services  map[string]service
+	upstreams *upstreamPool // upstream ENs of the server, nil if none
 }
 
 // service represents a registered object.
@@ -0,0 +1,474 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/golang-lru"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/rcrowley/go-metrics"
+)
+
+// DefaultUpstreamHealthCheckInterval is how often the upstreams are checked by default.
+const DefaultUpstreamHealthCheckInterval = 5 * time.Second
+
+var (
+	errNoUpstream = errors.New("no upstream rpc endpoint")
+
+	// sharedUpstreams is used by the servers created after SetUpstreamConfig, nil if no
+	// upstream is configured.
+	sharedUpstreams *upstreamPool
+
+	// upstreamWriteMethods are the methods, without namespace, routed to the write upstreams.
+	upstreamWriteMethods = map[string]bool{
+		"sendRawTransaction":        true,
+		"sendRawTransactions":       true,
+		"sendTransaction":           true,
+		"sendTransactionAsFeePayer": true,
+	}
+
+	// upstreamImmutableMethods are the methods, without namespace, whose non-null
+	// results never change once the block is given by number or hash. Blocks are final
+	// as soon as they are committed, so there is no reorg to invalidate them. The
+	// transactions of upstreamPendingMethods are immutable once they are in a block.
+	upstreamImmutableMethods = map[string]bool{
+		"getBlockByHash":                      true,
+		"getBlockByNumber":                    true,
+		"getHeaderByHash":                     true,
+		"getHeaderByNumber":                   true,
+		"getBlockReceipts":                    true,
+		"getBlockWithConsensusInfoByHash":     true,
+		"getBlockWithConsensusInfoByNumber":   true,
+		"getTransactionByHash":                true,
+		"getTransactionBySenderTxHash":        true,
+		"getTransactionReceipt":               true,
+		"getTransactionReceiptBySenderTxHash": true,
+	}
+
+	// upstreamPendingMethods are the immutable methods also returning pending
+	// transactions, whose results are cached only once they have a block hash.
+	upstreamPendingMethods = map[string]bool{
+		"getTransactionByHash":         true,
+		"getTransactionBySenderTxHash": true,
+	}
+
+	// upstreamBlockTags select blocks that move with the chain head.
+	upstreamBlockTags = [][]byte{[]byte(`"latest"`), []byte(`"pending"`), []byte(`"safe"`), []byte(`"finalized"`)}
+
+	upstreamCacheHitCounter  = metrics.NewRegisteredCounter("rpc/upstream/cache/hits", nil)
+	upstreamCacheMissCounter = metrics.NewRegisteredCounter("rpc/upstream/cache/misses", nil)
+)
+
+// UpstreamConfig configures the upstream ENs the RPC servers forward calls to. By
+// default only the calls failing for lack of state are forwarded; the calls of the
+// proxied namespaces are forwarded as a whole, which lets a node without the chain
+// APIs front a cluster of ENs.
+type UpstreamConfig struct {
+	Endpoints           []string      // upstreams serving the reads, in order of preference
+	WriteEndpoints      []string      // upstreams receiving the transactions, Endpoints if empty
+	ProxyNamespaces     []string      // namespaces forwarded without being served locally
+	HealthCheckInterval time.Duration // DefaultUpstreamHealthCheckInterval if 0
+	MaxBlockLag         uint64        // blocks an upstream may lag behind the best one, 0 disables the check
+	CacheSize           int           // cached responses of immutable queries, 0 disables the cache
+}
+
+// SetUpstreamConfig validates cfg and installs it for the servers created afterwards,
+// replacing the previous upstreams. An empty Endpoints list disables the upstreams.
+func SetUpstreamConfig(cfg UpstreamConfig) error {
+	p, err := newUpstreamPool(cfg)
+	if err != nil {
+		return err
+	}
+	if sharedUpstreams != nil {
+		sharedUpstreams.stop()
+	}
+	sharedUpstreams = p
+	if p != nil {
+		go p.loop()
+	}
+	return nil
+}
+
+// upstream is an upstream EN with its health and metrics.
+type upstream struct {
+	url  string
+	name string // used in metrics and logs
+
+	mu     sync.Mutex
+	client *Client
+
+	healthy atomic.Bool
+	head    atomic.Uint64
+
+	requests metrics.Counter
+	failures metrics.Counter
+	latency  metrics.Timer
+	health   metrics.Gauge
+}
+
+var upstreamNameRe = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+func newUpstream(rawurl string) (*upstream, error) {
+	parsed, err := url.Parse(rawurl)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid upstream rpc endpoint %q", rawurl)
+	}
+	name := upstreamNameRe.ReplaceAllString(parsed.Host, "_")
+	u := &upstream{
+		url:      rawurl,
+		name:     name,
+		requests: metrics.GetOrRegisterCounter("rpc/upstream/"+name+"/requests", nil),
+		failures: metrics.GetOrRegisterCounter("rpc/upstream/"+name+"/failures", nil),
+		latency:  metrics.GetOrRegisterTimer("rpc/upstream/"+name+"/latency", nil),
+		health:   metrics.GetOrRegisterGauge("rpc/upstream/"+name+"/healthy", nil),
+	}
+	// Upstreams are healthy until proven otherwise, so that calls are served before
+	// the first health check completes.
+	u.setHealthy(true)
+	return u, nil
+}
+
+func (u *upstream) setHealthy(healthy bool) {
+	u.healthy.Store(healthy)
+	if healthy {
+		u.health.Update(1)
+	} else {
+		u.health.Update(0)
+	}
+}
+
+// dial returns the client of the upstream, connecting it if needed.
+func (u *upstream) dial(ctx context.Context) (*Client, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.client == nil {
+		c, err := DialContext(ctx, u.url)
+		if err != nil {
+			return nil, err
+		}
+		u.client = c
+	}
+	return u.client, nil
+}
+
+// reset drops the client after a transport failure, so that the next call reconnects.
+func (u *upstream) reset() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.client != nil {
+		u.client.Close()
+		u.client = nil
+	}
+}
+
+// call calls method with the raw params on the upstream.
+func (u *upstream) call(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	u.requests.Inc(1)
+	c, err := u.dial(ctx)
+	if err != nil {
+		u.failures.Inc(1)
+		return nil, err
+	}
+	args := make([]interface{}, len(params))
+	for i, p := range params {
+		args[i] = p
+	}
+	var result json.RawMessage
+	start := time.Now()
+	err = c.CallContext(ctx, &result, method, args...)
+	u.latency.UpdateSince(start)
+	if err != nil {
+		u.failures.Inc(1)
+		if !isUpstreamAnswer(err) {
+			u.reset()
+		}
+		return nil, err
+	}
+	// A null result leaves result untouched, as CallContext decodes into an interface.
+	if len(result) == 0 {
+		result = null
+	}
+	return result, nil
+}
+
+// isUpstreamAnswer reports whether err is an error response of the upstream rather
+// than a failure to reach it.
+func isUpstreamAnswer(err error) bool {
+	var answer *jsonError
+	return errors.As(err, &answer)
+}
+
+// upstreamPool routes the forwarded calls to the upstreams.
+type upstreamPool struct {
+	cfg     UpstreamConfig
+	reads   []*upstream
+	writes  []*upstream
+	all     []*upstream
+	proxied map[string]bool
+	cache   *lru.Cache
+
+	quit chan struct{}
+}
+
+// newUpstreamPool validates cfg. It returns nil if cfg has no upstream.
+func newUpstreamPool(cfg UpstreamConfig) (*upstreamPool, error) {
+	if len(cfg.Endpoints) == 0 {
+		if len(cfg.WriteEndpoints) > 0 || len(cfg.ProxyNamespaces) > 0 {
+			return nil, errors.New("upstream write endpoints or proxied namespaces given without upstream endpoints")
+		}
+		return nil, nil
+	}
+	if cfg.HealthCheckInterval < 0 || cfg.CacheSize < 0 {
+		return nil, errors.New("negative upstream health check interval or cache size")
+	}
+	if cfg.HealthCheckInterval == 0 {
+		cfg.HealthCheckInterval = DefaultUpstreamHealthCheckInterval
+	}
+	p := &upstreamPool{
+		cfg:     cfg,
+		proxied: make(map[string]bool, len(cfg.ProxyNamespaces)),
+		quit:    make(chan struct{}),
+	}
+	// The same endpoint may serve both reads and writes, keep a single upstream for it.
+	byURL := make(map[string]*upstream)
+	add := func(list []*upstream, rawurl string) ([]*upstream, error) {
+		u, ok := byURL[rawurl]
+		if !ok {
+			var err error
+			if u, err = newUpstream(rawurl); err != nil {
+				return nil, err
+			}
+			byURL[rawurl] = u
+			p.all = append(p.all, u)
+		}
+		return append(list, u), nil
+	}
+	var err error
+	for _, rawurl := range cfg.Endpoints {
+		if p.reads, err = add(p.reads, rawurl); err != nil {
+			return nil, err
+		}
+	}
+	p.writes = p.reads
+	if len(cfg.WriteEndpoints) > 0 {
+		p.writes = nil
+		for _, rawurl := range cfg.WriteEndpoints {
+			if p.writes, err = add(p.writes, rawurl); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, namespace := range cfg.ProxyNamespaces {
+		p.proxied[namespace] = true
+	}
+	if cfg.CacheSize > 0 {
+		p.cache, _ = lru.New(cfg.CacheSize)
+	}
+	return p, nil
+}
+
+func (p *upstreamPool) stop() {
+	close(p.quit)
+	for _, u := range p.all {
+		u.reset()
+	}
+}
+
+// proxies reports whether method is forwarded without being served locally.
+func (p *upstreamPool) proxies(method string) bool {
+	namespace, _, _ := strings.Cut(method, serviceMethodSeparator)
+	return p.proxied[namespace]
+}
+
+// candidates orders the upstreams to try: the healthy ones first, in order of
+// preference, then the others as a last resort.
+func candidates(list []*upstream) []*upstream {
+	ordered := make([]*upstream, 0, len(list))
+	for _, u := range list {
+		if u.healthy.Load() {
+			ordered = append(ordered, u)
+		}
+	}
+	for _, u := range list {
+		if !u.healthy.Load() {
+			ordered = append(ordered, u)
+		}
+	}
+	return ordered
+}
+
+// call calls method on the first upstream of list able to answer, failing over to
+// the next ones when an upstream cannot be reached.
+func (p *upstreamPool) call(ctx context.Context, list []*upstream, method string, params []json.RawMessage) (json.RawMessage, error) {
+	err := errNoUpstream
+	for _, u := range candidates(list) {
+		var result json.RawMessage
+		if result, err = u.call(ctx, method, params); err == nil || isUpstreamAnswer(err) || ctx.Err() != nil {
+			return result, err
+		}
+		logger.Warn("Upstream rpc endpoint failed, trying the next one", "upstream", u.name, "method", method, "err", err)
+		u.setHealthy(false)
+	}
+	return nil, err
+}
+
+// cacheKey returns the cache key of a call, or "" if its result may change.
+func cacheKey(method string, params json.RawMessage) string {
+	_, name, _ := strings.Cut(method, serviceMethodSeparator)
+	if !upstreamImmutableMethods[name] {
+		return ""
+	}
+	for _, tag := range upstreamBlockTags {
+		if bytes.Contains(params, tag) {
+			return ""
+		}
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, params); err != nil {
+		return ""
+	}
+	return method + compact.String()
+}
+
+// cacheable reports whether the result of an immutable call can be cached, that is if
+// it is neither null nor a pending transaction.
+func cacheable(method string, result json.RawMessage) bool {
+	if bytes.Equal(result, null) {
+		return false
+	}
+	_, name, _ := strings.Cut(method, serviceMethodSeparator)
+	if !upstreamPendingMethods[name] {
+		return true
+	}
+	var tx struct {
+		BlockHash json.RawMessage `json:"blockHash"`
+	}
+	return json.Unmarshal(result, &tx) == nil && len(tx.BlockHash) > 0 && !bytes.Equal(tx.BlockHash, null)
+}
+
+// forward answers msg with the response of the upstreams.
+func (p *upstreamPool) forward(ctx context.Context, msg *jsonrpcMessage) *jsonrpcMessage {
+	ctx, cancel := context.WithTimeout(ctx, DefaultHTTPTimeouts.ExecutionTimeout)
+	defer cancel()
+
+	var params []json.RawMessage
+	if len(msg.Params) > 0 {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			rpcErrorResponsesCounter.Inc(1)
+			return msg.errorResponse(&invalidParamsError{"non-array args"})
+		}
+	}
+	key := ""
+	if p.cache != nil {
+		if key = cacheKey(msg.Method, msg.Params); key != "" {
+			if result, ok := p.cache.Get(key); ok {
+				upstreamCacheHitCounter.Inc(1)
+				rpcSuccessResponsesCounter.Inc(1)
+				return msg.response(result)
+			}
+			upstreamCacheMissCounter.Inc(1)
+		}
+	}
+
+	list := p.reads
+	_, name, _ := strings.Cut(msg.Method, serviceMethodSeparator)
+	if upstreamWriteMethods[name] {
+		list = p.writes
+	}
+	result, err := p.call(ctx, list, msg.Method, params)
+	if err != nil {
+		rpcErrorResponsesCounter.Inc(1)
+		if isUpstreamAnswer(err) {
+			return msg.errorResponse(err)
+		}
+		return msg.errorResponse(fmt.Errorf("from upstream rpc endpoint: %w", err))
+	}
+	if key != "" && cacheable(msg.Method, result) {
+		p.cache.Add(key, result)
+	}
+	rpcSuccessResponsesCounter.Inc(1)
+	return msg.response(result)
+}
+
+// loop checks the health of the upstreams until the pool is stopped.
+func (p *upstreamPool) loop() {
+	ticker := time.NewTicker(p.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+
+	p.checkHealth()
+	for {
+		select {
+		case <-ticker.C:
+			p.checkHealth()
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+// checkHealth polls the block number of the upstreams. An upstream is healthy if it
+// answers and, if MaxBlockLag is set, is not too far behind the best upstream.
+func (p *upstreamPool) checkHealth() {
+	ctx, cancel := context.WithTimeout(context.Background(), p.cfg.HealthCheckInterval)
+	defer cancel()
+
+	answered := make([]bool, len(p.all))
+	var wg sync.WaitGroup
+	for i, u := range p.all {
+		wg.Add(1)
+		go func(i int, u *upstream) {
+			defer wg.Done()
+			result, err := u.call(ctx, "kaia_blockNumber", nil)
+			if err != nil {
+				logger.Debug("Upstream rpc endpoint health check failed", "upstream", u.name, "err", err)
+				return
+			}
+			var number hexutil.Uint64
+			if err := json.Unmarshal(result, &number); err != nil {
+				return
+			}
+			u.head.Store(uint64(number))
+			answered[i] = true
+		}(i, u)
+	}
+	wg.Wait()
+
+	var best uint64
+	for i, u := range p.all {
+		if answered[i] {
+			best = max(best, u.head.Load())
+		}
+	}
+	for i, u := range p.all {
+		healthy := answered[i] && (p.cfg.MaxBlockLag == 0 || best-u.head.Load() <= p.cfg.MaxBlockLag)
+		if healthy != u.healthy.Load() {
+			logger.Info("Upstream rpc endpoint health changed", "upstream", u.name, "healthy", healthy, "head", u.head.Load(), "best", best)
+		}
+		u.setHealthy(healthy)
+	}
+}
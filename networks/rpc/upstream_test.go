@@ -0,0 +1,295 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/storage/statedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upstreamTestService plays the kaia namespace of an upstream EN.
+type upstreamTestService struct {
+	head  uint64
+	mined bool // whether the transactions are in a block
+
+	mu    sync.Mutex
+	calls map[string]int
+	txs   []hexutil.Bytes
+}
+
+func (s *upstreamTestService) count(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+func (s *upstreamTestService) called(method string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.calls == nil {
+		s.calls = make(map[string]int)
+	}
+	s.calls[method]++
+}
+
+func (s *upstreamTestService) setHead(head uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.head = head
+}
+
+func (s *upstreamTestService) currentHead() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.head
+}
+
+func (s *upstreamTestService) BlockNumber() hexutil.Uint64 {
+	s.called("blockNumber")
+	return hexutil.Uint64(s.currentHead())
+}
+
+func (s *upstreamTestService) GetBlockByNumber(number BlockNumber) map[string]interface{} {
+	s.called("getBlockByNumber")
+	head := s.currentHead()
+	if number == LatestBlockNumber {
+		number = BlockNumber(head)
+	}
+	if number.Int64() > int64(head) {
+		return nil
+	}
+	return map[string]interface{}{"number": hexutil.Uint64(number)}
+}
+
+func (s *upstreamTestService) GetTransactionByHash(hash string) map[string]interface{} {
+	s.called("getTransactionByHash")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := map[string]interface{}{"hash": hash, "blockHash": nil}
+	if s.mined {
+		tx["blockHash"] = "0xb1"
+	}
+	return tx
+}
+
+func (s *upstreamTestService) GetBalance() (hexutil.Uint64, error) {
+	s.called("getBalance")
+	return 42, nil
+}
+
+func (s *upstreamTestService) SendRawTransaction(tx hexutil.Bytes) (hexutil.Bytes, error) {
+	s.called("sendRawTransaction")
+	if len(tx) == 0 {
+		return nil, &invalidParamsError{"empty transaction"}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.txs = append(s.txs, tx)
+	return tx, nil
+}
+
+// missingStateService is a local service lacking the state of the calls.
+type missingStateService struct{}
+
+func (s *missingStateService) GetBalance() (hexutil.Uint64, error) {
+	return 0, &statedb.MissingNodeError{}
+}
+
+func newUpstreamTestServer(t *testing.T, head uint64) (*upstreamTestService, string) {
+	service := &upstreamTestService{head: head}
+	srv := newTestServer("kaia", service)
+	hs := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		hs.Close()
+		srv.Stop()
+	})
+	return service, hs.URL
+}
+
+func setTestUpstreams(t *testing.T, cfg UpstreamConfig) {
+	require.NoError(t, SetUpstreamConfig(cfg))
+	t.Cleanup(func() { SetUpstreamConfig(UpstreamConfig{}) })
+}
+
+func TestUpstreamConfig(t *testing.T) {
+	p, err := newUpstreamPool(UpstreamConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	_, err = newUpstreamPool(UpstreamConfig{ProxyNamespaces: []string{"kaia"}})
+	assert.Error(t, err, "proxy without upstream")
+	_, err = newUpstreamPool(UpstreamConfig{Endpoints: []string{"not a url"}})
+	assert.Error(t, err)
+
+	p, err = newUpstreamPool(UpstreamConfig{
+		Endpoints:      []string{"http://a:8551", "http://b:8551"},
+		WriteEndpoints: []string{"http://b:8551"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, p.all, 2, "shared endpoint")
+	assert.Same(t, p.reads[1], p.writes[0])
+	assert.Equal(t, "b_8551", p.writes[0].name)
+}
+
+func TestUpstreamProxy(t *testing.T) {
+	reads, readURL := newUpstreamTestServer(t, 10)
+	writes, writeURL := newUpstreamTestServer(t, 10)
+	setTestUpstreams(t, UpstreamConfig{
+		Endpoints:       []string{readURL},
+		WriteEndpoints:  []string{writeURL},
+		ProxyNamespaces: []string{"kaia"},
+		CacheSize:       16,
+	})
+
+	// The gateway does not serve the kaia namespace itself.
+	gateway := newTestServer("service", new(Service))
+	defer gateway.Stop()
+	client := DialInProc(gateway)
+	defer client.Close()
+	ctx := context.Background()
+
+	var number hexutil.Uint64
+	require.NoError(t, client.CallContext(ctx, &number, "kaia_blockNumber"))
+	assert.Equal(t, hexutil.Uint64(10), number)
+
+	// Writes go to the write upstream, and its errors are passed through.
+	var echoed hexutil.Bytes
+	require.NoError(t, client.CallContext(ctx, &echoed, "kaia_sendRawTransaction", hexutil.Bytes{1, 2}))
+	assert.Equal(t, hexutil.Bytes{1, 2}, echoed)
+	assert.Len(t, writes.txs, 1)
+	assert.Zero(t, reads.count("sendRawTransaction"))
+
+	err := client.CallContext(ctx, &echoed, "kaia_sendRawTransaction", hexutil.Bytes{})
+	var rpcErr Error
+	require.True(t, errors.As(err, &rpcErr), err)
+	assert.Equal(t, -32602, rpcErr.ErrorCode())
+
+	// Blocks given by number are cached, unless they do not exist yet or are given by tag.
+	block := map[string]interface{}{}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, client.CallContext(ctx, &block, "kaia_getBlockByNumber", "0x5"))
+		assert.Equal(t, "0x5", block["number"])
+	}
+	assert.Equal(t, 1, reads.count("getBlockByNumber"))
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, client.CallContext(ctx, &block, "kaia_getBlockByNumber", "latest"))
+		var missing map[string]interface{}
+		require.NoError(t, client.CallContext(ctx, &missing, "kaia_getBlockByNumber", "0x20"))
+		assert.Nil(t, missing)
+	}
+	assert.Equal(t, 5, reads.count("getBlockByNumber"))
+
+	// Transactions are cached once they are in a block.
+	tx := map[string]interface{}{}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, client.CallContext(ctx, &tx, "kaia_getTransactionByHash", "0x01"))
+		assert.Nil(t, tx["blockHash"])
+	}
+	reads.mu.Lock()
+	reads.mined = true
+	reads.mu.Unlock()
+	for i := 0; i < 2; i++ {
+		require.NoError(t, client.CallContext(ctx, &tx, "kaia_getTransactionByHash", "0x01"))
+		assert.Equal(t, "0xb1", tx["blockHash"])
+	}
+	assert.Equal(t, 3, reads.count("getTransactionByHash"))
+
+	// Namespaces not proxied are served locally.
+	var res echoResult
+	require.NoError(t, client.CallContext(ctx, &res, "service_echo", "x", 1, &echoArgs{"y"}))
+	assert.Equal(t, "x", res.String)
+}
+
+func TestUpstreamMissingState(t *testing.T) {
+	upstream, url := newUpstreamTestServer(t, 10)
+	setTestUpstreams(t, UpstreamConfig{Endpoints: []string{url}})
+
+	local := newTestServer("kaia", new(missingStateService))
+	defer local.Stop()
+	client := DialInProc(local)
+	defer client.Close()
+
+	var balance hexutil.Uint64
+	require.NoError(t, client.CallContext(context.Background(), &balance, "kaia_getBalance"))
+	assert.Equal(t, hexutil.Uint64(42), balance)
+	assert.Equal(t, 1, upstream.count("getBalance"))
+}
+
+func TestUpstreamFailover(t *testing.T) {
+	dead := httptest.NewServer(nil)
+	deadURL := dead.URL
+	dead.Close()
+	service, url := newUpstreamTestServer(t, 10)
+
+	p, err := newUpstreamPool(UpstreamConfig{Endpoints: []string{deadURL, url}})
+	require.NoError(t, err)
+	defer p.stop()
+
+	result, err := p.call(context.Background(), p.reads, "kaia_blockNumber", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `"0xa"`, string(result))
+	assert.False(t, p.reads[0].healthy.Load())
+	assert.Equal(t, []*upstream{p.reads[1], p.reads[0]}, candidates(p.reads))
+
+	// Once marked unhealthy, the dead upstream is not tried first anymore.
+	_, err = p.call(context.Background(), p.reads, "kaia_blockNumber", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, service.count("blockNumber"))
+	assert.Equal(t, int64(1), p.reads[0].failures.Count())
+
+	// All upstreams down.
+	p2, err := newUpstreamPool(UpstreamConfig{Endpoints: []string{deadURL}})
+	require.NoError(t, err)
+	defer p2.stop()
+	_, err = p2.call(context.Background(), p2.reads, "kaia_blockNumber", nil)
+	assert.Error(t, err)
+	assert.False(t, isUpstreamAnswer(err))
+}
+
+func TestUpstreamHealthCheck(t *testing.T) {
+	_, ahead := newUpstreamTestServer(t, 100)
+	_, nearby := newUpstreamTestServer(t, 95)
+	lagging, behind := newUpstreamTestServer(t, 50)
+	dead := httptest.NewServer(nil)
+	deadURL := dead.URL
+	dead.Close()
+
+	p, err := newUpstreamPool(UpstreamConfig{Endpoints: []string{behind, deadURL, nearby, ahead}, MaxBlockLag: 10})
+	require.NoError(t, err)
+	defer p.stop()
+
+	p.checkHealth()
+	assert.False(t, p.reads[0].healthy.Load(), "lagging")
+	assert.False(t, p.reads[1].healthy.Load(), "dead")
+	assert.True(t, p.reads[2].healthy.Load())
+	assert.True(t, p.reads[3].healthy.Load())
+	assert.Equal(t, []*upstream{p.reads[2], p.reads[3], p.reads[0], p.reads[1]}, candidates(p.reads))
+
+	// The lagging upstream recovers once it catches up.
+	lagging.setHead(99)
+	p.checkHealth()
+	assert.True(t, p.reads[0].healthy.Load())
+}
@@ -88,7 +88,7 @@ func (srv *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			return
 		}
 		codec := newWebsocketCodec(conn)
-		srv.ServeCodec(codec, 0)
+		srv.ServeCodec(srv.limitCodec(codec, r.Header.Get(srv.apiKeyHeader()), r.RemoteAddr, nil), 0)
 	})
 }
 
@@ -104,6 +104,7 @@ func (srv *Server) FastWebsocketHandler(ctx *fasthttp.RequestCtx) {
 		ctx.Response.Header.Set("Sec-WebSocket-Protocol", string(protocol))
 	}
 
+	apiKey, remote := string(ctx.Request.Header.Peek(srv.apiKeyHeader())), ctx.RemoteAddr().String()
 	err := upgrader.Upgrade(ctx, func(conn *fastws.Conn) {
 		if atomic.LoadInt32(&srv.wsConnCount) >= MaxWebsocketConnections {
 			return
@@ -143,7 +144,8 @@ func (srv *Server) FastWebsocketHandler(ctx *fasthttp.RequestCtx) {
 		}
 
 		reader := bufio.NewReaderSize(bytes.NewReader(ctx.Request.Body()), common.MaxRequestContentLength)
-		srv.ServeCodec(NewFuncCodec(&httpReadWriteNopCloser{reader, ctx.Response.BodyWriter()}, encoder, decoder), 0)
+		codec := NewFuncCodec(&httpReadWriteNopCloser{reader, ctx.Response.BodyWriter()}, encoder, decoder)
+		srv.ServeCodec(srv.limitCodec(codec, apiKey, remote, nil), 0)
 	})
 	if err != nil {
 		logger.Error("FastWebsocketHandler fail to upgrade message", "err", err)
@@ -273,6 +275,16 @@ func (e wsHandshakeError) Error() string {
 // The context is used for the initial connection establishment. It does not
 // affect subsequent interactions with the client.
 func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error) {
+	return dialWebsocket(ctx, endpoint, origin, nil)
+}
+
+// DialAuthWebsocket creates a new RPC client that communicates with a JWT authenticated
+// JSON-RPC server over websocket. A token is signed with secret on every (re)connection.
+func DialAuthWebsocket(ctx context.Context, endpoint string, secret []byte) (*Client, error) {
+	return dialWebsocket(ctx, endpoint, "", secret)
+}
+
+func dialWebsocket(ctx context.Context, endpoint, origin string, secret []byte) (*Client, error) {
 	endpoint, header, err := wsClientHeaders(endpoint, origin)
 	if err != nil {
 		return nil, err
@@ -285,6 +297,11 @@ func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error
 	}
 
 	return NewClient(ctx, func(ctx context.Context) (ServerCodec, error) {
+		header := header
+		if secret != nil {
+			header = header.Clone()
+			header.Set("Authorization", "Bearer "+NewJWTToken(secret, time.Now()))
+		}
 		conn, resp, err := dialer.DialContext(ctx, endpoint, header)
 		if resp != nil && resp.Body != nil {
 			defer resp.Body.Close()
@@ -24,10 +24,12 @@ package node
 
 import (
 	"encoding/hex"
+	"fmt"
 
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/crypto/bls"
 	"github.com/kaiachain/kaia/networks/p2p"
+	"github.com/kaiachain/kaia/networks/p2p/discover"
 )
 
 // AdminNodeAPI is the collection of administrative API methods exposed over
@@ -52,6 +54,47 @@ func (api *AdminNodeAPI) Peers() ([]*p2p.PeerInfo, error) {
 	return server.PeersInfo(), nil
 }
 
+// PeerScores retrieves the reputation of the misbehaving peers and the nodes
+// that are currently banned.
+func (api *AdminNodeAPI) PeerScores() ([]*p2p.PeerScore, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	return server.PeerScores(), nil
+}
+
+// LookupNodes searches the network for nodes of the given type (cn, pn, en
+// or bn) whose discovery node record advertises all of the given services,
+// e.g. "snap" or "bridge", and the chain of the local node if it is known.
+// It returns the kni URLs of the matching nodes.
+func (api *AdminNodeAPI) LookupNodes(nodeType string, services []string) ([]string, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	nType := discover.ParseNodeType(nodeType)
+	if nType == discover.NodeTypeUnknown {
+		return nil, fmt.Errorf("invalid node type: %q", nodeType)
+	}
+	var filters []discover.RecordFilter
+	if record := server.NodeInfo().Record; record != nil {
+		var chainID uint64
+		if err := record.Load(discover.RecordKeyChainID, &chainID); err == nil {
+			filters = append(filters, discover.WithChainID(chainID))
+		}
+	}
+	for _, service := range services {
+		filters = append(filters, discover.WithService(service))
+	}
+	nodes := server.LookupByRecord(nType, filters...)
+	urls := make([]string, len(nodes))
+	for i, n := range nodes {
+		urls[i] = n.String()
+	}
+	return urls, nil
+}
+
 // BlsPublicKeyInfoOutput has string fields unlike system.BlsPublicKeyInfo.
 type BlsPublicKeyInfoOutput struct {
 	PublicKey string `json:"publicKey"`
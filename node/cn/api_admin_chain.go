@@ -31,6 +31,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/kaiachain/kaia/blockchain"
 	"github.com/kaiachain/kaia/blockchain/types"
@@ -252,6 +253,35 @@ func (api *AdminChainCNAPI) GetSpamThrottlerCandidateList(ctx context.Context) (
 	return throttler.GetCandidates(), nil
 }
 
+// AddSpamThrottlerWhiteList adds the addresses to the white list for the given seconds.
+// Zero seconds means that the addresses are never expired.
+func (api *AdminChainCNAPI) AddSpamThrottlerWhiteList(ctx context.Context, addrs []common.Address, seconds uint64) error {
+	throttler := blockchain.GetSpamThrottler()
+	if throttler == nil {
+		return errors.New("spam throttler is not running")
+	}
+	throttler.AddAllowed(addrs, time.Duration(seconds)*time.Second)
+	return nil
+}
+
+// GetSpamThrottlerScore returns the spam weight of the address and its breakdown by the scoring rules.
+func (api *AdminChainCNAPI) GetSpamThrottlerScore(ctx context.Context, addr common.Address) (*blockchain.SpamScore, error) {
+	throttler := blockchain.GetSpamThrottler()
+	if throttler == nil {
+		return nil, errors.New("spam throttler is not running")
+	}
+	return throttler.GetScore(addr), nil
+}
+
+// GetSpamThrottlerPeerScores returns the spam weights of the candidate and throttled peers and remote IPs.
+func (api *AdminChainCNAPI) GetSpamThrottlerPeerScores(ctx context.Context) (map[string]*blockchain.SpamPeerScore, error) {
+	throttler := blockchain.GetSpamThrottler()
+	if throttler == nil {
+		return nil, errors.New("spam throttler is not running")
+	}
+	return throttler.GetPeerScores(), nil
+}
+
 func (s *AdminChainCNAPI) NodeConfig(ctx context.Context) interface{} {
 	return *s.cn.config
 }
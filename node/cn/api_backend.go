@@ -40,6 +40,7 @@ import (
 	"github.com/kaiachain/kaia/consensus"
 	"github.com/kaiachain/kaia/event"
 	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/node/cn/filters"
 	"github.com/kaiachain/kaia/node/cn/gasprice"
 	"github.com/kaiachain/kaia/node/cn/tracers"
 	"github.com/kaiachain/kaia/params"
@@ -366,6 +367,11 @@ func (b *CNAPIBackend) ServiceFilter(ctx context.Context, session *bloombits.Mat
 	}
 }
 
+// LogIndex returns the log index used by the log filters, or nil if it is disabled.
+func (b *CNAPIBackend) LogIndex() filters.LogIndex {
+	return b.cn.logIndex
+}
+
 func (b *CNAPIBackend) IsParallelDBWrite() bool {
 	return b.cn.BlockChain().IsParallelDBWrite()
 }
@@ -34,6 +34,7 @@ import (
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/networks/rpc"
 	"github.com/kaiachain/kaia/storage/statedb"
+	"github.com/kaiachain/kaia/work"
 )
 
 // DebugCNAPI is the collection of Kaia full node APIs exposed
@@ -281,3 +282,22 @@ func (api *DebugCNAPI) getModifiedStorageNodes(contractAddr common.Address, star
 		"startBlock", startBlock.NumberU64(), "endBlock", endBlock.NumberU64(), "numModifiedNodes", numModifiedNodes, "elapsed", time.Since(start))
 	return numModifiedNodes, nil
 }
+
+// GetBlockBuildReport returns how this node built the block of the given number, listing each
+// candidate transaction with its execution order and the reason if it was skipped.
+// "pending" refers to the block being built.
+func (api *DebugCNAPI) GetBlockBuildReport(number rpc.BlockNumber) (*work.BuildReport, error) {
+	num := uint64(number.Int64())
+	switch number {
+	case rpc.LatestBlockNumber:
+		num = api.cn.blockchain.CurrentBlock().NumberU64()
+	case rpc.PendingBlockNumber:
+		num = api.cn.blockchain.CurrentBlock().NumberU64() + 1
+	}
+	return api.cn.miner.BuildReport(num)
+}
+
+// GetTxBuildHistory returns the results of the given transaction in the recent block build reports, from the newest.
+func (api *DebugCNAPI) GetTxBuildHistory(hash common.Hash) ([]*work.TxBuildRecord, error) {
+	return api.cn.miner.TxBuildHistory(hash)
+}
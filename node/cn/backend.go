@@ -47,9 +47,14 @@ import (
 	"github.com/kaiachain/kaia/kaiax"
 	"github.com/kaiachain/kaia/kaiax/auction"
 	auction_impl "github.com/kaiachain/kaia/kaiax/auction/impl"
+	bundle_impl "github.com/kaiachain/kaia/kaiax/bundle/impl"
 	gasless_impl "github.com/kaiachain/kaia/kaiax/gasless/impl"
 	"github.com/kaiachain/kaia/kaiax/gov"
 	gov_impl "github.com/kaiachain/kaia/kaiax/gov/impl"
+	"github.com/kaiachain/kaia/kaiax/logindex"
+	logindex_impl "github.com/kaiachain/kaia/kaiax/logindex/impl"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
+	privatetx_impl "github.com/kaiachain/kaia/kaiax/privatetx/impl"
 	randao_impl "github.com/kaiachain/kaia/kaiax/randao/impl"
 	reward_impl "github.com/kaiachain/kaia/kaiax/reward/impl"
 	"github.com/kaiachain/kaia/kaiax/staking"
@@ -58,6 +63,7 @@ import (
 	"github.com/kaiachain/kaia/kaiax/valset"
 	valset_impl "github.com/kaiachain/kaia/kaiax/valset/impl"
 	"github.com/kaiachain/kaia/networks/p2p"
+	"github.com/kaiachain/kaia/networks/p2p/discover"
 	"github.com/kaiachain/kaia/networks/rpc"
 	"github.com/kaiachain/kaia/node"
 	"github.com/kaiachain/kaia/node/cn/filters"
@@ -91,6 +97,10 @@ type Miner interface {
 	SetExtra(extra []byte) error
 	Pending() (*types.Block, types.Receipts, *state.StateDB)
 	PendingBlock() *types.Block
+	SetTxOrderingPolicy(policy work.TxOrderingPolicy)
+	SetBuildReportLimit(limit uint64)
+	BuildReport(num uint64) (*work.BuildReport, error)
+	TxBuildHistory(hash common.Hash) ([]*work.TxBuildRecord, error)
 	kaiax.ExecutionModuleHost  // Because miner executes blocks, inject ExecutionModule.
 	kaiax.TxBundlingModuleHost // Because miner bundle transactions, inject TxBundlingModule
 }
@@ -111,6 +121,7 @@ type BackendProtocolManager interface {
 	SetSyncStop(flag bool)
 	staking.StakingModuleHost
 	auction.AuctionModuleHost
+	privatetx.PrivateTxModuleHost
 }
 
 // CN implements the Kaia consensus node service.
@@ -135,7 +146,8 @@ type CN struct {
 	bloomIndexer      *blockchain.ChainIndexer       // Bloom indexer operating during block imports
 	closeBloomHandler chan struct{}
 
-	APIBackend *CNAPIBackend
+	APIBackend    *CNAPIBackend
+	kaiaFilterAPI *filters.KaiaFilterAPI // shared by the kaia namespace and the other services
 
 	miner Miner
 
@@ -155,6 +167,8 @@ type CN struct {
 	baseModules    []kaiax.BaseModule
 	jsonRpcModules []kaiax.JsonRpcModule
 	stakingModule  staking.StakingModule // TODO-kaiax: temporary for governance/api.go. Remove it after having kaiax/reward.
+
+	logIndex logindex.LogIndexModule // nil if the log index is disabled
 }
 
 func (s *CN) AddLesServer(ls LesServer) {
@@ -344,6 +358,9 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = ctx.ResolvePath(config.TxPool.Journal)
 	}
+	if config.TxPool.SpamThrottlerJournal != "" {
+		config.TxPool.SpamThrottlerJournal = ctx.ResolvePath(config.TxPool.SpamThrottlerJournal)
+	}
 	// TODO-Kaia-ServiceChain: add account creation prevention in the txPool if TxTypeAccountCreation is supported.
 	config.TxPool.NoAccountCreation = config.NoAccountCreation
 
@@ -391,18 +408,27 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 
 	// istanbul BFT
 	cn.miner.SetExtra(makeExtraData(config.ExtraData))
+	cn.miner.SetBuildReportLimit(config.BuildReportBlocks)
+	txOrderingPolicy, err := work.NewTxOrderingPolicy(config.TxOrderingPolicy)
+	if err != nil {
+		return nil, err
+	}
+	cn.miner.SetTxOrderingPolicy(txOrderingPolicy)
 
 	cn.APIBackend = &CNAPIBackend{cn, nil}
 
 	gpoParams := config.GPO
 
 	cn.APIBackend.gpo = gasprice.NewOracle(cn.APIBackend, gpoParams, cn.txPool, mGov)
+	cn.kaiaFilterAPI = filters.NewKaiaFilterAPI(cn.APIBackend)
 	//@TODO Kaia add core component
 	cn.addComponent(cn.blockchain)
 	cn.addComponent(cn.txPool)
 	cn.addComponent(cn.APIs())
 	cn.addComponent(cn.ChainDB())
 	cn.addComponent(cn.engine)
+	cn.addComponent(cn.APIBackend)
+	cn.addComponent(cn.kaiaFilterAPI)
 
 	if err := cn.SetupKaiaxModules(ctx, mValset); err != nil {
 		logger.Error("Failed to setup kaiax modules", "err", err)
@@ -522,6 +548,10 @@ func (s *CN) SetupKaiaxModules(ctx *node.ServiceContext, mValset valset.ValsetMo
 		mSupply  = supply_impl.NewSupplyModule()
 		mGasless = gasless_impl.NewGaslessModule()
 		mAuction = auction_impl.NewAuctionModule()
+		mBundle  = bundle_impl.NewBundleModule()
+
+		mLogIndex  = logindex_impl.NewLogIndexModule()
+		mPrivateTx = privatetx_impl.NewPrivateTxModule()
 	)
 
 	err := errors.Join(
@@ -530,6 +560,8 @@ func (s *CN) SetupKaiaxModules(ctx *node.ServiceContext, mValset valset.ValsetMo
 			Chain:         s.blockchain,
 			GovModule:     s.govModule,
 			StakingModule: s.stakingModule,
+			ChainKv:       s.chainDB.GetMiscDB(),
+			LedgerConfig:  s.config.RewardLedger,
 		}),
 		mSupply.Init(&supply_impl.InitOpts{
 			ChainKv:      s.chainDB.GetMiscDB(),
@@ -548,6 +580,7 @@ func (s *CN) SetupKaiaxModules(ctx *node.ServiceContext, mValset valset.ValsetMo
 			NodeKey:       ctx.NodeKey(),
 			Chain:         s.blockchain,
 			NodeType:      ctx.NodeType(),
+			Miner:         s.miner,
 		}),
 		mAuction.Init(&auction_impl.InitOpts{
 			ChainConfig:   s.chainConfig,
@@ -555,19 +588,37 @@ func (s *CN) SetupKaiaxModules(ctx *node.ServiceContext, mValset valset.ValsetMo
 			Chain:         s.blockchain,
 			Backend:       s.APIBackend,
 			Downloader:    s.protocolManager.Downloader(),
+			ChainKv:       s.chainDB.GetMiscDB(),
 			NodeKey:       ctx.NodeKey(),
 		}),
+		mBundle.Init(&bundle_impl.InitOpts{
+			ChainConfig:  s.chainConfig,
+			BundleConfig: s.config.Bundle,
+			Chain:        s.blockchain,
+			GovModule:    s.govModule,
+			Miner:        s.miner,
+		}),
+		mPrivateTx.Init(&privatetx_impl.InitOpts{
+			Chain:  s.blockchain,
+			TxPool: s.txPool,
+		}),
+		mLogIndex.Init(&logindex_impl.InitOpts{
+			ChainKv:        s.chainDB.GetMiscDB(),
+			LogIndexConfig: s.config.LogIndex,
+			Chain:          s.blockchain,
+		}),
 	)
 	if err != nil {
 		return err
 	}
+	s.addComponent(mReward)
 
 	mBase := []kaiax.BaseModule{s.stakingModule, mReward, mSupply, s.govModule, mValset, mRandao}
-	mExecution := []kaiax.ExecutionModule{s.stakingModule, mSupply, s.govModule, mValset, mRandao}
+	mExecution := []kaiax.ExecutionModule{s.stakingModule, mReward, mSupply, s.govModule, mValset, mRandao}
 	mTxBundling := []kaiax.TxBundlingModule{}
 	mTxPool := []kaiax.TxPoolModule{}
 	mJsonRpc := []kaiax.JsonRpcModule{s.stakingModule, mReward, mSupply, s.govModule, mValset, mRandao}
-	mRewindable := []kaiax.RewindableModule{s.stakingModule, mSupply, s.govModule, mValset, mRandao}
+	mRewindable := []kaiax.RewindableModule{s.stakingModule, mReward, mSupply, s.govModule, mValset, mRandao}
 
 	if !mGasless.IsDisabled() {
 		mExecution = append(mExecution, mGasless)
@@ -587,8 +638,31 @@ func (s *CN) SetupKaiaxModules(ctx *node.ServiceContext, mValset valset.ValsetMo
 		if !mGasless.IsDisabled() {
 			mAuction.RegisterGaslessModule(mGasless)
 		}
+		mAuction.RegisterPrivateTxModule(mPrivateTx)
+	}
+
+	if !mBundle.IsDisabled() {
+		mBase = append(mBase, mBundle)
+		mExecution = append(mExecution, mBundle)
+		mTxBundling = append(mTxBundling, mBundle)
+		mJsonRpc = append(mJsonRpc, mBundle)
 	}
 
+	if !mLogIndex.IsDisabled() {
+		mBase = append(mBase, mLogIndex)
+		mExecution = append(mExecution, mLogIndex)
+		mRewindable = append(mRewindable, mLogIndex)
+		s.logIndex = mLogIndex
+	}
+
+	// The private tx module is registered last so that it does not take precedence over other txpool modules.
+	mBase = append(mBase, mPrivateTx)
+	mExecution = append(mExecution, mPrivateTx)
+	mTxBundling = append(mTxBundling, mPrivateTx)
+	mTxPool = append(mTxPool, mPrivateTx)
+	mJsonRpc = append(mJsonRpc, mPrivateTx)
+	s.protocolManager.RegisterPrivateTxModule(mPrivateTx)
+
 	// Register modules to respective components
 	// TODO-kaiax: Organize below lines.
 	s.RegisterBaseModules(mBase...)
@@ -724,7 +798,6 @@ func (s *CN) APIs() []rpc.API {
 	// Append any APIs exposed explicitly by the consensus engine
 	apis = append(apis, s.engine.APIs(s.BlockChain())...)
 
-	kaiaFilterAPI := filters.NewKaiaFilterAPI(s.APIBackend)
 	ethFilterAPI := filters.NewEthFilterAPI(s.APIBackend)
 	kaiaDownloaderAPI := downloader.NewKaiaDownloaderAPI(s.protocolManager.Downloader(), s.eventMux)
 	ethDownloaderAPI := downloader.NewEthDownloaderAPI(s.protocolManager.Downloader(), s.eventMux)
@@ -753,7 +826,7 @@ func (s *CN) APIs() []rpc.API {
 		}, {
 			Namespace: "kaia",
 			Version:   "1.0",
-			Service:   kaiaFilterAPI,
+			Service:   s.kaiaFilterAPI,
 			Public:    true,
 		}, {
 			Namespace: "eth",
@@ -901,6 +974,16 @@ func (s *CN) Start(srvr p2p.Server) error {
 	// Start the RPC service
 	s.p2pServer = srvr
 
+	// Advertise the chain and the snap service in the discovery node record
+	if err := srvr.SetRecordAttribute(discover.RecordKeyChainID, s.chainConfig.ChainID.Uint64()); err != nil {
+		return err
+	}
+	if s.config.SnapshotCacheSize > 0 {
+		if err := srvr.AddRecordService(discover.ServiceSnap); err != nil {
+			return err
+		}
+	}
+
 	// Figure out a max peers count based on the server limits
 	maxPeers := srvr.MaxPeers()
 	// Start the networking layer and the light server if requested
@@ -35,7 +35,10 @@ import (
 	"github.com/kaiachain/kaia/consensus/istanbul"
 	"github.com/kaiachain/kaia/datasync/downloader"
 	"github.com/kaiachain/kaia/kaiax/auction"
+	"github.com/kaiachain/kaia/kaiax/bundle"
 	"github.com/kaiachain/kaia/kaiax/gasless"
+	"github.com/kaiachain/kaia/kaiax/logindex"
+	"github.com/kaiachain/kaia/kaiax/reward"
 	"github.com/kaiachain/kaia/log"
 	"github.com/kaiachain/kaia/node/cn/gasprice"
 	"github.com/kaiachain/kaia/params"
@@ -74,6 +77,10 @@ func GetDefaultConfig() *Config {
 
 		Gasless: gasless.DefaultGaslessConfig(),
 		Auction: auction.DefaultAuctionConfig(),
+		Bundle:  bundle.DefaultBundleConfig(),
+
+		RewardLedger: reward.DefaultLedgerConfig(),
+		LogIndex:     logindex.DefaultLogIndexConfig(),
 	}
 }
 
@@ -172,6 +179,11 @@ type Config struct {
 	TxResendCount     int
 	TxResendUseLegacy bool
 
+	// The number of recent blocks to keep the block build report. 0 disables the report.
+	BuildReportBlocks uint64
+	// The name of the policy that decides the execution order of the pending transactions.
+	TxOrderingPolicy string
+
 	// Service Chain
 	NoAccountCreation bool
 
@@ -207,6 +219,10 @@ type Config struct {
 	// Kaiax configs
 	Gasless *gasless.GaslessConfig
 	Auction *auction.AuctionConfig
+	Bundle  *bundle.BundleConfig
+
+	RewardLedger *reward.LedgerConfig
+	LogIndex     *logindex.LogIndexConfig
 }
 
 type configMarshaling struct {
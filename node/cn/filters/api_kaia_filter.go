@@ -398,10 +398,16 @@ func (api *KaiaFilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
 	return logsSub.ID, nil
 }
 
+// WithGetLogsLimits bounds the filters run with the returned context by GetLogsMaxItems
+// and GetLogsDeadline, the limits of the getLogs APIs.
+func WithGetLogsLimits(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = context.WithValue(ctx, getLogsCxtKeyMaxItems, GetLogsMaxItems)
+	return context.WithTimeout(ctx, GetLogsDeadline)
+}
+
 // GetLogs returns logs matching the given argument that are stored within the state.
 func (api *KaiaFilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*types.Log, error) {
-	ctx = context.WithValue(ctx, getLogsCxtKeyMaxItems, GetLogsMaxItems)
-	ctx, cancelFnc := context.WithTimeout(ctx, GetLogsDeadline)
+	ctx, cancelFnc := WithGetLogsLimits(ctx)
 	defer cancelFnc()
 
 	var filter *Filter
@@ -451,8 +457,7 @@ func (api *KaiaFilterAPI) UninstallFilter(id rpc.ID) bool {
 // GetFilterLogs returns the logs for the filter with the given id.
 // If the filter could not be found an empty array of logs is returned.
 func (api *KaiaFilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*types.Log, error) {
-	ctx = context.WithValue(ctx, getLogsCxtKeyMaxItems, GetLogsMaxItems)
-	ctx, cancelFnc := context.WithTimeout(ctx, GetLogsDeadline)
+	ctx, cancelFnc := WithGetLogsLimits(ctx)
 	defer cancelFnc()
 
 	api.filtersMu.Lock()
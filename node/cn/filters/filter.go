@@ -36,6 +36,7 @@ import (
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/consensus"
 	"github.com/kaiachain/kaia/event"
+	"github.com/kaiachain/kaia/kaiax/logindex"
 	"github.com/kaiachain/kaia/networks/rpc"
 	"github.com/kaiachain/kaia/params"
 	"github.com/kaiachain/kaia/storage/database"
@@ -63,6 +64,20 @@ type Backend interface {
 	Engine() consensus.Engine
 }
 
+// LogIndex is a persistent index of the logs by address and topics.
+// It is used in place of the bloombits for the queries over large block ranges.
+type LogIndex interface {
+	IndexedRange() (start, last uint64)
+	MinRange() uint64
+	FindLogs(ctx context.Context, begin, end uint64, addresses []common.Address, topics [][]common.Hash, limit int) ([]logindex.LogPosition, error)
+}
+
+// LogIndexBackend is implemented by the backends that maintain a log index.
+// LogIndex returns nil if the log index is disabled.
+type LogIndexBackend interface {
+	LogIndex() LogIndex
+}
+
 // Filter can be used to retrieve and filter logs.
 type Filter struct {
 	backend Backend
@@ -162,6 +177,30 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	if f.end == rpc.LatestBlockNumber.Int64() {
 		end = head
 	}
+	// Serve the part of the range covered by the log index from the index,
+	// and the rest from the bloombits and the raw blocks.
+	var logs []*types.Log
+	if index, lower, upper, ok := f.logIndexRange(end); ok {
+		if uint64(f.begin) < lower {
+			found, err := f.rangeLogs(ctx, lower-1)
+			logs = append(logs, found...)
+			if err != nil {
+				return logs, err
+			}
+		}
+		found, err := f.logIndexLogs(ctx, index, upper)
+		logs = append(logs, found...)
+		if err != nil || upper == end {
+			return logs, err
+		}
+	}
+	rest, err := f.rangeLogs(ctx, end)
+	logs = append(logs, rest...)
+	return logs, err
+}
+
+// rangeLogs returns the logs matching the filter criteria from the beginning of the filter up to end.
+func (f *Filter) rangeLogs(ctx context.Context, end uint64) ([]*types.Log, error) {
 	// Gather all indexed logs, and finish with non indexed ones
 	var (
 		logs []*types.Log
@@ -183,6 +222,98 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	return logs, err
 }
 
+// logIndexRange returns the part of the filter range up to end that is covered by the log index.
+// ok is false if the backend has no log index, the filter has no criteria to look up,
+// or the covered range is shorter than the minimum range of the index.
+func (f *Filter) logIndexRange(end uint64) (index LogIndex, lower, upper uint64, ok bool) {
+	backend, isIndexed := f.backend.(LogIndexBackend)
+	if !isIndexed {
+		return nil, 0, 0, false
+	}
+	if index = backend.LogIndex(); index == nil || !f.hasCriteria() {
+		return nil, 0, 0, false
+	}
+	start, last := index.IndexedRange()
+	lower, upper = uint64(f.begin), end
+	if lower < start {
+		lower = start
+	}
+	if upper > last {
+		upper = last
+	}
+	if lower > upper || upper-lower+1 < index.MinRange() {
+		return nil, 0, 0, false
+	}
+	return index, lower, upper, true
+}
+
+// hasCriteria returns true if the filter has any address or topic to look up.
+func (f *Filter) hasCriteria() bool {
+	if len(f.addresses) > 0 {
+		return true
+	}
+	for _, sub := range f.topics {
+		if len(sub) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// logIndexLogs returns the logs matching the filter criteria based on the log index.
+// The beginning of the filter must be covered by the index.
+func (f *Filter) logIndexLogs(ctx context.Context, index LogIndex, end uint64) ([]*types.Log, error) {
+	maxItems := getMaxItems(ctx)
+
+	// One more position than maxItems is enough to tell that the query returns too many logs.
+	positions, err := index.FindLogs(ctx, uint64(f.begin), end, f.addresses, f.topics, maxItems+1)
+	switch {
+	case err == logindex.ErrNotIndexed:
+		// The index has been rewound since the range was checked.
+		return f.rangeLogs(ctx, end)
+	case err == context.DeadlineExceeded:
+		return nil, errors.New("query timeout exceeded")
+	case err == context.Canceled:
+		return nil, errors.New("query is canceled. " + err.Error())
+	case err != nil:
+		return nil, err
+	}
+
+	var logs []*types.Log
+
+	for i := 0; i < len(positions); {
+		number := positions[i].BlockNumber
+		for i < len(positions) && positions[i].BlockNumber == number {
+			i++
+		}
+		f.begin = int64(number) + 1
+
+		// Retrieve the indexed block and pull the matching logs
+		header, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+		if header == nil || err != nil {
+			return logs, err
+		}
+		found, err := f.checkMatches(ctx, header)
+		if err != nil {
+			return logs, err
+		}
+		logs = append(logs, found...)
+		if len(logs) > maxItems {
+			return logs, errors.New("query returned more than " + strconv.Itoa(maxItems) + " results")
+		}
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return logs, errors.New("query timeout exceeded")
+			}
+			return logs, errors.New("query is canceled. " + ctx.Err().Error())
+		default:
+		}
+	}
+	f.begin = int64(end) + 1
+	return logs, nil
+}
+
 // blockLogs returns the logs matching the filter criteria within a single block.
 func (f *Filter) blockLogs(ctx context.Context, header *types.Header) (logs []*types.Log, err error) {
 	if bloomFilter(header.Bloom, f.addresses, f.topics) {
@@ -36,6 +36,7 @@ import (
 	"github.com/kaiachain/kaia/consensus/faker"
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/event"
+	"github.com/kaiachain/kaia/kaiax/logindex"
 	"github.com/kaiachain/kaia/networks/rpc"
 	cn "github.com/kaiachain/kaia/node/cn/filters/mock"
 	"github.com/kaiachain/kaia/params"
@@ -388,3 +389,109 @@ func TestFilters(t *testing.T) {
 		}
 	})
 }
+
+// testLogIndex finds the logs by scanning the receipts, and records the queried ranges.
+type testLogIndex struct {
+	db          database.DBManager
+	start, last uint64
+	minRange    uint64
+	queries     [][2]uint64
+}
+
+func (idx *testLogIndex) IndexedRange() (uint64, uint64) { return idx.start, idx.last }
+func (idx *testLogIndex) MinRange() uint64               { return idx.minRange }
+
+func (idx *testLogIndex) FindLogs(ctx context.Context, begin, end uint64, addresses []common.Address, topics [][]common.Hash, limit int) ([]logindex.LogPosition, error) {
+	idx.queries = append(idx.queries, [2]uint64{begin, end})
+
+	var positions []logindex.LogPosition
+	for num := begin; num <= end; num++ {
+		var index uint
+		for _, receipt := range idx.db.ReadReceipts(idx.db.ReadCanonicalHash(num), num) {
+			for _, log := range receipt.Logs {
+				if len(filterLogs([]*types.Log{log}, nil, nil, addresses, topics)) > 0 {
+					positions = append(positions, logindex.LogPosition{BlockNumber: num, Index: index})
+				}
+				index++
+			}
+		}
+	}
+	if limit > 0 && len(positions) > limit {
+		positions = positions[:limit]
+	}
+	return positions, nil
+}
+
+type logIndexTestBackend struct {
+	*testBackend
+	index *testLogIndex
+}
+
+func (b *logIndexTestBackend) LogIndex() LogIndex { return b.index }
+
+func TestFilters_LogIndex(t *testing.T) {
+	var (
+		db         = database.NewMemoryDBManager()
+		mux        = new(event.TypeMux)
+		txFeed     = new(event.Feed)
+		rmLogsFeed = new(event.Feed)
+		logsFeed   = new(event.Feed)
+		chainFeed  = new(event.Feed)
+		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed, params.TestChainConfig, nil, nil, nil}
+		index      = &testLogIndex{db: db, start: 100, last: 999, minRange: 100}
+		indexed    = &logIndexTestBackend{backend, index}
+		key1, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr       = crypto.PubkeyToAddress(key1.PublicKey)
+
+		hash1 = common.BytesToHash([]byte("topic1"))
+		hash2 = common.BytesToHash([]byte("topic2"))
+	)
+	defer db.Close()
+
+	genesis := blockchain.GenesisBlockForTesting(db, addr, big.NewInt(1000000))
+	chain, receipts := blockchain.GenerateChain(params.TestChainConfig, genesis, faker.NewFaker(), db, 1000, func(i int, gen *blockchain.BlockGen) {
+		if i%100 != 50 {
+			return
+		}
+		receipt := genReceipt(false, 0)
+		receipt.Logs = []*types.Log{
+			{Address: addr, Topics: []common.Hash{hash1}},
+			{Address: addr, Topics: []common.Hash{hash2}},
+		}
+		gen.AddUncheckedReceipt(receipt)
+		gen.AddUncheckedTx(types.NewTransaction(uint64(i), common.HexToAddress("0x1"), big.NewInt(1), 1, big.NewInt(1), nil))
+	})
+	for i, block := range chain {
+		db.WriteBlock(block)
+		db.WriteCanonicalHash(block.Hash(), block.NumberU64())
+		db.WriteHeadBlockHash(block.Hash())
+		db.WriteReceipts(block.Hash(), block.NumberU64(), receipts[i])
+	}
+
+	for i, tc := range []struct {
+		begin, end int64
+		addresses  []common.Address
+		topics     [][]common.Hash
+		queries    [][2]uint64
+	}{
+		// Partially covered by the index
+		{0, int64(rpc.LatestBlockNumber), []common.Address{addr}, [][]common.Hash{{hash2}}, [][2]uint64{{100, 999}}},
+		{300, 1000, nil, [][]common.Hash{{hash1}}, [][2]uint64{{300, 999}}},
+		// Fully covered by the index
+		{200, 800, []common.Address{addr}, nil, [][2]uint64{{200, 800}}},
+		// Shorter than the minimum range, or not covered by the index
+		{900, 960, []common.Address{addr}, nil, nil},
+		{0, 99, []common.Address{addr}, nil, nil},
+		// No criteria to look up
+		{0, 999, nil, [][]common.Hash{{}}, nil},
+	} {
+		index.queries = nil
+		want, err := NewRangeFilter(backend, tc.begin, tc.end, tc.addresses, tc.topics).Logs(context.Background())
+		assert.NoError(t, err, i)
+		have, err := NewRangeFilter(indexed, tc.begin, tc.end, tc.addresses, tc.topics).Logs(context.Background())
+		assert.NoError(t, err, i)
+		assert.NotEmpty(t, have, i)
+		assert.Equal(t, want, have, i)
+		assert.Equal(t, tc.queries, index.queries, i)
+	}
+}
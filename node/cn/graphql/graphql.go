@@ -0,0 +1,915 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/kaiachain/kaia"
+	"github.com/kaiachain/kaia/api"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/blockchain/vm"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/consensus"
+	"github.com/kaiachain/kaia/kaiax/reward"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/node/cn/filters"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+// maxBlocksPerQuery bounds the number of blocks a blocks query returns.
+const maxBlocksPerQuery = 1000
+
+var (
+	errBlockInvariant    = errors.New("block objects must be instantiated with at least one of num or hash")
+	errNoRewardModule    = errors.New("block rewards are not available")
+	errTooManyBlocks     = fmt.Errorf("too many blocks requested, the limit is %d", maxBlocksPerQuery)
+	errInvalidBlockRange = errors.New("invalid block range")
+)
+
+// Long is a 64 bit unsigned integer, which does not fit in the GraphQL Int.
+type Long int64
+
+// ImplementsGraphQLType returns true if Long implements the provided GraphQL type.
+func (b Long) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+// UnmarshalGraphQL unmarshals the provided GraphQL query data.
+func (b *Long) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case string:
+		if value, err := hexutil.DecodeUint64(input); err == nil {
+			*b = Long(value)
+			return nil
+		}
+		value, err := strconv.ParseInt(input, 10, 64)
+		*b = Long(value)
+		return err
+	case int32:
+		*b = Long(input)
+	case int64:
+		*b = Long(input)
+	case float64:
+		*b = Long(input)
+	default:
+		return fmt.Errorf("unexpected type %T for Long", input)
+	}
+	return nil
+}
+
+// Account represents a Kaia account at a particular block.
+type Account struct {
+	r             *Resolver
+	address       common.Address
+	blockNrOrHash rpc.BlockNumberOrHash
+}
+
+func (a *Account) Address(ctx context.Context) (common.Address, error) {
+	return a.address, nil
+}
+
+func (a *Account) Balance(ctx context.Context) (hexutil.Big, error) {
+	state, _, err := a.r.backend.StateAndHeaderByNumberOrHash(ctx, a.blockNrOrHash)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*state.GetBalance(a.address)), state.Error()
+}
+
+func (a *Account) TransactionCount(ctx context.Context) (Long, error) {
+	state, _, err := a.r.backend.StateAndHeaderByNumberOrHash(ctx, a.blockNrOrHash)
+	if err != nil {
+		return 0, err
+	}
+	return Long(state.GetNonce(a.address)), state.Error()
+}
+
+func (a *Account) Code(ctx context.Context) (hexutil.Bytes, error) {
+	state, _, err := a.r.backend.StateAndHeaderByNumberOrHash(ctx, a.blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return state.GetCode(a.address), state.Error()
+}
+
+func (a *Account) Storage(ctx context.Context, args struct{ Slot common.Hash }) (common.Hash, error) {
+	state, _, err := a.r.backend.StateAndHeaderByNumberOrHash(ctx, a.blockNrOrHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return state.GetState(a.address, args.Slot), state.Error()
+}
+
+// Log represents an individual log message.
+type Log struct {
+	r           *Resolver
+	transaction *Transaction
+	log         *types.Log
+}
+
+func (l *Log) Transaction(ctx context.Context) *Transaction {
+	return l.transaction
+}
+
+func (l *Log) Account(ctx context.Context, args BlockNumberArgs) *Account {
+	return &Account{r: l.r, address: l.log.Address, blockNrOrHash: args.NumberOrLatest()}
+}
+
+func (l *Log) Index(ctx context.Context) Long {
+	return Long(l.log.Index)
+}
+
+func (l *Log) Topics(ctx context.Context) []common.Hash {
+	return l.log.Topics
+}
+
+func (l *Log) Data(ctx context.Context) hexutil.Bytes {
+	return l.log.Data
+}
+
+// Transaction represents a Kaia transaction. The block and the index are unset for
+// the pending transactions.
+type Transaction struct {
+	r     *Resolver
+	tx    *types.Transaction
+	block *Block
+	index uint64
+}
+
+func (t *Transaction) Hash(ctx context.Context) common.Hash {
+	return t.tx.Hash()
+}
+
+func (t *Transaction) SenderTxHash(ctx context.Context) common.Hash {
+	return t.tx.SenderTxHashAll()
+}
+
+func (t *Transaction) Type(ctx context.Context) Long {
+	return Long(t.tx.Type())
+}
+
+func (t *Transaction) TypeName(ctx context.Context) string {
+	return t.tx.Type().String()
+}
+
+func (t *Transaction) Nonce(ctx context.Context) Long {
+	return Long(t.tx.Nonce())
+}
+
+func (t *Transaction) Index(ctx context.Context) *Long {
+	if t.block == nil {
+		return nil
+	}
+	index := Long(t.index)
+	return &index
+}
+
+func (t *Transaction) From(ctx context.Context, args BlockNumberArgs) *Account {
+	return &Account{r: t.r, address: sender(t.tx), blockNrOrHash: args.NumberOrLatest()}
+}
+
+func (t *Transaction) To(ctx context.Context, args BlockNumberArgs) *Account {
+	to := t.tx.To()
+	if to == nil {
+		return nil
+	}
+	return &Account{r: t.r, address: *to, blockNrOrHash: args.NumberOrLatest()}
+}
+
+func (t *Transaction) FeePayer(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	if !t.tx.IsFeeDelegatedTransaction() {
+		return nil, nil
+	}
+	feePayer, err := t.tx.FeePayer()
+	if err != nil {
+		return nil, err
+	}
+	return &Account{r: t.r, address: feePayer, blockNrOrHash: args.NumberOrLatest()}, nil
+}
+
+func (t *Transaction) FeeRatio(ctx context.Context) *Long {
+	ratio, ok := t.tx.FeeRatio()
+	if !ok {
+		return nil
+	}
+	l := Long(ratio)
+	return &l
+}
+
+func (t *Transaction) Value(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*t.tx.Value())
+}
+
+func (t *Transaction) GasPrice(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*t.tx.GasPrice())
+}
+
+func (t *Transaction) MaxFeePerGas(ctx context.Context) *hexutil.Big {
+	if _, ok := t.tx.GetTxInternalData().(types.TxInternalDataBaseFee); !ok {
+		return nil
+	}
+	return (*hexutil.Big)(t.tx.GasFeeCap())
+}
+
+func (t *Transaction) MaxPriorityFeePerGas(ctx context.Context) *hexutil.Big {
+	if _, ok := t.tx.GetTxInternalData().(types.TxInternalDataBaseFee); !ok {
+		return nil
+	}
+	return (*hexutil.Big)(t.tx.GasTipCap())
+}
+
+func (t *Transaction) EffectiveGasPrice(ctx context.Context) *hexutil.Big {
+	if t.block == nil {
+		return nil
+	}
+	return (*hexutil.Big)(t.tx.EffectiveGasPrice(t.block.block.Header(), t.r.backend.ChainConfig()))
+}
+
+func (t *Transaction) Gas(ctx context.Context) Long {
+	return Long(t.tx.Gas())
+}
+
+func (t *Transaction) InputData(ctx context.Context) hexutil.Bytes {
+	return t.tx.Data()
+}
+
+func (t *Transaction) Block(ctx context.Context) *Block {
+	return t.block
+}
+
+// receipt returns the receipt of the transaction, or nil if it is pending.
+func (t *Transaction) receipt(ctx context.Context) *types.Receipt {
+	if t.block == nil {
+		return nil
+	}
+	receipts := t.block.receipts(ctx)
+	if t.index >= uint64(len(receipts)) {
+		return nil
+	}
+	return receipts[t.index]
+}
+
+func (t *Transaction) Status(ctx context.Context) *Long {
+	receipt := t.receipt(ctx)
+	if receipt == nil {
+		return nil
+	}
+	status := Long(0)
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		status = 1
+	}
+	return &status
+}
+
+func (t *Transaction) GasUsed(ctx context.Context) *Long {
+	receipt := t.receipt(ctx)
+	if receipt == nil {
+		return nil
+	}
+	used := Long(receipt.GasUsed)
+	return &used
+}
+
+func (t *Transaction) CreatedContract(ctx context.Context, args BlockNumberArgs) *Account {
+	receipt := t.receipt(ctx)
+	if receipt == nil || t.tx.To() != nil || receipt.ContractAddress == (common.Address{}) {
+		return nil
+	}
+	return &Account{r: t.r, address: receipt.ContractAddress, blockNrOrHash: args.NumberOrLatest()}
+}
+
+func (t *Transaction) Logs(ctx context.Context) *[]*Log {
+	receipt := t.receipt(ctx)
+	if receipt == nil {
+		return nil
+	}
+	ret := make([]*Log, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		ret = append(ret, &Log{r: t.r, transaction: t, log: log})
+	}
+	return &ret
+}
+
+// signature returns the first signature of the sender.
+func (t *Transaction) signature() *types.TxSignature {
+	if sigs := t.tx.RawSignatureValues(); len(sigs) > 0 && sigs[0] != nil {
+		return sigs[0]
+	}
+	return &types.TxSignature{V: new(big.Int), R: new(big.Int), S: new(big.Int)}
+}
+
+func (t *Transaction) R(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*t.signature().R)
+}
+
+func (t *Transaction) S(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*t.signature().S)
+}
+
+func (t *Transaction) V(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*t.signature().V)
+}
+
+func (t *Transaction) Raw(ctx context.Context) (hexutil.Bytes, error) {
+	return t.tx.MarshalBinary()
+}
+
+// sender returns the sender of tx the way the JSON-RPC API reports it.
+func sender(tx *types.Transaction) common.Address {
+	var from common.Address
+	if tx.IsEthereumTransaction() {
+		from, _ = types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	} else {
+		from, _ = tx.From()
+	}
+	return from
+}
+
+// ConsensusInfo represents the consensus information of a block.
+type ConsensusInfo struct {
+	info consensus.ConsensusInfo
+}
+
+func (c *ConsensusInfo) Proposer(ctx context.Context) common.Address {
+	return c.info.Proposer
+}
+
+func (c *ConsensusInfo) OriginProposer(ctx context.Context) *common.Address {
+	return c.info.OriginProposer
+}
+
+func (c *ConsensusInfo) Committee(ctx context.Context) []common.Address {
+	return c.info.Committee
+}
+
+func (c *ConsensusInfo) Committers(ctx context.Context) []common.Address {
+	return c.info.Committers
+}
+
+func (c *ConsensusInfo) Round(ctx context.Context) int32 {
+	return int32(c.info.Round)
+}
+
+// RewardRecipient represents an account rewarded by a block.
+type RewardRecipient struct {
+	address common.Address
+	amount  *big.Int
+}
+
+func (r *RewardRecipient) Address(ctx context.Context) common.Address {
+	return r.address
+}
+
+func (r *RewardRecipient) Amount(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*r.amount)
+}
+
+// BlockReward represents the reward distributed at a block.
+type BlockReward struct {
+	spec *reward.RewardSpec
+}
+
+func (b *BlockReward) Minted(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*b.spec.Minted)
+}
+
+func (b *BlockReward) TotalFee(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*b.spec.TotalFee)
+}
+
+func (b *BlockReward) BurntFee(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*b.spec.BurntFee)
+}
+
+func (b *BlockReward) Proposer(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*b.spec.Proposer)
+}
+
+func (b *BlockReward) Stakers(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*b.spec.Stakers)
+}
+
+func (b *BlockReward) Kif(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*b.spec.KIF)
+}
+
+func (b *BlockReward) Kef(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*b.spec.KEF)
+}
+
+func (b *BlockReward) Recipients(ctx context.Context) []*RewardRecipient {
+	ret := make([]*RewardRecipient, 0, len(b.spec.Rewards))
+	for addr, amount := range b.spec.Rewards {
+		ret = append(ret, &RewardRecipient{address: addr, amount: amount})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return bytes.Compare(ret[i].address[:], ret[j].address[:]) < 0
+	})
+	return ret
+}
+
+// Block represents a Kaia block. Its receipts are loaded on demand.
+type Block struct {
+	r     *Resolver
+	block *types.Block
+
+	mu          sync.Mutex
+	receiptList types.Receipts
+}
+
+func (b *Block) numberOrHash() rpc.BlockNumberOrHash {
+	return rpc.NewBlockNumberOrHashWithHash(b.block.Hash(), false)
+}
+
+func (b *Block) receipts(ctx context.Context) types.Receipts {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.receiptList == nil {
+		b.receiptList = b.r.backend.GetBlockReceipts(ctx, b.block.Hash())
+	}
+	return b.receiptList
+}
+
+func (b *Block) Number(ctx context.Context) Long {
+	return Long(b.block.NumberU64())
+}
+
+func (b *Block) Hash(ctx context.Context) common.Hash {
+	return b.block.Hash()
+}
+
+func (b *Block) Parent(ctx context.Context) (*Block, error) {
+	if b.block.NumberU64() == 0 {
+		return nil, nil
+	}
+	return b.r.blockByHash(ctx, b.block.ParentHash())
+}
+
+func (b *Block) TransactionsRoot(ctx context.Context) common.Hash {
+	return b.block.Header().TxHash
+}
+
+func (b *Block) TransactionCount(ctx context.Context) Long {
+	return Long(len(b.block.Transactions()))
+}
+
+func (b *Block) StateRoot(ctx context.Context) common.Hash {
+	return b.block.Root()
+}
+
+func (b *Block) ReceiptsRoot(ctx context.Context) common.Hash {
+	return b.block.ReceiptHash()
+}
+
+func (b *Block) Rewardbase(ctx context.Context) common.Address {
+	return b.block.Rewardbase()
+}
+
+func (b *Block) ExtraData(ctx context.Context) hexutil.Bytes {
+	return b.block.Extra()
+}
+
+func (b *Block) GasUsed(ctx context.Context) Long {
+	return Long(b.block.GasUsed())
+}
+
+func (b *Block) BaseFeePerGas(ctx context.Context) *hexutil.Big {
+	if baseFee := b.block.Header().BaseFee; baseFee != nil {
+		return (*hexutil.Big)(baseFee)
+	}
+	return nil
+}
+
+func (b *Block) Timestamp(ctx context.Context) Long {
+	return Long(b.block.Time().Uint64())
+}
+
+func (b *Block) LogsBloom(ctx context.Context) hexutil.Bytes {
+	return b.block.Bloom().Bytes()
+}
+
+func (b *Block) BlockScore(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*b.block.BlockScore())
+}
+
+func (b *Block) GovernanceData(ctx context.Context) hexutil.Bytes {
+	return b.block.Header().Governance
+}
+
+func (b *Block) VoteData(ctx context.Context) hexutil.Bytes {
+	return b.block.Header().Vote
+}
+
+func (b *Block) Consensus(ctx context.Context) (*ConsensusInfo, error) {
+	info, err := b.r.backend.Engine().GetConsensusInfo(b.block)
+	if err != nil {
+		return nil, err
+	}
+	return &ConsensusInfo{info: info}, nil
+}
+
+func (b *Block) Reward(ctx context.Context) (*BlockReward, error) {
+	if b.r.reward == nil {
+		return nil, errNoRewardModule
+	}
+	spec, err := b.r.reward.GetBlockReward(b.block.NumberU64())
+	if err != nil {
+		return nil, err
+	}
+	return &BlockReward{spec: spec}, nil
+}
+
+func (b *Block) Transactions(ctx context.Context) []*Transaction {
+	txs := b.block.Transactions()
+	ret := make([]*Transaction, 0, len(txs))
+	for i, tx := range txs {
+		ret = append(ret, &Transaction{r: b.r, tx: tx, block: b, index: uint64(i)})
+	}
+	return ret
+}
+
+func (b *Block) TransactionAt(ctx context.Context, args struct{ Index Long }) *Transaction {
+	txs := b.block.Transactions()
+	if args.Index < 0 || int(args.Index) >= len(txs) {
+		return nil
+	}
+	return &Transaction{r: b.r, tx: txs[args.Index], block: b, index: uint64(args.Index)}
+}
+
+// BlockFilterCriteria encapsulates criteria passed to a `logs` accessor inside
+// a block.
+type BlockFilterCriteria struct {
+	Addresses *[]common.Address // restricts matches to events created by specific contracts
+	Topics    *[][]common.Hash  // restricts matches to particular event topics
+}
+
+func (c BlockFilterCriteria) unpack() ([]common.Address, [][]common.Hash) {
+	var (
+		addresses []common.Address
+		topics    [][]common.Hash
+	)
+	if c.Addresses != nil {
+		addresses = *c.Addresses
+	}
+	if c.Topics != nil {
+		topics = *c.Topics
+	}
+	return addresses, topics
+}
+
+func (b *Block) Logs(ctx context.Context, args struct{ Filter BlockFilterCriteria }) ([]*Log, error) {
+	addresses, topics := args.Filter.unpack()
+	ctx, cancel := filters.WithGetLogsLimits(ctx)
+	defer cancel()
+	filter := filters.NewBlockFilter(b.r.backend, b.block.Hash(), addresses, topics)
+	logs, err := filter.Logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.r.toLogs(ctx, logs, b), nil
+}
+
+func (b *Block) Account(ctx context.Context, args struct{ Address common.Address }) *Account {
+	return &Account{r: b.r, address: args.Address, blockNrOrHash: b.numberOrHash()}
+}
+
+// CallData encapsulates arguments to `call` or `estimateGas`.
+// All arguments are optional.
+type CallData struct {
+	From                 *common.Address // The Kaia address the call is from.
+	To                   *common.Address // The Kaia address the call is to.
+	Gas                  *Long           // The amount of gas provided for the call.
+	GasPrice             *hexutil.Big    // The price of each unit of gas, in kei.
+	MaxFeePerGas         *hexutil.Big    // The max price of each unit of gas, in kei.
+	MaxPriorityFeePerGas *hexutil.Big    // The max tip of each unit of gas, in kei.
+	Value                *hexutil.Big    // The value sent along with the call.
+	Data                 *hexutil.Bytes  // Any data sent with the call.
+}
+
+func (c CallData) toCallArgs() api.CallArgs {
+	args := api.CallArgs{
+		To:                   c.To,
+		GasPrice:             c.GasPrice,
+		MaxFeePerGas:         c.MaxFeePerGas,
+		MaxPriorityFeePerGas: c.MaxPriorityFeePerGas,
+	}
+	if c.From != nil {
+		args.From = *c.From
+	}
+	if c.Gas != nil {
+		gas := hexutil.Uint64(*c.Gas)
+		args.Gas = &gas
+	}
+	if c.Value != nil {
+		args.Value = *c.Value
+	}
+	if c.Data != nil {
+		args.Data = *c.Data
+	}
+	return args
+}
+
+// CallResult encapsulates the result of an invocation of the `call` accessor.
+type CallResult struct {
+	data    hexutil.Bytes // The return data from the call
+	gasUsed Long          // The amount of gas used
+	status  Long          // The return status of the call - 0 for failure or 1 for success.
+}
+
+func (c *CallResult) Data() hexutil.Bytes {
+	return c.data
+}
+
+func (c *CallResult) GasUsed() Long {
+	return c.gasUsed
+}
+
+func (c *CallResult) Status() Long {
+	return c.status
+}
+
+func (b *Block) Call(ctx context.Context, args struct{ Data CallData }) (*CallResult, error) {
+	backend := b.r.backend
+	vmCfg := vm.Config{ComputationCostLimit: params.OpcodeComputationCostLimitInfinite, UseConsoleLog: backend.IsConsoleLogEnabled()}
+	gasCap := big.NewInt(0)
+	if rpcGasCap := backend.RPCGasCap(); rpcGasCap != nil {
+		gasCap = rpcGasCap
+	}
+	result, _, err := api.DoCall(ctx, backend, args.Data.toCallArgs(), b.numberOrHash(), vmCfg, backend.RPCEVMTimeout(), gasCap)
+	if err != nil {
+		return nil, err
+	}
+	status := Long(1)
+	if result.Failed() {
+		status = 0
+	}
+	return &CallResult{
+		data:    result.Return(),
+		gasUsed: Long(result.UsedGas),
+		status:  status,
+	}, nil
+}
+
+func (b *Block) EstimateGas(ctx context.Context, args struct{ Data CallData }) (Long, error) {
+	backend := b.r.backend
+	gasCap := big.NewInt(0)
+	if rpcGasCap := backend.RPCGasCap(); rpcGasCap != nil {
+		gasCap = rpcGasCap
+	}
+	gas, err := api.DoEstimateGas(ctx, backend, args.Data.toCallArgs(), b.numberOrHash(), nil, backend.RPCEVMTimeout(), gasCap)
+	return Long(gas), err
+}
+
+func (b *Block) RawHeader(ctx context.Context) (hexutil.Bytes, error) {
+	return rlp.EncodeToBytes(b.block.Header())
+}
+
+func (b *Block) Raw(ctx context.Context) (hexutil.Bytes, error) {
+	return rlp.EncodeToBytes(b.block)
+}
+
+// BlockNumberArgs encapsulates arguments to accessors that specify a block number.
+type BlockNumberArgs struct {
+	// TODO: Ideally we could use input unions to allow the query to specify the
+	// block parameter by hash, block number, or tag but input unions aren't part of the
+	// standard GraphQL schema SDL yet, see: https://github.com/graphql/graphql-spec/issues/488
+	Block *Long
+}
+
+// NumberOrLatest returns the value of the block number argument, or the latest
+// block if it is unset.
+func (a BlockNumberArgs) NumberOrLatest() rpc.BlockNumberOrHash {
+	if a.Block != nil {
+		return rpc.NewBlockNumberOrHashWithNumber(rpc.BlockNumber(*a.Block))
+	}
+	return rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+}
+
+// SyncState represents the synchronisation status returned from the `syncing` accessor.
+type SyncState struct {
+	progress kaia.SyncProgress
+}
+
+func (s *SyncState) StartingBlock() Long {
+	return Long(s.progress.StartingBlock)
+}
+
+func (s *SyncState) CurrentBlock() Long {
+	return Long(s.progress.CurrentBlock)
+}
+
+func (s *SyncState) HighestBlock() Long {
+	return Long(s.progress.HighestBlock)
+}
+
+// Resolver is the top-level object in the GraphQL hierarchy.
+type Resolver struct {
+	backend Backend
+	reward  reward.RewardModule
+	txAPI   *api.KaiaTransactionAPI
+}
+
+func newResolver(backend Backend, rewardModule reward.RewardModule) *Resolver {
+	return &Resolver{
+		backend: backend,
+		reward:  rewardModule,
+		txAPI:   api.NewKaiaTransactionAPI(backend, new(api.AddrLocker)),
+	}
+}
+
+func (r *Resolver) blockByHash(ctx context.Context, hash common.Hash) (*Block, error) {
+	block, err := r.backend.BlockByHash(ctx, hash)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return &Block{r: r, block: block}, nil
+}
+
+func (r *Resolver) blockByNumber(ctx context.Context, number rpc.BlockNumber) (*Block, error) {
+	block, err := r.backend.BlockByNumber(ctx, number)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return &Block{r: r, block: block}, nil
+}
+
+// toLogs wraps logs into resolvers, loading their transactions. The logs are known
+// to belong to block if it is not nil.
+func (r *Resolver) toLogs(ctx context.Context, logs []*types.Log, block *Block) []*Log {
+	var (
+		ret    = make([]*Log, 0, len(logs))
+		blocks = make(map[common.Hash]*Block)
+	)
+	if block != nil {
+		blocks[block.block.Hash()] = block
+	}
+	for _, log := range logs {
+		b, ok := blocks[log.BlockHash]
+		if !ok {
+			b, _ = r.blockByHash(ctx, log.BlockHash)
+			blocks[log.BlockHash] = b
+		}
+		var tx *Transaction
+		if b != nil && int(log.TxIndex) < len(b.block.Transactions()) {
+			tx = &Transaction{r: r, tx: b.block.Transactions()[log.TxIndex], block: b, index: uint64(log.TxIndex)}
+		}
+		ret = append(ret, &Log{r: r, transaction: tx, log: log})
+	}
+	return ret
+}
+
+func (r *Resolver) Block(ctx context.Context, args struct {
+	Number *Long
+	Hash   *common.Hash
+}) (*Block, error) {
+	switch {
+	case args.Number != nil && args.Hash != nil:
+		return nil, errors.New("only one of number or hash must be specified")
+	case args.Hash != nil:
+		return r.blockByHash(ctx, *args.Hash)
+	case args.Number != nil:
+		if *args.Number < 0 {
+			return nil, errBlockInvariant
+		}
+		return r.blockByNumber(ctx, rpc.BlockNumber(*args.Number))
+	default:
+		return r.blockByNumber(ctx, rpc.LatestBlockNumber)
+	}
+}
+
+func (r *Resolver) Blocks(ctx context.Context, args struct {
+	From *Long
+	To   *Long
+}) ([]*Block, error) {
+	latest := Long(r.backend.CurrentBlock().NumberU64())
+	from, to := latest, latest
+	if args.From != nil {
+		from = *args.From
+	}
+	if args.To != nil {
+		to = *args.To
+	}
+	if from < 0 || to < from {
+		return nil, errInvalidBlockRange
+	}
+	if to > latest {
+		to = latest
+	}
+	if to >= from && to-from+1 > maxBlocksPerQuery {
+		return nil, errTooManyBlocks
+	}
+	ret := make([]*Block, 0, max(to-from+1, 0))
+	for i := from; i <= to; i++ {
+		block, err := r.blockByNumber(ctx, rpc.BlockNumber(i))
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			// Blocks after must be non-existent too, break.
+			break
+		}
+		ret = append(ret, block)
+	}
+	return ret, nil
+}
+
+func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash common.Hash }) (*Transaction, error) {
+	tx, blockHash, _, index := r.backend.GetTxAndLookupInfo(args.Hash)
+	if tx == nil {
+		// Fall back to the pool for the pending transactions.
+		if tx = r.backend.GetPoolTransaction(args.Hash); tx == nil {
+			return nil, nil
+		}
+		return &Transaction{r: r, tx: tx}, nil
+	}
+	block, err := r.blockByHash(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{r: r, tx: tx, block: block, index: index}, nil
+}
+
+// FilterCriteria encapsulates the arguments to `logs` on the root resolver object.
+type FilterCriteria struct {
+	FromBlock *Long             // beginning of the queried range, nil means latest block
+	ToBlock   *Long             // end of the range, nil means latest block
+	Addresses *[]common.Address // restricts matches to events created by specific contracts
+	Topics    *[][]common.Hash  // restricts matches to particular event topics
+}
+
+func (r *Resolver) Logs(ctx context.Context, args struct{ Filter FilterCriteria }) ([]*Log, error) {
+	begin := rpc.LatestBlockNumber.Int64()
+	if args.Filter.FromBlock != nil {
+		begin = int64(*args.Filter.FromBlock)
+	}
+	end := rpc.LatestBlockNumber.Int64()
+	if args.Filter.ToBlock != nil {
+		end = int64(*args.Filter.ToBlock)
+	}
+	if begin > 0 && end > 0 && begin > end {
+		return nil, errInvalidBlockRange
+	}
+	addresses, topics := BlockFilterCriteria{args.Filter.Addresses, args.Filter.Topics}.unpack()
+	ctx, cancel := filters.WithGetLogsLimits(ctx)
+	defer cancel()
+	logs, err := filters.NewRangeFilter(r.backend, begin, end, addresses, topics).Logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.toLogs(ctx, logs, nil), nil
+}
+
+func (r *Resolver) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	price, err := r.backend.SuggestPrice(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*price), nil
+}
+
+func (r *Resolver) MaxPriorityFeePerGas(ctx context.Context) (hexutil.Big, error) {
+	tip, err := r.backend.SuggestTipCap(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*tip), nil
+}
+
+func (r *Resolver) ChainID(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*r.backend.ChainConfig().ChainID)
+}
+
+func (r *Resolver) Syncing(ctx context.Context) *SyncState {
+	progress := r.backend.Progress()
+	// Return nil if there is no sync in progress.
+	if progress.CurrentBlock >= progress.HighestBlock {
+		return nil
+	}
+	return &SyncState{progress: progress}
+}
+
+func (r *Resolver) SendRawTransaction(ctx context.Context, args struct{ Data hexutil.Bytes }) (common.Hash, error) {
+	return r.txAPI.SendRawTransaction(ctx, args.Data)
+}
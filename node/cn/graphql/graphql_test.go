@@ -0,0 +1,178 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/api/mocks"
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/bloombits"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus"
+	consensus_mocks "github.com/kaiachain/kaia/consensus/mocks"
+	"github.com/kaiachain/kaia/event"
+	"github.com/kaiachain/kaia/kaiax/reward"
+	reward_mock "github.com/kaiachain/kaia/kaiax/reward/mock"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBackend adds the log filtering methods to the api.Backend mock.
+type testBackend struct {
+	*mock_api.MockBackend
+}
+
+func (b *testBackend) GetLogs(ctx context.Context, blockHash common.Hash) ([][]*types.Log, error) {
+	return nil, nil
+}
+
+func (b *testBackend) SubscribeRemovedLogsEvent(ch chan<- blockchain.RemovedLogsEvent) event.Subscription {
+	return nil
+}
+
+func (b *testBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
+	return nil
+}
+
+func (b *testBackend) BloomStatus() (uint64, uint64) { return 0, 0 }
+
+func (b *testBackend) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession) {}
+
+func query(t *testing.T, h http.Handler, q string) map[string]interface{} {
+	body, _ := json.Marshal(map[string]string{"query": q})
+	req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(string(body)))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var res struct {
+		Data   map[string]interface{} `json:"data"`
+		Errors []interface{}          `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Empty(t, res.Errors)
+	return res.Data
+}
+
+func TestBlockQuery(t *testing.T) {
+	blockchain.InitDeriveSha(params.TestChainConfig)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		from     = common.HexToAddress("0x520af902892196a3449b06ead301daeaf67e77e8")
+		to       = common.HexToAddress("0xa06fa690d92788cac4953da5f2dfbc4a2b3871db")
+		feePayer = common.HexToAddress("0xa142f7b24a618778165c9b06e15a61f100c51400")
+		proposer = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	)
+	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransferWithRatio, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:              uint64(3),
+		types.TxValueKeyFrom:               from,
+		types.TxValueKeyTo:                 to,
+		types.TxValueKeyAmount:             big.NewInt(5),
+		types.TxValueKeyGasLimit:           uint64(100000),
+		types.TxValueKeyGasPrice:           big.NewInt(25),
+		types.TxValueKeyFeePayer:           feePayer,
+		types.TxValueKeyFeeRatioOfFeePayer: types.FeeRatio(30),
+	})
+	require.NoError(t, err)
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: tx.Hash(), GasUsed: 21000, Logs: []*types.Log{}}
+	header := &types.Header{Number: big.NewInt(7), BlockScore: big.NewInt(1), Time: big.NewInt(100), Rewardbase: proposer}
+	block := types.NewBlock(header, []*types.Transaction{tx}, []*types.Receipt{receipt})
+
+	backend := mock_api.NewMockBackend(ctrl)
+	backend.EXPECT().BlockByNumber(gomock.Any(), rpc.LatestBlockNumber).Return(block, nil)
+	backend.EXPECT().GetBlockReceipts(gomock.Any(), block.Hash()).Return(types.Receipts{receipt})
+	engine := consensus_mocks.NewMockEngine(ctrl)
+	engine.EXPECT().GetConsensusInfo(block).Return(consensus.ConsensusInfo{
+		Proposer: proposer, Committee: []common.Address{proposer}, Committers: []common.Address{proposer}, Round: 2,
+	}, nil)
+	backend.EXPECT().Engine().Return(engine)
+
+	rewards := reward_mock.NewMockRewardModule(ctrl)
+	spec := reward.NewRewardSpec()
+	spec.Minted = big.NewInt(9)
+	spec.Rewards[proposer] = big.NewInt(9)
+	rewards.EXPECT().GetBlockReward(uint64(7)).Return(spec, nil)
+
+	h, err := newHandler(&testBackend{backend}, rewards)
+	require.NoError(t, err)
+
+	data := query(t, h, `{ block {
+		number rewardbase
+		consensus { proposer committee round }
+		reward { minted recipients { address amount } }
+		transactions { index typeName nonce value status gasUsed
+			from { address } feePayer { address } feeRatio }
+	} }`)
+
+	b := data["block"].(map[string]interface{})
+	assert.Equal(t, float64(7), b["number"])
+	assert.Equal(t, strings.ToLower(proposer.Hex()), strings.ToLower(b["rewardbase"].(string)))
+	assert.Equal(t, float64(2), b["consensus"].(map[string]interface{})["round"])
+	r := b["reward"].(map[string]interface{})
+	assert.Equal(t, "0x9", r["minted"])
+	assert.Len(t, r["recipients"], 1)
+
+	txs := b["transactions"].([]interface{})
+	require.Len(t, txs, 1)
+	got := txs[0].(map[string]interface{})
+	assert.Equal(t, float64(0), got["index"])
+	assert.Equal(t, "TxTypeFeeDelegatedValueTransferWithRatio", got["typeName"])
+	assert.Equal(t, float64(3), got["nonce"])
+	assert.Equal(t, "0x5", got["value"])
+	assert.Equal(t, float64(1), got["status"])
+	assert.Equal(t, float64(21000), got["gasUsed"])
+	assert.Equal(t, float64(30), got["feeRatio"])
+	assert.Equal(t, strings.ToLower(from.Hex()), strings.ToLower(got["from"].(map[string]interface{})["address"].(string)))
+	assert.Equal(t, strings.ToLower(feePayer.Hex()), strings.ToLower(got["feePayer"].(map[string]interface{})["address"].(string)))
+}
+
+func TestLongUnmarshal(t *testing.T) {
+	for _, tc := range []struct {
+		input interface{}
+		want  Long
+		ok    bool
+	}{
+		{"0x10", 16, true},
+		{"42", 42, true},
+		{int32(7), 7, true},
+		{float64(8), 8, true},
+		{"nope", 0, false},
+		{true, 0, false},
+	} {
+		var l Long
+		err := l.UnmarshalGraphQL(tc.input)
+		if tc.ok {
+			assert.NoError(t, err, tc.input)
+			assert.Equal(t, tc.want, l)
+		} else {
+			assert.Error(t, err, tc.input)
+		}
+	}
+}
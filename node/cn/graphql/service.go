@@ -0,0 +1,149 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package graphql serves an EIP-1767 GraphQL interface, extended with the Kaia
+// specific fields, on the HTTP-RPC endpoint.
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/kaiachain/kaia/api"
+	"github.com/kaiachain/kaia/kaiax/reward"
+	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/networks/p2p"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/node/cn/filters"
+)
+
+// Path is the path of the GraphQL handler on the HTTP-RPC endpoint.
+const Path = "/graphql"
+
+var (
+	logger = log.NewModuleLogger(log.NodeCNGraphQL)
+
+	errNoBackend = errors.New("graphql: no API backend from the core service")
+)
+
+// Backend is the view of the chain the resolvers read from.
+type Backend interface {
+	api.Backend
+	filters.Backend
+}
+
+// Service is a node service serving GraphQL queries on the HTTP-RPC endpoint. It
+// takes its backend and the reward module from the components of the core service.
+type Service struct {
+	backend Backend
+	reward  reward.RewardModule
+	handler http.Handler
+}
+
+// New creates a GraphQL service.
+func New() *Service {
+	return &Service{}
+}
+
+func (s *Service) Protocols() []p2p.Protocol { return nil }
+
+func (s *Service) APIs() []rpc.API { return nil }
+
+func (s *Service) Start(server p2p.Server) error {
+	if s.backend == nil {
+		return errNoBackend
+	}
+	h, err := newHandler(s.backend, s.reward)
+	if err != nil {
+		return err
+	}
+	s.handler = h
+	logger.Info("GraphQL service started", "path", Path)
+	return nil
+}
+
+func (s *Service) Stop() error { return nil }
+
+func (s *Service) Components() []interface{} { return nil }
+
+func (s *Service) SetComponents(components []interface{}) {
+	for _, component := range components {
+		switch v := component.(type) {
+		case Backend:
+			s.backend = v
+		case reward.RewardModule:
+			s.reward = v
+		}
+	}
+}
+
+// HTTPHandlers serves the GraphQL queries on Path.
+func (s *Service) HTTPHandlers() map[string]http.Handler {
+	return map[string]http.Handler{Path: s.handler}
+}
+
+// handler executes the GraphQL queries of the requests.
+type handler struct {
+	schema *graphql.Schema
+}
+
+func newHandler(backend Backend, rewardModule reward.RewardModule) (*handler, error) {
+	s, err := graphql.ParseSchema(schema, newResolver(backend, rewardModule))
+	if err != nil {
+		return nil, err
+	}
+	return &handler{schema: s}, nil
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	switch r.Method {
+	case http.MethodGet:
+		params.Query = r.URL.Query().Get("query")
+		params.OperationName = r.URL.Query().Get("operationName")
+		if vars := r.URL.Query().Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &params.Variables); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	response := h.schema.Exec(r.Context(), params.Query, params.OperationName, params.Variables)
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if len(response.Errors) > 0 && response.Data == nil {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	w.Write(responseJSON)
+}
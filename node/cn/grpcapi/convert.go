@@ -0,0 +1,295 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package grpcapi
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/kaiachain/kaia"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	pb "github.com/kaiachain/kaia/networks/grpc"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The conversions below follow the JSON-RPC representations of the api package,
+// with the quantities as big-endian bytes instead of hex strings.
+
+func bigBytes(x *big.Int) []byte {
+	if x == nil {
+		return nil
+	}
+	return x.Bytes()
+}
+
+func toAddress(b []byte) (common.Address, error) {
+	if len(b) != common.AddressLength {
+		return common.Address{}, status.Errorf(codes.InvalidArgument, "invalid address length %d", len(b))
+	}
+	return common.BytesToAddress(b), nil
+}
+
+func toHash(b []byte) (common.Hash, error) {
+	if len(b) != common.HashLength {
+		return common.Hash{}, status.Errorf(codes.InvalidArgument, "invalid hash length %d", len(b))
+	}
+	return common.BytesToHash(b), nil
+}
+
+func toBlockNumber(tag pb.BlockTag) (rpc.BlockNumber, error) {
+	switch tag {
+	case pb.BlockTag_LATEST:
+		return rpc.LatestBlockNumber, nil
+	case pb.BlockTag_EARLIEST:
+		return rpc.EarliestBlockNumber, nil
+	case pb.BlockTag_PENDING:
+		return rpc.PendingBlockNumber, nil
+	}
+	return 0, status.Errorf(codes.InvalidArgument, "invalid block tag %d", tag)
+}
+
+// toBlockNumberOrHash converts a block id to the block selector of the api
+// package. An empty id selects the latest block.
+func toBlockNumberOrHash(id *pb.BlockId) (rpc.BlockNumberOrHash, error) {
+	switch v := id.GetId().(type) {
+	case nil:
+		return rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil
+	case *pb.BlockId_Number:
+		if v.Number > math.MaxInt64 {
+			return rpc.BlockNumberOrHash{}, status.Error(codes.InvalidArgument, "block number too high")
+		}
+		return rpc.NewBlockNumberOrHashWithNumber(rpc.BlockNumber(v.Number)), nil
+	case *pb.BlockId_Hash:
+		hash, err := toHash(v.Hash)
+		if err != nil {
+			return rpc.BlockNumberOrHash{}, err
+		}
+		return rpc.NewBlockNumberOrHashWithHash(hash, false), nil
+	case *pb.BlockId_Tag:
+		number, err := toBlockNumber(v.Tag)
+		if err != nil {
+			return rpc.BlockNumberOrHash{}, err
+		}
+		return rpc.NewBlockNumberOrHashWithNumber(number), nil
+	}
+	return rpc.BlockNumberOrHash{}, status.Error(codes.InvalidArgument, "invalid block id")
+}
+
+// toRangeBound converts a block id bounding a log filter range. Hashes are not
+// allowed there; the filter takes a single block hash instead.
+func toRangeBound(id *pb.BlockId) (*big.Int, error) {
+	switch v := id.GetId().(type) {
+	case nil:
+		return nil, nil
+	case *pb.BlockId_Number:
+		return new(big.Int).SetUint64(v.Number), nil
+	case *pb.BlockId_Tag:
+		number, err := toBlockNumber(v.Tag)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(number.Int64()), nil
+	}
+	return nil, status.Error(codes.InvalidArgument, "log filter range must be given by block numbers")
+}
+
+func toFilterQuery(f *pb.LogFilter) (kaia.FilterQuery, error) {
+	var (
+		crit kaia.FilterQuery
+		err  error
+	)
+	if len(f.GetBlockHash()) > 0 {
+		if f.GetFromBlock() != nil || f.GetToBlock() != nil {
+			return crit, status.Error(codes.InvalidArgument, "can't specify from_block/to_block with block_hash")
+		}
+		hash, err := toHash(f.GetBlockHash())
+		if err != nil {
+			return crit, err
+		}
+		crit.BlockHash = &hash
+	}
+	if crit.FromBlock, err = toRangeBound(f.GetFromBlock()); err != nil {
+		return crit, err
+	}
+	if crit.ToBlock, err = toRangeBound(f.GetToBlock()); err != nil {
+		return crit, err
+	}
+	for _, b := range f.GetAddresses() {
+		addr, err := toAddress(b)
+		if err != nil {
+			return crit, err
+		}
+		crit.Addresses = append(crit.Addresses, addr)
+	}
+	for _, position := range f.GetTopics() {
+		var topics []common.Hash
+		for _, b := range position.GetTopics() {
+			topic, err := toHash(b)
+			if err != nil {
+				return crit, err
+			}
+			topics = append(topics, topic)
+		}
+		crit.Topics = append(crit.Topics, topics)
+	}
+	return crit, nil
+}
+
+// newHeader converts a header like api.RPCMarshalHeader.
+func newHeader(head *types.Header, rules params.Rules) *pb.Header {
+	h := &pb.Header{
+		Hash:             head.Hash().Bytes(),
+		ParentHash:       head.ParentHash.Bytes(),
+		Rewardbase:       head.Rewardbase.Bytes(),
+		StateRoot:        head.Root.Bytes(),
+		TransactionsRoot: head.TxHash.Bytes(),
+		ReceiptsRoot:     head.ReceiptHash.Bytes(),
+		LogsBloom:        head.Bloom.Bytes(),
+		BlockScore:       bigBytes(head.BlockScore),
+		Number:           head.Number.Uint64(),
+		GasUsed:          head.GasUsed,
+		Timestamp:        head.Time.Uint64(),
+		TimestampFos:     uint32(head.TimeFoS),
+		ExtraData:        head.Extra,
+		GovernanceData:   head.Governance,
+		VoteData:         head.Vote,
+	}
+	if rules.IsEthTxType {
+		if head.BaseFee == nil {
+			h.BaseFeePerGas = new(big.Int).SetUint64(params.ZeroBaseFee).Bytes()
+		} else {
+			h.BaseFeePerGas = head.BaseFee.Bytes()
+		}
+	}
+	if rules.IsRandao {
+		h.RandomReveal = head.RandomReveal
+		h.MixHash = head.MixHash
+	}
+	return h
+}
+
+// sender returns the sender of tx the way the JSON-RPC API reports it.
+func sender(tx *types.Transaction) common.Address {
+	var from common.Address
+	if tx.IsEthereumTransaction() {
+		from, _ = types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	} else {
+		from, _ = tx.From()
+	}
+	return from
+}
+
+// newTransaction converts a transaction like the transactions of the JSON-RPC API.
+// The header is nil for the transactions not processed yet.
+func newTransaction(header *types.Header, tx *types.Transaction, blockHash common.Hash, blockNumber, index uint64, config *params.ChainConfig) (*pb.Transaction, error) {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	t := &pb.Transaction{
+		Hash:             tx.Hash().Bytes(),
+		Type:             uint32(tx.Type()),
+		TypeName:         tx.Type().String(),
+		From:             sender(tx).Bytes(),
+		Nonce:            tx.Nonce(),
+		Gas:              tx.Gas(),
+		GasPrice:         bigBytes(tx.GasPrice()),
+		Value:            bigBytes(tx.Value()),
+		Input:            tx.Data(),
+		SenderTxHash:     tx.SenderTxHashAll().Bytes(),
+		BlockHash:        blockHash.Bytes(),
+		BlockNumber:      blockNumber,
+		TransactionIndex: index,
+		Raw:              raw,
+	}
+	if to := tx.To(); to != nil {
+		t.To = to.Bytes()
+	}
+	if _, ok := tx.GetTxInternalData().(types.TxInternalDataBaseFee); ok {
+		if header != nil {
+			t.GasPrice = bigBytes(tx.EffectiveGasPrice(header, config))
+		} else {
+			t.GasPrice = bigBytes(tx.EffectiveGasPrice(nil, nil))
+		}
+	}
+	if tx.IsFeeDelegatedTransaction() {
+		feePayer, err := tx.FeePayer()
+		if err != nil {
+			return nil, err
+		}
+		t.FeePayer = feePayer.Bytes()
+	}
+	if ratio, ok := tx.FeeRatio(); ok {
+		t.FeeRatio = uint32(ratio)
+	}
+	return t, nil
+}
+
+func newLog(l *types.Log) *pb.Log {
+	topics := make([][]byte, len(l.Topics))
+	for i, topic := range l.Topics {
+		topics[i] = topic.Bytes()
+	}
+	return &pb.Log{
+		Address:          l.Address.Bytes(),
+		Topics:           topics,
+		Data:             l.Data,
+		BlockNumber:      l.BlockNumber,
+		TransactionHash:  l.TxHash.Bytes(),
+		TransactionIndex: uint64(l.TxIndex),
+		BlockHash:        l.BlockHash.Bytes(),
+		LogIndex:         uint64(l.Index),
+		Removed:          l.Removed,
+	}
+}
+
+func newLogs(logs []*types.Log) *pb.Logs {
+	res := &pb.Logs{Logs: make([]*pb.Log, len(logs))}
+	for i, l := range logs {
+		res.Logs[i] = newLog(l)
+	}
+	return res
+}
+
+// newReceipt converts a receipt like api.RpcOutputReceipt. The Kaia specific
+// failure status of the receipt is kept as it is.
+func newReceipt(header *types.Header, tx *types.Transaction, blockHash common.Hash, blockNumber, index uint64, receipt *types.Receipt, config *params.ChainConfig) *pb.Receipt {
+	r := &pb.Receipt{
+		TransactionHash:   tx.Hash().Bytes(),
+		TransactionIndex:  index,
+		BlockHash:         blockHash.Bytes(),
+		BlockNumber:       blockNumber,
+		From:              sender(tx).Bytes(),
+		Status:            uint64(receipt.Status),
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: bigBytes(tx.EffectiveGasPrice(header, config)),
+		LogsBloom:         receipt.Bloom.Bytes(),
+		Logs:              newLogs(receipt.Logs).Logs,
+	}
+	if to := tx.To(); to != nil {
+		r.To = to.Bytes()
+	}
+	// If the ContractAddress is 20 0x0 bytes, assume it is not a contract creation
+	if receipt.ContractAddress != (common.Address{}) {
+		r.ContractAddress = receipt.ContractAddress.Bytes()
+	}
+	return r
+}
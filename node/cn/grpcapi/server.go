@@ -0,0 +1,363 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/kaiachain/kaia/api"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	pb "github.com/kaiachain/kaia/networks/grpc"
+	"github.com/kaiachain/kaia/node/cn/filters"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errBlockNotFound       = status.Error(codes.NotFound, "block not found")
+	errTransactionNotFound = status.Error(codes.NotFound, "transaction not found")
+	errReceiptNotFound     = status.Error(codes.NotFound, "receipt not found")
+
+	errSubscriptionOverflow = status.Error(codes.ResourceExhausted, "subscription queue overflow")
+)
+
+// subscriptionQueueSize is the number of messages queued for a subscriber. A subscriber
+// falling further behind is dropped, so that it does not block the event system.
+const subscriptionQueueSize = 1000
+
+// server implements pb.KaiaChainServer on top of the JSON-RPC API implementations.
+type server struct {
+	pb.UnimplementedKaiaChainServer
+
+	backend   Backend
+	chainAPI  *api.KaiaBlockChainAPI
+	txAPI     *api.KaiaTransactionAPI
+	filterAPI *filters.KaiaFilterAPI
+	events    *filters.EventSystem
+}
+
+func newServer(backend Backend, filterAPI *filters.KaiaFilterAPI) *server {
+	return &server{
+		backend:   backend,
+		chainAPI:  api.NewKaiaBlockChainAPI(backend),
+		txAPI:     api.NewKaiaTransactionAPI(backend, new(api.AddrLocker)),
+		filterAPI: filterAPI,
+		events:    filterAPI.Events(),
+	}
+}
+
+func (s *server) block(ctx context.Context, id *pb.BlockId) (*types.Block, error) {
+	blockNrOrHash, err := toBlockNumberOrHash(id)
+	if err != nil {
+		return nil, err
+	}
+	block, err := s.backend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errBlockNotFound
+	}
+	return block, nil
+}
+
+func (s *server) BlockNumber(ctx context.Context, req *pb.BlockNumberRequest) (*pb.BlockNumberResponse, error) {
+	return &pb.BlockNumberResponse{Number: uint64(s.chainAPI.BlockNumber())}, nil
+}
+
+func (s *server) GetHeader(ctx context.Context, req *pb.BlockRequest) (*pb.Header, error) {
+	blockNrOrHash, err := toBlockNumberOrHash(req.GetBlock())
+	if err != nil {
+		return nil, err
+	}
+	header, err := s.backend.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errBlockNotFound
+	}
+	return newHeader(header, s.backend.ChainConfig().Rules(header.Number)), nil
+}
+
+func (s *server) GetBlock(ctx context.Context, req *pb.BlockRequest) (*pb.Block, error) {
+	block, err := s.block(ctx, req.GetBlock())
+	if err != nil {
+		return nil, err
+	}
+	var (
+		config = s.backend.ChainConfig()
+		header = block.Header()
+		res    = &pb.Block{Header: newHeader(header, config.Rules(header.Number))}
+	)
+	for i, tx := range block.Transactions() {
+		if !req.GetFullTransactions() {
+			res.TransactionHashes = append(res.TransactionHashes, tx.Hash().Bytes())
+			continue
+		}
+		t, err := newTransaction(header, tx, block.Hash(), block.NumberU64(), uint64(i), config)
+		if err != nil {
+			return nil, err
+		}
+		res.Transactions = append(res.Transactions, t)
+	}
+	return res, nil
+}
+
+func (s *server) GetBlockReceipts(ctx context.Context, req *pb.BlockRequest) (*pb.Receipts, error) {
+	block, err := s.block(ctx, req.GetBlock())
+	if err != nil {
+		return nil, err
+	}
+	var (
+		config   = s.backend.ChainConfig()
+		txs      = block.Transactions()
+		receipts = s.backend.GetBlockReceipts(ctx, block.Hash())
+		res      = &pb.Receipts{}
+	)
+	if len(receipts) != len(txs) {
+		return nil, errReceiptNotFound
+	}
+	for i, receipt := range receipts {
+		res.Receipts = append(res.Receipts, newReceipt(block.Header(), txs[i], block.Hash(), block.NumberU64(), uint64(i), receipt, config))
+	}
+	return res, nil
+}
+
+func (s *server) GetTransaction(ctx context.Context, req *pb.HashRequest) (*pb.Transaction, error) {
+	hash, err := toHash(req.GetHash())
+	if err != nil {
+		return nil, err
+	}
+	config := s.backend.ChainConfig()
+	if tx, blockHash, blockNumber, index := s.backend.GetTxAndLookupInfo(hash); tx != nil {
+		header, err := s.backend.HeaderByHash(ctx, blockHash)
+		if err != nil {
+			return nil, err
+		}
+		return newTransaction(header, tx, blockHash, blockNumber, index, config)
+	}
+	// Fall back to the transactions not processed yet, like kaia_getTransactionByHash.
+	if tx := s.backend.GetPoolTransaction(hash); tx != nil {
+		return newTransaction(nil, tx, common.Hash{}, 0, 0, config)
+	}
+	return nil, errTransactionNotFound
+}
+
+func (s *server) GetTransactionReceipt(ctx context.Context, req *pb.HashRequest) (*pb.Receipt, error) {
+	hash, err := toHash(req.GetHash())
+	if err != nil {
+		return nil, err
+	}
+	tx, blockHash, blockNumber, index, receipt := s.backend.GetTxLookupInfoAndReceipt(ctx, hash)
+	if tx == nil || receipt == nil {
+		return nil, errReceiptNotFound
+	}
+	header, err := s.backend.HeaderByHash(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return newReceipt(header, tx, blockHash, blockNumber, index, receipt, s.backend.ChainConfig()), nil
+}
+
+func (s *server) GetLogs(ctx context.Context, req *pb.LogFilter) (*pb.Logs, error) {
+	crit, err := toFilterQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := s.filterAPI.GetLogs(ctx, filters.FilterCriteria(crit))
+	if err != nil {
+		return nil, err
+	}
+	return newLogs(logs), nil
+}
+
+func (s *server) GetBalance(ctx context.Context, req *pb.AccountRequest) (*pb.BalanceResponse, error) {
+	address, err := toAddress(req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+	blockNrOrHash, err := toBlockNumberOrHash(req.GetBlock())
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.chainAPI.GetBalance(ctx, address, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BalanceResponse{Balance: balance.ToInt().Bytes()}, nil
+}
+
+func (s *server) GetTransactionCount(ctx context.Context, req *pb.AccountRequest) (*pb.NonceResponse, error) {
+	address, err := toAddress(req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+	blockNrOrHash, err := toBlockNumberOrHash(req.GetBlock())
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := s.txAPI.GetTransactionCount(ctx, address, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.NonceResponse{Nonce: uint64(*nonce)}, nil
+}
+
+func (s *server) GetCode(ctx context.Context, req *pb.AccountRequest) (*pb.CodeResponse, error) {
+	address, err := toAddress(req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+	blockNrOrHash, err := toBlockNumberOrHash(req.GetBlock())
+	if err != nil {
+		return nil, err
+	}
+	code, err := s.chainAPI.GetCode(ctx, address, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CodeResponse{Code: code}, nil
+}
+
+func (s *server) GetStorageAt(ctx context.Context, req *pb.StorageRequest) (*pb.StorageResponse, error) {
+	address, err := toAddress(req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+	blockNrOrHash, err := toBlockNumberOrHash(req.GetBlock())
+	if err != nil {
+		return nil, err
+	}
+	value, err := s.chainAPI.GetStorageAt(ctx, address, hexutil.Encode(req.GetKey()), blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.StorageResponse{Value: value}, nil
+}
+
+func (s *server) SendRawTransaction(ctx context.Context, req *pb.RawTransaction) (*pb.TransactionHash, error) {
+	hash, err := s.txAPI.SendRawTransaction(ctx, req.GetData())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TransactionHash{Hash: hash.Bytes()}, nil
+}
+
+func (s *server) SubscribeNewHeads(req *pb.SubscribeNewHeadsRequest, stream pb.KaiaChain_SubscribeNewHeadsServer) error {
+	headers := make(chan *types.Header)
+	sub := s.events.SubscribeNewHeads(headers)
+	defer sub.Unsubscribe()
+
+	queue := newSendQueue(stream.Send)
+	defer queue.close()
+
+	config := s.backend.ChainConfig()
+	for {
+		select {
+		case h := <-headers:
+			if err := queue.push(newHeader(h, config.Rules(h.Number))); err != nil {
+				return err
+			}
+		case err := <-queue.err:
+			return err
+		case err := <-sub.Err():
+			return err
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (s *server) SubscribeLogs(req *pb.LogFilter, stream pb.KaiaChain_SubscribeLogsServer) error {
+	crit, err := toFilterQuery(req)
+	if err != nil {
+		return err
+	}
+	logs := make(chan []*types.Log)
+	sub, err := s.events.SubscribeLogs(crit, logs)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	defer sub.Unsubscribe()
+
+	queue := newSendQueue(stream.Send)
+	defer queue.close()
+
+	for {
+		select {
+		case l := <-logs:
+			if err := queue.push(newLogs(l)); err != nil {
+				return err
+			}
+		case err := <-queue.err:
+			return err
+		case err := <-sub.Err():
+			return err
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// sendQueue sends the messages of a subscription from its own goroutine, so that the
+// events keep being taken from the event system while the client is slow to receive.
+type sendQueue[T any] struct {
+	msgs chan T
+	err  chan error // receives the error of a failed send
+	quit chan struct{}
+}
+
+func newSendQueue[T any](send func(T) error) *sendQueue[T] {
+	q := &sendQueue[T]{
+		msgs: make(chan T, subscriptionQueueSize),
+		err:  make(chan error, 1),
+		quit: make(chan struct{}),
+	}
+	go q.loop(send)
+	return q
+}
+
+func (q *sendQueue[T]) loop(send func(T) error) {
+	for {
+		select {
+		case msg := <-q.msgs:
+			if err := send(msg); err != nil {
+				q.err <- err
+				return
+			}
+		case <-q.quit:
+			return
+		}
+	}
+}
+
+// push queues msg for sending, or returns errSubscriptionOverflow if the queue is full.
+func (q *sendQueue[T]) push(msg T) error {
+	select {
+	case q.msgs <- msg:
+		return nil
+	default:
+		return errSubscriptionOverflow
+	}
+}
+
+func (q *sendQueue[T]) close() {
+	close(q.quit)
+}
@@ -0,0 +1,244 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package grpcapi
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/api/mocks"
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/bloombits"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/event"
+	pb "github.com/kaiachain/kaia/networks/grpc"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/node/cn/filters"
+	"github.com/kaiachain/kaia/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testBackend adds the event feeds of the log filters to the api.Backend mock.
+type testBackend struct {
+	*mock_api.MockBackend
+
+	txsFeed    event.Feed
+	chainFeed  event.Feed
+	logsFeed   event.Feed
+	rmLogsFeed event.Feed
+}
+
+func (b *testBackend) SubscribeNewTxsEvent(ch chan<- blockchain.NewTxsEvent) event.Subscription {
+	return b.txsFeed.Subscribe(ch)
+}
+
+func (b *testBackend) SubscribeChainEvent(ch chan<- blockchain.ChainEvent) event.Subscription {
+	return b.chainFeed.Subscribe(ch)
+}
+
+func (b *testBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
+	return b.logsFeed.Subscribe(ch)
+}
+
+func (b *testBackend) SubscribeRemovedLogsEvent(ch chan<- blockchain.RemovedLogsEvent) event.Subscription {
+	return b.rmLogsFeed.Subscribe(ch)
+}
+
+func (b *testBackend) GetLogs(ctx context.Context, blockHash common.Hash) ([][]*types.Log, error) {
+	return nil, nil
+}
+
+func (b *testBackend) BloomStatus() (uint64, uint64) { return 0, 0 }
+
+func (b *testBackend) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession) {}
+
+func newTestClient(t *testing.T, ctrl *gomock.Controller) (*testBackend, pb.KaiaChainClient) {
+	backend := &testBackend{MockBackend: mock_api.NewMockBackend(ctrl)}
+	backend.EXPECT().EventMux().Return(nil).AnyTimes()
+	backend.EXPECT().ChainConfig().Return(params.TestChainConfig).AnyTimes()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterKaiaChainServer(srv, newServer(backend, filters.NewKaiaFilterAPI(backend)))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		// Fix the flow control windows, so that a client not receiving stalls the server soon.
+		grpc.WithInitialWindowSize(1<<16), grpc.WithInitialConnWindowSize(1<<16))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return backend, pb.NewKaiaChainClient(conn)
+}
+
+func TestGetBlock(t *testing.T) {
+	blockchain.InitDeriveSha(params.TestChainConfig)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		from     = common.HexToAddress("0x520af902892196a3449b06ead301daeaf67e77e8")
+		to       = common.HexToAddress("0xa06fa690d92788cac4953da5f2dfbc4a2b3871db")
+		feePayer = common.HexToAddress("0xa142f7b24a618778165c9b06e15a61f100c51400")
+	)
+	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransferWithRatio, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:              uint64(3),
+		types.TxValueKeyFrom:               from,
+		types.TxValueKeyTo:                 to,
+		types.TxValueKeyAmount:             big.NewInt(5),
+		types.TxValueKeyGasLimit:           uint64(100000),
+		types.TxValueKeyGasPrice:           big.NewInt(25),
+		types.TxValueKeyFeePayer:           feePayer,
+		types.TxValueKeyFeeRatioOfFeePayer: types.FeeRatio(30),
+	})
+	require.NoError(t, err)
+	header := &types.Header{Number: big.NewInt(7), BlockScore: big.NewInt(1), Time: big.NewInt(100)}
+	block := types.NewBlock(header, []*types.Transaction{tx}, nil)
+
+	backend, client := newTestClient(t, ctrl)
+	backend.EXPECT().BlockByNumberOrHash(gomock.Any(), rpc.NewBlockNumberOrHashWithNumber(7)).Return(block, nil).Times(2)
+
+	id := &pb.BlockId{Id: &pb.BlockId_Number{Number: 7}}
+	res, err := client.GetBlock(context.Background(), &pb.BlockRequest{Block: id})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), res.GetHeader().GetNumber())
+	assert.Equal(t, block.Hash().Bytes(), res.GetHeader().GetHash())
+	assert.Equal(t, [][]byte{tx.Hash().Bytes()}, res.GetTransactionHashes())
+	assert.Empty(t, res.GetTransactions())
+
+	res, err = client.GetBlock(context.Background(), &pb.BlockRequest{Block: id, FullTransactions: true})
+	require.NoError(t, err)
+	require.Len(t, res.GetTransactions(), 1)
+	got := res.GetTransactions()[0]
+	assert.Equal(t, uint32(types.TxTypeFeeDelegatedValueTransferWithRatio), got.GetType())
+	assert.Equal(t, "TxTypeFeeDelegatedValueTransferWithRatio", got.GetTypeName())
+	assert.Equal(t, from.Bytes(), got.GetFrom())
+	assert.Equal(t, to.Bytes(), got.GetTo())
+	assert.Equal(t, feePayer.Bytes(), got.GetFeePayer())
+	assert.Equal(t, uint32(30), got.GetFeeRatio())
+	assert.Equal(t, uint64(3), got.GetNonce())
+	assert.Equal(t, big.NewInt(5).Bytes(), got.GetValue())
+	assert.Equal(t, block.Hash().Bytes(), got.GetBlockHash())
+
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, raw, got.GetRaw())
+}
+
+func TestInvalidArguments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	_, client := newTestClient(t, ctrl)
+	ctx := context.Background()
+
+	_, err := client.GetBalance(ctx, &pb.AccountRequest{Address: []byte{1, 2, 3}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetHeader(ctx, &pb.BlockRequest{Block: &pb.BlockId{Id: &pb.BlockId_Tag{Tag: pb.BlockTag(9)}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetLogs(ctx, &pb.LogFilter{
+		BlockHash: common.Hash{1}.Bytes(),
+		FromBlock: &pb.BlockId{Id: &pb.BlockId_Number{Number: 1}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetLogs(ctx, &pb.LogFilter{ToBlock: &pb.BlockId{Id: &pb.BlockId_Hash{Hash: common.Hash{1}.Bytes()}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSubscribeNewHeads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	backend, client := newTestClient(t, ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.SubscribeNewHeads(ctx, &pb.SubscribeNewHeadsRequest{})
+	require.NoError(t, err)
+
+	header := &types.Header{Number: big.NewInt(11), BlockScore: big.NewInt(1), Time: big.NewInt(100)}
+	block := types.NewBlockWithHeader(header)
+
+	// The subscription is installed asynchronously, so keep sending until it is served.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			backend.chainFeed.Send(blockchain.ChainEvent{Block: block, Hash: block.Hash()})
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	got, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(11), got.GetNumber())
+	assert.Equal(t, header.Hash().Bytes(), got.GetHash())
+}
+
+func TestSubscribeNewHeads_SlowClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	backend, client := newTestClient(t, ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.SubscribeNewHeads(ctx, &pb.SubscribeNewHeadsRequest{})
+	require.NoError(t, err)
+
+	// The client never receives, so the subscription overflows instead of blocking the feed.
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		for i := 0; i < 10*subscriptionQueueSize; i++ {
+			header := &types.Header{Number: big.NewInt(int64(i)), BlockScore: big.NewInt(1), Time: big.NewInt(100)}
+			block := types.NewBlockWithHeader(header)
+			backend.chainFeed.Send(blockchain.ChainEvent{Block: block, Hash: block.Hash()})
+		}
+	}()
+	select {
+	case <-sent:
+	case <-time.After(10 * time.Second):
+		t.Fatal("chain events blocked by a slow subscriber")
+	}
+
+	for {
+		if _, err = stream.Recv(); err != nil {
+			break
+		}
+	}
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package grpcapi serves the typed KaiaChain gRPC service on the gRPC endpoint.
+// The methods are backed by the same API implementations as the JSON-RPC API, and
+// the results are converted to the protobuf messages of networks/grpc.
+package grpcapi
+
+import (
+	"errors"
+
+	"github.com/kaiachain/kaia/api"
+	"github.com/kaiachain/kaia/log"
+	pb "github.com/kaiachain/kaia/networks/grpc"
+	"github.com/kaiachain/kaia/networks/p2p"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/node/cn/filters"
+	"google.golang.org/grpc"
+)
+
+var (
+	logger = log.NewModuleLogger(log.NodeCNGRPCAPI)
+
+	errNoBackend   = errors.New("grpcapi: no API backend from the core service")
+	errNoFilterAPI = errors.New("grpcapi: no filter API from the core service")
+)
+
+// Backend is the view of the chain the service reads from.
+type Backend interface {
+	api.Backend
+	filters.Backend
+}
+
+// Service is a node service serving the KaiaChain gRPC service. It takes its
+// backend and filter API from the components of the core service.
+type Service struct {
+	backend   Backend
+	filterAPI *filters.KaiaFilterAPI
+	server    *server
+}
+
+// New creates a KaiaChain gRPC service.
+func New() *Service {
+	return &Service{}
+}
+
+func (s *Service) Protocols() []p2p.Protocol { return nil }
+
+func (s *Service) APIs() []rpc.API { return nil }
+
+func (s *Service) Start(server p2p.Server) error {
+	if s.backend == nil {
+		return errNoBackend
+	}
+	if s.filterAPI == nil {
+		return errNoFilterAPI
+	}
+	s.server = newServer(s.backend, s.filterAPI)
+	logger.Info("KaiaChain gRPC service started")
+	return nil
+}
+
+func (s *Service) Stop() error { return nil }
+
+func (s *Service) Components() []interface{} { return nil }
+
+func (s *Service) SetComponents(components []interface{}) {
+	for _, component := range components {
+		switch v := component.(type) {
+		case Backend:
+			s.backend = v
+		case *filters.KaiaFilterAPI:
+			// Share the event system of the kaia namespace rather than running another one.
+			s.filterAPI = v
+		}
+	}
+}
+
+// RegisterGRPC registers the KaiaChain service on the gRPC endpoint.
+func (s *Service) RegisterGRPC(r grpc.ServiceRegistrar) {
+	pb.RegisterKaiaChainServer(r, s.server)
+}
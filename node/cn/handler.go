@@ -29,6 +29,7 @@ import (
 	"math"
 	"math/big"
 	"math/rand"
+	"net"
 	"runtime/debug"
 	"sort"
 	"sync"
@@ -46,6 +47,7 @@ import (
 	"github.com/kaiachain/kaia/datasync/fetcher"
 	"github.com/kaiachain/kaia/event"
 	"github.com/kaiachain/kaia/kaiax/auction"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
 	"github.com/kaiachain/kaia/kaiax/staking"
 	"github.com/kaiachain/kaia/networks/p2p"
 	"github.com/kaiachain/kaia/networks/p2p/discover"
@@ -65,6 +67,9 @@ const (
 	// The number is referenced from the size of tx pool.
 	txChanSize = 4096
 
+	// privateTxChanSize is the size of channel listening to new private txs.
+	privateTxChanSize = 1024
+
 	// bidChanSize is the size of channel listening to NewBidEvent.
 	// The number is referenced from the size of bid pool.
 	bidChanSize = 2048
@@ -122,6 +127,8 @@ type ProtocolManager struct {
 	minedBlockSub *event.TypeMuxSubscription
 	bidCh         chan *auction.Bid
 	bidSub        event.Subscription
+	privateTxCh   chan []*privatetx.PrivateTx
+	privateTxSub  event.Subscription
 
 	// channels for fetcher, syncer, txsyncLoop
 	newPeerCh   chan Peer
@@ -148,6 +155,8 @@ type ProtocolManager struct {
 	stakingModule staking.StakingModule
 	auctionModule auction.AuctionModule
 
+	privateTxModule privatetx.PrivateTxModule
+
 	missingBlobSidecarCh  <-chan *kaia_blockchain.MissingBlobSidecar
 	blobSidecarReqManager *sidecarReqManager
 }
@@ -352,7 +361,7 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 			atomic.StoreUint32(&manager.acceptTxs, 1) // Mark initial sync done on any fetcher import
 			return manager.blockchain.InsertChain(blocks)
 		}
-		manager.fetcher = fetcher.New(blockchain.GetBlockByHash, validator, manager.BroadcastBlock, manager.BroadcastBlockHash, heighter, inserter, manager.removePeer)
+		manager.fetcher = fetcher.New(blockchain.GetBlockByHash, validator, manager.BroadcastBlock, manager.BroadcastBlockHash, heighter, inserter, manager.removePeer, manager.reportPeer)
 	}
 
 	if manager.useTxResend() {
@@ -378,10 +387,25 @@ func (pm *ProtocolManager) IsAuctionModuleDisabled() bool {
 	return pm.auctionModule == nil
 }
 
+func (pm *ProtocolManager) RegisterPrivateTxModule(privateTxModule privatetx.PrivateTxModule) {
+	pm.privateTxModule = privateTxModule
+}
+
+func (pm *ProtocolManager) IsPrivateTxModuleDisabled() bool {
+	return pm.privateTxModule == nil
+}
+
 func (pm *ProtocolManager) getWSEndPoint() string {
 	return pm.wsendpoint
 }
 
+// reportPeer lowers the reputation of the peer with the given id.
+func (pm *ProtocolManager) reportPeer(id string, ev p2p.ReputationEvent) {
+	if peer := pm.peers.Peer(id); peer != nil {
+		peer.GetP2PPeer().Report(ev)
+	}
+}
+
 func (pm *ProtocolManager) removePeer(id string) {
 	// Short circuit if the peer was already removed
 	peer := pm.peers.Peer(id)
@@ -428,6 +452,13 @@ func (pm *ProtocolManager) Start(maxPeers int) {
 		go pm.bidBroadcastLoop()
 	}
 
+	if !pm.IsPrivateTxModuleDisabled() {
+		// forward private txs
+		pm.privateTxCh = make(chan []*privatetx.PrivateTx, privateTxChanSize)
+		pm.privateTxSub = pm.privateTxModule.SubscribeNewPrivateTxs(pm.privateTxCh)
+		go pm.privateTxBroadcastLoop()
+	}
+
 	// sync missing blob sidecars
 	pm.missingBlobSidecarCh = pm.txpool.SubscribeMissingBlobSidecars()
 	go pm.blobSidecarSyncLoop()
@@ -445,6 +476,9 @@ func (pm *ProtocolManager) Stop() {
 	if !pm.IsAuctionModuleDisabled() {
 		pm.bidSub.Unsubscribe() // quits bidBroadcastLoop
 	}
+	if !pm.IsPrivateTxModuleDisabled() {
+		pm.privateTxSub.Unsubscribe() // quits privateTxBroadcastLoop
+	}
 
 	// Quit the sync loop.
 	// After this send has completed, no new peers will be accepted.
@@ -652,6 +686,7 @@ func (pm *ProtocolManager) processMsg(msgCh <-chan p2p.Msg, p Peer, addr common.
 		for msg := range msgCh {
 			if err := pm.handleMsg(p, addr, msg); err != nil {
 				p.GetP2PPeer().Log().Error("ProtocolManager failed to handle message", "msg", msg, "err", err)
+				p.GetP2PPeer().Report(p2p.RepProtocolError)
 				errCh <- err
 				return
 			}
@@ -779,6 +814,11 @@ func (pm *ProtocolManager) handleMsg(p Peer, addr common.Address, msg p2p.Msg) e
 			return err
 		}
 
+	case p.GetVersion() >= kaia68 && msg.Code == PrivateTxMsg:
+		if err := handlePrivateTxMsg(pm, p, msg); err != nil {
+			return err
+		}
+
 	case msg.Code == NewBlockHashesMsg:
 		if err := handleNewBlockHashesMsg(pm, p, msg); err != nil {
 			return err
@@ -1479,13 +1519,62 @@ func handleTxMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
 			}
 		}
 		p.AddToKnownTxs(tx.Hash())
+		// Transactions for another chain or above the gas cap can never be
+		// included, so they only count against the peer's reputation.
+		if tx.Gas() > params.UpperGasLimit || isForeignChainTx(tx, pm.chainconfig.ChainID) {
+			p.GetP2PPeer().Report(p2p.RepInvalidTx)
+			continue
+		}
 		validTxs = append(validTxs, tx)
 		txReceiveCounter.Inc(1)
 	}
-	pm.txpool.HandleTxMsg(validTxs)
+	pm.txpool.HandleTxMsg(p.GetID(), peerIP(p), validTxs)
 	return err
 }
 
+// peerIP returns the remote IP of the peer, or an empty string if unknown.
+// The spam throttler scores the peers sharing an IP together.
+func peerIP(p Peer) string {
+	if addr, ok := p.GetP2PPeer().RemoteAddr().(*net.TCPAddr); ok {
+		return addr.IP.String()
+	}
+	return ""
+}
+
+// isForeignChainTx reports whether the transaction is signed for another chain.
+// Unsigned and unprotected legacy transactions are not considered foreign.
+func isForeignChainTx(tx *types.Transaction, chainID *big.Int) bool {
+	if tx.IsEthTypedTransaction() {
+		return tx.ChainId() != nil && tx.ChainId().Cmp(chainID) != 0
+	}
+	sigs := tx.RawSignatureValues()
+	if len(sigs) == 0 || sigs[0].V == nil || !tx.Protected() {
+		return false
+	}
+	return tx.ChainId().Cmp(chainID) != 0
+}
+
+// handlePrivateTxMsg handles private transaction message.
+func handlePrivateTxMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
+	if pm.IsPrivateTxModuleDisabled() || atomic.LoadUint32(&pm.acceptTxs) == 0 {
+		return nil
+	}
+
+	var ptxs []*privatetx.PrivateTx
+	if err := msg.Decode(&ptxs); err != nil {
+		return errResp(ErrDecode, "msg %v: %v", msg, err)
+	}
+	for i, ptx := range ptxs {
+		if ptx == nil || ptx.Tx == nil {
+			return errResp(ErrDecode, "private transaction %d is nil", i)
+		}
+		p.AddToKnownTxs(ptx.Tx.Hash())
+		txReceiveCounter.Inc(1)
+	}
+	pm.privateTxModule.HandlePrivateTxs(p.GetID(), ptxs)
+	return nil
+}
+
 // sampleSize calculates the number of peers to send block.
 // If calcSampleSize is smaller than minNumPeersToSendBlock, it returns minNumPeersToSendBlock.
 // Otherwise, it returns calcSampleSize.
@@ -1559,6 +1648,10 @@ func (pm *ProtocolManager) BroadcastTxs(txs types.Transactions) {
 	// This function calls sendTransaction() to broadcast the transactions for each peer.
 	// In that case, transactions are sorted for each peer in sendTransaction().
 	// Therefore, it prevents sorting transactions by each peer.
+	txs = pm.withoutPrivateTxs(txs)
+	if len(txs) == 0 {
+		return
+	}
 	baseFee := big.NewInt(int64(params.DefaultLowerBoundBaseFee))
 	if pm.blockchain != nil && pm.blockchain.CurrentHeader() != nil && pm.blockchain.CurrentHeader().BaseFee != nil {
 		baseFee = pm.blockchain.CurrentHeader().BaseFee
@@ -1638,6 +1731,52 @@ func (pm *ProtocolManager) broadcastTxsFromEN(txs types.Transactions) {
 	sendTransactions(peersWithoutTxs)
 }
 
+// BroadcastPrivateTxs forwards private transactions towards CNs. ENs never receive them.
+// An EN sends them to its CN and PN peers, a PN to its CN peers (or PN peers if it has no CN peer),
+// and a CN to its CN peers.
+func (pm *ProtocolManager) BroadcastPrivateTxs(ptxs []*privatetx.PrivateTx) {
+	peersWithoutTxs := make(map[Peer][]*privatetx.PrivateTx)
+	for _, ptx := range ptxs {
+		hash := ptx.Tx.Hash()
+		peers := pm.peers.CNWithoutTx(hash)
+		switch pm.nodetype {
+		case common.ENDPOINTNODE:
+			peers = append(peers, pm.peers.TypePeersWithoutTx(hash, common.PROXYNODE)...)
+		case common.PROXYNODE:
+			if len(peers) == 0 {
+				peers = pm.peers.TypePeersWithoutTx(hash, common.PROXYNODE)
+			}
+		}
+		for _, peer := range peers {
+			if peer.GetVersion() >= kaia68 {
+				peersWithoutTxs[peer] = append(peersWithoutTxs[peer], ptx)
+			}
+		}
+		logger.Trace("Broadcast private transaction", "hash", hash, "recipients", len(peers))
+	}
+
+	for peer, ptxs := range peersWithoutTxs {
+		if err := peer.SendPrivateTxs(ptxs); err != nil {
+			logger.Error("Failed to send private txs", "peer", peer.GetAddr(), "peerType", peer.ConnType(), "numTxs", len(ptxs), "err", err)
+		}
+	}
+}
+
+// withoutPrivateTxs returns the transactions excluding private ones,
+// which must only be propagated via BroadcastPrivateTxs.
+func (pm *ProtocolManager) withoutPrivateTxs(txs types.Transactions) types.Transactions {
+	if pm.IsPrivateTxModuleDisabled() {
+		return txs
+	}
+	publicTxs := make(types.Transactions, 0, len(txs))
+	for _, tx := range txs {
+		if !pm.privateTxModule.IsPrivateTx(tx.Hash()) {
+			publicTxs = append(publicTxs, tx)
+		}
+	}
+	return publicTxs
+}
+
 // ReBroadcastTxs sends transactions, not considering whether the peer has the transaction or not.
 // Only PN and EN rebroadcast transactions to its peers, a CN does not rebroadcast transactions.
 func (pm *ProtocolManager) ReBroadcastTxs(txs types.Transactions) {
@@ -1646,6 +1785,7 @@ func (pm *ProtocolManager) ReBroadcastTxs(txs types.Transactions) {
 		return
 	}
 
+	txs = pm.withoutPrivateTxs(txs)
 	baseFee := big.NewInt(int64(params.DefaultLowerBoundBaseFee))
 	if pm.blockchain != nil && pm.blockchain.CurrentHeader() != nil && pm.blockchain.CurrentHeader().BaseFee != nil {
 		baseFee = pm.blockchain.CurrentHeader().BaseFee
@@ -1713,6 +1853,17 @@ func (pm *ProtocolManager) bidBroadcastLoop() {
 	}
 }
 
+func (pm *ProtocolManager) privateTxBroadcastLoop() {
+	for {
+		select {
+		case ptxs := <-pm.privateTxCh:
+			pm.BroadcastPrivateTxs(ptxs)
+		case <-pm.privateTxSub.Err():
+			return
+		}
+	}
+}
+
 func (pm *ProtocolManager) txBroadcastLoop() {
 	for {
 		select {
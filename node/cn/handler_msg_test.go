@@ -35,6 +35,8 @@ import (
 	"github.com/kaiachain/kaia/crypto/kzg4844"
 	"github.com/kaiachain/kaia/kaiax/auction"
 	auction_mock "github.com/kaiachain/kaia/kaiax/auction/mock"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
+	privatetx_mock "github.com/kaiachain/kaia/kaiax/privatetx/mock"
 	"github.com/kaiachain/kaia/kaiax/staking"
 	staking_mock "github.com/kaiachain/kaia/kaiax/staking/mock"
 	"github.com/kaiachain/kaia/networks/p2p"
@@ -262,7 +264,7 @@ func TestHandleNewBlockMsg_SmallLocalPeerBlockScore_NoSynchronise(t *testing.T)
 }
 
 func TestHandleTxMsg(t *testing.T) {
-	pm := &ProtocolManager{}
+	pm := &ProtocolManager{chainconfig: params.TestChainConfig}
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	mockPeer := NewMockPeer(mockCtrl)
@@ -282,14 +284,45 @@ func TestHandleTxMsg(t *testing.T) {
 
 		// The time field in received transaction through pm.handleMsg() has different value from generated transaction(`tx1`).
 		// It can check whether the transaction created `HandleTxMsg()` is the same as `tx1` through `AddToKnownTxs(txs[0].Hash())`.
-		mockTxPool.EXPECT().HandleTxMsg(gomock.Any()).AnyTimes()
+		mockTxPool.EXPECT().HandleTxMsg(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 		pm.txpool = mockTxPool
 
 		mockPeer.EXPECT().AddToKnownTxs(txs[0].Hash()).Times(1)
+		mockPeer.EXPECT().GetID().Return(nodeids[0].String()).AnyTimes()
+		mockPeer.EXPECT().GetP2PPeer().Return(p2pPeers[0]).AnyTimes()
 		assert.NoError(t, pm.handleMsg(mockPeer, addrs[0], msg))
 	}
 }
 
+func TestHandleTxMsg_InvalidTx(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	pm := &ProtocolManager{chainconfig: params.TestChainConfig}
+	atomic.StoreUint32(&pm.acceptTxs, 1)
+	mockTxPool := mocks.NewMockTxPool(mockCtrl)
+	pm.txpool = mockTxPool
+
+	mockPeer := NewMockPeer(mockCtrl)
+	mockPeer.EXPECT().GetVersion().Return(kaia63).AnyTimes()
+	mockPeer.EXPECT().GetID().Return("test-peer").AnyTimes()
+	mockPeer.EXPECT().GetP2PPeer().Return(p2pPeers[0]).Times(3)
+
+	// A transaction signed for another chain and one above the gas cap are dropped.
+	wrongChain, err := types.SignTx(types.NewTransaction(0, addrs[0], big.NewInt(1), 21000, big.NewInt(25), nil),
+		types.LatestSignerForChainID(new(big.Int).Add(params.TestChainConfig.ChainID, common.Big1)), keys[0])
+	require.NoError(t, err)
+	tooMuchGas, err := types.SignTx(types.NewTransaction(0, addrs[0], big.NewInt(1), params.UpperGasLimit+1, big.NewInt(25), nil),
+		types.LatestSignerForChainID(params.TestChainConfig.ChainID), keys[0])
+	require.NoError(t, err)
+
+	txs := types.Transactions{wrongChain, tooMuchGas, tx1}
+	mockPeer.EXPECT().AddToKnownTxs(gomock.Any()).Times(len(txs))
+	mockTxPool.EXPECT().HandleTxMsg("test-peer", "", gomock.Len(1)).Times(1)
+
+	assert.NoError(t, handleTxMsg(pm, mockPeer, generateMsg(t, TxMsg, txs)))
+}
+
 func TestHandleTxMsg_KZGVerificationError(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -741,7 +774,7 @@ func TestHandleBidMsg(t *testing.T) {
 
 	msg := generateMsg(t, BidMsg, testBid)
 
-	mockPeer.EXPECT().GetVersion().Return(kaia63).Times(9)
+	mockPeer.EXPECT().GetVersion().Return(kaia63).Times(10)
 	assert.Error(t, pm.handleMsg(mockPeer, addrs[0], msg), "should return error when protocol version is not kaia66")
 
 	mockPeer.EXPECT().GetVersion().Return(kaia66).AnyTimes()
@@ -750,6 +783,31 @@ func TestHandleBidMsg(t *testing.T) {
 	mockCtrl.Finish()
 }
 
+func TestHandlePrivateTxMsg(t *testing.T) {
+	mockCtrl, _, mockPeer, pm := prepareBlockChain(t)
+	defer mockCtrl.Finish()
+
+	ptxs := []*privatetx.PrivateTx{{Tx: tx1, MaxBlockNumber: 100}}
+	msg := generateMsg(t, PrivateTxMsg, ptxs)
+
+	// Ignored if the private tx module is disabled.
+	assert.NoError(t, handlePrivateTxMsg(pm, mockPeer, msg))
+
+	mPrivateTx := privatetx_mock.NewMockPrivateTxModule(mockCtrl)
+	pm.RegisterPrivateTxModule(mPrivateTx)
+	pm.SetAcceptTxs()
+
+	mockPeer.EXPECT().AddToKnownTxs(tx1.Hash()).Times(1)
+	mPrivateTx.EXPECT().HandlePrivateTxs(nodeids[0].String(), gomock.Any()).Do(func(peerID string, received []*privatetx.PrivateTx) {
+		assert.Len(t, received, 1)
+		assert.Equal(t, tx1.Hash(), received[0].Tx.Hash())
+		assert.Equal(t, uint64(100), received[0].MaxBlockNumber)
+	}).Times(1)
+
+	msg = generateMsg(t, PrivateTxMsg, ptxs)
+	assert.NoError(t, handlePrivateTxMsg(pm, mockPeer, msg))
+}
+
 func TestHandleBlobSidecarsRequestMsg(t *testing.T) {
 	// test if the blob sidecars are retrieved from the block chain
 	{
@@ -40,6 +40,8 @@ import (
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/datasync/downloader"
 	"github.com/kaiachain/kaia/event"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
+	privatetx_mock "github.com/kaiachain/kaia/kaiax/privatetx/mock"
 	"github.com/kaiachain/kaia/networks/p2p"
 	"github.com/kaiachain/kaia/networks/p2p/discover"
 	"github.com/kaiachain/kaia/node/cn/mocks"
@@ -584,6 +586,86 @@ func TestBroadcastTxsFrom_DefaultCase(t *testing.T) {
 	pm.BroadcastTxs(txs)
 }
 
+func TestBroadcastTxsFromEN_PrivateTx(t *testing.T) {
+	pm := &ProtocolManager{}
+	pm.nodetype = common.ENDPOINTNODE
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	peers := newPeerSet()
+	pm.peers = peers
+	createAndRegisterPeers(mockCtrl, peers)
+
+	mPrivateTx := privatetx_mock.NewMockPrivateTxModule(mockCtrl)
+	mPrivateTx.EXPECT().IsPrivateTx(tx1.Hash()).Return(true).Times(1)
+	pm.RegisterPrivateTxModule(mPrivateTx)
+
+	// There are no expected calls for the peers.
+	pm.BroadcastTxs(txs)
+}
+
+func TestBroadcastPrivateTxs(t *testing.T) {
+	ptxs := []*privatetx.PrivateTx{{Tx: tx1, MaxBlockNumber: 100}}
+	testcases := []struct {
+		nodetype                     common.ConnType
+		sendToCN, sendToPN, sendToEN int
+	}{
+		{common.CONSENSUSNODE, 1, 0, 0},
+		{common.PROXYNODE, 1, 0, 0},
+		{common.ENDPOINTNODE, 1, 1, 0},
+	}
+	for _, tc := range testcases {
+		pm := &ProtocolManager{}
+		pm.nodetype = tc.nodetype
+		mockCtrl := gomock.NewController(t)
+
+		peers := newPeerSet()
+		pm.peers = peers
+		cnPeer, pnPeer, enPeer := createAndRegisterPeers(mockCtrl, peers)
+
+		cnPeer.EXPECT().ConnType().Return(common.CONSENSUSNODE).AnyTimes()
+		pnPeer.EXPECT().ConnType().Return(common.PROXYNODE).AnyTimes()
+		enPeer.EXPECT().ConnType().Return(common.ENDPOINTNODE).AnyTimes()
+		for _, peer := range []*MockPeer{cnPeer, pnPeer, enPeer} {
+			peer.EXPECT().KnowsTx(tx1.Hash()).Return(false).AnyTimes()
+			peer.EXPECT().GetVersion().Return(kaia68).AnyTimes()
+		}
+		cnPeer.EXPECT().SendPrivateTxs(gomock.Eq(ptxs)).Times(tc.sendToCN)
+		pnPeer.EXPECT().SendPrivateTxs(gomock.Eq(ptxs)).Times(tc.sendToPN)
+		enPeer.EXPECT().SendPrivateTxs(gomock.Any()).Times(tc.sendToEN)
+
+		pm.BroadcastPrivateTxs(ptxs)
+		mockCtrl.Finish()
+	}
+}
+
+func TestBroadcastPrivateTxsFromPN_CN_NotExists(t *testing.T) {
+	pm := &ProtocolManager{}
+	pm.nodetype = common.PROXYNODE
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	peers := newPeerSet()
+	pm.peers = peers
+	cnPeer, pnPeer, enPeer := createAndRegisterPeers(mockCtrl, peers)
+	ptxs := []*privatetx.PrivateTx{{Tx: tx1, MaxBlockNumber: 100}}
+
+	cnPeer.EXPECT().ConnType().Return(common.CONSENSUSNODE).AnyTimes()
+	pnPeer.EXPECT().ConnType().Return(common.PROXYNODE).AnyTimes()
+	enPeer.EXPECT().ConnType().Return(common.ENDPOINTNODE).AnyTimes()
+
+	cnPeer.EXPECT().KnowsTx(tx1.Hash()).Return(true).AnyTimes()
+	pnPeer.EXPECT().KnowsTx(tx1.Hash()).Return(false).AnyTimes()
+	enPeer.EXPECT().KnowsTx(tx1.Hash()).Return(false).AnyTimes()
+	pnPeer.EXPECT().GetVersion().Return(kaia68).AnyTimes()
+
+	// The PN relays to another PN if no CN peer is without the tx, but never to ENs.
+	pnPeer.EXPECT().SendPrivateTxs(gomock.Eq(ptxs)).Times(1)
+	enPeer.EXPECT().SendPrivateTxs(gomock.Any()).Times(0)
+
+	pm.BroadcastPrivateTxs(ptxs)
+}
+
 func TestProtocolManager_txResendLoop(t *testing.T) {
 	pm := &ProtocolManager{}
 	pm.nodetype = common.CONSENSUSNODE
@@ -10,6 +10,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	state "github.com/kaiachain/kaia/blockchain/state"
 	types "github.com/kaiachain/kaia/blockchain/types"
+	common "github.com/kaiachain/kaia/common"
 	kaiax "github.com/kaiachain/kaia/kaiax"
 	work "github.com/kaiachain/kaia/work"
 )
@@ -37,6 +38,21 @@ func (m *MockMiner) EXPECT() *MockMinerMockRecorder {
 	return m.recorder
 }
 
+// BuildReport mocks base method.
+func (m *MockMiner) BuildReport(arg0 uint64) (*work.BuildReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildReport", arg0)
+	ret0, _ := ret[0].(*work.BuildReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuildReport indicates an expected call of BuildReport.
+func (mr *MockMinerMockRecorder) BuildReport(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildReport", reflect.TypeOf((*MockMiner)(nil).BuildReport), arg0)
+}
+
 // HashRate mocks base method.
 func (m *MockMiner) HashRate() int64 {
 	m.ctrl.T.Helper()
@@ -139,6 +155,18 @@ func (mr *MockMinerMockRecorder) RegisterTxBundlingModule(arg0 ...interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTxBundlingModule", reflect.TypeOf((*MockMiner)(nil).RegisterTxBundlingModule), arg0...)
 }
 
+// SetBuildReportLimit mocks base method.
+func (m *MockMiner) SetBuildReportLimit(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBuildReportLimit", arg0)
+}
+
+// SetBuildReportLimit indicates an expected call of SetBuildReportLimit.
+func (mr *MockMinerMockRecorder) SetBuildReportLimit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBuildReportLimit", reflect.TypeOf((*MockMiner)(nil).SetBuildReportLimit), arg0)
+}
+
 // SetExtra mocks base method.
 func (m *MockMiner) SetExtra(arg0 []byte) error {
 	m.ctrl.T.Helper()
@@ -153,6 +181,18 @@ func (mr *MockMinerMockRecorder) SetExtra(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExtra", reflect.TypeOf((*MockMiner)(nil).SetExtra), arg0)
 }
 
+// SetTxOrderingPolicy mocks base method.
+func (m *MockMiner) SetTxOrderingPolicy(arg0 work.TxOrderingPolicy) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTxOrderingPolicy", arg0)
+}
+
+// SetTxOrderingPolicy indicates an expected call of SetTxOrderingPolicy.
+func (mr *MockMinerMockRecorder) SetTxOrderingPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTxOrderingPolicy", reflect.TypeOf((*MockMiner)(nil).SetTxOrderingPolicy), arg0)
+}
+
 // Start mocks base method.
 func (m *MockMiner) Start() {
 	m.ctrl.T.Helper()
@@ -176,3 +216,18 @@ func (mr *MockMinerMockRecorder) Stop() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockMiner)(nil).Stop))
 }
+
+// TxBuildHistory mocks base method.
+func (m *MockMiner) TxBuildHistory(arg0 common.Hash) ([]*work.TxBuildRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxBuildHistory", arg0)
+	ret0, _ := ret[0].([]*work.TxBuildRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TxBuildHistory indicates an expected call of TxBuildHistory.
+func (mr *MockMinerMockRecorder) TxBuildHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxBuildHistory", reflect.TypeOf((*MockMiner)(nil).TxBuildHistory), arg0)
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/datasync/downloader"
 	"github.com/kaiachain/kaia/kaiax/auction"
+	"github.com/kaiachain/kaia/kaiax/privatetx"
 	"github.com/kaiachain/kaia/networks/p2p"
 	"github.com/kaiachain/kaia/networks/p2p/discover"
 	"github.com/kaiachain/kaia/node/cn/snap"
@@ -131,6 +132,10 @@ type Peer interface {
 	// AsyncSendTransactions sends transactions asynchronously to the peer.
 	AsyncSendTransactions(txs types.Transactions)
 
+	// SendPrivateTxs sends private transactions to the peer and includes the hashes
+	// in its transaction hash set for future reference.
+	SendPrivateTxs(txs []*privatetx.PrivateTx) error
+
 	// SendNewBlockHashes announces the availability of a number of blocks through
 	// a hash notification.
 	SendNewBlockHashes(hashes []common.Hash, numbers []uint64) error
@@ -365,6 +370,9 @@ var ChannelOfMessage = map[uint64]int{
 	// Protocol messages belonging to kaia/67
 	BlobSidecarsRequestMsg: p2p.ConnDefault,
 	BlobSidecarsMsg:        p2p.ConnDefault,
+
+	// Protocol messages belonging to kaia/68
+	PrivateTxMsg: p2p.ConnTxMsg,
 }
 
 var ConcurrentOfChannel = []int{
@@ -517,6 +525,15 @@ func (p *basePeer) SendTransactions(txs types.Transactions) error {
 	return p2p.Send(p.rw, TxMsg, txs)
 }
 
+// SendPrivateTxs sends private transactions to the peer and includes the hashes
+// in its transaction hash set for future reference.
+func (p *basePeer) SendPrivateTxs(txs []*privatetx.PrivateTx) error {
+	for _, ptx := range txs {
+		p.AddToKnownTxs(ptx.Tx.Hash())
+	}
+	return p2p.Send(p.rw, PrivateTxMsg, txs)
+}
+
 // ReSendTransactions sends txs to a peer in order to prevent the txs from missing.
 func (p *basePeer) ReSendTransactions(txs types.Transactions) error {
 	return p2p.Send(p.rw, TxMsg, txs)
@@ -952,6 +969,15 @@ func (p *multiChannelPeer) SendTransactions(txs types.Transactions) error {
 	return p.msgSender(TxMsg, txs)
 }
 
+// SendPrivateTxs sends private transactions to the peer and includes the hashes
+// in its transaction hash set for future reference.
+func (p *multiChannelPeer) SendPrivateTxs(txs []*privatetx.PrivateTx) error {
+	for _, ptx := range txs {
+		p.AddToKnownTxs(ptx.Tx.Hash())
+	}
+	return p.msgSender(PrivateTxMsg, txs)
+}
+
 // ReSendTransactions sends txs to a peer in order to prevent the txs from missing.
 func (p *multiChannelPeer) ReSendTransactions(txs types.Transactions) error {
 	return p.msgSender(TxMsg, txs)
@@ -12,6 +12,7 @@ import (
 	types "github.com/kaiachain/kaia/blockchain/types"
 	common "github.com/kaiachain/kaia/common"
 	auction "github.com/kaiachain/kaia/kaiax/auction"
+	privatetx "github.com/kaiachain/kaia/kaiax/privatetx"
 	p2p "github.com/kaiachain/kaia/networks/p2p"
 	discover "github.com/kaiachain/kaia/networks/p2p/discover"
 	snap "github.com/kaiachain/kaia/node/cn/snap"
@@ -708,6 +709,20 @@ func (mr *MockPeerMockRecorder) SendNodeData(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendNodeData", reflect.TypeOf((*MockPeer)(nil).SendNodeData), arg0)
 }
 
+// SendPrivateTxs mocks base method.
+func (m *MockPeer) SendPrivateTxs(arg0 []*privatetx.PrivateTx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPrivateTxs", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPrivateTxs indicates an expected call of SendPrivateTxs.
+func (mr *MockPeerMockRecorder) SendPrivateTxs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPrivateTxs", reflect.TypeOf((*MockPeer)(nil).SendPrivateTxs), arg0)
+}
+
 // SendReceiptsRLP mocks base method.
 func (m *MockPeer) SendReceiptsRLP(arg0 []rlp.RawValue) error {
 	m.ctrl.T.Helper()
@@ -11,6 +11,7 @@ import (
 	types "github.com/kaiachain/kaia/blockchain/types"
 	common "github.com/kaiachain/kaia/common"
 	auction "github.com/kaiachain/kaia/kaiax/auction"
+	privatetx "github.com/kaiachain/kaia/kaiax/privatetx"
 	staking "github.com/kaiachain/kaia/kaiax/staking"
 	p2p "github.com/kaiachain/kaia/networks/p2p"
 )
@@ -118,6 +119,18 @@ func (mr *MockBackendProtocolManagerMockRecorder) RegisterAuctionModule(arg0 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterAuctionModule", reflect.TypeOf((*MockBackendProtocolManager)(nil).RegisterAuctionModule), arg0)
 }
 
+// RegisterPrivateTxModule mocks base method.
+func (m *MockBackendProtocolManager) RegisterPrivateTxModule(arg0 privatetx.PrivateTxModule) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterPrivateTxModule", arg0)
+}
+
+// RegisterPrivateTxModule indicates an expected call of RegisterPrivateTxModule.
+func (mr *MockBackendProtocolManagerMockRecorder) RegisterPrivateTxModule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPrivateTxModule", reflect.TypeOf((*MockBackendProtocolManager)(nil).RegisterPrivateTxModule), arg0)
+}
+
 // RegisterStakingModule mocks base method.
 func (m *MockBackendProtocolManager) RegisterStakingModule(arg0 staking.StakingModule) {
 	m.ctrl.T.Helper()
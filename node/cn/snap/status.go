@@ -0,0 +1,265 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/event"
+)
+
+// statusInterval is the minimum time between two status snapshots published
+// to the subscribers.
+const statusInterval = 3 * time.Second
+
+// Sync phases reported in SyncStatus.
+const (
+	PhaseIdle = "idle" // No sync cycle has been run yet
+	PhaseSync = "sync" // Downloading accounts, storage slots and bytecodes
+	PhaseHeal = "heal" // Healing the trie boundaries of the downloaded state
+	PhaseDone = "done" // The state of the last sync root is complete
+)
+
+// capacityKinds maps the name reported for a peer capacity to the msgrate kind.
+var capacityKinds = map[string]uint64{
+	"accountRange":  AccountRangeMsg,
+	"storageRanges": StorageRangesMsg,
+	"byteCodes":     ByteCodesMsg,
+	"trieNodes":     TrieNodesMsg,
+}
+
+// SyncStatus is a detailed report of a snap sync, meant to tell whether a slow
+// sync is limited by the peers or by the local disk. Durations are in seconds.
+type SyncStatus struct {
+	Root  common.Hash `json:"root"`
+	Phase string      `json:"phase"`
+
+	Elapsed  float64 `json:"elapsed"`  // Time since the first sync cycle started
+	Progress float64 `json:"progress"` // Estimated percentage of the state downloaded in the sync phase
+	ETA      float64 `json:"eta"`      // Estimated time left in the sync phase, zero if unknown
+
+	Downloaded  common.StorageSize `json:"downloaded"`  // Bytes persisted over all the phases
+	ProcessTime float64            `json:"processTime"` // Time spent validating and persisting responses
+
+	Accounts     TaskStatus `json:"accounts"`
+	Storage      TaskStatus `json:"storage"`
+	Bytecodes    TaskStatus `json:"bytecodes"`
+	TrienodeHeal HealStatus `json:"trienodeHeal"`
+	BytecodeHeal HealStatus `json:"bytecodeHeal"`
+
+	TargetRTT float64       `json:"targetRTT"` // Round trip time the peer capacities are measured against
+	Peers     []*PeerStatus `json:"peers"`
+}
+
+// TaskStatus reports the progress of one kind of data in the sync phase.
+type TaskStatus struct {
+	Synced   uint64             `json:"synced"`   // Number of items downloaded
+	Bytes    common.StorageSize `json:"bytes"`    // Number of bytes persisted
+	Pending  int                `json:"pending"`  // Number of tasks left
+	Requests int                `json:"requests"` // Number of requests in flight
+}
+
+// HealStatus reports the progress of one kind of data in the heal phase.
+type HealStatus struct {
+	Synced   uint64             `json:"synced"`   // Number of items downloaded
+	Bytes    common.StorageSize `json:"bytes"`    // Number of bytes persisted
+	Pending  int                `json:"pending"`  // Number of items queued for retrieval
+	Requests int                `json:"requests"` // Number of requests in flight
+	Dups     uint64             `json:"dups"`     // Number of items already processed
+	Nops     uint64             `json:"nops"`     // Number of items not requested
+}
+
+// PeerStatus reports the throughput of a snap peer. Capacities are the number
+// of items the peer is expected to deliver within the target round trip time.
+type PeerStatus struct {
+	ID        string         `json:"id"`
+	Stateless bool           `json:"stateless"` // Peer refused to serve the current root
+	Capacity  map[string]int `json:"capacity"`
+}
+
+// Status returns the latest snapshot of the sync progress together with the
+// current capacity of every peer.
+func (s *Syncer) Status() *SyncStatus {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	status := &SyncStatus{Phase: PhaseIdle}
+	if s.extStatus != nil {
+		*status = *s.extStatus
+	}
+	status.TargetRTT = s.rates.TargetRoundTrip().Seconds()
+	status.Peers = s.peerStatuses()
+	return status
+}
+
+// SubscribeStatus registers a subscription for the status snapshots published
+// while syncing.
+func (s *Syncer) SubscribeStatus(ch chan<- *SyncStatus) event.Subscription {
+	return s.statusFeed.Subscribe(ch)
+}
+
+// peerStatuses returns the status of every registered peer sorted by id. The
+// caller must hold the lock.
+func (s *Syncer) peerStatuses() []*PeerStatus {
+	targetRTT := s.rates.TargetRoundTrip()
+	peers := make([]*PeerStatus, 0, len(s.peers))
+	for id := range s.peers {
+		_, stateless := s.statelessPeers[id]
+		peer := &PeerStatus{ID: id, Stateless: stateless, Capacity: make(map[string]int, len(capacityKinds))}
+		for name, kind := range capacityKinds {
+			peer.Capacity[name] = s.rates.Capacity(id, kind, targetRTT)
+		}
+		peers = append(peers, peer)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].ID < peers[j].ID })
+	return peers
+}
+
+// updateStatus takes a new status snapshot and publishes it to the subscribers
+// without waiting for them, at most once per statusInterval unless forced. It
+// must only be called from the sync cycle since it reads the sync progress
+// without locking.
+func (s *Syncer) updateStatus(force bool) {
+	if !force && time.Since(s.statusTime) < statusInterval {
+		return
+	}
+	s.statusTime = time.Now()
+
+	status := &SyncStatus{
+		Root:        s.root,
+		Phase:       PhaseDone,
+		ProcessTime: s.processTime.Seconds(),
+		Downloaded: s.accountBytes + s.bytecodeBytes + s.storageBytes +
+			s.trienodeHealBytes + s.bytecodeHealBytes,
+		Accounts:  TaskStatus{Synced: s.accountSynced, Bytes: s.accountBytes, Pending: len(s.tasks)},
+		Storage:   TaskStatus{Synced: s.storageSynced, Bytes: s.storageBytes},
+		Bytecodes: TaskStatus{Synced: s.bytecodeSynced, Bytes: s.bytecodeBytes},
+		TrienodeHeal: HealStatus{
+			Synced: s.trienodeHealSynced, Bytes: s.trienodeHealBytes,
+			Dups: s.trienodeHealDups, Nops: s.trienodeHealNops,
+		},
+		BytecodeHeal: HealStatus{
+			Synced: s.bytecodeHealSynced, Bytes: s.bytecodeHealBytes,
+			Dups: s.bytecodeHealDups, Nops: s.bytecodeHealNops,
+		},
+	}
+	if !s.startTime.IsZero() {
+		status.Elapsed = time.Since(s.startTime).Seconds()
+	}
+	for _, task := range s.tasks {
+		status.Storage.Pending += len(task.stateTasks)
+		for _, subtasks := range task.SubTasks {
+			status.Storage.Pending += len(subtasks)
+		}
+		status.Bytecodes.Pending += len(task.codeTasks)
+	}
+	switch {
+	case len(s.tasks) > 0:
+		status.Phase = PhaseSync
+		if synced, estBytes := s.estimateSyncedState(); estBytes >= 1.0 {
+			elapsed := time.Since(s.startTime)
+			status.Progress = float64(synced) * 100 / estBytes
+			status.ETA = estimateRemainingTime(elapsed, synced, estBytes).Seconds()
+		}
+	case s.healer != nil && s.healer.scheduler.Pending() > 0:
+		status.Phase = PhaseHeal
+		status.Progress = 100
+	default:
+		status.Progress = 100
+	}
+	if s.healer != nil {
+		status.TrienodeHeal.Pending = len(s.healer.trieTasks)
+		status.BytecodeHeal.Pending = len(s.healer.codeTasks)
+	}
+
+	s.lock.Lock()
+	status.Accounts.Requests = len(s.accountReqs)
+	status.Storage.Requests = len(s.storageReqs)
+	status.Bytecodes.Requests = len(s.bytecodeReqs)
+	status.TrienodeHeal.Requests = len(s.trienodeHealReqs)
+	status.BytecodeHeal.Requests = len(s.bytecodeHealReqs)
+	s.extStatus = status
+	published := *status
+	published.TargetRTT = s.rates.TargetRoundTrip().Seconds()
+	published.Peers = s.peerStatuses()
+
+	// A slow subscriber must not stall the sync, so the snapshots are published by
+	// a goroutine, dropping the ones superseded before they could be sent.
+	s.nextStatus = &published
+	if !s.publishing {
+		s.publishing = true
+		go s.publishStatus()
+	}
+	s.lock.Unlock()
+}
+
+// publishStatus sends the pending status snapshots to the subscribers until none is left.
+func (s *Syncer) publishStatus() {
+	for {
+		s.lock.Lock()
+		status := s.nextStatus
+		s.nextStatus = nil
+		if status == nil {
+			s.publishing = false
+		}
+		s.lock.Unlock()
+
+		if status == nil {
+			return
+		}
+		s.statusFeed.Send(status)
+	}
+}
+
+// trackProcessTime accumulates the time spent on integrating a response since
+// the given start.
+func (s *Syncer) trackProcessTime(start time.Time) {
+	s.processTime += time.Since(start)
+}
+
+// estimateSyncedState returns the number of state bytes persisted in the sync
+// phase and the estimated size of the whole state, extrapolated from the filled
+// portion of the account hash space. The estimate is zero until some accounts
+// have been filled.
+func (s *Syncer) estimateSyncedState() (common.StorageSize, float64) {
+	synced := s.accountBytes + s.bytecodeBytes + s.storageBytes
+	if synced == 0 {
+		return 0, 0
+	}
+	accountGaps := new(big.Int)
+	for _, task := range s.tasks {
+		accountGaps.Add(accountGaps, new(big.Int).Sub(task.Last.Big(), task.Next.Big()))
+	}
+	accountFills := new(big.Int).Sub(hashSpace, accountGaps)
+	if accountFills.BitLen() == 0 {
+		return synced, 0
+	}
+	estBytes := float64(new(big.Int).Div(
+		new(big.Int).Mul(new(big.Int).SetUint64(uint64(synced)), hashSpace),
+		accountFills,
+	).Uint64())
+	return synced, estBytes
+}
+
+// estimateRemainingTime extrapolates the time left to persist the estimated
+// state size at the rate seen so far.
+func estimateRemainingTime(elapsed time.Duration, synced common.StorageSize, estBytes float64) time.Duration {
+	return elapsed/time.Duration(synced)*time.Duration(estBytes) - elapsed
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/kaiachain/kaia/common/math"
 	"github.com/kaiachain/kaia/event"
 	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/networks/p2p"
 	"github.com/kaiachain/kaia/networks/p2p/msgrate"
 	"github.com/kaiachain/kaia/rlp"
 	"github.com/kaiachain/kaia/storage/database"
@@ -379,6 +380,19 @@ type SyncPeer interface {
 	Log() log.Logger
 }
 
+// reputationReporter is implemented by the sync peers whose reputation can be
+// lowered on misbehaviour. Peers that don't implement it are never reported.
+type reputationReporter interface {
+	Report(ev p2p.ReputationEvent)
+}
+
+// reportPeer lowers the reputation of the peer if it supports it.
+func reportPeer(peer SyncPeer, ev p2p.ReputationEvent) {
+	if r, ok := peer.(reputationReporter); ok {
+		r.Report(ev)
+	}
+}
+
 // Syncer is an Kaia account and storage trie syncer based on snapshots and
 // the snap protocol. It's purpose is to download all the accounts and storage
 // slots from remote peers and reassemble chunks of the state trie, on top of
@@ -422,6 +436,12 @@ type Syncer struct {
 	storageBytes   common.StorageSize // Number of storage trie bytes persisted to disk
 
 	extProgress *SyncProgress // progress that can be exposed to external caller.
+	extStatus   *SyncStatus   // Latest detailed status snapshot exposed to external callers
+	statusTime  time.Time     // Time instance when the status snapshot was last taken
+	statusFeed  event.Feed    // Event feed publishing the status snapshots
+	nextStatus  *SyncStatus   // Status snapshot waiting to be published, if any
+	publishing  bool          // Whether a goroutine is publishing the status snapshots
+	processTime time.Duration // Time spent on integrating responses into the database
 
 	// Request tracking during healing phase
 	trienodeHealIdlers map[string]struct{} // Peers that aren't serving trie node requests
@@ -563,6 +583,7 @@ func (s *Syncer) Sync(root common.Hash, cancel chan struct{}) error {
 	s.loadSyncStatus()
 	if len(s.tasks) == 0 && s.healer.scheduler.Pending() == 0 {
 		logger.Debug("Snapshot sync already completed")
+		s.updateStatus(true)
 		return nil
 	}
 	defer func() { // Persist any progress, independent of failure
@@ -584,6 +605,7 @@ func (s *Syncer) Sync(root common.Hash, cancel chan struct{}) error {
 		s.stateWriter.Release()
 	}()
 	defer s.report(true)
+	defer s.updateStatus(true)
 
 	// Whether sync completed or not, disregard any future packets
 	defer func() {
@@ -652,6 +674,8 @@ func (s *Syncer) Sync(root common.Hash, cancel chan struct{}) error {
 			BytecodeHealBytes:  s.bytecodeHealBytes,
 		}
 		s.lock.Unlock()
+		s.updateStatus(false)
+
 		// Wait for something to happen
 		select {
 		case <-s.update:
@@ -1736,6 +1760,8 @@ func (s *Syncer) revertBytecodeHealRequest(req *bytecodeHealRequest) {
 // processAccountResponse integrates an already validated account range response
 // into the account tasks.
 func (s *Syncer) processAccountResponse(res *accountResponse) {
+	defer s.trackProcessTime(time.Now())
+
 	// Switch the task from pending to filling
 	res.task.req = nil
 	res.task.res = res
@@ -1824,6 +1850,8 @@ func (s *Syncer) processAccountResponse(res *accountResponse) {
 // processBytecodeResponse integrates an already validated bytecode response
 // into the account tasks.
 func (s *Syncer) processBytecodeResponse(res *bytecodeResponse) {
+	defer s.trackProcessTime(time.Now())
+
 	batch := s.db.NewBatch(database.StateTrieDB)
 	defer batch.Release()
 
@@ -1870,6 +1898,8 @@ func (s *Syncer) processBytecodeResponse(res *bytecodeResponse) {
 // processStorageResponse integrates an already validated storage response
 // into the account tasks.
 func (s *Syncer) processStorageResponse(res *storageResponse) {
+	defer s.trackProcessTime(time.Now())
+
 	// Switch the subtask from pending to idle
 	if res.subTask != nil {
 		res.subTask.req = nil
@@ -2071,6 +2101,8 @@ func (s *Syncer) processStorageResponse(res *storageResponse) {
 // processTrienodeHealResponse integrates an already validated trienode response
 // into the healer tasks.
 func (s *Syncer) processTrienodeHealResponse(res *trienodeHealResponse) {
+	defer s.trackProcessTime(time.Now())
+
 	for i, hash := range res.hashes {
 		node := res.nodes[i]
 
@@ -2108,6 +2140,8 @@ func (s *Syncer) processTrienodeHealResponse(res *trienodeHealResponse) {
 // processBytecodeHealResponse integrates an already validated bytecode response
 // into the healer tasks.
 func (s *Syncer) processBytecodeHealResponse(res *bytecodeHealResponse) {
+	defer s.trackProcessTime(time.Now())
+
 	for i, hash := range res.hashes {
 		node := res.codes[i]
 
@@ -2260,6 +2294,7 @@ func (s *Syncer) OnAccounts(peer SyncPeer, id uint64, hashes []common.Hash, acco
 	if len(hashes) == 0 && len(accounts) == 0 && len(proof) == 0 {
 		logger.Debug("Peer rejected account range request", "root", s.root)
 		s.statelessPeers[peer.ID()] = struct{}{}
+		reportPeer(peer, p2p.RepUselessResponse)
 		s.lock.Unlock()
 
 		// Signal this request as failed, and ready for rescheduling
@@ -2286,6 +2321,7 @@ func (s *Syncer) OnAccounts(peer SyncPeer, id uint64, hashes []common.Hash, acco
 	}
 	cont, err := statedb.VerifyRangeProof(root, req.origin[:], end, keys, accounts, proofdb)
 	if err != nil {
+		reportPeer(peer, p2p.RepInvalidResponse)
 		logger.Warn("Account range failed proof", "err", err)
 		// Signal this request as failed, and ready for rescheduling
 		s.scheduleRevertAccountRequest(req)
@@ -2372,6 +2408,7 @@ func (s *Syncer) onByteCodes(peer SyncPeer, id uint64, bytecodes [][]byte) error
 	if len(bytecodes) == 0 {
 		logger.Debug("Peer rejected bytecode request")
 		s.statelessPeers[peer.ID()] = struct{}{}
+		reportPeer(peer, p2p.RepUselessResponse)
 		s.lock.Unlock()
 
 		// Signal this request as failed, and ready for rescheduling
@@ -2404,6 +2441,7 @@ func (s *Syncer) onByteCodes(peer SyncPeer, id uint64, bytecodes [][]byte) error
 		logger.Warn("Unexpected bytecodes", "count", len(bytecodes)-i)
 		// Signal this request as failed, and ready for rescheduling
 		s.scheduleRevertBytecodeRequest(req)
+		reportPeer(peer, p2p.RepInvalidResponse)
 		return errors.New("unexpected bytecode")
 	}
 	// Response validated, send it to the scheduler for filling
@@ -2481,12 +2519,14 @@ func (s *Syncer) OnStorage(peer SyncPeer, id uint64, hashes [][]common.Hash, slo
 		s.lock.Unlock()
 		s.scheduleRevertStorageRequest(req) // reschedule request
 		logger.Warn("Hash and slot set size mismatch", "hashset", len(hashes), "slotset", len(slots))
+		reportPeer(peer, p2p.RepInvalidResponse)
 		return errors.New("hash and slot set size mismatch")
 	}
 	if len(hashes) > len(req.accounts) {
 		s.lock.Unlock()
 		s.scheduleRevertStorageRequest(req) // reschedule request
 		logger.Warn("Hash set larger than requested", "hashset", len(hashes), "requested", len(req.accounts))
+		reportPeer(peer, p2p.RepInvalidResponse)
 		return errors.New("hash set larger than requested")
 	}
 	// Response is valid, but check if peer is signalling that it does not have
@@ -2496,6 +2536,7 @@ func (s *Syncer) OnStorage(peer SyncPeer, id uint64, hashes [][]common.Hash, slo
 	if len(hashes) == 0 && len(proof) == 0 {
 		logger.Debug("Peer rejected storage request")
 		s.statelessPeers[peer.ID()] = struct{}{}
+		reportPeer(peer, p2p.RepUselessResponse)
 		s.lock.Unlock()
 		s.scheduleRevertStorageRequest(req) // reschedule request
 		return nil
@@ -2532,6 +2573,7 @@ func (s *Syncer) OnStorage(peer SyncPeer, id uint64, hashes [][]common.Hash, slo
 			_, err = statedb.VerifyRangeProof(req.roots[i], nil, nil, keys, slots[i], nil)
 			if err != nil {
 				s.scheduleRevertStorageRequest(req) // reschedule request
+				reportPeer(peer, p2p.RepInvalidResponse)
 				logger.Warn("Storage slots failed proof", "err", err)
 				return err
 			}
@@ -2547,6 +2589,7 @@ func (s *Syncer) OnStorage(peer SyncPeer, id uint64, hashes [][]common.Hash, slo
 			cont, err = statedb.VerifyRangeProof(req.roots[i], req.origin[:], end, keys, slots[i], proofdb)
 			if err != nil {
 				s.scheduleRevertStorageRequest(req) // reschedule request
+				reportPeer(peer, p2p.RepInvalidResponse)
 				logger.Warn("Storage range failed proof", "err", err)
 				return err
 			}
@@ -2616,6 +2659,7 @@ func (s *Syncer) OnTrieNodes(peer SyncPeer, id uint64, trienodes [][]byte) error
 	if len(trienodes) == 0 {
 		logger.Debug("Peer rejected trienode heal request")
 		s.statelessPeers[peer.ID()] = struct{}{}
+		reportPeer(peer, p2p.RepUselessResponse)
 		s.lock.Unlock()
 
 		// Signal this request as failed, and ready for rescheduling
@@ -2648,6 +2692,7 @@ func (s *Syncer) OnTrieNodes(peer SyncPeer, id uint64, trienodes [][]byte) error
 		logger.Warn("Unexpected healing trienodes", "count", len(trienodes)-i)
 		// Signal this request as failed, and ready for rescheduling
 		s.scheduleRevertTrienodeHealRequest(req)
+		reportPeer(peer, p2p.RepInvalidResponse)
 		return errors.New("unexpected healing trienode")
 	}
 	// Response validated, send it to the scheduler for filling
@@ -2711,6 +2756,7 @@ func (s *Syncer) onHealByteCodes(peer SyncPeer, id uint64, bytecodes [][]byte) e
 	if len(bytecodes) == 0 {
 		logger.Debug("Peer rejected bytecode heal request")
 		s.statelessPeers[peer.ID()] = struct{}{}
+		reportPeer(peer, p2p.RepUselessResponse)
 		s.lock.Unlock()
 
 		// Signal this request as failed, and ready for rescheduling
@@ -2743,6 +2789,7 @@ func (s *Syncer) onHealByteCodes(peer SyncPeer, id uint64, bytecodes [][]byte) e
 		logger.Warn("Unexpected healing bytecodes", "count", len(bytecodes)-i)
 		// Signal this request as failed, and ready for rescheduling
 		s.scheduleRevertBytecodeHealRequest(req)
+		reportPeer(peer, p2p.RepInvalidResponse)
 		return errors.New("unexpected healing bytecode")
 	}
 	// Response validated, send it to the scheduler for filling
@@ -2800,29 +2847,12 @@ func (s *Syncer) reportSyncProgress(force bool) {
 		return
 	}
 	// Don't report anything until we have a meaningful progress
-	synced := s.accountBytes + s.bytecodeBytes + s.storageBytes
-	if synced == 0 {
-		return
-	}
-	accountGaps := new(big.Int)
-	for _, task := range s.tasks {
-		accountGaps.Add(accountGaps, new(big.Int).Sub(task.Last.Big(), task.Next.Big()))
-	}
-	accountFills := new(big.Int).Sub(hashSpace, accountGaps)
-	if accountFills.BitLen() == 0 {
-		return
-	}
-	s.logTime = time.Now()
-	estBytes := float64(new(big.Int).Div(
-		new(big.Int).Mul(new(big.Int).SetUint64(uint64(synced)), hashSpace),
-		accountFills,
-	).Uint64())
-	// Don't report anything until we have a meaningful progress
+	synced, estBytes := s.estimateSyncedState()
 	if estBytes < 1.0 {
 		return
 	}
+	s.logTime = time.Now()
 	elapsed := time.Since(s.startTime)
-	estTime := elapsed / time.Duration(synced) * time.Duration(estBytes)
 
 	// Create a mega progress report
 	var (
@@ -2832,7 +2862,7 @@ func (s *Syncer) reportSyncProgress(force bool) {
 		bytecode = fmt.Sprintf("%v@%v", s.bytecodeSynced, s.bytecodeBytes.TerminalString())
 	)
 	logger.Info("State sync in progress", "synced", progress, "state", synced,
-		"accounts", accounts, "slots", storage, "codes", bytecode, "eta", common.PrettyDuration(estTime-elapsed))
+		"accounts", accounts, "slots", storage, "codes", bytecode, "eta", common.PrettyDuration(estimateRemainingTime(elapsed, synced, estBytes)))
 }
 
 // reportHealProgress calculates various status reports and provides it to the user.
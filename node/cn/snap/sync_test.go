@@ -776,6 +776,66 @@ func TestSyncWithStorage(t *testing.T) {
 	verifyTrie(syncer.db, sourceAccountTrie.Hash(), t)
 }
 
+// TestSyncStatus tests that the detailed status reflects a completed sync and
+// is published to the subscribers.
+func TestSyncStatus(t *testing.T) {
+	t.Parallel()
+
+	var (
+		once   sync.Once
+		cancel = make(chan struct{})
+		term   = func() {
+			once.Do(func() {
+				close(cancel)
+			})
+		}
+	)
+	sourceAccountTrie, elems, storageTries, storageElems := makeAccountTrieWithStorage(3, 3000, true, false, false)
+
+	source := newTestPeer("source", t, term)
+	source.accountTrie = sourceAccountTrie
+	source.accountValues = elems
+	source.storageTries = storageTries
+	source.storageValues = storageElems
+
+	syncer := setupSyncer(source)
+	if status := syncer.Status(); status.Phase != PhaseIdle || len(status.Peers) != 1 || status.Peers[0].ID != "source" {
+		t.Fatalf("unexpected status before sync: %+v", status)
+	}
+	statuses := make(chan *SyncStatus, 16)
+	sub := syncer.SubscribeStatus(statuses)
+	defer sub.Unsubscribe()
+
+	done := checkStall(t, term)
+	if err := syncer.Sync(sourceAccountTrie.Hash(), cancel); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	close(done)
+
+	status := syncer.Status()
+	if status.Phase != PhaseDone || status.Root != sourceAccountTrie.Hash() || status.Progress != 100 {
+		t.Fatalf("unexpected status after sync: phase %v, root %x, progress %v", status.Phase, status.Root, status.Progress)
+	}
+	if status.Accounts.Synced != uint64(len(elems)) || status.Storage.Synced == 0 || status.Downloaded == 0 {
+		t.Fatalf("unexpected sync counters: accounts %+v, storage %+v, downloaded %v", status.Accounts, status.Storage, status.Downloaded)
+	}
+	if status.Accounts.Pending != 0 || status.Accounts.Requests != 0 {
+		t.Fatalf("tasks left after sync: %+v", status.Accounts)
+	}
+	if len(status.Peers) != 1 || len(status.Peers[0].Capacity) != len(capacityKinds) {
+		t.Fatalf("unexpected peer status: %+v", status.Peers)
+	}
+	// The final status is always published
+	timeout := time.After(5 * time.Second)
+	for last := (*SyncStatus)(nil); last == nil || last.Phase != PhaseDone; {
+		select {
+		case last = <-statuses:
+		case <-timeout:
+			t.Fatalf("final status not published: %+v", last)
+		}
+	}
+}
+
 // TestMultiSyncManyUseless contains one good peer, and many which doesn't return anything valuable at all
 func TestMultiSyncManyUseless(t *testing.T) {
 	t.Parallel()
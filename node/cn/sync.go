@@ -56,6 +56,7 @@ func (pm *ProtocolManager) syncTransactions(p Peer) {
 	for _, batch := range pending {
 		txs = append(txs, batch...)
 	}
+	txs = pm.withoutPrivateTxs(txs)
 	if len(txs) == 0 {
 		return
 	}
@@ -24,7 +24,10 @@ package node
 
 import (
 	"crypto/ecdsa"
+	crand "crypto/rand"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -33,6 +36,7 @@ import (
 	"github.com/kaiachain/kaia/accounts"
 	"github.com/kaiachain/kaia/accounts/keystore"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/crypto/bls"
 	"github.com/kaiachain/kaia/log"
@@ -49,6 +53,7 @@ const (
 	datadirStaticNodes     = "static-nodes.json"  // Path within the datadir to the static node list
 	datadirTrustedNodes    = "trusted-nodes.json" // Path within the datadir to the trusted node list
 	datadirNodeDatabase    = "nodes"              // Path within the datadir to store the node infos
+	datadirJWTSecret       = "jwtsecret"          // Path within the datadir to the secret of the authenticated RPC server
 )
 
 // Config represents a small collection of configuration values to fine tune the
@@ -180,6 +185,26 @@ type Config struct {
 	// ephemeral nodes).
 	GRPCPort int `toml:",omitempty"`
 
+	// AuthHost is the host interface on which to start the JWT authenticated HTTP and
+	// websocket RPC server. If this field is empty, no authenticated endpoint will be
+	// started.
+	AuthHost string `toml:",omitempty"`
+
+	// AuthPort is the TCP port number on which to start the authenticated RPC server.
+	AuthPort int `toml:",omitempty"`
+
+	// AuthVirtualHosts is the list of virtual hostnames which are allowed on incoming
+	// requests to the authenticated RPC server.
+	AuthVirtualHosts []string `toml:",omitempty"`
+
+	// AuthModules is a list of API modules to expose via the authenticated RPC server.
+	// Unlike the other endpoints, modules restricted to IPC are exposed as well.
+	AuthModules []string `toml:",omitempty"`
+
+	// JWTSecret is the path to the hex encoded HS256 secret of the authenticated RPC
+	// server. If the file does not exist, a new secret is generated into it.
+	JWTSecret string `toml:",omitempty"`
+
 	// UpstreamArchiveEN is an archive mode EN endpoint
 	UpstreamArchiveEN string
 
@@ -282,6 +307,15 @@ func DefaultGRPCEndpoint() string {
 	return config.GRPCEndpoint()
 }
 
+// AuthEndpoint resolves the endpoint of the authenticated RPC server based on the
+// configured host interface and port parameters.
+func (c *Config) AuthEndpoint() string {
+	if c.AuthHost == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", c.AuthHost, c.AuthPort)
+}
+
 // NodeName returns the devp2p node identifier.
 func (c *Config) NodeName() string {
 	name := c.name()
@@ -511,3 +545,37 @@ func makeAccountManager(conf *Config) (*accounts.Manager, string, error) {
 	}
 	return accounts.NewManager(backends...), ephemeral, nil
 }
+
+// AuthSecret loads the HS256 secret of the authenticated RPC server from JWTSecret,
+// falling back to the one in the data folder. If the file does not exist, a new secret
+// is generated and stored.
+func (c *Config) AuthSecret() ([]byte, error) {
+	path := c.JWTSecret
+	if path == "" {
+		path = datadirJWTSecret
+	}
+	if path = c.ResolvePath(path); path == "" {
+		return nil, errors.New("no jwt secret path for an ephemeral node")
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		secret := common.FromHex(strings.TrimSpace(string(data)))
+		if len(secret) != rpc.JWTSecretLength {
+			return nil, fmt.Errorf("invalid jwt secret in %s, want %d hex encoded bytes", path, rpc.JWTSecretLength)
+		}
+		return secret, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	secret := make([]byte, rpc.JWTSecretLength)
+	if _, err := crand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, []byte(hexutil.Encode(secret)), 0o600); err != nil {
+		return nil, err
+	}
+	logger.Warn("Generated jwt secret", "path", path)
+	return secret, nil
+}
@@ -31,6 +31,7 @@ import (
 
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/networks/p2p"
+	"github.com/kaiachain/kaia/networks/rpc"
 )
 
 // Tests that datadirs can be successfully created, be them manually configured
@@ -156,3 +157,37 @@ func TestNodeKeyPersistency(t *testing.T) {
 		}
 	*/
 }
+
+// Tests that the jwt secret of the authenticated RPC server is generated once and
+// loaded afterwards, and that malformed secrets are rejected.
+func TestAuthSecret(t *testing.T) {
+	dir := t.TempDir()
+
+	config := &Config{Name: "unit-test", DataDir: dir}
+	secret1, err := config.AuthSecret()
+	if err != nil {
+		t.Fatalf("failed to generate jwt secret: %v", err)
+	}
+	if len(secret1) != rpc.JWTSecretLength {
+		t.Fatalf("jwt secret length mismatch: have %d, want %d", len(secret1), rpc.JWTSecretLength)
+	}
+	secret2, err := config.AuthSecret()
+	if err != nil {
+		t.Fatalf("failed to load persisted jwt secret: %v", err)
+	}
+	if !bytes.Equal(secret1, secret2) {
+		t.Fatalf("persisted jwt secret mismatch: have %x, want %x", secret2, secret1)
+	}
+
+	path := filepath.Join(dir, "secret")
+	if err := os.WriteFile(path, []byte("0x1234\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config = &Config{Name: "unit-test", DataDir: dir, JWTSecret: path}
+	if _, err := config.AuthSecret(); err == nil {
+		t.Fatalf("short jwt secret accepted")
+	}
+	if _, err := (&Config{Name: "unit-test"}).AuthSecret(); err == nil {
+		t.Fatalf("jwt secret of an ephemeral node accepted")
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"maps"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -77,15 +78,20 @@ type Node struct {
 	ipcListener net.Listener // IPC RPC listener socket to serve API requests
 	ipcHandler  *rpc.Server  // IPC RPC request handler to process the API requests
 
-	httpEndpoint  string       // HTTP endpoint (interface + port) to listen at (empty = HTTP disabled)
-	httpWhitelist []string     // HTTP RPC modules to allow through this endpoint
-	httpListener  net.Listener // HTTP RPC listener socket to server API requests
-	httpHandler   *rpc.Server  // HTTP RPC request handler to process the API requests
+	httpEndpoint  string                  // HTTP endpoint (interface + port) to listen at (empty = HTTP disabled)
+	httpWhitelist []string                // HTTP RPC modules to allow through this endpoint
+	httpListener  net.Listener            // HTTP RPC listener socket to server API requests
+	httpHandler   *rpc.Server             // HTTP RPC request handler to process the API requests
+	httpPaths     map[string]http.Handler // Service handlers served on the HTTP endpoint next to the API
 
 	wsEndpoint string       // Websocket endpoint (interface + port) to listen at (empty = websocket disabled)
 	wsListener net.Listener // Websocket RPC listener socket to server API requests
 	wsHandler  *rpc.Server  // Websocket RPC request handler to process the API requests
 
+	authEndpoint string       // Authenticated RPC endpoint (interface + port) to listen at (empty = disabled)
+	authListener net.Listener // Authenticated HTTP and websocket RPC listener socket
+	authHandler  *rpc.Server  // Authenticated RPC request handler to process the API requests
+
 	grpcEndpoint string         // gRPC endpoint (interface + port) to listen at (empty = gRPC disabled)
 	grpcListener *grpc.Listener // gRPC listener socket to server API requests
 	grpcHandler  *rpc.Server    // gRPC request handler to process the API requests
@@ -143,6 +149,7 @@ func New(conf *Config) (*Node, error) {
 		httpEndpoint:      conf.HTTPEndpoint(),
 		wsEndpoint:        conf.WSEndpoint(),
 		grpcEndpoint:      conf.GRPCEndpoint(),
+		authEndpoint:      conf.AuthEndpoint(),
 		eventmux:          new(event.TypeMux),
 		logger:            conf.Logger,
 	}, nil
@@ -340,8 +347,21 @@ func (n *Node) openDataDir() error {
 // assumptions about the state of the node.
 func (n *Node) startRPC(services map[reflect.Type]Service) error {
 	apis := n.apis()
+	n.httpPaths = make(map[string]http.Handler)
+	var grpcServices []GRPCService
 	for _, service := range services {
 		apis = append(apis, service.APIs()...)
+		if s, ok := service.(GRPCService); ok {
+			grpcServices = append(grpcServices, s)
+		}
+		if s, ok := service.(HTTPHandlerService); ok {
+			for path, handler := range s.HTTPHandlers() {
+				if _, exists := n.httpPaths[path]; exists {
+					return fmt.Errorf("duplicate HTTP handler for path %q", path)
+				}
+				n.httpPaths[path] = handler
+			}
+		}
 	}
 	// Start the various API endpoints, terminating all in case of errors
 	if err := n.startInProc(apis); err != nil {
@@ -363,9 +383,18 @@ func (n *Node) startRPC(services map[reflect.Type]Service) error {
 		n.stopInProc()
 		return err
 	}
+	if err := n.startAuth(n.authEndpoint, apis); err != nil {
+		n.stopWS()
+		n.stopHTTP()
+		n.stopIPC()
+		n.stopInProc()
+		return err
+	}
 
 	// start gRPC server
-	if err := n.startgRPC(apis); err != nil {
+	if err := n.startgRPC(apis, grpcServices); err != nil {
+		n.stopAuth()
+		n.stopWS()
 		n.stopHTTP()
 		n.stopIPC()
 		n.stopInProc()
@@ -429,7 +458,7 @@ func (n *Node) stopIPC() {
 }
 
 // startgRPC initializes and starts the gRPC endpoint.
-func (n *Node) startgRPC(apis []rpc.API) error {
+func (n *Node) startgRPC(apis []rpc.API, services []GRPCService) error {
 	if n.grpcEndpoint == "" {
 		return nil
 	}
@@ -448,6 +477,9 @@ func (n *Node) startgRPC(apis []rpc.API) error {
 	n.grpcHandler = handler
 	n.grpcListener = listener
 	listener.SetRPCServer(handler)
+	for _, service := range services {
+		service.RegisterGRPC(listener)
+	}
 
 	go listener.Start()
 	n.logger.Info("gRPC endpoint opened", "url", n.grpcEndpoint)
@@ -460,7 +492,7 @@ func (n *Node) startHTTP(endpoint string, apis []rpc.API, modules []string, cors
 	if endpoint == "" {
 		return nil
 	}
-	listener, handler, err := rpc.StartHTTPEndpoint(endpoint, apis, modules, cors, vhosts, timeouts)
+	listener, handler, err := rpc.StartHTTPEndpoint(endpoint, apis, modules, cors, vhosts, timeouts, n.httpPaths)
 	if err != nil {
 		return err
 	}
@@ -520,6 +552,43 @@ func (n *Node) stopWS() {
 	}
 }
 
+// startAuth initializes and starts the JWT authenticated RPC endpoint.
+func (n *Node) startAuth(endpoint string, apis []rpc.API) error {
+	// Short circuit if the authenticated endpoint isn't being exposed
+	if endpoint == "" {
+		return nil
+	}
+	secret, err := n.config.AuthSecret()
+	if err != nil {
+		return err
+	}
+	listener, handler, err := rpc.StartAuthEndpoint(endpoint, apis, n.config.AuthModules, n.config.AuthVirtualHosts, n.config.HTTPTimeouts, secret)
+	if err != nil {
+		return err
+	}
+	n.logger.Info("Authenticated RPC endpoint opened", "url", fmt.Sprintf("http://%s", listener.Addr()), "modules", strings.Join(n.config.AuthModules, ","))
+	// All listeners booted successfully
+	n.authEndpoint = endpoint
+	n.authListener = listener
+	n.authHandler = handler
+
+	return nil
+}
+
+// stopAuth terminates the authenticated RPC endpoint.
+func (n *Node) stopAuth() {
+	if n.authListener != nil {
+		n.authListener.Close()
+		n.authListener = nil
+
+		n.logger.Info("Authenticated RPC endpoint closed", "url", fmt.Sprintf("http://%s", n.authEndpoint))
+	}
+	if n.authHandler != nil {
+		n.authHandler.Stop()
+		n.authHandler = nil
+	}
+}
+
 func (n *Node) stopgRPC() {
 	if n.grpcListener != nil {
 		n.grpcListener.Stop()
@@ -546,6 +615,7 @@ func (n *Node) Stop() error {
 	}
 
 	// Terminate the API, services and the p2p server.
+	n.stopAuth()
 	n.stopWS()
 	n.stopHTTP()
 	n.stopIPC()
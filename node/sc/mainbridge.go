@@ -343,6 +343,11 @@ func (mb *MainBridge) Start(srvr p2p.Server) error {
 		return errors.New("fail to bridgeserver start")
 	}
 
+	// Advertise the bridge in the node record of the main network
+	if err := srvr.AddRecordService(discover.ServiceBridge); err != nil {
+		return err
+	}
+
 	// Start the RPC service
 	mb.netRPCService = api.NewNetAPI(mb.bridgeServer, mb.NetVersion())
 
@@ -101,6 +101,16 @@ type BridgeTxPool interface {
 	Stop()
 }
 
+// bridgeOriginTxPool journals the transactions sent through the local backend
+// as bridge transactions.
+type bridgeOriginTxPool struct {
+	*blockchain.TxPool
+}
+
+func (p *bridgeOriginTxPool) AddLocal(tx *types.Transaction) error {
+	return p.AddLocalWithOrigin(tx, blockchain.TxOriginBridge)
+}
+
 // SubBridge implements the Kaia consensus node service.
 type SubBridge struct {
 	config *SCConfig
@@ -369,7 +379,7 @@ func (sb *SubBridge) SetComponents(components []interface{}) {
 	}
 
 	es := filters.NewEventSystem(sb.eventMux, &filterLocalBackend{sb})
-	sb.localBackend = backends.NewBlockchainContractBackend(sb.blockchain, sb.txPool, es)
+	sb.localBackend = backends.NewBlockchainContractBackend(sb.blockchain, &bridgeOriginTxPool{sb.txPool}, es)
 
 	sb.bridgeManager, err = NewBridgeManager(sb)
 	if err != nil {
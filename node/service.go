@@ -24,6 +24,7 @@ package node
 
 import (
 	"crypto/ecdsa"
+	"net/http"
 	"reflect"
 
 	"github.com/kaiachain/kaia/accounts"
@@ -33,6 +34,7 @@ import (
 	"github.com/kaiachain/kaia/networks/p2p"
 	"github.com/kaiachain/kaia/networks/rpc"
 	"github.com/kaiachain/kaia/storage/database"
+	"google.golang.org/grpc"
 )
 
 type ServiceContext struct {
@@ -87,6 +89,22 @@ func (ctx *ServiceContext) NodeType() common.ConnType {
 	return ctx.config.P2P.ConnectionType
 }
 
+// HTTPHandlerService is implemented by the services that serve their own HTTP paths,
+// such as GraphQL, on the HTTP-RPC endpoint next to the JSON-RPC API.
+type HTTPHandlerService interface {
+	// HTTPHandlers returns the handlers to serve, keyed by path. It is called once the
+	// services are started.
+	HTTPHandlers() map[string]http.Handler
+}
+
+// GRPCService is implemented by the services that serve typed gRPC services on the
+// gRPC endpoint next to the JSON-RPC tunnel.
+type GRPCService interface {
+	// RegisterGRPC registers the gRPC services. It is called once the services are
+	// started, before the gRPC endpoint is opened.
+	RegisterGRPC(s grpc.ServiceRegistrar)
+}
+
 // ServiceConstructor is the function signature of the constructors needed to be
 // registered for service instantiation.
 type ServiceConstructor func(ctx *ServiceContext) (Service, error)
@@ -33,6 +33,7 @@ import (
 	"github.com/kaiachain/kaia/blockchain/system"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
 	"github.com/kaiachain/kaia/consensus/istanbul"
 	uniswapFactoryContracts "github.com/kaiachain/kaia/contracts/contracts/libs/uniswap/factory"
 	uniswapRouterContracts "github.com/kaiachain/kaia/contracts/contracts/libs/uniswap/router"
@@ -40,6 +41,8 @@ import (
 	gaslessContract "github.com/kaiachain/kaia/contracts/contracts/system_contracts/kip247"
 	testingContracts "github.com/kaiachain/kaia/contracts/contracts/testing/system_contracts"
 	testingGaslessContracts "github.com/kaiachain/kaia/contracts/contracts/testing/system_contracts/gasless"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/kaiax/gasless"
 	gaslessImpl "github.com/kaiachain/kaia/kaiax/gasless/impl"
 	"github.com/kaiachain/kaia/log"
 	"github.com/kaiachain/kaia/networks/rpc"
@@ -253,6 +256,9 @@ func TestGasless(t *testing.T) {
 	_, err = sendSwapTx(t, gsrContract, accounts[0], testTokenAddr, swapAmmount, minAmountOut, amountRepaySwap, common.Big1)
 	assert.ErrorContains(t, err, "insufficient deadline: deadline=1")
 
+	//// The quoted swapTx is accepted and succeeds.
+	testQuote(t, node.BlockChain(), contracts, accounts[0], swapAmmount)
+
 	// reject swapTx originating from an EOA with code
 	sendSetCodeTx(t, chain, transactor, accounts[0])
 	_, err = sendSwapTx(t, gsrContract, accounts[0], testTokenAddr, swapAmmount, minAmountOut, amountRepaySwap, deadline)
@@ -489,6 +495,44 @@ func sendSwapTx(t *testing.T, gsrContract *gaslessContract.GaslessSwapRouter, ow
 	return swapTx, nil
 }
 
+// testQuote sends a swapTx built from debug_gaslessQuote and checks that it succeeds.
+func testQuote(t *testing.T, bc backends.BlockChainForCaller, contracts contractsForGasless, sender *TestAccountType, swapAmount *big.Int) {
+	g := gaslessImpl.NewGaslessModule()
+	nodeKey, _ := crypto.GenerateKey()
+	require.NoError(t, g.Init(&gaslessImpl.InitOpts{
+		ChainConfig:   bc.Config(),
+		GaslessConfig: gasless.DefaultGaslessConfig(),
+		NodeKey:       nodeKey,
+		Chain:         bc,
+		NodeType:      common.ENDPOINTNODE,
+	}))
+	api := gaslessImpl.NewGaslessAPI(g)
+
+	// amountIn not enough to cover the repay amount is rejected.
+	_, err := api.GaslessQuote(context.Background(), gaslessImpl.GaslessQuoteArgs{From: sender.Addr, Token: contracts.testTokenAddr, AmountIn: (*hexutil.Big)(bigGkei)})
+	assert.ErrorContains(t, err, "insufficient amountIn")
+
+	quote, err := api.GaslessQuote(context.Background(), gaslessImpl.GaslessQuoteArgs{From: sender.Addr, Token: contracts.testTokenAddr, AmountIn: (*hexutil.Big)(swapAmount)})
+	require.NoError(t, err)
+	require.Nil(t, quote.ApproveGas, "already approved")
+	require.Equal(t, contracts.gsrAddr, quote.SwapRouter)
+	require.Equal(t, sender.Nonce, uint64(quote.SwapNonce))
+	require.True(t, quote.MinAmountIn.ToInt().Cmp(swapAmount) <= 0)
+
+	opts := bind.NewKeyedTransactor(sender.Keys[0])
+	opts.GasLimit = uint64(quote.SwapGas)
+	opts.GasPrice = quote.GasPrice.ToInt()
+	opts.Nonce = new(big.Int).SetUint64(uint64(quote.SwapNonce))
+	swapTx, err := contracts.gsrContract.SwapForGas(opts, contracts.testTokenAddr, swapAmount, quote.MinAmountOut.ToInt(), quote.RepayAmount.ToInt(), quote.Deadline.ToInt())
+	require.NoError(t, err)
+	sender.Nonce += 1
+
+	receipt := waitReceipt(bc.(*blockchain.BlockChain), swapTx.Hash())
+	require.NotNil(t, receipt)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "quoted swapTx failed")
+	require.True(t, receipt.GasUsed <= uint64(quote.SwapGas))
+}
+
 func sendSetCodeTx(t *testing.T, chain *blockchain.BlockChain, transactor bind.ContractBackend, sender *TestAccountType) {
 	chainID, err := transactor.ChainID(context.Background())
 	require.NoError(t, err)
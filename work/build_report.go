@@ -0,0 +1,323 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package work
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/kaiachain/kaia/work/builder"
+)
+
+const (
+	BuildStatusIncluded = "included"
+	BuildStatusSkipped  = "skipped"
+	// BuildStatusUnexecuted means the candidate was not tried at all.
+	BuildStatusUnexecuted = "unexecuted"
+)
+
+// Reasons why a candidate is skipped or left unexecuted.
+const (
+	BuildReasonBelowBaseFee       = "below base fee"
+	BuildReasonFilteredByModule   = "filtered by tx bundling module"
+	BuildReasonInvalidTarget      = "bundle target tx not satisfied"
+	BuildReasonTxGeneration       = "tx generation error"
+	BuildReasonNoBlobSpace        = "not enough blob space"
+	BuildReasonNonceTooLow        = "nonce too low"
+	BuildReasonNonceTooHigh       = "nonce too high"
+	BuildReasonTxTypeNotSupported = "tx type not supported"
+	BuildReasonBundleReverted     = "bundle reverted"
+	BuildReasonBlockSizeLimit     = "block size limit"
+	BuildReasonTimeLimit          = "block generation time limit"
+	BuildReasonDependency         = "preceding tx of the same sender or bundle was skipped"
+)
+
+var (
+	ErrBuildReportDisabled = errors.New("block build report is disabled")
+	ErrBuildReportNotFound = errors.New("block build report not found")
+
+	buildReportPrefix = []byte("blockBuildReport")
+)
+
+// BuildCandidate is a transaction considered during block building.
+type BuildCandidate struct {
+	// Position in the execution order, i.e. sorted by price and nonce with the bundles incorporated.
+	// -1 if the candidate was filtered out before ordering.
+	Position int             `json:"position"`
+	Hash     common.Hash     `json:"hash"` // tx hash, or the id of the tx generator
+	Sender   *common.Address `json:"sender,omitempty"`
+	Nonce    *hexutil.Uint64 `json:"nonce,omitempty"`
+	Bundle   int             `json:"bundle"` // index in BuildReport.Bundles, or -1 if not bundled
+	Status   string          `json:"status"`
+	Reason   string          `json:"reason,omitempty"`
+}
+
+// BuildBundle is a bundle extracted by the tx bundling modules.
+type BuildBundle struct {
+	TargetTxHash   common.Hash   `json:"targetTxHash"`
+	TargetRequired bool          `json:"targetRequired"`
+	Txs            []common.Hash `json:"txs"`
+	// Conflict is true if the bundle was dropped because it conflicts with a previous bundle.
+	Conflict bool `json:"conflict"`
+}
+
+// BuildReport explains how the local node built the block of the given number.
+// The block may not be the canonical one if another proposer's block was committed.
+type BuildReport struct {
+	Number     uint64            `json:"number"`
+	ParentHash common.Hash       `json:"parentHash"`
+	BaseFee    *hexutil.Big      `json:"baseFee,omitempty"`
+	Candidates []*BuildCandidate `json:"candidates"`
+	Bundles    []*BuildBundle    `json:"bundles"`
+	// StopReason is set if block building stopped before trying all candidates.
+	StopReason string `json:"stopReason,omitempty"`
+
+	signer types.Signer
+	byId   map[common.Hash]*BuildCandidate
+}
+
+// TxBuildRecord is the result of a transaction in the block build report of the block Number.
+type TxBuildRecord struct {
+	Number uint64 `json:"number"`
+	*BuildCandidate
+}
+
+func newBuildReport(number uint64, parentHash common.Hash, signer types.Signer) *BuildReport {
+	return &BuildReport{
+		Number:     number,
+		ParentHash: parentHash,
+		Candidates: []*BuildCandidate{},
+		Bundles:    []*BuildBundle{},
+		signer:     signer,
+		byId:       make(map[common.Hash]*BuildCandidate),
+	}
+}
+
+// The methods below are no-op on a nil report, so that the block building does not need to check
+// whether the report is enabled.
+
+func (r *BuildReport) newCandidate(tx *types.Transaction, position int) *BuildCandidate {
+	c := &BuildCandidate{Position: position, Hash: tx.Hash(), Bundle: -1}
+	if from, err := types.Sender(r.signer, tx); err == nil {
+		c.Sender = &from
+	}
+	nonce := hexutil.Uint64(tx.Nonce())
+	c.Nonce = &nonce
+	return c
+}
+
+// addFiltered records the transactions in `before` that are missing in `after`.
+func (r *BuildReport) addFiltered(before, after map[common.Address]types.Transactions, reason string) {
+	if r == nil {
+		return
+	}
+	remaining := make(map[common.Hash]struct{})
+	for _, txs := range after {
+		for _, tx := range txs {
+			remaining[tx.Hash()] = struct{}{}
+		}
+	}
+	for _, txs := range before {
+		for _, tx := range txs {
+			if _, ok := remaining[tx.Hash()]; ok {
+				continue
+			}
+			if _, ok := r.byId[tx.Hash()]; ok {
+				continue
+			}
+			c := r.newCandidate(tx, -1)
+			c.Status, c.Reason = BuildStatusSkipped, reason
+			r.Candidates = append(r.Candidates, c)
+			r.byId[c.Hash] = c
+		}
+	}
+}
+
+// addCandidates records the ordered candidates and the extracted bundles.
+func (r *BuildReport) addCandidates(txOrGens []*builder.TxOrGen, bundles, conflicts []*builder.Bundle) {
+	if r == nil {
+		return
+	}
+	for i, txOrGen := range txOrGens {
+		var c *BuildCandidate
+		if txOrGen.IsConcreteTx() {
+			tx, _ := txOrGen.GetTx(0)
+			c = r.newCandidate(tx, i)
+		} else {
+			c = &BuildCandidate{Position: i, Hash: txOrGen.Id, Bundle: -1}
+		}
+		c.Status = BuildStatusUnexecuted
+		r.Candidates = append(r.Candidates, c)
+		r.byId[c.Hash] = c
+	}
+	for _, bundle := range bundles {
+		r.addBundle(bundle, false)
+	}
+	for _, bundle := range conflicts {
+		r.addBundle(bundle, true)
+	}
+}
+
+func (r *BuildReport) addBundle(bundle *builder.Bundle, conflict bool) {
+	idx := len(r.Bundles)
+	b := &BuildBundle{
+		TargetTxHash:   bundle.TargetTxHash,
+		TargetRequired: bundle.TargetRequired,
+		Txs:            make([]common.Hash, 0, len(bundle.BundleTxs)),
+		Conflict:       conflict,
+	}
+	for _, txOrGen := range bundle.BundleTxs {
+		b.Txs = append(b.Txs, txOrGen.Id)
+		if c, ok := r.byId[txOrGen.Id]; ok && !conflict {
+			c.Bundle = idx
+		}
+	}
+	r.Bundles = append(r.Bundles, b)
+}
+
+// mark sets the result of the first `num` candidates in `txOrGens`.
+func (r *BuildReport) mark(txOrGens []*builder.TxOrGen, num int, status, reason string) {
+	if r == nil {
+		return
+	}
+	for _, txOrGen := range txOrGens[:min(num, len(txOrGens))] {
+		if c, ok := r.byId[txOrGen.Id]; ok {
+			c.Status, c.Reason = status, reason
+		}
+	}
+}
+
+// finish sets the reason of the candidates that were never tried. The candidates in `remaining`
+// were left when block building stopped, and the others were removed along with a skipped tx.
+func (r *BuildReport) finish(remaining []*builder.TxOrGen, stopReason string) {
+	if r == nil {
+		return
+	}
+	if len(remaining) > 0 {
+		r.StopReason = stopReason
+		r.mark(remaining, len(remaining), BuildStatusUnexecuted, stopReason)
+	}
+	for _, c := range r.Candidates {
+		if c.Status == BuildStatusUnexecuted && c.Reason == "" {
+			c.Reason = BuildReasonDependency
+		}
+	}
+}
+
+func (self *worker) setBuildReportLimit(limit uint64) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.buildReportLimit = limit
+}
+
+func (self *worker) getBuildReportLimit() uint64 {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return self.buildReportLimit
+}
+
+func (self *worker) buildReport(num uint64) (*BuildReport, error) {
+	if self.getBuildReportLimit() == 0 {
+		return nil, ErrBuildReportDisabled
+	}
+	report := ReadBuildReport(self.chainDB.GetMiscDB(), num)
+	if report == nil {
+		return nil, ErrBuildReportNotFound
+	}
+	return report, nil
+}
+
+// txBuildHistory scans the reports of the recent blocks, including the one being built, from the newest.
+func (self *worker) txBuildHistory(hash common.Hash) ([]*TxBuildRecord, error) {
+	limit := self.getBuildReportLimit()
+	if limit == 0 {
+		return nil, ErrBuildReportDisabled
+	}
+
+	var (
+		db      = self.chainDB.GetMiscDB()
+		next    = self.chain.CurrentBlock().NumberU64() + 1
+		records = []*TxBuildRecord{}
+	)
+	for num := next; num > 0 && next-num < limit; num-- {
+		report := ReadBuildReport(db, num)
+		if report == nil {
+			continue
+		}
+		for _, c := range report.Candidates {
+			if c.Hash == hash {
+				records = append(records, &TxBuildRecord{Number: num, BuildCandidate: c})
+				break
+			}
+		}
+	}
+	return records, nil
+}
+
+func buildReportKey(num uint64) []byte {
+	return append(common.CopyBytes(buildReportPrefix), common.Int64ToByteBigEndian(num)...)
+}
+
+func ReadBuildReport(db database.Database, num uint64) *BuildReport {
+	b, err := db.Get(buildReportKey(num))
+	if err != nil || len(b) == 0 {
+		return nil
+	}
+	report := new(BuildReport)
+	if err := json.Unmarshal(b, report); err != nil {
+		logger.Error("Malformed block build report", "num", num, "err", err)
+		return nil
+	}
+	return report
+}
+
+func WriteBuildReport(db database.Database, report *BuildReport) {
+	b, err := json.Marshal(report)
+	if err != nil {
+		logger.Crit("Failed to encode block build report", "num", report.Number, "err", err)
+	}
+	if err := db.Put(buildReportKey(report.Number), b); err != nil {
+		logger.Crit("Failed to write block build report", "num", report.Number, "err", err)
+	}
+}
+
+func DeleteBuildReport(db database.Database, num uint64) {
+	if err := db.Delete(buildReportKey(num)); err != nil {
+		logger.Crit("Failed to delete block build report", "num", num, "err", err)
+	}
+}
+
+// DeleteBuildReportsBefore deletes all block build reports below the given block number.
+func DeleteBuildReportsBefore(db database.Database, num uint64) {
+	it := db.NewIterator(buildReportPrefix, nil)
+	defer it.Release()
+
+	limit := buildReportKey(num)
+	for it.Next() {
+		if bytes.Compare(it.Key(), limit) >= 0 {
+			break
+		}
+		if err := db.Delete(common.CopyBytes(it.Key())); err != nil {
+			logger.Crit("Failed to delete block build report", "key", it.Key(), "err", err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package work
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/kaiachain/kaia/work/builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildReport(t *testing.T) {
+	var (
+		signer  = types.LatestSignerForChainID(common.Big1)
+		key, _  = crypto.GenerateKey()
+		from    = crypto.PubkeyToAddress(key.PublicKey)
+		txs     = make([]*types.Transaction, 5)
+		gen     = builder.NewTxOrGenFromGen(func(uint64) (*types.Transaction, error) { return nil, nil }, common.Hash{1})
+		baseFee = big.NewInt(25)
+	)
+	for i := range txs {
+		txs[i], _ = types.SignTx(types.NewTransaction(uint64(i), common.Address{}, common.Big1, 21000, baseFee, nil), signer, key)
+	}
+
+	report := newBuildReport(10, common.Hash{0xa}, signer)
+
+	// txs[4] is filtered out before ordering.
+	report.addFiltered(
+		map[common.Address]types.Transactions{from: txs},
+		map[common.Address]types.Transactions{from: txs[:4]},
+		BuildReasonFilteredByModule,
+	)
+
+	// gen and txs[1] are bundled after txs[0].
+	var (
+		bundle   = builder.NewBundle([]*builder.TxOrGen{gen, builder.NewTxOrGenFromTx(txs[1])}, txs[0].Hash(), false)
+		conflict = builder.NewBundle(builder.NewTxOrGenList(txs[2]), txs[0].Hash(), false)
+		ordered  = []*builder.TxOrGen{builder.NewTxOrGenFromTx(txs[0]), gen, builder.NewTxOrGenFromTx(txs[1]), builder.NewTxOrGenFromTx(txs[2]), builder.NewTxOrGenFromTx(txs[3])}
+	)
+	report.addCandidates(ordered, []*builder.Bundle{bundle}, []*builder.Bundle{conflict})
+
+	report.mark(ordered, 1, BuildStatusIncluded, "")
+	report.mark(ordered[1:], 2, BuildStatusSkipped, BuildReasonBundleReverted)
+	// txs[2] is removed along with the bundle, and the block is full before txs[3].
+	report.finish(ordered[4:], BuildReasonBlockSizeLimit)
+
+	expected := []struct {
+		hash     common.Hash
+		position int
+		bundle   int
+		status   string
+		reason   string
+	}{
+		{txs[4].Hash(), -1, -1, BuildStatusSkipped, BuildReasonFilteredByModule},
+		{txs[0].Hash(), 0, -1, BuildStatusIncluded, ""},
+		{gen.Id, 1, 0, BuildStatusSkipped, BuildReasonBundleReverted},
+		{txs[1].Hash(), 2, 0, BuildStatusSkipped, BuildReasonBundleReverted},
+		{txs[2].Hash(), 3, -1, BuildStatusUnexecuted, BuildReasonDependency},
+		{txs[3].Hash(), 4, -1, BuildStatusUnexecuted, BuildReasonBlockSizeLimit},
+	}
+	require.Len(t, report.Candidates, len(expected))
+	for i, e := range expected {
+		c := report.Candidates[i]
+		assert.Equal(t, e.hash, c.Hash, i)
+		assert.Equal(t, e.position, c.Position, i)
+		assert.Equal(t, e.bundle, c.Bundle, i)
+		assert.Equal(t, e.status, c.Status, i)
+		assert.Equal(t, e.reason, c.Reason, i)
+	}
+	assert.Nil(t, report.Candidates[2].Sender)
+	assert.Equal(t, from, *report.Candidates[1].Sender)
+	assert.Equal(t, BuildReasonBlockSizeLimit, report.StopReason)
+	require.Len(t, report.Bundles, 2)
+	assert.False(t, report.Bundles[0].Conflict)
+	assert.True(t, report.Bundles[1].Conflict)
+
+	// Persistence
+	db := database.NewMemDB()
+	assert.Nil(t, ReadBuildReport(db, 10))
+	WriteBuildReport(db, report)
+	stored := ReadBuildReport(db, 10)
+	require.NotNil(t, stored)
+	assert.Equal(t, report.Candidates, stored.Candidates)
+	assert.Equal(t, report.Bundles, stored.Bundles)
+	DeleteBuildReport(db, 10)
+	assert.Nil(t, ReadBuildReport(db, 10))
+
+	// Range pruning removes every report below the bound, including non-contiguous ones.
+	for _, num := range []uint64{3, 7, 8, 20} {
+		WriteBuildReport(db, &BuildReport{Number: num})
+	}
+	DeleteBuildReportsBefore(db, 8)
+	assert.Nil(t, ReadBuildReport(db, 3))
+	assert.Nil(t, ReadBuildReport(db, 7))
+	assert.NotNil(t, ReadBuildReport(db, 8))
+	assert.NotNil(t, ReadBuildReport(db, 20))
+
+	// Nil report does nothing.
+	var nilReport *BuildReport
+	nilReport.addCandidates(ordered, nil, nil)
+	nilReport.mark(ordered, 1, BuildStatusIncluded, "")
+	nilReport.finish(ordered, BuildReasonTimeLimit)
+}
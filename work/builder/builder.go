@@ -268,14 +268,22 @@ type TxBundlingModule interface {
 }
 
 func ExtractBundlesAndIncorporate(arrayTxs []*types.Transaction, txBundlingModules []TxBundlingModule) ([]*TxOrGen, []*Bundle) {
+	incorporatedTxs, bundles, _ := ExtractBundlesAndIncorporateWithConflicts(arrayTxs, txBundlingModules)
+	return incorporatedTxs, bundles
+}
+
+// ExtractBundlesAndIncorporateWithConflicts is ExtractBundlesAndIncorporate that also returns
+// the bundles dropped because they conflict with a previously extracted bundle.
+func ExtractBundlesAndIncorporateWithConflicts(arrayTxs []*types.Transaction, txBundlingModules []TxBundlingModule) ([]*TxOrGen, []*Bundle, []*Bundle) {
 	// Detect bundles and add them to bundles
 	bundles := []*Bundle{}
+	conflicts := []*Bundle{}
 	flattenedTxs := []*TxOrGen{}
 	if txBundlingModules == nil {
 		for _, tx := range arrayTxs {
 			flattenedTxs = append(flattenedTxs, NewTxOrGenFromTx(tx))
 		}
-		return flattenedTxs, nil
+		return flattenedTxs, nil, nil
 	}
 
 	for _, txBundlingModule := range txBundlingModules {
@@ -289,6 +297,9 @@ func ExtractBundlesAndIncorporate(arrayTxs []*types.Transaction, txBundlingModul
 					break
 				}
 			}
+			if isConflict {
+				conflicts = append(conflicts, newBundle)
+			}
 			// Not allowing empty bundles
 			if !isConflict && len(newBundle.BundleTxs) > 0 {
 				bundles = append(bundles, newBundle)
@@ -303,10 +314,10 @@ func ExtractBundlesAndIncorporate(arrayTxs []*types.Transaction, txBundlingModul
 
 	incorporatedTxs, err := IncorporateBundleTx(arrayTxs, bundles)
 	if err != nil {
-		return flattenedTxs, nil
+		return flattenedTxs, nil, conflicts
 	}
 
-	return incorporatedTxs, bundles
+	return incorporatedTxs, bundles, conflicts
 }
 
 func FilterTxs(txs map[common.Address]types.Transactions, txBundlingModules []TxBundlingModule) {
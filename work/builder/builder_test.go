@@ -160,6 +160,43 @@ func TestExtractBundles(t *testing.T) {
 	}
 }
 
+type testBundlingModule struct {
+	bundles []*Bundle
+}
+
+func (m *testBundlingModule) ExtractTxBundles(txs []*types.Transaction, prevBundles []*Bundle) []*Bundle {
+	return m.bundles
+}
+
+func (m *testBundlingModule) FilterTxs(txs map[common.Address]types.Transactions) {}
+
+func TestExtractBundlesAndIncorporateWithConflicts(t *testing.T) {
+	txs := make([]*types.Transaction, 4)
+	for i := range txs {
+		txs[i] = types.NewTransaction(uint64(i), common.Address{}, common.Big0, 0, common.Big0, nil)
+	}
+
+	var (
+		b0       = NewBundle(NewTxOrGenList(txs[1], txs[2]), txs[0].Hash(), false)
+		conflict = NewBundle(NewTxOrGenList(txs[2]), txs[1].Hash(), false)
+		modules  = []TxBundlingModule{
+			&testBundlingModule{bundles: []*Bundle{b0}},
+			&testBundlingModule{bundles: []*Bundle{conflict}},
+		}
+	)
+
+	incorporated, bundles, conflicts := ExtractBundlesAndIncorporateWithConflicts(txs, modules)
+	assert.Len(t, incorporated, 4)
+	assert.Equal(t, []*Bundle{b0}, bundles)
+	assert.Equal(t, []*Bundle{conflict}, conflicts)
+
+	// Without modules, nothing is bundled.
+	incorporated, bundles, conflicts = ExtractBundlesAndIncorporateWithConflicts(txs, nil)
+	assert.Len(t, incorporated, 4)
+	assert.Empty(t, bundles)
+	assert.Empty(t, conflicts)
+}
+
 func TestIncorporate(t *testing.T) {
 	// Create test transactions
 	txs := []*types.Transaction{
@@ -31,6 +31,10 @@ type Bundle struct {
 	// and only if the target tx is successfully executed.
 	TargetRequired bool
 
+	// RevertingTxs is the ids of bundle txs that may fail without discarding the bundle.
+	// A failed tx in this set is included in the block with a failed receipt.
+	RevertingTxs map[common.Hash]bool
+
 	// lookup map for O(1) membership checks (lazy initialized)
 	txLookup map[common.Hash]int
 }
@@ -68,6 +72,11 @@ func (b *Bundle) FindIdx(id common.Hash) int {
 	return -1
 }
 
+// CanRevert returns if the tx with the given id may fail without discarding the bundle.
+func (b *Bundle) CanRevert(id common.Hash) bool {
+	return b.RevertingTxs[id]
+}
+
 // IsConflict checks if newBundle conflicts with current bundle.
 func (b *Bundle) IsConflict(newBundle *Bundle) bool {
 	// 1. Check for same target tx hash and both are required
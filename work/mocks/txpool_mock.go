@@ -53,6 +53,20 @@ func (mr *MockTxPoolMockRecorder) AddLocal(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLocal", reflect.TypeOf((*MockTxPool)(nil).AddLocal), arg0)
 }
 
+// AddRemotes mocks base method.
+func (m *MockTxPool) AddRemotes(arg0 []*types.Transaction) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRemotes", arg0)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// AddRemotes indicates an expected call of AddRemotes.
+func (mr *MockTxPoolMockRecorder) AddRemotes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRemotes", reflect.TypeOf((*MockTxPool)(nil).AddRemotes), arg0)
+}
+
 // CachedPendingTxsByCount mocks base method.
 func (m *MockTxPool) CachedPendingTxsByCount(arg0 int) types.Transactions {
 	m.ctrl.T.Helper()
@@ -155,15 +169,15 @@ func (mr *MockTxPoolMockRecorder) GetPendingNonce(arg0 interface{}) *gomock.Call
 }
 
 // HandleTxMsg mocks base method.
-func (m *MockTxPool) HandleTxMsg(arg0 types.Transactions) {
+func (m *MockTxPool) HandleTxMsg(arg0, arg1 string, arg2 types.Transactions) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "HandleTxMsg", arg0)
+	m.ctrl.Call(m, "HandleTxMsg", arg0, arg1, arg2)
 }
 
 // HandleTxMsg indicates an expected call of HandleTxMsg.
-func (mr *MockTxPoolMockRecorder) HandleTxMsg(arg0 interface{}) *gomock.Call {
+func (mr *MockTxPoolMockRecorder) HandleTxMsg(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleTxMsg", reflect.TypeOf((*MockTxPool)(nil).HandleTxMsg), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleTxMsg", reflect.TypeOf((*MockTxPool)(nil).HandleTxMsg), arg0, arg1, arg2)
 }
 
 // Pending mocks base method.
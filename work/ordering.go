@@ -0,0 +1,148 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package work
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/work/builder"
+)
+
+const (
+	// TxOrderingPriceAndTime orders the transactions by effective tip, then by arrival time. This is the default.
+	TxOrderingPriceAndTime = "price-time"
+	// TxOrderingFIFO orders the transactions strictly by arrival time, regardless of the price.
+	TxOrderingFIFO = "fifo"
+	// TxOrderingFairShare takes one transaction from each sender in turn, so that a sender with
+	// many pending transactions cannot push back the others.
+	TxOrderingFairShare = "fair-share"
+)
+
+// TxOrderingPolicy decides the execution order of the pending transactions in a block.
+// The bundles from TxBundlingModules are incorporated into the returned order afterwards.
+type TxOrderingPolicy interface {
+	// Order flattens the pending transactions, each list sorted by nonce, into the execution order.
+	// The transactions of a sender must stay in the nonce order. Order may modify `pending`.
+	Order(signer types.Signer, pending map[common.Address]types.Transactions, baseFee *big.Int) []*types.Transaction
+}
+
+// NewTxOrderingPolicy returns the policy of the given name. An empty name selects the default policy.
+func NewTxOrderingPolicy(name string) (TxOrderingPolicy, error) {
+	switch name {
+	case "", TxOrderingPriceAndTime:
+		return &priceAndTimeOrdering{}, nil
+	case TxOrderingFIFO:
+		return &fifoOrdering{}, nil
+	case TxOrderingFairShare:
+		return &fairShareOrdering{}, nil
+	default:
+		return nil, fmt.Errorf("unknown tx ordering policy %q", name)
+	}
+}
+
+type priceAndTimeOrdering struct{}
+
+func (*priceAndTimeOrdering) Order(signer types.Signer, pending map[common.Address]types.Transactions, baseFee *big.Int) []*types.Transaction {
+	return builder.Arrayify(types.NewTransactionsByPriceAndNonce(signer, pending, baseFee))
+}
+
+// txArrivedBefore orders by the arrival time, and by hash for the ones arrived at the same time
+// so that the order does not depend on the map iteration.
+func txArrivedBefore(a, b *types.Transaction) bool {
+	if !a.Time().Equal(b.Time()) {
+		return a.Time().Before(b.Time())
+	}
+	return bytes.Compare(a.Hash().Bytes(), b.Hash().Bytes()) < 0
+}
+
+// senderQueue is a nonce-sorted list of the transactions from a sender.
+type senderQueue types.Transactions
+
+// senderHeap is a min-heap of senders by the arrival time of their next transaction.
+type senderHeap []senderQueue
+
+func (h senderHeap) Len() int            { return len(h) }
+func (h senderHeap) Less(i, j int) bool  { return txArrivedBefore(h[i][0], h[j][0]) }
+func (h senderHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *senderHeap) Push(x interface{}) { *h = append(*h, x.(senderQueue)) }
+func (h *senderHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+type fifoOrdering struct{}
+
+func (*fifoOrdering) Order(signer types.Signer, pending map[common.Address]types.Transactions, baseFee *big.Int) []*types.Transaction {
+	var (
+		heads = make(senderHeap, 0, len(pending))
+		ret   = make([]*types.Transaction, 0, len(pending))
+	)
+	for _, txs := range pending {
+		if len(txs) > 0 {
+			heads = append(heads, senderQueue(txs))
+		}
+	}
+	heap.Init(&heads)
+
+	// A transaction that arrived earlier than its predecessor nonce waits for the predecessor.
+	for len(heads) > 0 {
+		ret = append(ret, heads[0][0])
+		if heads[0] = heads[0][1:]; len(heads[0]) > 0 {
+			heap.Fix(&heads, 0)
+		} else {
+			heap.Pop(&heads)
+		}
+	}
+	return ret
+}
+
+type fairShareOrdering struct{}
+
+func (*fairShareOrdering) Order(signer types.Signer, pending map[common.Address]types.Transactions, baseFee *big.Int) []*types.Transaction {
+	var (
+		queues = make([]senderQueue, 0, len(pending))
+		ret    = make([]*types.Transaction, 0, len(pending))
+	)
+	for _, txs := range pending {
+		if len(txs) > 0 {
+			queues = append(queues, senderQueue(txs))
+		}
+	}
+
+	// In each round, every sender gets one slot, and the slots are ordered by arrival time.
+	for len(queues) > 0 {
+		sort.Slice(queues, func(i, j int) bool { return txArrivedBefore(queues[i][0], queues[j][0]) })
+		next := queues[:0]
+		for _, q := range queues {
+			ret = append(ret, q[0])
+			if len(q) > 1 {
+				next = append(next, q[1:])
+			}
+		}
+		queues = next
+	}
+	return ret
+}
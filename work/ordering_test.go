@@ -0,0 +1,204 @@
+// Copyright 2024 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package work
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/state"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/blockchain/vm"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
+	chain_mock "github.com/kaiachain/kaia/work/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedTx is an entry of a recorded mempool. Arrival is in milliseconds from the start of the recording.
+type recordedTx struct {
+	sender  byte
+	nonce   uint64
+	price   int64
+	arrival int64
+}
+
+// mempoolHarness replays a recorded mempool and builds blocks from it, so that the result of
+// a TxOrderingPolicy can be compared without running a node.
+type mempoolHarness struct {
+	signer  types.Signer
+	senders map[common.Address]byte
+	txs     map[common.Address]types.Transactions
+}
+
+func newMempoolHarness(t *testing.T, records []recordedTx) *mempoolHarness {
+	var (
+		signer = types.LatestSignerForChainID(common.Big1)
+		start  = time.Unix(1700000000, 0)
+		h      = &mempoolHarness{signer: signer, senders: make(map[common.Address]byte), txs: make(map[common.Address]types.Transactions)}
+	)
+	for _, r := range records {
+		key, err := crypto.ToECDSA(common.LeftPadBytes([]byte{r.sender}, 32))
+		require.Nil(t, err)
+		from := crypto.PubkeyToAddress(key.PublicKey)
+
+		tx, err := types.SignTx(types.NewTransaction(r.nonce, common.Address{}, common.Big0, 21000, big.NewInt(r.price), nil), signer, key)
+		require.Nil(t, err)
+		tx.SetTime(start.Add(time.Duration(r.arrival) * time.Millisecond))
+
+		h.senders[from] = r.sender
+		h.txs[from] = append(h.txs[from], tx)
+	}
+	for _, txs := range h.txs {
+		sort.Sort(types.TxByNonce(txs))
+	}
+	return h
+}
+
+func (h *mempoolHarness) label(tx *types.Transaction) string {
+	from, _ := types.Sender(h.signer, tx)
+	return fmt.Sprintf("%c%d", h.senders[from], tx.Nonce())
+}
+
+// pending returns a fresh copy of the remaining transactions like TxPool.Pending().
+func (h *mempoolHarness) pending() map[common.Address]types.Transactions {
+	pending := make(map[common.Address]types.Transactions)
+	for from, txs := range h.txs {
+		if len(txs) > 0 {
+			pending[from] = append(types.Transactions{}, txs...)
+		}
+	}
+	return pending
+}
+
+// buildBlocks orders the remaining transactions, puts up to txsPerBlock of them into a block,
+// and removes them from the mempool until it is empty.
+func (h *mempoolHarness) buildBlocks(policy TxOrderingPolicy, txsPerBlock int) [][]string {
+	blocks := [][]string{}
+	for len(h.pending()) > 0 {
+		ordered := policy.Order(h.signer, h.pending(), nil)
+		block := []string{}
+		for _, tx := range ordered[:min(txsPerBlock, len(ordered))] {
+			from, _ := types.Sender(h.signer, tx)
+			h.txs[from] = h.txs[from][1:]
+			block = append(block, h.label(tx))
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// applyBlock orders the remaining transactions and builds a block from them through
+// ApplyOrderedTransactions. The mocked chain only checks and increments the sender nonce.
+func (h *mempoolHarness) applyBlock(t *testing.T, policy TxOrderingPolicy) []string {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	require.Nil(t, err)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	bc := chain_mock.NewMockBlockChain(mockCtrl)
+	bc.EXPECT().ApplyTransaction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *params.ChainConfig, _ *common.Address, statedb *state.StateDB, _ *types.Header, tx *types.Transaction, usedGas *uint64, _ *vm.Config) (*types.Receipt, *vm.InternalTxTrace, error) {
+			from, _ := types.Sender(h.signer, tx)
+			if nonce := statedb.GetNonce(from); tx.Nonce() < nonce {
+				return nil, nil, blockchain.ErrNonceTooLow
+			} else if tx.Nonce() > nonce {
+				return nil, nil, blockchain.ErrNonceTooHigh
+			}
+			statedb.IncNonce(from)
+			*usedGas += tx.Gas()
+			return &types.Receipt{Status: types.ReceiptStatusSuccessful, GasUsed: tx.Gas()}, nil, nil
+		}).AnyTimes()
+
+	task := NewTask(&params.ChainConfig{ChainID: common.Big1}, h.signer, statedb, &types.Header{Number: common.Big1, BlockScore: common.Big1, Time: common.Big0})
+	task.ApplyOrderedTransactions(policy.Order(h.signer, h.pending(), nil), bc, common.Address{}, nil)
+
+	block := []string{}
+	for _, tx := range task.Transactions() {
+		block = append(block, h.label(tx))
+	}
+	return block
+}
+
+var testRecordedMempool = []recordedTx{
+	// A sends many transactions at a moderate price.
+	{'A', 0, 10, 0}, {'A', 1, 10, 1}, {'A', 2, 10, 2}, {'A', 3, 10, 3},
+	{'B', 0, 50, 4},
+	// C1 arrived before C0, so it has to wait for C0.
+	{'C', 1, 100, 5}, {'C', 0, 5, 6},
+	{'D', 0, 10, 7},
+}
+
+func TestTxOrderingPolicy(t *testing.T) {
+	testcases := []struct {
+		policy  string
+		order   []string
+		blocks3 [][]string
+	}{
+		{
+			TxOrderingPriceAndTime,
+			[]string{"B0", "A0", "A1", "A2", "A3", "D0", "C0", "C1"},
+			[][]string{{"B0", "A0", "A1"}, {"A2", "A3", "D0"}, {"C0", "C1"}},
+		},
+		{
+			TxOrderingFIFO,
+			[]string{"A0", "A1", "A2", "A3", "B0", "C0", "C1", "D0"},
+			[][]string{{"A0", "A1", "A2"}, {"A3", "B0", "C0"}, {"C1", "D0"}},
+		},
+		{
+			TxOrderingFairShare,
+			[]string{"A0", "B0", "C0", "D0", "A1", "C1", "A2", "A3"},
+			[][]string{{"A0", "B0", "C0"}, {"A1", "C1", "D0"}, {"A2", "A3"}},
+		},
+	}
+	for _, tc := range testcases {
+		policy, err := NewTxOrderingPolicy(tc.policy)
+		require.Nil(t, err, tc.policy)
+
+		// The order must not depend on the map iteration order.
+		for i := 0; i < 10; i++ {
+			h := newMempoolHarness(t, testRecordedMempool)
+			order := []string{}
+			for _, tx := range policy.Order(h.signer, h.pending(), nil) {
+				order = append(order, h.label(tx))
+			}
+			assert.Equal(t, tc.order, order, tc.policy)
+		}
+
+		h := newMempoolHarness(t, testRecordedMempool)
+		assert.Equal(t, tc.blocks3, h.buildBlocks(policy, 3), tc.policy)
+
+		// Every transaction is executable, so the block keeps the order of the policy.
+		h = newMempoolHarness(t, testRecordedMempool)
+		assert.Equal(t, tc.order, h.applyBlock(t, policy), tc.policy)
+	}
+
+	policy, err := NewTxOrderingPolicy("")
+	assert.Nil(t, err)
+	assert.IsType(t, &priceAndTimeOrdering{}, policy)
+
+	_, err = NewTxOrderingPolicy("random")
+	assert.NotNil(t, err)
+}
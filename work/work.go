@@ -53,8 +53,8 @@ var logger = log.NewModuleLogger(log.Work)
 //
 //go:generate mockgen -destination=./mocks/txpool_mock.go -package=mocks github.com/kaiachain/kaia/work TxPool
 type TxPool interface {
-	// HandleTxMsg should add the given transactions to the pool.
-	HandleTxMsg(types.Transactions)
+	// HandleTxMsg should add the given transactions received from the peer to the pool.
+	HandleTxMsg(peerID, peerIP string, txs types.Transactions)
 
 	// Pending should return pending transactions.
 	// The slice should be modifiable by the caller.
@@ -68,6 +68,7 @@ type TxPool interface {
 
 	GetPendingNonce(addr common.Address) uint64
 	AddLocal(tx *types.Transaction) error
+	AddRemotes(txs []*types.Transaction) []error
 	GasPrice() *big.Int
 	SetGasPrice(price *big.Int)
 	Stop()
@@ -230,6 +231,26 @@ func (self *Miner) PendingBlock() *types.Block {
 	return self.worker.pendingBlock()
 }
 
+// SetTxOrderingPolicy sets the policy that decides the execution order of the pending transactions.
+func (self *Miner) SetTxOrderingPolicy(policy TxOrderingPolicy) {
+	self.worker.setTxOrderingPolicy(policy)
+}
+
+// SetBuildReportLimit sets the number of recent blocks to keep the block build report. 0 disables the report.
+func (self *Miner) SetBuildReportLimit(limit uint64) {
+	self.worker.setBuildReportLimit(limit)
+}
+
+// BuildReport returns how the local node built the block of the given number.
+func (self *Miner) BuildReport(num uint64) (*BuildReport, error) {
+	return self.worker.buildReport(num)
+}
+
+// TxBuildHistory returns the results of the given transaction in the recent block build reports.
+func (self *Miner) TxBuildHistory(hash common.Hash) ([]*TxBuildRecord, error) {
+	return self.worker.txBuildHistory(hash)
+}
+
 // RegisterExecutionModule registers kaiax.ExecutionModule to underlying worker.
 func (self *Miner) RegisterExecutionModule(modules ...kaiax.ExecutionModule) {
 	self.worker.RegisterExecutionModule(modules...)
@@ -24,6 +24,7 @@ package work
 
 import (
 	"fmt"
+	"maps"
 	"math"
 	"math/big"
 	"strconv"
@@ -36,6 +37,7 @@ import (
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/blockchain/vm"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
 	"github.com/kaiachain/kaia/consensus"
 	"github.com/kaiachain/kaia/consensus/istanbul/core"
 	"github.com/kaiachain/kaia/consensus/misc"
@@ -131,6 +133,8 @@ type Task struct {
 	blobs    int
 
 	createdAt time.Time
+
+	report *BuildReport // nil if the block build report is disabled
 }
 
 // txFitsSize reports whether the transaction fits into the block size limit.
@@ -191,6 +195,11 @@ type worker struct {
 
 	extra []byte
 
+	// The number of recent blocks to keep the block build report. 0 disables the report.
+	buildReportLimit uint64
+
+	txOrderingPolicy TxOrderingPolicy
+
 	currentMu sync.Mutex
 	current   *Task
 	nodeAddr  common.Address
@@ -224,6 +233,8 @@ func newWorker(config *params.ChainConfig, engine consensus.Engine, nodeAddr com
 		nodetype:    nodetype,
 		nodeAddr:    nodeAddr,
 		govModule:   govModule,
+
+		txOrderingPolicy: &priceAndTimeOrdering{},
 	}
 
 	// Subscribe NewTxsEvent for tx pool
@@ -237,6 +248,12 @@ func newWorker(config *params.ChainConfig, engine consensus.Engine, nodeAddr com
 	return worker
 }
 
+func (self *worker) setTxOrderingPolicy(policy TxOrderingPolicy) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.txOrderingPolicy = policy
+}
+
 func (self *worker) setExtra(extra []byte) {
 	self.mu.Lock()
 	defer self.mu.Unlock()
@@ -568,7 +585,12 @@ func (self *worker) commitNewWork() {
 	var pending map[common.Address]types.Transactions
 	var err error
 	var nextBaseFee *big.Int
+	var report *BuildReport
 	if self.nodetype == common.CONSENSUSNODE {
+		if self.buildReportLimit > 0 {
+			report = newBuildReport(nextBlockNum.Uint64(), parent.Hash(), types.MakeSigner(self.config, nextBlockNum))
+		}
+
 		// Check any fork transitions needed
 		pending, err = self.backend.TxPool().Pending()
 		if err != nil {
@@ -581,11 +603,18 @@ func (self *worker) commitNewWork() {
 			// So above code, TxPool().Pending(), is separated with this and can be refactored later.
 			pset := self.govModule.GetParamSet(nextBlockNum.Uint64())
 			nextBaseFee = misc.NextMagmaBlockBaseFee(parent.Header(), pset.ToKip71Config())
-			pending = types.FilterTransactionWithBaseFee(pending, nextBaseFee)
+			filtered := types.FilterTransactionWithBaseFee(pending, nextBaseFee)
+			report.addFiltered(pending, filtered, BuildReasonBelowBaseFee)
+			pending = filtered
 		}
 
 		// Filter txs with txBundlingModules
+		unfiltered := pending
+		if report != nil {
+			unfiltered = maps.Clone(pending)
+		}
 		builder.FilterTxs(pending, self.txBundlingModules)
+		report.addFiltered(unfiltered, pending, BuildReasonFilteredByModule)
 	}
 
 	header := &types.Header{
@@ -632,7 +661,11 @@ func (self *worker) commitNewWork() {
 		minerBalanceGauge.Update(getBalanceForGauge(work.state, self.nodeAddr))
 
 		// Sort txs then execute them
-		txs := types.NewTransactionsByPriceAndNonce(self.current.signer, pending, work.header.BaseFee)
+		if report != nil && header.BaseFee != nil {
+			report.BaseFee = (*hexutil.Big)(header.BaseFee)
+		}
+		work.report = report
+		txs := self.txOrderingPolicy.Order(self.current.signer, pending, work.header.BaseFee)
 		work.commitTransactions(self.mux, txs, self.chain, self.nodeAddr, self.txBundlingModules)
 		finishedCommitTx := time.Now()
 
@@ -643,6 +676,10 @@ func (self *worker) commitNewWork() {
 		}
 		finishedFinalize := time.Now()
 
+		if report != nil {
+			self.writeBuildReport(report)
+		}
+
 		// We only care about logging if we're actually mining.
 		if atomic.LoadInt32(&self.mining) == 1 {
 			// Update the metrics subsystem with all the measurements
@@ -700,6 +737,16 @@ func (self *worker) updateSnapshot() {
 	self.snapshotState = self.current.state.Copy()
 }
 
+// writeBuildReport stores the report and deletes the ones that fall out of the retention window.
+// Reports below the window are pruned by range, so skipped block numbers do not leave stale reports.
+func (self *worker) writeBuildReport(report *BuildReport) {
+	db := self.chainDB.GetMiscDB()
+	WriteBuildReport(db, report)
+	if report.Number > self.buildReportLimit {
+		DeleteBuildReportsBefore(db, report.Number-self.buildReportLimit+1)
+	}
+}
+
 func (self *worker) RegisterExecutionModule(modules ...kaiax.ExecutionModule) {
 	self.executionModules = append(self.executionModules, modules...)
 }
@@ -718,8 +765,8 @@ func getBalanceForGauge(state *state.StateDB, nodeAddr common.Address) int64 {
 	}
 }
 
-func (env *Task) commitTransactions(mux *event.TypeMux, txs *types.TransactionsByPriceAndNonce, bc BlockChain, nodeAddr common.Address, txBundlingModules []builder.TxBundlingModule) {
-	coalescedLogs := env.ApplyTransactions(txs, bc, nodeAddr, txBundlingModules)
+func (env *Task) commitTransactions(mux *event.TypeMux, txs []*types.Transaction, bc BlockChain, nodeAddr common.Address, txBundlingModules []builder.TxBundlingModule) {
+	coalescedLogs := env.ApplyOrderedTransactions(txs, bc, nodeAddr, txBundlingModules)
 
 	if len(coalescedLogs) > 0 || env.tcount > 0 {
 		// make a copy, the state caches the logs and these logs get "upgraded" from pending to mined
@@ -742,13 +789,19 @@ func (env *Task) commitTransactions(mux *event.TypeMux, txs *types.TransactionsB
 }
 
 func (env *Task) ApplyTransactions(txs *types.TransactionsByPriceAndNonce, bc BlockChain, nodeAddr common.Address, txBundlingModules []builder.TxBundlingModule) []*types.Log {
+	return env.ApplyOrderedTransactions(builder.Arrayify(txs), bc, nodeAddr, txBundlingModules)
+}
+
+// ApplyOrderedTransactions executes the transactions in the given order, which is decided by a TxOrderingPolicy.
+func (env *Task) ApplyOrderedTransactions(arrayTxs []*types.Transaction, bc BlockChain, nodeAddr common.Address, txBundlingModules []builder.TxBundlingModule) []*types.Log {
 	var (
-		arrayTxs                 = builder.Arrayify(txs)
-		incorporatedTxs, bundles = builder.ExtractBundlesAndIncorporate(arrayTxs, txBundlingModules)
-		totalTxs                 = len(incorporatedTxs)
-		totalBundles             = len(bundles)
-		coalescedLogs            []*types.Log
+		incorporatedTxs, bundles, conflicts = builder.ExtractBundlesAndIncorporateWithConflicts(arrayTxs, txBundlingModules)
+		totalTxs                            = len(incorporatedTxs)
+		totalBundles                        = len(bundles)
+		coalescedLogs                       []*types.Log
+		stopReason                          = BuildReasonTimeLimit
 	)
+	env.report.addCandidates(incorporatedTxs, bundles, conflicts)
 
 	// Limit the execution time of all transactions in a block
 	var abort int32 = 0            // To break the below `CommitTransactionLoop` for loop when timed out
@@ -834,6 +887,7 @@ CommitTransactionLoop:
 			// 2. The previous transaction failed (receipt status not successful)
 			if discard, err := env.shouldDiscardBundle(targetBundle); discard {
 				logger.Warn("Skipping bundle due to invalid target tx", "err", err.Error(), "target tx", targetBundle.TargetTxHash.String(), "bundle tx", txOrGen.Id.String(), "numShift", numShift)
+				env.report.mark(incorporatedTxs, numShift, BuildStatusSkipped, BuildReasonInvalidTarget)
 				builder.PopTxs(&incorporatedTxs, numShift, &bundles, env.signer)
 				continue
 			}
@@ -842,6 +896,7 @@ CommitTransactionLoop:
 		tx, err := txOrGen.GetTx(env.state.GetNonce(nodeAddr))
 		if err != nil {
 			logger.Warn("TxGenerator returned a nil tx", "error", err)
+			env.report.mark(incorporatedTxs, numShift, BuildStatusSkipped, BuildReasonTxGeneration)
 			builder.PopTxs(&incorporatedTxs, numShift, &bundles, env.signer)
 			continue
 		}
@@ -849,12 +904,14 @@ CommitTransactionLoop:
 			// if inclusion of the transaction would put the block size over the
 			// maximum we allow, don't add any more txs to the payload.
 			if !env.txFitsSizeForBundle(nodeAddr, targetBundle) {
+				stopReason = BuildReasonBlockSizeLimit
 				break
 			}
 		} else {
 			// if inclusion of the transaction would put the block size over the
 			// maximum we allow, don't add any more txs to the payload.
 			if !env.txFitsSize(tx) {
+				stopReason = BuildReasonBlockSizeLimit
 				break
 			}
 		}
@@ -864,6 +921,7 @@ CommitTransactionLoop:
 		// a defined schedule, so we need to verify it's safe to call.
 		if env.config.IsOsakaForkEnabled(env.header.Number) {
 			if hasBlobSpace := env.hasBlobSpace(tx, targetBundle, nodeAddr); !hasBlobSpace {
+				env.report.mark(incorporatedTxs, numShift, BuildStatusSkipped, BuildReasonNoBlobSpace)
 				builder.PopTxs(&incorporatedTxs, numShift, &bundles, env.signer)
 				continue
 			}
@@ -905,12 +963,14 @@ CommitTransactionLoop:
 			// New head notification data race between the transaction pool and miner, shift
 			logger.Trace("Skipping transaction with low nonce", "sender", from, "nonce", tx.Nonce())
 			numTxsNonceTooLow++
+			env.report.mark(incorporatedTxs, numShift, BuildStatusSkipped, BuildReasonNonceTooLow)
 			builder.ShiftTxs(&incorporatedTxs, numShift)
 
 		case blockchain.ErrNonceTooHigh:
 			// Reorg notification data race between the transaction pool and miner, skip account =
 			logger.Trace("Skipping account with high nonce", "sender", from, "nonce", tx.Nonce())
 			numTxsNonceTooHigh++
+			env.report.mark(incorporatedTxs, numShift, BuildStatusSkipped, BuildReasonNonceTooHigh)
 			builder.PopTxs(&incorporatedTxs, numShift, &bundles, env.signer)
 
 		case vm.ErrTotalTimeLimitReached:
@@ -921,22 +981,26 @@ CommitTransactionLoop:
 		case blockchain.ErrTxTypeNotSupported:
 			// Pop the unsupported transaction without shifting in the next from the account
 			logger.Trace("Skipping unsupported transaction type", "sender", from, "type", tx.Type())
+			env.report.mark(incorporatedTxs, numShift, BuildStatusSkipped, BuildReasonTxTypeNotSupported)
 			builder.PopTxs(&incorporatedTxs, numShift, &bundles, env.signer)
 
 		case kerrors.ErrRevertedBundleByVmErr:
 			// Pop transaction in bundle reverted by vm err without shifting in the next from the account
 			// During bundle execution, vm err is reverted, including the increment of the nonce, so a pop is executed.
 			logger.Trace("Skipping transaction in bundle reverted by vm err", "sender", from, "hash", tx.Hash().String())
+			env.report.mark(incorporatedTxs, numShift, BuildStatusSkipped, BuildReasonBundleReverted)
 			builder.PopTxs(&incorporatedTxs, numShift, &bundles, env.signer)
 
 		case kerrors.ErrTxGeneration:
 			// Pop transaction in bundle due to tx generation error without shifting in the next from the account
 			logger.Trace("Skipping transaction in bundle due to tx generation error", "err", err)
+			env.report.mark(incorporatedTxs, numShift, BuildStatusSkipped, BuildReasonTxGeneration)
 			builder.PopTxs(&incorporatedTxs, numShift, &bundles, env.signer)
 
 		case nil:
 			// Everything ok, collect the logs and shift in the next transaction from the same account
 			coalescedLogs = append(coalescedLogs, logs...)
+			env.report.mark(incorporatedTxs, numShift, BuildStatusIncluded, "")
 			builder.ShiftTxs(&incorporatedTxs, numShift)
 
 		default:
@@ -944,6 +1008,7 @@ CommitTransactionLoop:
 			// nonce-too-high clause will prevent us from executing in vain).
 			logger.Warn("Transaction failed, account skipped", "sender", from, "hash", tx.Hash().String(), "err", err)
 			strangeErrorTxsCounter.Inc(1)
+			env.report.mark(incorporatedTxs, numShift, BuildStatusSkipped, err.Error())
 			builder.ShiftTxs(&incorporatedTxs, numShift)
 		}
 		if len(targetBundle.BundleTxs) != 0 {
@@ -976,6 +1041,8 @@ CommitTransactionLoop:
 		}
 	}
 
+	env.report.finish(incorporatedTxs, stopReason)
+
 	// Update the number of transactions checked and dropped during ApplyTransactions.
 	checkedTxsGauge.Update(numTxsChecked)
 	nonceTooLowTxsGauge.Update(numTxsNonceTooLow)
@@ -1052,9 +1119,9 @@ func (env *Task) commitBundleTransaction(bundle *builder.Bundle, bc BlockChain,
 
 		env.state.SetTxContext(tx.Hash(), common.Hash{}, env.tcount)
 		receipt, _, err := bc.ApplyTransaction(env.config, &nodeAddr, env.state, env.header, tx, &env.header.GasUsed, vmConfig)
-		// Bundled tx will be rejected with any receipt.Status other than success.
+		// Bundled tx will be rejected with any receipt.Status other than success, unless the bundle allows it to revert.
 		// There may be cases where a revert occurs within the EVM, which could result in an attack on a tx sender in an already executed bundle.
-		if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		if err != nil || (receipt.Status != types.ReceiptStatusSuccessful && !bundle.CanRevert(txOrGen.Id)) {
 			if err != vm.ErrInsufficientBalance && err != vm.ErrTotalTimeLimitReached {
 				markAllTxUnexecutable()
 			}
@@ -21,6 +21,7 @@ package work
 import (
 	"github.com/kaiachain/kaia/blockchain/state"
 	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/kaiax"
 )
 
@@ -43,3 +44,9 @@ func (*FakeWorker) Pending() (*types.Block, types.Receipts, *state.StateDB)    {
 func (*FakeWorker) PendingBlock() *types.Block                                 { return nil }
 func (*FakeWorker) RegisterExecutionModule(modules ...kaiax.ExecutionModule)   {}
 func (*FakeWorker) RegisterTxBundlingModule(modules ...kaiax.TxBundlingModule) {}
+func (*FakeWorker) SetTxOrderingPolicy(TxOrderingPolicy)                       {}
+func (*FakeWorker) SetBuildReportLimit(uint64)                                 {}
+func (*FakeWorker) BuildReport(uint64) (*BuildReport, error)                   { return nil, ErrBuildReportDisabled }
+func (*FakeWorker) TxBuildHistory(common.Hash) ([]*TxBuildRecord, error) {
+	return nil, ErrBuildReportDisabled
+}